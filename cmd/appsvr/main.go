@@ -162,6 +162,10 @@ import (
 
 	"github.com/bhojpur/service/pkg/configuration"
 	configuration_redis "github.com/bhojpur/service/pkg/configuration/redis"
+
+	lock_loader "github.com/bhojpur/application/pkg/components/lock"
+	"github.com/bhojpur/application/pkg/lock"
+	lock_inmemory "github.com/bhojpur/application/pkg/lock/inmemory"
 )
 
 var (
@@ -276,6 +280,11 @@ func main() {
 				return configuration_redis.NewRedisConfigurationStore(logService)
 			}),
 		),
+		runtime.WithLocks(
+			lock_loader.New("in-memory", func() lock.Store {
+				return lock_inmemory.NewInMemoryLock()
+			}),
+		),
 		runtime.WithPubSubs(
 			/*
 				pubsub_loader.New("azure.eventhubs", func() pubs.PubSub {
@@ -0,0 +1,373 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime"
+
+	"github.com/gocarina/gocsv"
+	"github.com/phayes/freeport"
+	"github.com/spf13/cobra"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/bhojpur/application/pkg/kubernetes"
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+var (
+	actorsAppID          string
+	actorsKubernetesMode bool
+	actorsNamespace      string
+	actorsSocket         string
+	actorsOutput         string
+	actorType            string
+	actorID              string
+	actorMethod          string
+	actorVerb            string
+	actorData            string
+	actorDataFile        string
+	actorStateKey        string
+	actorReminderName    string
+	actorReminderNewName string
+)
+
+var ActorsCmd = &cobra.Command{
+	Use:   "actors",
+	Short: "List, invoke and inspect the actors of an application. Supported platforms: Kubernetes and self-hosted",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if actorsOutput != "" && actorsOutput != "json" && actorsOutput != "yaml" && actorsOutput != "table" {
+			utils.FailureStatusEvent(os.Stderr, "An invalid output format was specified.")
+			os.Exit(1)
+		}
+
+		// TODO: add Windows support
+		if actorsSocket != "" {
+			if runtime.GOOS == "windows" {
+				utils.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
+				os.Exit(1)
+			} else {
+				utils.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
+			}
+		}
+	},
+}
+
+var ActorsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the number of active actors per type",
+	Example: `
+# List the active actors of myapp
+appctl actors list --app-id myapp
+
+# List the active actors of myapp running in Kubernetes
+appctl actors list --app-id myapp -k
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, stop := newActorsClient()
+		defer stop()
+
+		actors, err := client.ListActors()
+		if err != nil {
+			exitActors(stop, "Error listing actors of %s: %s", actorsAppID, err)
+		}
+		if len(actors) == 0 && actorsOutput != "json" && actorsOutput != "yaml" {
+			fmt.Println("No active actors found.")
+			return
+		}
+		outputActors(stop, actors)
+	},
+}
+
+var ActorsInvokeCmd = &cobra.Command{
+	Use:   "invoke",
+	Short: "Invoke a method of an actor",
+	Example: `
+# Invoke the method of an actor
+appctl actors invoke --app-id myapp --type Order --id 1 --method ship --data '{"carrier":"ups"}'
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if actorDataFile != "" && actorData != "" {
+			utils.FailureStatusEvent(os.Stderr, "Only one of --data and --data-file allowed in the same invoke command")
+			os.Exit(1)
+		}
+
+		data := []byte(actorData)
+		if actorDataFile != "" {
+			var err error
+			data, err = ioutil.ReadFile(actorDataFile)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, "Error reading payload from '%s'. Error: %s", actorDataFile, err)
+				os.Exit(1)
+			}
+		}
+
+		client, stop := newActorsClient()
+		defer stop()
+
+		resp, err := client.InvokeActor(actorType, actorID, actorMethod, actorVerb, data)
+		if err != nil {
+			exitActors(stop, "Error invoking method %s of actor %s/%s: %s", actorMethod, actorType, actorID, err)
+		}
+		if len(resp) > 0 {
+			fmt.Println(string(resp))
+		}
+		utils.SuccessStatusEvent(os.Stdout, "Actor invoked successfully")
+	},
+}
+
+var ActorsStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Inspect the state of an actor",
+}
+
+var ActorsStateGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a key of the state of an actor",
+	Example: `
+# Get a key of the state of an actor
+appctl actors state get --app-id myapp --type Order --id 1 --key status
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, stop := newActorsClient()
+		defer stop()
+
+		value, err := client.GetActorState(actorType, actorID, actorStateKey)
+		if err != nil {
+			exitActors(stop, "Error getting key %s of actor %s/%s: %s", actorStateKey, actorType, actorID, err)
+		}
+		if value == nil {
+			utils.WarningStatusEvent(os.Stdout, "Key %s not found in the state of actor %s/%s", actorStateKey, actorType, actorID)
+			return
+		}
+		fmt.Println(string(value))
+	},
+}
+
+var ActorsRemindersCmd = &cobra.Command{
+	Use:   "reminders",
+	Short: "Manage the reminders of actors",
+}
+
+var ActorsRemindersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the reminders of an actor, or of all the actors of a type",
+	Example: `
+# List the reminders of all the actors of a type
+appctl actors reminders list --app-id myapp --type Order
+
+# List the reminders of an actor as YAML
+appctl actors reminders list --app-id myapp --type Order --id 1 -o yaml
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, stop := newActorsClient()
+		defer stop()
+
+		reminders, err := client.ListActorReminders(actorType, actorID)
+		if err != nil {
+			exitActors(stop, "Error listing reminders of actor type %s: %s", actorType, err)
+		}
+		if len(reminders) == 0 && actorsOutput != "json" && actorsOutput != "yaml" {
+			fmt.Println("No reminders found.")
+			return
+		}
+		outputActors(stop, reminders)
+	},
+}
+
+var ActorsRemindersGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a reminder of an actor",
+	Example: `
+# Get a reminder of an actor
+appctl actors reminders get --app-id myapp --type Order --id 1 --name checkout
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, stop := newActorsClient()
+		defer stop()
+
+		reminder, err := client.GetActorReminder(actorType, actorID, actorReminderName)
+		if err != nil {
+			exitActors(stop, "Error getting reminder %s of actor %s/%s: %s", actorReminderName, actorType, actorID, err)
+		}
+		if reminder == nil {
+			utils.WarningStatusEvent(os.Stdout, "Reminder %s not found for actor %s/%s", actorReminderName, actorType, actorID)
+			return
+		}
+		outputActors(stop, []standalone.ActorReminder{*reminder})
+	},
+}
+
+var ActorsRemindersDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a reminder of an actor",
+	Example: `
+# Delete a reminder of an actor
+appctl actors reminders delete --app-id myapp --type Order --id 1 --name checkout
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, stop := newActorsClient()
+		defer stop()
+
+		err := client.DeleteActorReminder(actorType, actorID, actorReminderName)
+		if err != nil {
+			exitActors(stop, "Error deleting reminder %s of actor %s/%s: %s", actorReminderName, actorType, actorID, err)
+		}
+		utils.SuccessStatusEvent(os.Stdout, "Reminder %s deleted successfully", actorReminderName)
+	},
+}
+
+var ActorsRemindersRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename a reminder of an actor",
+	Example: `
+# Rename a reminder of an actor
+appctl actors reminders rename --app-id myapp --type Order --id 1 --name checkout --new-name payment
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, stop := newActorsClient()
+		defer stop()
+
+		err := client.RenameActorReminder(actorType, actorID, actorReminderName, actorReminderNewName)
+		if err != nil {
+			exitActors(stop, "Error renaming reminder %s of actor %s/%s: %s", actorReminderName, actorType, actorID, err)
+		}
+		utils.SuccessStatusEvent(os.Stdout, "Reminder %s renamed to %s successfully", actorReminderName, actorReminderNewName)
+	},
+}
+
+// newActorsClient returns a client for the sidecar of the app ID. In Kubernetes
+// mode the sidecar is reached through a port-forward, which is closed by the
+// returned stop function.
+func newActorsClient() (*standalone.ActorsClient, func()) {
+	if !actorsKubernetesMode {
+		client, err := standalone.NewActorsClient(actorsAppID, actorsSocket)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error finding app id %s: %s", actorsAppID, err)
+			os.Exit(1)
+		}
+		return client, func() {}
+	}
+
+	config, _, err := kubernetes.GetKubeConfigClient()
+	if err != nil {
+		utils.FailureStatusEvent(os.Stderr, "Failed to initialize kubernetes client: %s", err.Error())
+		os.Exit(1)
+	}
+
+	localPort, err := freeport.GetFreePort()
+	if err != nil {
+		utils.FailureStatusEvent(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	portForward, err := kubernetes.NewAppPortForward(config, actorsNamespace, actorsAppID, "localhost", localPort, false)
+	if err != nil {
+		utils.FailureStatusEvent(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	if err = portForward.Init(); err != nil {
+		utils.FailureStatusEvent(os.Stderr, "Error in port forwarding: %s", err)
+		os.Exit(1)
+	}
+
+	return standalone.NewActorsClientForAddress(fmt.Sprintf("localhost:%d", localPort)), portForward.Stop
+}
+
+// exitActors reports a failure and exits once the port-forward, if any, is closed.
+func exitActors(stop func(), format string, a ...interface{}) {
+	utils.FailureStatusEvent(os.Stderr, format, a...)
+	stop()
+	os.Exit(1)
+}
+
+func outputActors(stop func(), list interface{}) {
+	if actorsOutput == "json" || actorsOutput == "yaml" {
+		err := utils.PrintDetail(os.Stdout, actorsOutput, list)
+		if err != nil {
+			exitActors(stop, err.Error())
+		}
+		return
+	}
+
+	table, err := gocsv.MarshalString(list)
+	if err != nil {
+		exitActors(stop, err.Error())
+	}
+	utils.PrintTable(table)
+}
+
+func init() {
+	ActorsCmd.PersistentFlags().StringVarP(&actorsAppID, "app-id", "a", "", "The application id hosting the actors")
+	ActorsCmd.PersistentFlags().BoolVarP(&actorsKubernetesMode, "kubernetes", "k", false, "Reach the application running in a Kubernetes cluster")
+	ActorsCmd.PersistentFlags().StringVarP(&actorsNamespace, "namespace", "n", meta_v1.NamespaceAll, "The Kubernetes namespace of the application, all namespaces when not set")
+	ActorsCmd.PersistentFlags().StringVarP(&actorsSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Bhojpur Application API servers will use Unix Domain Sockets")
+	ActorsCmd.PersistentFlags().StringVarP(&actorsOutput, "output", "o", "", "The output format. Valid values are: json, yaml, or table (default)")
+	ActorsCmd.PersistentFlags().BoolP("help", "h", false, "Print this help message")
+	ActorsCmd.MarkPersistentFlagRequired("app-id")
+
+	ActorsInvokeCmd.Flags().StringVarP(&actorType, "type", "t", "", "The actor type")
+	ActorsInvokeCmd.Flags().StringVarP(&actorID, "id", "i", "", "The actor id")
+	ActorsInvokeCmd.Flags().StringVarP(&actorMethod, "method", "m", "", "The actor method to invoke")
+	ActorsInvokeCmd.Flags().StringVarP(&actorVerb, "verb", "v", http.MethodPost, "The HTTP verb to use")
+	ActorsInvokeCmd.Flags().StringVarP(&actorData, "data", "d", "", "The JSON serialized data string (optional)")
+	ActorsInvokeCmd.Flags().StringVarP(&actorDataFile, "data-file", "f", "", "A file containing the JSON serialized data (optional)")
+	ActorsInvokeCmd.MarkFlagRequired("type")
+	ActorsInvokeCmd.MarkFlagRequired("id")
+	ActorsInvokeCmd.MarkFlagRequired("method")
+
+	ActorsStateGetCmd.Flags().StringVarP(&actorType, "type", "t", "", "The actor type")
+	ActorsStateGetCmd.Flags().StringVarP(&actorID, "id", "i", "", "The actor id")
+	ActorsStateGetCmd.Flags().StringVar(&actorStateKey, "key", "", "The state key to get")
+	ActorsStateGetCmd.MarkFlagRequired("type")
+	ActorsStateGetCmd.MarkFlagRequired("id")
+	ActorsStateGetCmd.MarkFlagRequired("key")
+
+	ActorsRemindersListCmd.Flags().StringVarP(&actorType, "type", "t", "", "The actor type")
+	ActorsRemindersListCmd.Flags().StringVarP(&actorID, "id", "i", "", "The actor id, all the actors of the type when not set")
+	ActorsRemindersListCmd.MarkFlagRequired("type")
+
+	for _, c := range []*cobra.Command{ActorsRemindersGetCmd, ActorsRemindersDeleteCmd, ActorsRemindersRenameCmd} {
+		c.Flags().StringVarP(&actorType, "type", "t", "", "The actor type")
+		c.Flags().StringVarP(&actorID, "id", "i", "", "The actor id")
+		c.Flags().StringVar(&actorReminderName, "name", "", "The reminder name")
+		c.MarkFlagRequired("type")
+		c.MarkFlagRequired("id")
+		c.MarkFlagRequired("name")
+	}
+	ActorsRemindersRenameCmd.Flags().StringVar(&actorReminderNewName, "new-name", "", "The new reminder name")
+	ActorsRemindersRenameCmd.MarkFlagRequired("new-name")
+
+	ActorsStateCmd.AddCommand(ActorsStateGetCmd)
+	ActorsRemindersCmd.AddCommand(ActorsRemindersListCmd)
+	ActorsRemindersCmd.AddCommand(ActorsRemindersGetCmd)
+	ActorsRemindersCmd.AddCommand(ActorsRemindersDeleteCmd)
+	ActorsRemindersCmd.AddCommand(ActorsRemindersRenameCmd)
+	ActorsCmd.AddCommand(ActorsListCmd)
+	ActorsCmd.AddCommand(ActorsInvokeCmd)
+	ActorsCmd.AddCommand(ActorsStateCmd)
+	ActorsCmd.AddCommand(ActorsRemindersCmd)
+	rootCmd.AddCommand(ActorsCmd)
+}
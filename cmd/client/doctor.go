@@ -0,0 +1,166 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/bhojpur/application/pkg/kubernetes"
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+var (
+	doctorOutputFormat string
+	doctorKubernetes   bool
+)
+
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment used to run Bhojpur Applications. Supported platforms: Kubernetes and self-hosted",
+	Example: `
+# Diagnose the self-hosted environment
+appctl doctor
+
+# Diagnose the ports and components used by appctl run
+appctl doctor --app-http-port 3500 --components-path ./components
+
+# Also diagnose the Kubernetes control plane and print a JSON report for CI
+appctl doctor -k -o json
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("placement-host-address", cmd.Flags().Lookup("placement-host-address"))
+		if doctorOutputFormat != "" && doctorOutputFormat != "json" && doctorOutputFormat != "yaml" && doctorOutputFormat != "table" {
+			utils.FailureStatusEvent(os.Stderr, "An invalid output format was specified.")
+			os.Exit(1)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		checks := standalone.Doctor(standalone.DoctorConfig{
+			CLIVersion:        rootCmd.Version,
+			ComponentsPath:    componentsPath,
+			PlacementHostAddr: viper.GetString("placement-host-address"),
+			HTTPPort:          port,
+			GRPCPort:          grpcPort,
+			MetricsPort:       metricsPort,
+			ProfilePort:       profilePort,
+		})
+		if doctorKubernetes {
+			checks = append(checks, kubernetesDoctorChecks()...)
+		}
+		report := standalone.NewDoctorReport(checks)
+
+		if doctorOutputFormat == "json" || doctorOutputFormat == "yaml" {
+			err := utils.PrintDetail(os.Stdout, doctorOutputFormat, report)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		} else {
+			table, err := gocsv.MarshalString(report.Checks)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			utils.PrintTable(table)
+		}
+
+		if report.Status == standalone.DoctorFail {
+			os.Exit(1)
+		}
+	},
+}
+
+// kubernetesDoctorChecks diagnoses the control plane and the root certificate
+// of the Kubernetes cluster.
+func kubernetesDoctorChecks() []standalone.DoctorCheck {
+	checks := []standalone.DoctorCheck{}
+
+	controlPlane := standalone.DoctorCheck{Name: "kubernetes control plane"}
+	status, err := kubernetesStatus()
+	switch {
+	case err != nil:
+		controlPlane.Status = standalone.DoctorFail
+		controlPlane.Message = err.Error()
+	case len(status) == 0:
+		controlPlane.Status = standalone.DoctorFail
+		controlPlane.Message = "no control plane services found. Run `appctl init -k` to install the Bhojpur Application runtime"
+	default:
+		controlPlane.Status = standalone.DoctorPass
+		controlPlane.Message = fmt.Sprintf("%d control plane services are healthy", len(status))
+		for _, s := range status {
+			if s.Status != "Running" || s.Healthy != "True" {
+				controlPlane.Status = standalone.DoctorFail
+				controlPlane.Message = fmt.Sprintf("%s in namespace %s is %s and healthy is %s", s.Name, s.Namespace, s.Status, s.Healthy)
+				break
+			}
+		}
+	}
+	checks = append(checks, controlPlane)
+
+	certificate := standalone.DoctorCheck{Name: "kubernetes mtls certificate"}
+	expiry, err := kubernetes.Expiry()
+	if err != nil {
+		certificate.Status = standalone.DoctorWarn
+		certificate.Message = fmt.Sprintf("could not get the root certificate expiry: %s", err)
+	} else {
+		days := int(expiry.Sub(time.Now().UTC()).Hours() / 24)
+		switch {
+		case days < 0:
+			certificate.Status = standalone.DoctorFail
+			certificate.Message = fmt.Sprintf("root certificate expired on %s", expiry.Format(time.RFC1123))
+		case days < kubernetes.WarningDaysForCertExpiry:
+			certificate.Status = standalone.DoctorWarn
+			certificate.Message = fmt.Sprintf("root certificate expires in %d days on %s", days, expiry.Format(time.RFC1123))
+		default:
+			certificate.Status = standalone.DoctorPass
+			certificate.Message = fmt.Sprintf("root certificate expires in %d days on %s", days, expiry.Format(time.RFC1123))
+		}
+	}
+	return append(checks, certificate)
+}
+
+func kubernetesStatus() ([]kubernetes.StatusOutput, error) {
+	sc, err := kubernetes.NewStatusClient()
+	if err != nil {
+		return nil, err
+	}
+	return sc.Status()
+}
+
+func init() {
+	DoctorCmd.Flags().BoolVarP(&doctorKubernetes, "kubernetes", "k", false, "Also diagnose the Bhojpur Application runtime in a Kubernetes cluster")
+	DoctorCmd.Flags().StringVarP(&doctorOutputFormat, "output", "o", "", "The output format of the report. Valid values are: json, yaml, or table (default)")
+	DoctorCmd.Flags().StringVarP(&componentsPath, "components-path", "d", standalone.DefaultComponentsDirPath(), "The path for components directory")
+	DoctorCmd.Flags().String("placement-host-address", "localhost", "The address of the placement service. Format is either <hostname> for default port or <hostname>:<port> for custom port")
+	DoctorCmd.Flags().IntVarP(&port, "app-http-port", "H", -1, "The HTTP port for Bhojpur Application runtime to listen on")
+	DoctorCmd.Flags().IntVarP(&grpcPort, "app-grpc-port", "G", -1, "The gRPC port for Bhojpur Application runtime to listen on")
+	DoctorCmd.Flags().IntVarP(&metricsPort, "metrics-port", "M", -1, "The port of metrics on Bhojpur Application runtime")
+	DoctorCmd.Flags().IntVarP(&profilePort, "profile-port", "", -1, "The port for the profile server to listen on")
+	DoctorCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	rootCmd.AddCommand(DoctorCmd)
+}
@@ -41,6 +41,7 @@ var (
 	dashboardVersion string
 	initNamespace    string
 	enableMTLS       bool
+	localMTLS        bool
 	enableHA         bool
 	values           []string
 )
@@ -74,6 +75,9 @@ appctl init -k --runtime-version 0.10.0
 # Initialize the Bhojpur Application in slim self-hosted mode
 appctl init -s
 
+# Initialize the Bhojpur Application runtime in self-hosted mode with mTLS between sidecars
+appctl init --mtls
+
 # See more at: https://docs.bhojpur.net/getting-started/
 `,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -104,7 +108,7 @@ appctl init -s
 				dockerNetwork = viper.GetString("network")
 				imageRepositoryURL = viper.GetString("image-repository")
 			}
-			err := standalone.Init(runtimeVersion, dashboardVersion, dockerNetwork, slimMode, imageRepositoryURL)
+			err := standalone.Init(runtimeVersion, dashboardVersion, dockerNetwork, slimMode, imageRepositoryURL, localMTLS)
 			if err != nil {
 				utils.FailureStatusEvent(os.Stderr, err.Error())
 				os.Exit(1)
@@ -135,6 +139,7 @@ func init() {
 	InitCmd.Flags().StringVarP(&dashboardVersion, "dashboard-version", "", defaultDashboardVersion, "The version of the Bhojpur Application dashboard to install, for example: 1.0.0")
 	InitCmd.Flags().StringVarP(&initNamespace, "namespace", "n", "app-system", "The Kubernetes namespace to install Bhojpur Application runtime in")
 	InitCmd.Flags().BoolVarP(&enableMTLS, "enable-mtls", "", true, "Enable mTLS in your cluster")
+	InitCmd.Flags().BoolVarP(&localMTLS, "mtls", "", false, "Generate a local trust bundle and run sentry to enable mTLS in self-hosted mode")
 	InitCmd.Flags().BoolVarP(&enableHA, "enable-ha", "", false, "Enable high availability (HA) mode")
 	InitCmd.Flags().String("network", "", "The Docker network on which to deploy the Bhojpur Application runtime")
 	InitCmd.Flags().BoolP("help", "h", false, "Print this help message")
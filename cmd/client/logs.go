@@ -21,48 +21,146 @@ package cmd
 // THE SOFTWARE.
 
 import (
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/bhojpur/application/pkg/kubernetes"
+	"github.com/bhojpur/application/pkg/standalone"
 	"github.com/bhojpur/application/pkg/utils"
 )
 
 var (
-	logsAppID string
-	podName   string
-	namespace string
-	k8s       bool
+	logsAppID     string
+	podName       string
+	namespace     string
+	k8s           bool
+	logsFollow    bool
+	logsSince     time.Duration
+	logsLevel     string
+	logsComponent string
+	logsRaw       bool
 )
 
 var LogsCmd = &cobra.Command{
 	Use:   "logs",
-	Short: "Get the runtime sidecar logs for an application. Supported platforms: Kubernetes",
+	Short: "Get the runtime sidecar and application logs for an application. Supported platforms: Kubernetes and self-hosted",
 	Example: `
+# Get logs of a sample application started with appctl run
+appctl logs --app-id sample
+
+# Follow the error logs of the sidecar of a sample application written in the last 10 minutes
+appctl logs --app-id sample --component sidecar --level error --since 10m --follow
+
 # Get logs of a sample application from target Pod in custom namespace
 appctl logs -k --app-id sample --pod-name target --namespace custom
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		err := kubernetes.Logs(logsAppID, podName, namespace)
+		if k8s {
+			err := kubernetes.Logs(logsAppID, podName, namespace)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			utils.SuccessStatusEvent(os.Stdout, "Fetched logs")
+			return
+		}
+
+		components := standalone.LogComponents
+		if logsComponent != "" {
+			if logsComponent != standalone.LogComponentSidecar && logsComponent != standalone.LogComponentApp {
+				utils.FailureStatusEvent(os.Stderr, "invalid component %s. Valid values are: %s", logsComponent, strings.Join(standalone.LogComponents, ", "))
+				os.Exit(1)
+			}
+			components = []string{logsComponent}
+		}
+
+		filter := standalone.LogFilter{MinLevel: logsLevel}
+		if logsLevel != "" {
+			if err := standalone.ValidateLogLevel(logsLevel); err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		}
+		if logsSince > 0 {
+			filter.Since = time.Now().Add(-logsSince)
+		}
+
+		lines, err := standalone.ReadLogs(logsAppID, components, filter)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		for _, l := range lines {
+			printLogLine(l, len(components) > 1)
+		}
+		if !logsFollow {
+			return
+		}
+
+		sigCh := make(chan os.Signal, 1)
+		setupShutdownNotify(sigCh)
+		stopCh := make(chan struct{})
+		go func() {
+			<-sigCh
+			close(stopCh)
+		}()
+
+		err = standalone.FollowLogs(logsAppID, components, filter, stopCh, func(l standalone.LogLine) {
+			printLogLine(l, len(components) > 1)
+		})
 		if err != nil {
 			utils.FailureStatusEvent(os.Stderr, err.Error())
 			os.Exit(1)
 		}
-		utils.SuccessStatusEvent(os.Stdout, "Fetched logs")
 	},
 	PostRun: func(cmd *cobra.Command, args []string) {
-		kubernetes.CheckForCertExpiry()
+		if k8s {
+			kubernetes.CheckForCertExpiry()
+		}
 	},
 }
 
+// printLogLine prints a captured log line. Structured JSON lines are rendered
+// as time, level, scope and message unless the raw output is requested.
+func printLogLine(l standalone.LogLine, showComponent bool) {
+	var b strings.Builder
+	if showComponent {
+		b.WriteString(utils.Blue(fmt.Sprintf("== %s == ", l.Component)))
+	}
+	if !l.JSON || logsRaw {
+		b.WriteString(l.Raw)
+		fmt.Println(b.String())
+		return
+	}
+
+	b.WriteString(l.Time.Local().Format(time.RFC3339))
+	b.WriteString(" ")
+	b.WriteString(strings.ToUpper(l.Level))
+	if l.Scope != "" {
+		b.WriteString(" [")
+		b.WriteString(l.Scope)
+		b.WriteString("]")
+	}
+	b.WriteString(" ")
+	b.WriteString(l.Message)
+	fmt.Println(b.String())
+}
+
 func init() {
-	LogsCmd.Flags().BoolVarP(&k8s, "kubernetes", "k", true, "Get logs from a Kubernetes cluster")
+	LogsCmd.Flags().BoolVarP(&k8s, "kubernetes", "k", false, "Get logs from a Kubernetes cluster")
 	LogsCmd.Flags().StringVarP(&logsAppID, "app-id", "a", "", "The application id for which logs are needed")
 	LogsCmd.Flags().StringVarP(&podName, "pod-name", "p", "", "The name of the pod in Kubernetes, in case your application has multiple pods (optional)")
 	LogsCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The Kubernetes namespace in which your application is deployed")
+	LogsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep printing new log lines as they are written. Supported platforms: self-hosted")
+	LogsCmd.Flags().DurationVar(&logsSince, "since", 0, "Only print log lines written within the given duration, e.g. 10m or 1h. Supported platforms: self-hosted")
+	LogsCmd.Flags().StringVar(&logsLevel, "level", "", "Only print log lines at or above the given level: debug, info, warn, error, fatal or panic. Supported platforms: self-hosted")
+	LogsCmd.Flags().StringVar(&logsComponent, "component", "", "Only print the logs of the given component: sidecar or app. Supported platforms: self-hosted")
+	LogsCmd.Flags().BoolVar(&logsRaw, "raw", false, "Print JSON formatted log lines as written instead of parsing them. Supported platforms: self-hosted")
 	LogsCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	LogsCmd.MarkFlagRequired("app-id")
-	LogsCmd.MarkFlagRequired("kubernetes")
 	rootCmd.AddCommand(LogsCmd)
 }
@@ -0,0 +1,147 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bhojpur/application/pkg/recorder"
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+var (
+	replayFile         string
+	replayAppID        string
+	replayTargetAppID  string
+	replayKinds        []string
+	replayIgnoreFields []string
+	replaySocket       string
+)
+
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay a recording of service invocations against a running application. Supported platforms: Self-hosted",
+	Example: `
+# Replay the calls recorded for myapp through its sidecar and diff the responses
+appctl replay --file myapp.recording.jsonl --app-id myapp
+
+# Replay service invocations and published events, ignoring fields which change on every call
+appctl replay -f myapp.recording.jsonl -a myapp --kind invoke,publish --ignore-fields id,timestamp
+
+# Replay the recording against another app ID
+appctl replay -f myapp.recording.jsonl -a myapp --target myapp-v2
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// TODO: add Windows support
+		if replaySocket != "" {
+			if runtime.GOOS == "windows" {
+				utils.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
+				os.Exit(1)
+			} else {
+				utils.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
+			}
+		}
+
+		f, err := os.Open(replayFile)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error opening recording '%s'. Error: %s", replayFile, err)
+			os.Exit(1)
+		}
+		entries, err := recorder.ReadEntries(f)
+		f.Close()
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error reading recording '%s'. Error: %s", replayFile, err)
+			os.Exit(1)
+		}
+
+		results, err := standalone.NewClient().Replay(entries, standalone.ReplayOptions{
+			AppID:        replayAppID,
+			TargetAppID:  replayTargetAppID,
+			Kinds:        replayKinds,
+			IgnoreFields: replayIgnoreFields,
+			Socket:       replaySocket,
+		})
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error replaying recording: %s", err)
+			os.Exit(1)
+		}
+		if len(results) == 0 {
+			utils.WarningStatusEvent(os.Stdout, "No recorded entries of kind %s found in '%s'", strings.Join(replayKinds, ","), replayFile)
+			return
+		}
+
+		mismatches, skipped := 0, 0
+		for _, r := range results {
+			name := replayEntryName(r.Entry)
+			switch {
+			case r.Skipped:
+				skipped++
+				utils.WarningStatusEvent(os.Stdout, "%s: skipped, the recorded body was streamed", name)
+			case r.Err != nil:
+				mismatches++
+				utils.FailureStatusEvent(os.Stdout, "%s: %s", name, r.Err)
+			case !r.Match:
+				mismatches++
+				utils.FailureStatusEvent(os.Stdout, "%s: response differs (status %d)", name, r.Status)
+				fmt.Fprint(os.Stdout, r.Diff)
+			default:
+				utils.SuccessStatusEvent(os.Stdout, "%s: response matches (status %d)", name, r.Status)
+			}
+		}
+
+		replayed := len(results) - skipped
+		if mismatches > 0 {
+			utils.FailureStatusEvent(os.Stderr, "%d of %d replayed entries differ from the recording", mismatches, replayed)
+			os.Exit(1)
+		}
+		utils.SuccessStatusEvent(os.Stdout, "All %d replayed entries match the recording", replayed)
+	},
+}
+
+func replayEntryName(e recorder.Entry) string {
+	switch {
+	case e.Event != nil:
+		return fmt.Sprintf("%s %s/%s", e.Kind, e.Event.PubsubName, e.Event.Topic)
+	case e.Request != nil:
+		return fmt.Sprintf("%s %s %s/%s", e.Kind, e.Request.Verb, e.TargetAppID, e.Request.Method)
+	default:
+		return e.Kind
+	}
+}
+
+func init() {
+	ReplayCmd.Flags().StringVarP(&replayFile, "file", "f", "", "The recording file written by the sidecar")
+	ReplayCmd.Flags().StringVarP(&replayAppID, "app-id", "a", "", "The application id whose sidecar the recording is sent through")
+	ReplayCmd.Flags().StringVar(&replayTargetAppID, "target", "", "The application id recorded invocations are sent to. Defaults to the recorded target")
+	ReplayCmd.Flags().StringSliceVar(&replayKinds, "kind", []string{recorder.KindApp, recorder.KindInvoke}, "The comma separated kinds of recorded entries to replay. Valid values are: app, invoke, or publish")
+	ReplayCmd.Flags().StringSliceVar(&replayIgnoreFields, "ignore-fields", nil, "The comma separated JSON fields left out when comparing responses")
+	ReplayCmd.Flags().StringVarP(&replaySocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Bhojpur Application API servers will use Unix Domain Sockets")
+	ReplayCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ReplayCmd.MarkFlagRequired("file")
+	ReplayCmd.MarkFlagRequired("app-id")
+	rootCmd.AddCommand(ReplayCmd)
+}
@@ -23,6 +23,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strconv"
@@ -52,6 +53,7 @@ var (
 	metricsPort        int
 	maxRequestBodySize int
 	unixDomainSocket   string
+	runFilePath        string
 )
 
 const (
@@ -79,12 +81,20 @@ appctl run --app-id myapp
 
 # Run a gRPC application written in Go (listening on port 3000)
 appctl run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
+
+# Run all the applications listed in a run file
+appctl run -f run.yaml
   `,
 	Args: cobra.MinimumNArgs(0),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlag("placement-host-address", cmd.Flags().Lookup("placement-host-address"))
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if runFilePath != "" {
+			runFromFile(runFilePath)
+			return
+		}
+
 		if len(args) == 0 {
 			fmt.Println(utils.WhiteBold("WARNING: no application command found."))
 		}
@@ -149,8 +159,8 @@ appctl run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			}
 			utils.InfoStatusEvent(os.Stdout, startInfo)
 
-			output.SvrCMD.Stdout = os.Stdout
-			output.SvrCMD.Stderr = os.Stderr
+			output.SvrCMD.Stdout = io.MultiWriter(os.Stdout, output.SidecarLog)
+			output.SvrCMD.Stderr = io.MultiWriter(os.Stderr, output.SidecarLog)
 
 			err = output.SvrCMD.Start()
 			if err != nil {
@@ -244,12 +254,14 @@ appctl run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			go func() {
 				for errScanner.Scan() {
 					fmt.Println(utils.Blue(fmt.Sprintf("== MANAGED APPLICATION == %s", errScanner.Text())))
+					fmt.Fprintln(output.AppLog, errScanner.Text())
 				}
 			}()
 
 			go func() {
 				for outScanner.Scan() {
 					fmt.Println(utils.Blue(fmt.Sprintf("== MANAGED APPLICATION == %s", outScanner.Text())))
+					fmt.Fprintln(output.AppLog, outScanner.Text())
 				}
 			}()
 
@@ -286,6 +298,11 @@ appctl run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			os.Exit(1)
 		}
 
+		err = output.UpdateInstance()
+		if err != nil {
+			utils.WarningStatusEvent(os.Stdout, "Could not update the instances registry: %s", err.Error())
+		}
+
 		// Metadata API is only available if Bhojpur Application has started listening to port, so wait
 		// for application to start before calling metadata API.
 		err = utils.Put(output.AppHTTPPort, "cliPID", strconv.Itoa(os.Getpid()), appID, unixDomainSocket)
@@ -332,6 +349,12 @@ appctl run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 				os.Remove(utils.GetSocket(unixDomainSocket, output.AppID, s))
 			}
 		}
+
+		err = output.RemoveInstance()
+		if err != nil {
+			utils.WarningStatusEvent(os.Stdout, "Could not remove %s from the instances registry: %s", output.AppID, err.Error())
+		}
+		output.CloseLogs()
 	},
 }
 
@@ -353,6 +376,7 @@ func init() {
 	RunCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	RunCmd.Flags().IntVarP(&maxRequestBodySize, "app-http-max-request-size", "", -1, "Max size of request body in MB")
 	RunCmd.Flags().StringVarP(&unixDomainSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Bhojpur Application API servers will use Unix Domain Sockets")
+	RunCmd.Flags().StringVarP(&runFilePath, "run-file", "f", "", "Path to a run file listing multiple applications to run together")
 
 	rootCmd.AddCommand(RunCmd)
 }
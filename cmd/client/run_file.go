@@ -0,0 +1,280 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+// runFileColors is the palette used to tell apart the log lines of the
+// applications started from a run file.
+var runFileColors = []color.Attribute{
+	color.FgHiCyan,
+	color.FgHiMagenta,
+	color.FgHiGreen,
+	color.FgHiYellow,
+	color.FgHiBlue,
+	color.FgCyan,
+	color.FgMagenta,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+}
+
+// prefixWriter writes every complete line it receives to the shared output,
+// prefixed with the name of the process that produced it.
+type prefixWriter struct {
+	out    io.Writer
+	lock   *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.lock.Lock()
+		fmt.Fprintf(w.out, "%s %s\n", w.prefix, w.buf[:i])
+		w.lock.Unlock()
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// runFileApp is an application of a run file along with its sidecar.
+type runFileApp struct {
+	config  *standalone.RunConfig
+	output  *standalone.RunOutput
+	stopped bool
+}
+
+// newWriter returns the writer multiplexing the output of a process to the
+// console and to its captured log file.
+func (a *runFileApp) newWriter(lock *sync.Mutex, colorIndex int, name string, log io.Writer) io.Writer {
+	paint := color.New(runFileColors[colorIndex%len(runFileColors)], color.Bold).SprintFunc()
+	return io.MultiWriter(&prefixWriter{
+		out:    os.Stdout,
+		lock:   lock,
+		prefix: paint(fmt.Sprintf("== %s ==", name)),
+	}, log)
+}
+
+// start launches the sidecar and the application. Whenever one of them exits,
+// the app is sent on exited so that the other one is shut down too.
+func (a *runFileApp) start(lock *sync.Mutex, colorIndex int, exited chan<- *runFileApp) error {
+	appID := a.output.AppID
+	utils.InfoStatusEvent(os.Stdout, "Starting Bhojpur Application runtime with ID %s. HTTP Port: %v. gRPC Port: %v",
+		appID, a.output.AppHTTPPort, a.output.AppGRPCPort)
+
+	a.output.SvrCMD.Stdout = a.newWriter(lock, colorIndex, appID+" runtime", a.output.SidecarLog)
+	a.output.SvrCMD.Stderr = a.output.SvrCMD.Stdout
+	err := a.output.SvrCMD.Start()
+	if err != nil {
+		return err
+	}
+	go a.wait(a.output.SvrCMD, fmt.Sprintf("Bhojpur Application runtime %s", appID), exited)
+
+	if a.config.AppPort <= 0 {
+		// The sidecar only waits for applications listening on a port, so
+		// an application without one is started once the sidecar is up.
+		err = utils.IsAppListeningOnPort(a.output.AppHTTPPort, time.Duration(runtimeWaitTimeoutInSeconds)*time.Second)
+		if err != nil {
+			utils.WarningStatusEvent(os.Stdout, "Bhojpur Application runtime sidecar %s is not listening on HTTP port: %s", appID, err.Error())
+		}
+	}
+
+	if a.output.AppCMD == nil {
+		return nil
+	}
+
+	a.output.AppCMD.Stdout = a.newWriter(lock, colorIndex, appID, a.output.AppLog)
+	a.output.AppCMD.Stderr = a.output.AppCMD.Stdout
+	err = a.output.AppCMD.Start()
+	if err != nil {
+		return err
+	}
+	go a.wait(a.output.AppCMD, fmt.Sprintf("Bhojpur Application %s", appID), exited)
+	return nil
+}
+
+// updateInstance records the started processes in the instances registry.
+func (a *runFileApp) updateInstance() {
+	err := a.output.UpdateInstance()
+	if err != nil {
+		utils.WarningStatusEvent(os.Stdout, "Could not update the instances registry for %s: %s", a.output.AppID, err.Error())
+	}
+}
+
+// updateMetadata records the cli PID and application command in the sidecar
+// metadata, which is what `appctl list` and `appctl stop` rely upon.
+func (a *runFileApp) updateMetadata() {
+	appID := a.output.AppID
+	err := utils.IsAppListeningOnPort(a.output.AppHTTPPort, time.Duration(runtimeWaitTimeoutInSeconds)*time.Second)
+	if err != nil {
+		utils.WarningStatusEvent(os.Stdout, "Bhojpur Application runtime sidecar %s is not listening on HTTP port: %s", appID, err.Error())
+		return
+	}
+
+	err = utils.Put(a.output.AppHTTPPort, "cliPID", strconv.Itoa(os.Getpid()), appID, "")
+	if err != nil {
+		utils.WarningStatusEvent(os.Stdout, "Could not update sidecar %s metadata for cliPID: %s", appID, err.Error())
+	}
+
+	if a.output.AppCMD != nil {
+		err = utils.Put(a.output.AppHTTPPort, "appCommand", strings.Join(a.config.Arguments, " "), appID, "")
+		if err != nil {
+			utils.WarningStatusEvent(os.Stdout, "Could not update sidecar %s metadata for appCommand: %s", appID, err.Error())
+		}
+	}
+}
+
+// stop kills the application first and its sidecar afterwards.
+func (a *runFileApp) stop() {
+	if a.stopped {
+		return
+	}
+	a.stopped = true
+
+	appID := a.output.AppID
+	if a.output.AppCMD != nil {
+		killRunFileProcess(a.output.AppCMD, fmt.Sprintf("Bhojpur Application %s", appID))
+	}
+	killRunFileProcess(a.output.SvrCMD, fmt.Sprintf("Bhojpur Application runtime %s", appID))
+
+	err := a.output.RemoveInstance()
+	if err != nil {
+		utils.WarningStatusEvent(os.Stdout, "Could not remove %s from the instances registry: %s", appID, err.Error())
+	}
+	a.output.CloseLogs()
+}
+
+func (a *runFileApp) wait(cmd *exec.Cmd, name string, exited chan<- *runFileApp) {
+	err := cmd.Wait()
+	if err != nil {
+		utils.FailureStatusEvent(os.Stderr, "%s process exited with error code: %s", name, err.Error())
+	} else {
+		utils.SuccessStatusEvent(os.Stdout, "Exited %s successfully", name)
+	}
+	exited <- a
+}
+
+func killRunFileProcess(cmd *exec.Cmd, name string) {
+	if cmd.Process == nil {
+		return
+	}
+	err := cmd.Process.Kill()
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
+		utils.FailureStatusEvent(os.Stderr, "Error exiting %s: %s", name, err)
+	}
+}
+
+// runFromFile starts all the applications of a run file together. Each app is
+// shut down along with its sidecar when either exits, and all of them are shut
+// down when a termination signal is received. It returns once no app is left.
+func runFromFile(path string) {
+	runFile, err := standalone.ParseRunFile(path)
+	if err != nil {
+		utils.FailureStatusEvent(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	setupShutdownNotify(sigCh)
+
+	// Every app reports the exit of both its sidecar and its application.
+	exited := make(chan *runFileApp, 2*len(runFile.Apps))
+
+	var outputLock sync.Mutex
+	apps := make([]*runFileApp, 0, len(runFile.Apps))
+	stopAll := func() {
+		for i := len(apps) - 1; i >= 0; i-- {
+			apps[i].stop()
+		}
+	}
+
+	// Applications are started one after the other, so that ports picked
+	// for a sidecar are already taken when the next one is validated.
+	for i, config := range runFile.RunConfigs(viper.GetString("placement-host-address")) {
+		output, err := standalone.Run(config)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Failed to start %s: %s", config.AppID, err.Error())
+			stopAll()
+			os.Exit(1)
+		}
+
+		app := &runFileApp{config: config, output: output}
+		apps = append(apps, app)
+		err = app.start(&outputLock, i, exited)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Failed to start %s: %s", config.AppID, err.Error())
+			stopAll()
+			os.Exit(1)
+		}
+		app.updateInstance()
+	}
+
+	var wg sync.WaitGroup
+	for _, app := range apps {
+		wg.Add(1)
+		go func(app *runFileApp) {
+			defer wg.Done()
+			app.updateMetadata()
+		}(app)
+	}
+	wg.Wait()
+
+	utils.SuccessStatusEvent(os.Stdout, "You're up and running! Logs of all %d applications will appear here.\n", len(apps))
+
+	for running := len(apps); running > 0; {
+		select {
+		case <-sigCh:
+			utils.InfoStatusEvent(os.Stdout, "\nterminated signal received: shutting down")
+			stopAll()
+			return
+		case app := <-exited:
+			if app.stopped {
+				continue
+			}
+			app.stop()
+			running--
+		}
+	}
+	utils.InfoStatusEvent(os.Stdout, "All applications exited: shutting down")
+}
@@ -0,0 +1,319 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+
+	"github.com/gocarina/gocsv"
+	"github.com/spf13/cobra"
+
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+var (
+	stateAppID       string
+	stateStoreName   string
+	stateKey         string
+	stateKeys        []string
+	stateValue       string
+	stateValueFile   string
+	stateQuery       string
+	stateQueryFile   string
+	stateETag        string
+	stateConsistency string
+	stateConcurrency string
+	stateMetadata    map[string]string
+	stateSocket      string
+	stateOutput      string
+)
+
+// stateRow is the table representation of a state item.
+type stateRow struct {
+	Key   string `csv:"KEY"`
+	ETag  string `csv:"ETAG"`
+	Value string `csv:"VALUE"`
+	Error string `csv:"ERROR"`
+}
+
+var StateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Read and write the state stores of an application. Supported platforms: Self-hosted",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if stateOutput != "" && stateOutput != "json" && stateOutput != "yaml" && stateOutput != "table" {
+			utils.FailureStatusEvent(os.Stderr, "An invalid output format was specified.")
+			os.Exit(1)
+		}
+
+		// TODO: add Windows support
+		if stateSocket != "" {
+			if runtime.GOOS == "windows" {
+				utils.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
+				os.Exit(1)
+			} else {
+				utils.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
+			}
+		}
+	},
+}
+
+var StateGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a key from a state store",
+	Example: `
+# Get a key from the statestore of myapp
+appctl state get --app-id myapp --store-name statestore --key order-1
+
+# Get a key with strong consistency as JSON
+appctl state get --app-id myapp --store-name statestore --key order-1 --consistency strong -o json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		item, err := standalone.NewClient().GetState(stateAppID, stateStoreName, stateKey, stateOptions(), stateSocket)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error getting key %s from state store %s: %s", stateKey, stateStoreName, err)
+			os.Exit(1)
+		}
+		if item == nil {
+			utils.WarningStatusEvent(os.Stdout, "Key %s not found in state store %s", stateKey, stateStoreName)
+			return
+		}
+
+		outputStateItems([]standalone.StateItem{*item})
+	},
+}
+
+var StateSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Save a key to a state store",
+	Example: `
+# Save a JSON value to the statestore of myapp
+appctl state set --app-id myapp --store-name statestore --key order-1 --value '{"id":1}'
+
+# Save a value only if the etag still matches
+appctl state set --app-id myapp --store-name statestore --key order-1 --value-file order.json --etag 3 --concurrency first-write
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if stateValueFile != "" && stateValue != "" {
+			utils.FailureStatusEvent(os.Stderr, "Only one of --value and --value-file allowed in the same set command")
+			os.Exit(1)
+		}
+
+		value := []byte(stateValue)
+		if stateValueFile != "" {
+			var err error
+			value, err = ioutil.ReadFile(stateValueFile)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, "Error reading value from '%s'. Error: %s", stateValueFile, err)
+				os.Exit(1)
+			}
+		}
+
+		err := standalone.NewClient().SaveState(stateAppID, stateStoreName, stateKey, value, stateOptions(), stateSocket)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error saving key %s to state store %s: %s", stateKey, stateStoreName, err)
+			os.Exit(1)
+		}
+
+		utils.SuccessStatusEvent(os.Stdout, "Key %s saved successfully", stateKey)
+	},
+}
+
+var StateDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a key from a state store",
+	Example: `
+# Delete a key from the statestore of myapp
+appctl state delete --app-id myapp --store-name statestore --key order-1
+
+# Delete a key only if the etag still matches
+appctl state delete --app-id myapp --store-name statestore --key order-1 --etag 3
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := standalone.NewClient().DeleteState(stateAppID, stateStoreName, stateKey, stateOptions(), stateSocket)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error deleting key %s from state store %s: %s", stateKey, stateStoreName, err)
+			os.Exit(1)
+		}
+
+		utils.SuccessStatusEvent(os.Stdout, "Key %s deleted successfully", stateKey)
+	},
+}
+
+var StateBulkGetCmd = &cobra.Command{
+	Use:   "bulk-get",
+	Short: "Get several keys from a state store",
+	Example: `
+# Get several keys from the statestore of myapp
+appctl state bulk-get --app-id myapp --store-name statestore --keys order-1,order-2
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		items, err := standalone.NewClient().BulkGetState(stateAppID, stateStoreName, stateKeys, stateOptions(), stateSocket)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error getting keys from state store %s: %s", stateStoreName, err)
+			os.Exit(1)
+		}
+
+		outputStateItems(items)
+	},
+}
+
+var StateQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query a state store",
+	Example: `
+# Query the statestore of myapp
+appctl state query --app-id myapp --store-name statestore --query '{"filter":{"EQ":{"state":"CA"}}}'
+
+# Query the statestore of myapp with a query read from a file, as YAML
+appctl state query --app-id myapp --store-name statestore --query-file query.json -o yaml
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if stateQueryFile != "" && stateQuery != "" {
+			utils.FailureStatusEvent(os.Stderr, "Only one of --query and --query-file allowed in the same query command")
+			os.Exit(1)
+		}
+
+		query := []byte(stateQuery)
+		if stateQueryFile != "" {
+			var err error
+			query, err = ioutil.ReadFile(stateQueryFile)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, "Error reading query from '%s'. Error: %s", stateQueryFile, err)
+				os.Exit(1)
+			}
+		}
+
+		result, err := standalone.NewClient().QueryState(stateAppID, stateStoreName, query, stateOptions(), stateSocket)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, "Error querying state store %s: %s", stateStoreName, err)
+			os.Exit(1)
+		}
+
+		if stateOutput == "json" || stateOutput == "yaml" {
+			err = utils.PrintDetail(os.Stdout, stateOutput, result)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			return
+		}
+
+		outputStateItems(result.Results)
+		if result.Token != "" {
+			fmt.Printf("\nPagination token: %s\n", result.Token)
+		}
+	},
+}
+
+func stateOptions() standalone.StateOptions {
+	return standalone.StateOptions{
+		ETag:        stateETag,
+		Consistency: stateConsistency,
+		Concurrency: stateConcurrency,
+		Metadata:    stateMetadata,
+	}
+}
+
+func outputStateItems(items []standalone.StateItem) {
+	if stateOutput == "json" || stateOutput == "yaml" {
+		err := utils.PrintDetail(os.Stdout, stateOutput, items)
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No state found.")
+		return
+	}
+
+	rows := make([]stateRow, 0, len(items))
+	for _, item := range items {
+		row := stateRow{
+			Key:   item.Key,
+			ETag:  item.ETag,
+			Error: item.Error,
+		}
+		if s, ok := item.Value.(string); ok {
+			row.Value = s
+		} else if item.Value != nil {
+			b, _ := json.Marshal(item.Value)
+			row.Value = string(b)
+		}
+		rows = append(rows, row)
+	}
+
+	table, err := gocsv.MarshalString(rows)
+	if err != nil {
+		utils.FailureStatusEvent(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	utils.PrintTable(table)
+}
+
+func init() {
+	StateCmd.PersistentFlags().StringVarP(&stateAppID, "app-id", "a", "", "The application id whose sidecar serves the state store")
+	StateCmd.PersistentFlags().StringVarP(&stateStoreName, "store-name", "s", "", "The name of the state store component")
+	StateCmd.PersistentFlags().StringToStringVarP(&stateMetadata, "metadata", "m", nil, "Metadata passed to the state store, as key=value pairs")
+	StateCmd.PersistentFlags().StringVarP(&stateSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Bhojpur Application API servers will use Unix Domain Sockets")
+	StateCmd.PersistentFlags().StringVarP(&stateOutput, "output", "o", "", "The output format. Valid values are: json, yaml, or table (default)")
+	StateCmd.PersistentFlags().BoolP("help", "h", false, "Print this help message")
+	StateCmd.MarkPersistentFlagRequired("app-id")
+	StateCmd.MarkPersistentFlagRequired("store-name")
+
+	StateGetCmd.Flags().StringVarP(&stateKey, "key", "k", "", "The key to get")
+	StateGetCmd.Flags().StringVar(&stateConsistency, "consistency", "", "The read consistency. Valid values are: eventual or strong")
+	StateGetCmd.MarkFlagRequired("key")
+
+	StateSetCmd.Flags().StringVarP(&stateKey, "key", "k", "", "The key to save")
+	StateSetCmd.Flags().StringVarP(&stateValue, "value", "v", "", "The value to save. JSON values are saved as JSON, anything else as a string")
+	StateSetCmd.Flags().StringVarP(&stateValueFile, "value-file", "f", "", "A file containing the value to save")
+	StateSetCmd.Flags().StringVar(&stateETag, "etag", "", "The etag the saved key must match")
+	StateSetCmd.Flags().StringVar(&stateConsistency, "consistency", "", "The write consistency. Valid values are: eventual or strong")
+	StateSetCmd.Flags().StringVar(&stateConcurrency, "concurrency", "", "The concurrency mode. Valid values are: first-write or last-write")
+	StateSetCmd.MarkFlagRequired("key")
+
+	StateDeleteCmd.Flags().StringVarP(&stateKey, "key", "k", "", "The key to delete")
+	StateDeleteCmd.Flags().StringVar(&stateETag, "etag", "", "The etag the deleted key must match")
+	StateDeleteCmd.Flags().StringVar(&stateConsistency, "consistency", "", "The write consistency. Valid values are: eventual or strong")
+	StateDeleteCmd.Flags().StringVar(&stateConcurrency, "concurrency", "", "The concurrency mode. Valid values are: first-write or last-write")
+	StateDeleteCmd.MarkFlagRequired("key")
+
+	StateBulkGetCmd.Flags().StringSliceVarP(&stateKeys, "keys", "k", nil, "The comma separated keys to get")
+	StateBulkGetCmd.MarkFlagRequired("keys")
+
+	StateQueryCmd.Flags().StringVarP(&stateQuery, "query", "q", "", "The JSON serialized query")
+	StateQueryCmd.Flags().StringVarP(&stateQueryFile, "query-file", "f", "", "A file containing the JSON serialized query")
+
+	StateCmd.AddCommand(StateGetCmd)
+	StateCmd.AddCommand(StateSetCmd)
+	StateCmd.AddCommand(StateDeleteCmd)
+	StateCmd.AddCommand(StateBulkGetCmd)
+	StateCmd.AddCommand(StateQueryCmd)
+	rootCmd.AddCommand(StateCmd)
+}
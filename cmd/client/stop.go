@@ -22,6 +22,7 @@ package cmd
 
 import (
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -29,7 +30,10 @@ import (
 	"github.com/bhojpur/application/pkg/utils"
 )
 
-var stopAppID string
+var (
+	stopAppID       string
+	stopRunFilePath string
+)
 
 var StopCmd = &cobra.Command{
 	Use:   "stop",
@@ -37,8 +41,25 @@ var StopCmd = &cobra.Command{
 	Example: `
 # Stop the Bhojpur Application
 appctl stop --app-id <ID>
+
+# Stop all the applications started from a run file
+appctl stop -f run.yaml
 `,
 	Run: func(cmd *cobra.Command, args []string) {
+		if stopRunFilePath != "" {
+			runFile, err := standalone.ParseRunFile(stopRunFilePath)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			err = standalone.StopRunFile(runFile)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, "failed to stop apps from run file %s: %s", stopRunFilePath, err)
+			} else {
+				utils.SuccessStatusEvent(os.Stdout, "apps stopped successfully: %s", strings.Join(runFile.AppIDs(), ", "))
+			}
+			return
+		}
 		if stopAppID != "" {
 			args = append(args, stopAppID)
 		}
@@ -55,6 +76,7 @@ appctl stop --app-id <ID>
 
 func init() {
 	StopCmd.Flags().StringVarP(&stopAppID, "app-id", "a", "", "The application id to be stopped")
+	StopCmd.Flags().StringVarP(&stopRunFilePath, "run-file", "f", "", "Path to the run file whose applications are to be stopped")
 	StopCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	rootCmd.AddCommand(StopCmd)
 }
@@ -0,0 +1,365 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+	reverseVideo   = "\x1b[7m"
+	resetVideo     = "\x1b[0m"
+)
+
+var (
+	topAppID   string
+	topRefresh time.Duration
+)
+
+var TopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show live request rates, latencies, actors and components of the applications running in self-hosted mode",
+	Example: `
+# Watch all the running applications
+appctl top
+
+# Watch a single application
+appctl top --app-id myapp
+
+# Refresh every 5 seconds
+appctl top --refresh 5s
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if topRefresh <= 0 {
+			utils.FailureStatusEvent(os.Stderr, "The refresh interval must be greater than zero")
+			os.Exit(1)
+		}
+
+		top := standalone.NewTop()
+		var err error
+		if term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+			err = runTopUI(top)
+		} else {
+			err = printTop(top)
+		}
+		if err != nil {
+			utils.FailureStatusEvent(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+type topKey int
+
+const (
+	topKeyNone topKey = iota
+	topKeyUp
+	topKeyDown
+	topKeyEnter
+	topKeyBack
+	topKeyQuit
+)
+
+func parseTopKey(b []byte) topKey {
+	switch {
+	case len(b) == 0:
+		return topKeyNone
+	case string(b) == "\x1b[A", b[0] == 'k':
+		return topKeyUp
+	case string(b) == "\x1b[B", b[0] == 'j':
+		return topKeyDown
+	case b[0] == '\r', b[0] == '\n', string(b) == "\x1b[C", b[0] == 'l':
+		return topKeyEnter
+	case string(b) == "\x1b", string(b) == "\x1b[D", b[0] == 127, b[0] == 'b', b[0] == 'h':
+		return topKeyBack
+	case b[0] == 'q', b[0] == 3:
+		return topKeyQuit
+	}
+	return topKeyNone
+}
+
+func readTopKeys(r io.Reader, keys chan<- topKey) {
+	buf := make([]byte, 8)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			keys <- topKeyQuit
+			return
+		}
+		keys <- parseTopKey(buf[:n])
+	}
+}
+
+// topView is the state of the terminal UI: the app list, or the details of
+// the app drilled into.
+type topView struct {
+	apps     []standalone.AppTop
+	err      error
+	updated  time.Time
+	selected int
+	appID    string
+}
+
+func (v *topView) update(apps []standalone.AppTop, err error) {
+	v.apps, v.err, v.updated = apps, err, time.Now()
+	if v.selected >= len(v.apps) {
+		v.selected = len(v.apps) - 1
+	}
+	if v.selected < 0 {
+		v.selected = 0
+	}
+}
+
+// handle applies a key press, returning false when the UI should exit.
+func (v *topView) handle(k topKey) bool {
+	switch k {
+	case topKeyQuit:
+		return false
+	case topKeyUp:
+		if v.appID == "" && v.selected > 0 {
+			v.selected--
+		}
+	case topKeyDown:
+		if v.appID == "" && v.selected < len(v.apps)-1 {
+			v.selected++
+		}
+	case topKeyEnter:
+		if v.appID == "" && v.selected < len(v.apps) {
+			v.appID = v.apps[v.selected].App.AppID
+		}
+	case topKeyBack:
+		v.appID = ""
+	}
+	return true
+}
+
+func (v *topView) app(appID string) *standalone.AppTop {
+	for i := range v.apps {
+		if v.apps[i].App.AppID == appID {
+			return &v.apps[i]
+		}
+	}
+	return nil
+}
+
+func (v *topView) render(w io.Writer, interactive bool) {
+	if v.err != nil {
+		fmt.Fprintf(w, "Error listing applications: %s\n", v.err)
+		return
+	}
+	if v.appID != "" {
+		v.renderApp(w)
+		return
+	}
+
+	if interactive {
+		fmt.Fprintf(w, "appctl top - %d apps - %s - refresh every %s\n", len(v.apps), v.updated.Format("15:04:05"), topRefresh)
+		fmt.Fprintln(w, "up/down: select   enter: details   q: quit")
+		fmt.Fprintln(w)
+	}
+	if len(v.apps) == 0 {
+		fmt.Fprintln(w, "No Bhojpur Application instances found.")
+		return
+	}
+
+	rows := make([][]string, 0, len(v.apps))
+	for _, a := range v.apps {
+		status := "running"
+		if a.Err != nil {
+			status = a.Err.Error()
+		}
+		rows = append(rows, []string{
+			a.App.AppID, formatRate(a.RequestRate), formatRate(a.ErrorRate),
+			formatLatency(a.P50), formatLatency(a.P90), formatLatency(a.P99),
+			strconv.Itoa(a.Actors()), strconv.Itoa(len(a.Components)), a.App.Age, status,
+		})
+	}
+	selected := -1
+	if interactive {
+		selected = v.selected
+	}
+	writeTopTable(w, []string{"APP ID", "REQ/S", "ERR/S", "P50 (MS)", "P90 (MS)", "P99 (MS)", "ACTORS", "COMPONENTS", "AGE", "STATUS"}, rows, selected)
+}
+
+func (v *topView) renderApp(w io.Writer) {
+	a := v.app(v.appID)
+	if a == nil {
+		fmt.Fprintf(w, "%s is no longer running.\n", v.appID)
+		return
+	}
+
+	fmt.Fprintf(w, "%s - %s - refresh every %s\n", a.App.AppID, v.updated.Format("15:04:05"), topRefresh)
+	fmt.Fprintf(w, "HTTP port: %d   gRPC port: %d   app port: %d   metrics port: %d   PID: %d   age: %s\n",
+		a.App.HTTPPort, a.App.GRPCPort, a.App.AppPort, a.App.MetricsPort, a.App.PID, a.App.Age)
+	fmt.Fprintf(w, "req/s: %s   err/s: %s   p50: %s ms   p90: %s ms   p99: %s ms\n",
+		formatRate(a.RequestRate), formatRate(a.ErrorRate), formatLatency(a.P50), formatLatency(a.P90), formatLatency(a.P99))
+	if a.Err != nil {
+		fmt.Fprintf(w, "error: %s\n", a.Err)
+	}
+
+	fmt.Fprintln(w)
+	rows := make([][]string, 0, len(a.Endpoints))
+	for _, e := range a.Endpoints {
+		rows = append(rows, []string{e.Name, formatRate(e.RequestRate), formatRate(e.ErrorRate), formatLatency(e.P50), formatLatency(e.P90), formatLatency(e.P99)})
+	}
+	writeTopSection(w, "Endpoints", []string{"ENDPOINT", "REQ/S", "ERR/S", "P50 (MS)", "P90 (MS)", "P99 (MS)"}, rows)
+
+	rows = make([][]string, 0, len(a.ActiveActors))
+	for _, actors := range a.ActiveActors {
+		rows = append(rows, []string{actors.Type, strconv.Itoa(actors.Count)})
+	}
+	writeTopSection(w, "Actors", []string{"TYPE", "COUNT"}, rows)
+
+	rows = make([][]string, 0, len(a.Components))
+	for _, c := range a.Components {
+		rows = append(rows, []string{c.Name, c.Type, c.Version})
+	}
+	writeTopSection(w, "Components", []string{"NAME", "TYPE", "VERSION"}, rows)
+}
+
+func writeTopSection(w io.Writer, title string, header []string, rows [][]string) {
+	fmt.Fprintln(w, title)
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "  none")
+	} else {
+		writeTopTable(w, header, rows, -1)
+	}
+	fmt.Fprintln(w)
+}
+
+// writeTopTable aligns the rows in columns, highlighting the selected row.
+func writeTopTable(w io.Writer, header []string, rows [][]string, selected int) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if i == selected+1 {
+			line = reverseVideo + line + resetVideo
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func formatRate(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+func formatLatency(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+// fitScreen clips the rendered lines to the terminal size and terminates them
+// with the carriage returns needed in raw mode.
+func fitScreen(s string, width, height int) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		if width > 0 && !strings.HasPrefix(line, reverseVideo) && utf8.RuneCountInString(line) > width {
+			lines[i] = string([]rune(line)[:width])
+		}
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+func runTopUI(top *standalone.Top) error {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	fmt.Print(enterAltScreen)
+	defer fmt.Print(leaveAltScreen)
+
+	keys := make(chan topKey)
+	go readTopKeys(os.Stdin, keys)
+
+	ticker := time.NewTicker(topRefresh)
+	defer ticker.Stop()
+
+	view := &topView{appID: topAppID}
+	view.update(top.Refresh())
+	for {
+		var buf bytes.Buffer
+		view.render(&buf, true)
+		width, height, _ := term.GetSize(int(os.Stdout.Fd()))
+		fmt.Print(clearScreen + fitScreen(buf.String(), width, height))
+
+		select {
+		case <-ticker.C:
+			view.update(top.Refresh())
+		case k := <-keys:
+			if !view.handle(k) {
+				return nil
+			}
+		}
+	}
+}
+
+// printTop prints a single sample when the output is not a terminal. Rates are
+// measured over one refresh interval.
+func printTop(top *standalone.Top) error {
+	_, err := top.Refresh()
+	if err != nil {
+		return err
+	}
+	time.Sleep(topRefresh)
+
+	view := &topView{appID: topAppID}
+	view.update(top.Refresh())
+	view.render(os.Stdout, false)
+	return view.err
+}
+
+func init() {
+	TopCmd.Flags().StringVarP(&topAppID, "app-id", "a", "", "Show the details of an application")
+	TopCmd.Flags().DurationVarP(&topRefresh, "refresh", "r", 2*time.Second, "The refresh interval")
+	TopCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	rootCmd.AddCommand(TopCmd)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bhojpur/application/pkg/lint"
+	"github.com/bhojpur/application/pkg/standalone"
+	"github.com/bhojpur/application/pkg/utils"
+	"github.com/bhojpur/service/pkg/utils/logger"
+)
+
+var (
+	validateComponentsPath string
+	validateConfigFile     string
+	validateOutputFormat   string
+	validateStrict         bool
+	validateSkipTypes      bool
+)
+
+var ValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Statically check a components directory and a configuration file. Supported platforms: self-hosted",
+	Example: `
+# Validate the default components directory and configuration file
+appctl validate
+
+# Validate the components and configuration of a project and print a JSON report for CI
+appctl validate --components-path ./components --config ./config.yaml -o json
+
+# Fail on warnings too
+appctl validate --strict
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if validateOutputFormat != "" && validateOutputFormat != "json" && validateOutputFormat != "yaml" && validateOutputFormat != "text" {
+			utils.FailureStatusEvent(os.Stderr, "An invalid output format was specified.")
+			os.Exit(1)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		// The access control parser logs the defaults it applies, which are
+		// reported as findings instead.
+		logger.NewLogger("app.acl").SetOutputLevel(logger.FatalLevel)
+
+		opts := lint.Options{
+			ComponentsPath: validateComponentsPath,
+			ConfigFile:     validateConfigFile,
+		}
+		if !validateSkipTypes {
+			types, err := standalone.GetComponentTypes()
+			if err != nil {
+				utils.WarningStatusEvent(os.Stderr, "Component types are not checked: %s", err)
+			} else {
+				opts.Registries = lint.NewRegistries(*types)
+			}
+		}
+
+		report := lint.Validate(opts)
+		if validateStrict && report.Warnings > 0 {
+			report.Valid = false
+		}
+
+		if validateOutputFormat == "json" || validateOutputFormat == "yaml" {
+			err := utils.PrintDetail(os.Stdout, validateOutputFormat, report)
+			if err != nil {
+				utils.FailureStatusEvent(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		} else {
+			for _, f := range report.Findings {
+				if f.Severity == lint.SeverityError {
+					utils.FailureStatusEvent(os.Stdout, "%s: %s: %s", f.File, f.Resource, f.Message)
+				} else {
+					utils.WarningStatusEvent(os.Stdout, "%s: %s: %s", f.File, f.Resource, f.Message)
+				}
+			}
+			if report.Valid {
+				utils.SuccessStatusEvent(os.Stdout, "Validation passed with %d errors and %d warnings", report.Errors, report.Warnings)
+			} else {
+				utils.FailureStatusEvent(os.Stdout, "Validation failed with %d errors and %d warnings", report.Errors, report.Warnings)
+			}
+		}
+
+		if !report.Valid {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ValidateCmd.Flags().StringVarP(&validateComponentsPath, "components-path", "d", standalone.DefaultComponentsDirPath(), "The path for components directory")
+	ValidateCmd.Flags().StringVarP(&validateConfigFile, "config", "c", standalone.DefaultConfigFilePath(), "Bhojpur Application runtime configuration file")
+	ValidateCmd.Flags().StringVarP(&validateOutputFormat, "output", "o", "", "The output format of the report. Valid values are: json, yaml, or text (default)")
+	ValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Fail when warnings are found")
+	ValidateCmd.Flags().BoolVar(&validateSkipTypes, "skip-types", false, "Do not check component types against the components included in the local runtime")
+	ValidateCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	rootCmd.AddCommand(ValidateCmd)
+}
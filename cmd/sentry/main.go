@@ -48,13 +48,14 @@ const (
 	// defaultAppSystemConfigName is the default resource object name for Bhojpur Application System Config.
 	defaultAppSystemConfigName = "appsystem"
 
-	healthzPort = 8080
+	defaultHealthzPort = 8080
 )
 
 func main() {
 	configName := flag.String("config", defaultAppSystemConfigName, "Path to config file, or name of a configuration object")
 	credsPath := flag.String("issuer-credentials", defaultCredentialsPath, "Path to the credentials directory holding the issuer data")
 	trustDomain := flag.String("trust-domain", "localhost", "The CA trust domain")
+	healthzPort := flag.Int("healthz-port", defaultHealthzPort, "The port for the healthz server to listen on")
 
 	loggerOptions := logger.DefaultOptions()
 	loggerOptions.AttachCmdFlags(flag.StringVar, flag.BoolVar)
@@ -124,7 +125,7 @@ func main() {
 		healthzServer := health.NewServer(log)
 		healthzServer.Ready()
 
-		err := healthzServer.Run(ctx, healthzPort)
+		err := healthzServer.Run(ctx, *healthzPort)
 		if err != nil {
 			log.Fatalf("failed to start healthz server: %s", err)
 		}
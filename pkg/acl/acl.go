@@ -148,6 +148,12 @@ func ParseAccessControlSpec(accessControlSpec config.AccessControlSpec, protocol
 			invalidAppName)
 	}
 
+	identifier, err := newCallerIdentifier(accessControlSpec.CallerIdentity, accessControlList.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access control spec. %s", err)
+	}
+	accessControlList.CallerIdentifier = identifier
+
 	return &accessControlList, nil
 }
 
@@ -245,12 +251,25 @@ func normalizeOperation(operation string) (string, error) {
 	return s, nil
 }
 
-func ApplyAccessControlPolicies(ctx context.Context, operation string, httpVerb commonv1pb.HTTPExtension_Verb, appProtocol string, acl *config.AccessControlList) (bool, string) {
+// ApplyAccessControlPolicies applies the access control list to an operation called
+// by the app identified by the SPIFFE id of its client certificate, or else by the
+// caller identifier of the list using the call metadata.
+func ApplyAccessControlPolicies(ctx context.Context, operation string, httpVerb commonv1pb.HTTPExtension_Verb, appProtocol string, acl *config.AccessControlList, metadata map[string][]string) (bool, string) {
 	// Apply access control list filter
-	spiffeID, err := GetAndParseSpiffeID(ctx)
-	if err != nil {
-		// Apply the default action
-		log.Debugf("error while reading spiffe id from client cert: %v. applying default global policy action", err.Error())
+	var spiffeID *config.SpiffeID
+	var err error
+	if acl != nil && acl.CallerIdentifier != nil {
+		spiffeID, err = acl.CallerIdentifier.IdentifyCaller(ctx, metadata)
+		if err != nil {
+			// Apply the default action
+			log.Debugf("error while identifying the caller: %v. applying default global policy action", err.Error())
+		}
+	} else {
+		spiffeID, err = GetAndParseSpiffeID(ctx)
+		if err != nil {
+			// Apply the default action
+			log.Debugf("error while reading spiffe id from client cert: %v. applying default global policy action", err.Error())
+		}
 	}
 	var appID, trustDomain, namespace string
 	if spiffeID != nil {
@@ -0,0 +1,303 @@
+package acl
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/bhojpur/application/pkg/config"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	auth "github.com/bhojpur/application/pkg/runtime/security"
+)
+
+const (
+	defaultJWTAppIDClaim     = "sub"
+	defaultJWTNamespaceClaim = "namespace"
+	defaultSecretHeader      = "app-caller-secret"
+
+	// jwksRefreshInterval is the minimum interval between two fetches of a JWKS
+	// URL, which is fetched again when a token is signed by an unknown key.
+	jwksRefreshInterval = time.Minute
+	jwksFetchTimeout    = 10 * time.Second
+)
+
+// newCallerIdentifier returns the identifier of the callers for the source of the
+// spec, or nil when callers are identified by their SPIFFE id. The callers which are
+// not identified by a SPIFFE id belong to the trust domain of the access control
+// list and, unless a JWT tells otherwise, to the namespace of this app.
+func newCallerIdentifier(spec config.CallerIdentitySpec, trustDomain string) (config.CallerIdentifier, error) {
+	namespace := os.Getenv("NAMESPACE")
+	if namespace == "" {
+		namespace = config.DefaultNamespace
+	}
+
+	switch spec.Source {
+	case "", config.CallerIdentitySPIFFE:
+		return nil, nil
+	case config.CallerIdentityJWT:
+		return newJWTIdentifier(spec.JWT, trustDomain, namespace)
+	case config.CallerIdentityAPIToken:
+		token := auth.GetAPIToken()
+		if token == "" {
+			return nil, fmt.Errorf("the %s caller identity requires an API token set in %s", spec.Source, auth.APITokenEnvVar)
+		}
+		return &apiTokenIdentifier{token: token, trustDomain: trustDomain, namespace: namespace}, nil
+	case config.CallerIdentityHeader:
+		return newHeaderIdentifier(spec.Header, trustDomain, namespace)
+	default:
+		return nil, fmt.Errorf("unknown caller identity source: %s", spec.Source)
+	}
+}
+
+// metadataValue returns the first value of a header of the request metadata,
+// whose keys keep the case of the caller. A header set in several cases with
+// different values is ambiguous, so no value is returned for it.
+func metadataValue(md map[string][]string, name string) string {
+	value, found := "", false
+	for k, v := range md {
+		if !strings.EqualFold(k, name) || len(v) == 0 {
+			continue
+		}
+		if found && v[0] != value {
+			return ""
+		}
+		value, found = v[0], true
+	}
+	return value
+}
+
+func secretsEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// apiTokenIdentifier trusts the caller app id set by the sidecar of the caller
+// when the call carries the API token shared by the sidecars.
+type apiTokenIdentifier struct {
+	token       string
+	trustDomain string
+	namespace   string
+}
+
+func (a *apiTokenIdentifier) IdentifyCaller(ctx context.Context, md map[string][]string) (*config.SpiffeID, error) {
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	tokens := incoming.Get(auth.APITokenHeader)
+	if len(tokens) == 0 || !secretsEqual(tokens[0], a.token) {
+		return nil, errors.New("missing or invalid API token")
+	}
+
+	// Only the key written by the sidecar is read, the app can set the header in other cases.
+	var appID string
+	if values := md[invokev1.CallerIDHeader]; len(values) > 0 {
+		appID = values[0]
+	}
+	if appID == "" {
+		return nil, errors.New("missing caller app id")
+	}
+	return &config.SpiffeID{TrustDomain: a.trustDomain, Namespace: a.namespace, AppID: appID}, nil
+}
+
+// headerIdentifier trusts the caller app id of a header when the request
+// carries a shared secret.
+type headerIdentifier struct {
+	name         string
+	secretHeader string
+	secret       string
+	trustDomain  string
+	namespace    string
+}
+
+func newHeaderIdentifier(spec config.HeaderIdentitySpec, trustDomain, namespace string) (*headerIdentifier, error) {
+	if spec.SecretEnvVar == "" {
+		return nil, errors.New("the header caller identity requires the secretEnvVar of the shared secret")
+	}
+	secret := os.Getenv(spec.SecretEnvVar)
+	if secret == "" {
+		return nil, fmt.Errorf("the shared secret of the header caller identity is not set in %s", spec.SecretEnvVar)
+	}
+
+	h := &headerIdentifier{
+		name:         spec.Name,
+		secretHeader: spec.SecretHeader,
+		secret:       secret,
+		trustDomain:  trustDomain,
+		namespace:    namespace,
+	}
+	if h.name == "" {
+		h.name = invokev1.CallerIDHeader
+	}
+	if h.secretHeader == "" {
+		h.secretHeader = defaultSecretHeader
+	}
+	return h, nil
+}
+
+func (h *headerIdentifier) IdentifyCaller(ctx context.Context, md map[string][]string) (*config.SpiffeID, error) {
+	if !secretsEqual(metadataValue(md, h.secretHeader), h.secret) {
+		return nil, fmt.Errorf("missing or invalid shared secret in header %s", h.secretHeader)
+	}
+
+	appID := metadataValue(md, h.name)
+	if appID == "" {
+		return nil, fmt.Errorf("missing caller app id in header %s", h.name)
+	}
+	return &config.SpiffeID{TrustDomain: h.trustDomain, Namespace: h.namespace, AppID: appID}, nil
+}
+
+// jwtIdentifier identifies the callers by the claims of the bearer JWT of the
+// Authorization header.
+type jwtIdentifier struct {
+	spec        config.JWTIdentitySpec
+	trustDomain string
+	namespace   string
+	client      *http.Client
+
+	lock      sync.Mutex
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+func newJWTIdentifier(spec config.JWTIdentitySpec, trustDomain, namespace string) (*jwtIdentifier, error) {
+	if (spec.JWKSFile == "") == (spec.JWKSURL == "") {
+		return nil, errors.New("the jwt caller identity requires one of jwksFile or jwksURL")
+	}
+	if spec.AppIDClaim == "" {
+		spec.AppIDClaim = defaultJWTAppIDClaim
+	}
+	if spec.NamespaceClaim == "" {
+		spec.NamespaceClaim = defaultJWTNamespaceClaim
+	}
+
+	j := &jwtIdentifier{
+		spec:        spec,
+		trustDomain: trustDomain,
+		namespace:   namespace,
+		client:      &http.Client{Timeout: jwksFetchTimeout},
+	}
+	// A JWKS file is read once, while a JWKS URL is fetched on first use.
+	if spec.JWKSFile != "" {
+		b, err := ioutil.ReadFile(spec.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the JWKS file: %s", err)
+		}
+		if j.keys, err = parseJWKS(b); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+func parseJWKS(b []byte) (*jose.JSONWebKeySet, error) {
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse the JWKS: %s", err)
+	}
+	return &keys, nil
+}
+
+// keySet returns the keys to verify a token signed by the key keyID, fetching
+// the JWKS URL again when the key is unknown.
+func (j *jwtIdentifier) keySet(keyID string) (*jose.JSONWebKeySet, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.spec.JWKSURL == "" || (j.keys != nil && len(j.keys.Key(keyID)) > 0) || time.Since(j.fetchedAt) < jwksRefreshInterval {
+		if j.keys == nil {
+			return nil, errors.New("the JWKS could not be fetched")
+		}
+		return j.keys, nil
+	}
+
+	j.fetchedAt = time.Now()
+	resp, err := j.client.Get(j.spec.JWKSURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the JWKS: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the JWKS: status code %d", resp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the JWKS: %s", err)
+	}
+	keys, err := parseJWKS(b)
+	if err != nil {
+		return nil, err
+	}
+	j.keys = keys
+	return j.keys, nil
+}
+
+func (j *jwtIdentifier) IdentifyCaller(ctx context.Context, md map[string][]string) (*config.SpiffeID, error) {
+	bearer := metadataValue(md, "Authorization")
+	if len(bearer) < 7 || !strings.EqualFold(bearer[:7], "Bearer ") {
+		return nil, errors.New("missing bearer token in the Authorization header")
+	}
+
+	token, err := jwt.ParseSigned(strings.TrimSpace(bearer[7:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the JWT: %s", err)
+	}
+	if len(token.Headers) == 0 || token.Headers[0].KeyID == "" {
+		return nil, errors.New("the JWT has no key id")
+	}
+	keys, err := j.keySet(token.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims jwt.Claims
+	custom := map[string]interface{}{}
+	if err = token.Claims(keys, &claims, &custom); err != nil {
+		return nil, fmt.Errorf("failed to verify the JWT: %s", err)
+	}
+	expected := jwt.Expected{Issuer: j.spec.Issuer, Time: time.Now()}
+	if j.spec.Audience != "" {
+		expected.Audience = jwt.Audience{j.spec.Audience}
+	}
+	if err = claims.Validate(expected); err != nil {
+		return nil, fmt.Errorf("invalid JWT: %s", err)
+	}
+
+	appID, _ := custom[j.spec.AppIDClaim].(string)
+	if appID == "" {
+		return nil, fmt.Errorf("missing caller app id in the JWT claim %s", j.spec.AppIDClaim)
+	}
+	namespace, _ := custom[j.spec.NamespaceClaim].(string)
+	if namespace == "" {
+		namespace = j.namespace
+	}
+	return &config.SpiffeID{TrustDomain: j.trustDomain, Namespace: namespace, AppID: appID}, nil
+}
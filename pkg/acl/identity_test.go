@@ -0,0 +1,294 @@
+package acl
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/bhojpur/api/pkg/core/v1/common"
+	"github.com/bhojpur/application/pkg/config"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	auth "github.com/bhojpur/application/pkg/runtime/security"
+)
+
+func identityAccessControlSpec(identity config.CallerIdentitySpec) config.AccessControlSpec {
+	return config.AccessControlSpec{
+		DefaultAction:  config.DenyAccess,
+		TrustDomain:    "public",
+		CallerIdentity: identity,
+		AppPolicies: []config.AppPolicySpec{
+			{
+				AppName:       app1,
+				DefaultAction: config.DenyAccess,
+				TrustDomain:   "public",
+				Namespace:     config.DefaultNamespace,
+				AppOperationActions: []config.AppOperation{
+					{
+						Action:    config.AllowAccess,
+						HTTPVerb:  []string{"POST"},
+						Operation: "/op1",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCallerIdentitySource(t *testing.T) {
+	t.Run("spiffe by default", func(t *testing.T) {
+		acl, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{}), config.HTTPProtocol)
+		require.NoError(t, err)
+		assert.Nil(t, acl.CallerIdentifier)
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		_, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{Source: "cookie"}), config.HTTPProtocol)
+		assert.Error(t, err)
+	})
+
+	t.Run("api token is not set", func(t *testing.T) {
+		_, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{Source: config.CallerIdentityAPIToken}), config.HTTPProtocol)
+		assert.Error(t, err)
+	})
+
+	t.Run("shared secret is not set", func(t *testing.T) {
+		_, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{
+			Source: config.CallerIdentityHeader,
+			Header: config.HeaderIdentitySpec{SecretEnvVar: "TEST_ACL_MISSING_SECRET"},
+		}), config.HTTPProtocol)
+		assert.Error(t, err)
+	})
+
+	t.Run("jwks is not set", func(t *testing.T) {
+		_, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{Source: config.CallerIdentityJWT}), config.HTTPProtocol)
+		assert.Error(t, err)
+	})
+}
+
+func TestAPITokenCallerIdentity(t *testing.T) {
+	os.Setenv(auth.APITokenEnvVar, "token")
+	defer os.Unsetenv(auth.APITokenEnvVar)
+
+	acl, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{Source: config.CallerIdentityAPIToken}), config.HTTPProtocol)
+	require.NoError(t, err)
+	md := map[string][]string{invokev1.CallerIDHeader: {app1}}
+
+	t.Run("valid token", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APITokenHeader, "token"))
+		allowed, _ := ApplyAccessControlPolicies(ctx, "op1", common.HTTPExtension_POST, config.HTTPProtocol, acl, md)
+		assert.True(t, allowed)
+		allowed, _ = ApplyAccessControlPolicies(ctx, "op2", common.HTTPExtension_POST, config.HTTPProtocol, acl, md)
+		assert.False(t, allowed)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APITokenHeader, "other"))
+		_, err := acl.CallerIdentifier.IdentifyCaller(ctx, md)
+		assert.Error(t, err)
+		allowed, _ := ApplyAccessControlPolicies(ctx, "op1", common.HTTPExtension_POST, config.HTTPProtocol, acl, md)
+		assert.False(t, allowed)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), md)
+		assert.Error(t, err)
+	})
+
+	t.Run("caller id in another case is ignored", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APITokenHeader, "token"))
+		for i := 0; i < 10; i++ {
+			id, err := acl.CallerIdentifier.IdentifyCaller(ctx, map[string][]string{
+				invokev1.CallerIDHeader: {app1},
+				"App-Caller-Id":         {"spoofed"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, app1, id.AppID)
+		}
+
+		_, err := acl.CallerIdentifier.IdentifyCaller(ctx, map[string][]string{"App-Caller-Id": {app1}})
+		assert.Error(t, err)
+	})
+}
+
+func TestHeaderCallerIdentity(t *testing.T) {
+	os.Setenv("TEST_ACL_SECRET", "secret")
+	defer os.Unsetenv("TEST_ACL_SECRET")
+
+	acl, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{
+		Source: config.CallerIdentityHeader,
+		Header: config.HeaderIdentitySpec{Name: "x-caller", SecretEnvVar: "TEST_ACL_SECRET"},
+	}), config.HTTPProtocol)
+	require.NoError(t, err)
+
+	t.Run("valid secret", func(t *testing.T) {
+		id, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), map[string][]string{
+			"X-Caller":          {app1},
+			"App-Caller-Secret": {"secret"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &config.SpiffeID{TrustDomain: "public", Namespace: config.DefaultNamespace, AppID: app1}, id)
+	})
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), map[string][]string{
+			"X-Caller":          {app1},
+			"App-Caller-Secret": {"guess"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing app id", func(t *testing.T) {
+		_, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), map[string][]string{
+			"App-Caller-Secret": {"secret"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("app id in several cases with different values", func(t *testing.T) {
+		_, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), map[string][]string{
+			"X-Caller":          {app1},
+			"x-caller":          {"app2"},
+			"App-Caller-Secret": {"secret"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTCallerIdentity(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &key.PublicKey, KeyID: "key1", Algorithm: string(jose.RS256), Use: "sig"},
+	}}
+	jwksJSON, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	sign := func(keyID string, claims interface{}) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+			(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID))
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		require.NoError(t, err)
+		return token
+	}
+	bearer := func(token string) map[string][]string {
+		return map[string][]string{"Authorization": {"Bearer " + token}}
+	}
+	now := time.Now()
+	claims := func(subject string) jwt.Claims {
+		return jwt.Claims{
+			Subject:  subject,
+			Issuer:   "issuer",
+			Audience: jwt.Audience{"app2"},
+			Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "acl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	jwksFile := filepath.Join(dir, "jwks.json")
+	require.NoError(t, ioutil.WriteFile(jwksFile, jwksJSON, 0o600))
+
+	acl, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{
+		Source: config.CallerIdentityJWT,
+		JWT:    config.JWTIdentitySpec{JWKSFile: jwksFile, Issuer: "issuer", Audience: "app2"},
+	}), config.HTTPProtocol)
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		md := bearer(sign("key1", claims(app1)))
+		allowed, _ := ApplyAccessControlPolicies(context.Background(), "op1", common.HTTPExtension_POST, config.HTTPProtocol, acl, md)
+		assert.True(t, allowed)
+		allowed, _ = ApplyAccessControlPolicies(context.Background(), "op1", common.HTTPExtension_GET, config.HTTPProtocol, acl, md)
+		assert.False(t, allowed)
+	})
+
+	t.Run("namespace claim", func(t *testing.T) {
+		id, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), bearer(sign("key1", struct {
+			jwt.Claims
+			Namespace string `json:"namespace"`
+		}{claims(app1), "ns1"})))
+		require.NoError(t, err)
+		assert.Equal(t, &config.SpiffeID{TrustDomain: "public", Namespace: "ns1", AppID: app1}, id)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		expired := claims(app1)
+		expired.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+		otherAudience := claims(app1)
+		otherAudience.Audience = jwt.Audience{"app3"}
+
+		for name, md := range map[string]map[string][]string{
+			"missing":        {},
+			"not bearer":     {"Authorization": {"Basic abc"}},
+			"malformed":      bearer("abc"),
+			"unknown key":    bearer(sign("key2", claims(app1))),
+			"expired":        bearer(sign("key1", expired)),
+			"other audience": bearer(sign("key1", otherAudience)),
+			"no subject":     bearer(sign("key1", claims(""))),
+		} {
+			_, err := acl.CallerIdentifier.IdentifyCaller(context.Background(), md)
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("jwks url", func(t *testing.T) {
+		fetches := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetches++
+			w.Write(jwksJSON)
+		}))
+		defer server.Close()
+
+		urlACL, err := ParseAccessControlSpec(identityAccessControlSpec(config.CallerIdentitySpec{
+			Source: config.CallerIdentityJWT,
+			JWT:    config.JWTIdentitySpec{JWKSURL: server.URL},
+		}), config.HTTPProtocol)
+		require.NoError(t, err)
+		assert.Equal(t, 0, fetches)
+
+		id, err := urlACL.CallerIdentifier.IdentifyCaller(context.Background(), bearer(sign("key1", claims(app1))))
+		require.NoError(t, err)
+		assert.Equal(t, app1, id.AppID)
+
+		// Unknown keys do not fetch the JWKS again before the refresh interval.
+		_, err = urlACL.CallerIdentifier.IdentifyCaller(context.Background(), bearer(sign("key2", claims(app1))))
+		assert.Error(t, err)
+		assert.Equal(t, 1, fetches)
+	})
+}
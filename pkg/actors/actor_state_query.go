@@ -0,0 +1,120 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/service/pkg/state"
+
+	state_loader "github.com/bhojpur/application/pkg/components/state"
+)
+
+// ErrActorStateQueryNotSupported is returned when the actor state store can't run queries.
+var ErrActorStateQueryNotSupported = errors.New("actor state store does not support queries")
+
+// QueryState queries the state of all the actors of a type, including actors that aren't active.
+// The state store is asked to scope the query to the keys of the actor type, but the results are
+// filtered here as well since most stores query all of their keys. The page size and the
+// continuation token apply to the store results, so keys of other actor types, keys holding TTL
+// indexes and expired keys can make a page shorter than its limit.
+func (a *actorsRuntime) QueryState(ctx context.Context, req *QueryStateRequest) (*QueryStateResponse, error) {
+	if a.store == nil {
+		return nil, errors.New("actors: state store does not exist or incorrectly configured")
+	}
+
+	querier, ok := a.store.(state.Querier)
+	if !ok || !state.FeatureTransactional.IsPresent(a.store.Features()) {
+		return nil, ErrActorStateQueryNotSupported
+	}
+
+	prefix := constructCompositeKey(a.config.AppID, req.ActorType) + appSeparator
+	metadata := make(map[string]string, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata[state_loader.QueryKeyPrefix] = prefix
+
+	resp, err := querier.Query(&state.QueryRequest{
+		Query:    req.Query,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &QueryStateResponse{
+		Results: []QueryStateItem{},
+	}
+	if resp == nil {
+		return res, nil
+	}
+	res.Token = resp.Token
+	res.Metadata = resp.Metadata
+
+	items := []QueryStateItem{}
+	actorIDs := []string{}
+	seen := map[string]struct{}{}
+	for _, item := range resp.Results {
+		if item.Error != "" || !strings.HasPrefix(item.Key, prefix) {
+			continue
+		}
+		// The actor ID can't contain the separator, the key can.
+		parts := strings.SplitN(strings.TrimPrefix(item.Key, prefix), appSeparator, 2)
+		if len(parts) != 2 || parts[1] == stateTTLIndexKey {
+			continue
+		}
+		items = append(items, QueryStateItem{
+			ActorID: parts[0],
+			Key:     parts[1],
+			Data:    item.Data,
+		})
+		if _, ok := seen[parts[0]]; !ok {
+			seen[parts[0]] = struct{}{}
+			actorIDs = append(actorIDs, parts[0])
+		}
+	}
+
+	if a.config.NativeStateTTL {
+		res.Results = items
+		return res, nil
+	}
+
+	ttlIndexes, err := a.peekStateTTLIndexes(req.ActorType, actorIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, item := range items {
+		index := ttlIndexes[item.ActorID]
+		index.lock.Lock()
+		expired := index.expired(item.Key, now)
+		index.lock.Unlock()
+		if !expired {
+			res.Results = append(res.Results, item)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,149 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/service/pkg/state"
+
+	state_loader "github.com/bhojpur/application/pkg/components/state"
+)
+
+// fakeQuerierStateStore is a fake state store that, like most stores, ignores the key prefix of a
+// query and returns all of its items.
+type fakeQuerierStateStore struct {
+	*fakeStateStore
+	nonTransactional bool
+	bulkGets         int
+	lastQuery        *state.QueryRequest
+}
+
+func (f *fakeQuerierStateStore) Features() []state.Feature {
+	if f.nonTransactional {
+		return []state.Feature{state.FeatureETag}
+	}
+	return f.fakeStateStore.Features()
+}
+
+func (f *fakeQuerierStateStore) BulkGet(req []state.GetRequest) (bool, []state.BulkGetResponse, error) {
+	f.bulkGets++
+	return f.fakeStateStore.BulkGet(req)
+}
+
+func (f *fakeQuerierStateStore) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	res := &state.QueryResponse{Token: "next"}
+	for key, item := range f.items {
+		res.Results = append(res.Results, state.QueryItem{Key: key, Data: item.data})
+	}
+	f.lastQuery = req
+	return res, nil
+}
+
+func TestQueryState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("state store without query support", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+
+		_, err := testActorRuntime.QueryState(ctx, &QueryStateRequest{ActorType: "cat"})
+		assert.True(t, errors.Is(err, ErrActorStateQueryNotSupported))
+	})
+
+	t.Run("state store that isn't transactional", func(t *testing.T) {
+		store := &fakeQuerierStateStore{fakeStateStore: fakeStore().(*fakeStateStore), nonTransactional: true}
+		testActorRuntime := newTestActorsRuntimeWithStore(store, false)
+
+		_, err := testActorRuntime.QueryState(ctx, &QueryStateRequest{ActorType: "cat"})
+		assert.True(t, errors.Is(err, ErrActorStateQueryNotSupported))
+	})
+
+	t.Run("results are scoped to the actor type", func(t *testing.T) {
+		store := &fakeQuerierStateStore{fakeStateStore: fakeStore().(*fakeStateStore)}
+		testActorRuntime := newTestActorsRuntimeWithStore(store, false)
+
+		for _, r := range []TransactionalRequest{
+			{ActorType: "cat", ActorID: "1", Operations: []TransactionalOperation{
+				{Operation: Upsert, Request: TransactionalUpsert{Key: "status", Value: "pending"}},
+				upsertWithTTL("expired", "pending", "60"),
+			}},
+			{ActorType: "cat", ActorID: "2", Operations: []TransactionalOperation{
+				{Operation: Upsert, Request: TransactionalUpsert{Key: "status||nested", Value: "done"}},
+			}},
+			{ActorType: "dog", ActorID: "1", Operations: []TransactionalOperation{
+				{Operation: Upsert, Request: TransactionalUpsert{Key: "status", Value: "pending"}},
+			}},
+		} {
+			fakeCallAndActivateActor(testActorRuntime, r.ActorType, r.ActorID)
+			require.NoError(t, testActorRuntime.TransactionalStateOperation(ctx, &r))
+		}
+
+		index, err := testActorRuntime.loadStateTTLIndex("cat", "1")
+		require.NoError(t, err)
+		index.expiries["expired"] = time.Now().Add(-time.Second)
+
+		res, err := testActorRuntime.QueryState(ctx, &QueryStateRequest{ActorType: "cat"})
+		require.NoError(t, err)
+		assert.Equal(t, "next", res.Token)
+		assert.Equal(t, TestAppID+"||cat||", store.lastQuery.Metadata[state_loader.QueryKeyPrefix])
+		assert.ElementsMatch(t, []QueryStateItem{
+			{ActorID: "1", Key: "status", Data: []byte(`"pending"`)},
+			{ActorID: "2", Key: "status||nested", Data: []byte(`"done"`)},
+		}, res.Results)
+	})
+
+	t.Run("TTL indexes of inactive actors are read in bulk", func(t *testing.T) {
+		store := &fakeQuerierStateStore{fakeStateStore: fakeStore().(*fakeStateStore)}
+		testActorRuntime := newTestActorsRuntimeWithStore(store, false)
+
+		for _, actorID := range []string{"1", "2"} {
+			r := TransactionalRequest{ActorType: "cat", ActorID: actorID, Operations: []TransactionalOperation{
+				{Operation: Upsert, Request: TransactionalUpsert{Key: "status", Value: "pending"}},
+				upsertWithTTL("expired", "pending", "60"),
+			}}
+			fakeCallAndActivateActor(testActorRuntime, r.ActorType, r.ActorID)
+			require.NoError(t, testActorRuntime.TransactionalStateOperation(ctx, &r))
+
+			require.NoError(t, store.Set(&state.SetRequest{
+				Key:   testActorRuntime.constructActorStateKey("cat", actorID, stateTTLIndexKey),
+				Value: map[string]time.Time{"expired": time.Now().Add(-time.Second)},
+			}))
+			testActorRuntime.stateTTLIndexes.Delete(constructCompositeKey("cat", actorID))
+		}
+
+		res, err := testActorRuntime.QueryState(ctx, &QueryStateRequest{ActorType: "cat"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, store.bulkGets)
+		assert.ElementsMatch(t, []QueryStateItem{
+			{ActorID: "1", Key: "status", Data: []byte(`"pending"`)},
+			{ActorID: "2", Key: "status", Data: []byte(`"pending"`)},
+		}, res.Results)
+	})
+}
@@ -0,0 +1,261 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/service/pkg/state"
+)
+
+const (
+	// metadataTTLInSeconds is the state operation metadata that sets the time to live of a key.
+	metadataTTLInSeconds = "ttlInSeconds"
+	// stateTTLIndexKey is the actor state key holding the expiry times of the actor's
+	// keys when the state store can't expire keys itself.
+	stateTTLIndexKey = "__app.ttl"
+)
+
+// ErrInvalidStateTTL is returned when the ttlInSeconds metadata of a state operation is not a positive integer.
+var ErrInvalidStateTTL = errors.New("invalid " + metadataTTLInSeconds)
+
+// stateTTLIndex holds the expiry times of the state keys of an active actor.
+type stateTTLIndex struct {
+	lock     sync.Mutex
+	expiries map[string]time.Time
+}
+
+// expired returns true if the key has an expiry time that has passed.
+func (i *stateTTLIndex) expired(key string, now time.Time) bool {
+	expiry, ok := i.expiries[key]
+	return ok && !now.Before(expiry)
+}
+
+// parseStateTTL returns the time to live set in the metadata of a state operation,
+// or nil if there is none.
+func parseStateTTL(metadata map[string]string) (*time.Duration, error) {
+	val, ok := metadata[metadataTTLInSeconds]
+	if !ok || val == "" {
+		return nil, nil
+	}
+
+	seconds, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || seconds <= 0 {
+		return nil, errors.Wrapf(ErrInvalidStateTTL, "%q must be a number of seconds greater than 0", val)
+	}
+	ttl := time.Duration(seconds) * time.Second
+	return &ttl, nil
+}
+
+// loadStateTTLIndex returns the TTL index of an actor. It is read from the state store
+// once and then kept in memory while the actor is active on this host.
+func (a *actorsRuntime) loadStateTTLIndex(actorType, actorID string) (*stateTTLIndex, error) {
+	actorKey := constructCompositeKey(actorType, actorID)
+	if index, ok := a.stateTTLIndexes.Load(actorKey); ok {
+		return index.(*stateTTLIndex), nil
+	}
+
+	index, err := a.readStateTTLIndex(actorType, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := a.stateTTLIndexes.LoadOrStore(actorKey, index)
+	return actual.(*stateTTLIndex), nil
+}
+
+// readStateTTLIndex reads the TTL index of an actor from the state store.
+func (a *actorsRuntime) readStateTTLIndex(actorType, actorID string) (*stateTTLIndex, error) {
+	partitionKey := constructCompositeKey(a.config.AppID, actorType, actorID)
+	resp, err := a.store.Get(&state.GetRequest{
+		Key:      a.constructActorStateKey(actorType, actorID, stateTTLIndexKey),
+		Metadata: map[string]string{metadataPartitionKey: partitionKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil {
+		return decodeStateTTLIndex(nil)
+	}
+	return decodeStateTTLIndex(resp.Data)
+}
+
+// peekStateTTLIndexes returns the TTL indexes of the given actors without keeping them in
+// memory for the actors that aren't active on this host. Those are read with a single bulk request.
+func (a *actorsRuntime) peekStateTTLIndexes(actorType string, actorIDs []string) (map[string]*stateTTLIndex, error) {
+	indexes := make(map[string]*stateTTLIndex, len(actorIDs))
+	keyActorIDs := map[string]string{}
+	getRequests := []state.GetRequest{}
+	for _, actorID := range actorIDs {
+		if index, ok := a.stateTTLIndexes.Load(constructCompositeKey(actorType, actorID)); ok {
+			indexes[actorID] = index.(*stateTTLIndex)
+			continue
+		}
+		key := a.constructActorStateKey(actorType, actorID, stateTTLIndexKey)
+		keyActorIDs[key] = actorID
+		getRequests = append(getRequests, state.GetRequest{
+			Key:      key,
+			Metadata: map[string]string{metadataPartitionKey: constructCompositeKey(a.config.AppID, actorType, actorID)},
+		})
+	}
+	if len(getRequests) == 0 {
+		return indexes, nil
+	}
+
+	bulkGet, bulkResponse, err := a.store.BulkGet(getRequests)
+	if err != nil {
+		return nil, err
+	}
+	if !bulkGet {
+		// if store doesn't support bulk get, fallback to call get() method one by one
+		for _, actorID := range keyActorIDs {
+			index, err := a.readStateTTLIndex(actorType, actorID)
+			if err != nil {
+				return nil, err
+			}
+			indexes[actorID] = index
+		}
+		return indexes, nil
+	}
+
+	for _, resp := range bulkResponse {
+		actorID, ok := keyActorIDs[resp.Key]
+		if !ok {
+			continue
+		}
+		if resp.Error != "" {
+			return nil, errors.Errorf("could not get actor state TTL index %s: %s", resp.Key, resp.Error)
+		}
+		index, err := decodeStateTTLIndex(resp.Data)
+		if err != nil {
+			return nil, err
+		}
+		indexes[actorID] = index
+	}
+	// Actors without a TTL index have no expiring keys.
+	for _, actorID := range keyActorIDs {
+		if _, ok := indexes[actorID]; !ok {
+			indexes[actorID] = &stateTTLIndex{expiries: map[string]time.Time{}}
+		}
+	}
+	return indexes, nil
+}
+
+func decodeStateTTLIndex(data []byte) (*stateTTLIndex, error) {
+	index := &stateTTLIndex{expiries: map[string]time.Time{}}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &index.expiries); err != nil {
+			return nil, errors.Wrap(err, "failed to read actor state TTL index")
+		}
+	}
+	return index, nil
+}
+
+// stateTTLIndexOperation returns the operation that persists the given expiry times.
+func (a *actorsRuntime) stateTTLIndexOperation(actorType, actorID string, expiries map[string]time.Time, metadata map[string]string) (state.TransactionalStateOperation, error) {
+	key := a.constructActorStateKey(actorType, actorID, stateTTLIndexKey)
+	if len(expiries) == 0 {
+		return state.TransactionalStateOperation{
+			Operation: state.Delete,
+			Request: state.DeleteRequest{
+				Key:      key,
+				Metadata: metadata,
+			},
+		}, nil
+	}
+
+	value, err := json.Marshal(expiries)
+	if err != nil {
+		return state.TransactionalStateOperation{}, err
+	}
+	return state.TransactionalStateOperation{
+		Operation: state.Upsert,
+		Request: state.SetRequest{
+			Key:      key,
+			Value:    value,
+			Metadata: metadata,
+		},
+	}, nil
+}
+
+// sweepExpiredState deletes the expired state keys of a deactivated actor when the
+// state store can't expire keys itself.
+func (a *actorsRuntime) sweepExpiredState(actorType, actorID string) {
+	if a.config.NativeStateTTL || a.store == nil || a.transactionalStore == nil {
+		return
+	}
+
+	actorKey := constructCompositeKey(actorType, actorID)
+	defer a.stateTTLIndexes.Delete(actorKey)
+
+	index, err := a.loadStateTTLIndex(actorType, actorID)
+	if err != nil {
+		log.Warnf("failed to load state TTL index of actor %s: %s", actorKey, err)
+		return
+	}
+
+	index.lock.Lock()
+	defer index.lock.Unlock()
+
+	partitionKey := constructCompositeKey(a.config.AppID, actorType, actorID)
+	metadata := map[string]string{metadataPartitionKey: partitionKey}
+	now := time.Now()
+	remaining := map[string]time.Time{}
+	operations := []state.TransactionalStateOperation{}
+	for key, expiry := range index.expiries {
+		if !index.expired(key, now) {
+			remaining[key] = expiry
+			continue
+		}
+		operations = append(operations, state.TransactionalStateOperation{
+			Operation: state.Delete,
+			Request: state.DeleteRequest{
+				Key:      a.constructActorStateKey(actorType, actorID, key),
+				Metadata: metadata,
+			},
+		})
+	}
+	if len(operations) == 0 {
+		return
+	}
+
+	indexOperation, err := a.stateTTLIndexOperation(actorType, actorID, remaining, metadata)
+	if err != nil {
+		log.Warnf("failed to sweep expired state of actor %s: %s", actorKey, err)
+		return
+	}
+	err = a.transactionalStore.Multi(&state.TransactionalStateRequest{
+		Operations: append(operations, indexOperation),
+		Metadata:   metadata,
+	})
+	if err != nil {
+		log.Warnf("failed to sweep expired state of actor %s: %s", actorKey, err)
+		return
+	}
+	index.expiries = remaining
+	log.Debugf("deleted %d expired state keys of actor %s", len(operations), actorKey)
+}
@@ -0,0 +1,203 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/service/pkg/state"
+)
+
+// recordingStateStore records the transactions sent to the fake state store.
+type recordingStateStore struct {
+	*fakeStateStore
+	requests []*state.TransactionalStateRequest
+}
+
+func (r *recordingStateStore) Multi(request *state.TransactionalStateRequest) error {
+	r.requests = append(r.requests, request)
+	return r.fakeStateStore.Multi(request)
+}
+
+func newTestActorsRuntimeWithStore(store state.Store, nativeStateTTL bool) *actorsRuntime {
+	builder := runtimeBuilder{}
+	a := builder.buildActorRuntime()
+	a.store = store
+	a.transactionalStore = store.(state.TransactionalStore)
+	a.config.NativeStateTTL = nativeStateTTL
+	return a
+}
+
+func upsertWithTTL(key string, value interface{}, ttl string) TransactionalOperation {
+	return TransactionalOperation{
+		Operation: Upsert,
+		Request: TransactionalUpsert{
+			Key:      key,
+			Value:    value,
+			Metadata: map[string]string{metadataTTLInSeconds: ttl},
+		},
+	}
+}
+
+func TestParseStateTTL(t *testing.T) {
+	ttl, err := parseStateTTL(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ttl)
+
+	ttl, err = parseStateTTL(map[string]string{metadataTTLInSeconds: "30"})
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, *ttl)
+
+	for _, val := range []string{"0", "-1", "abc"} {
+		_, err = parseStateTTL(map[string]string{metadataTTLInSeconds: val})
+		assert.True(t, errors.Is(err, ErrInvalidStateTTL), val)
+	}
+}
+
+func TestActorStateTTL(t *testing.T) {
+	ctx := context.Background()
+	actorType, actorID := getTestActorTypeAndID()
+
+	t.Run("native TTL stores receive the metadata", func(t *testing.T) {
+		store := &recordingStateStore{fakeStateStore: fakeStore().(*fakeStateStore)}
+		testActorRuntime := newTestActorsRuntimeWithStore(store, true)
+		fakeCallAndActivateActor(testActorRuntime, actorType, actorID)
+
+		err := testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Operations: []TransactionalOperation{upsertWithTTL("key1", "value1", "60")},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, store.requests, 1)
+		require.Len(t, store.requests[0].Operations, 1)
+		req := store.requests[0].Operations[0].Request.(state.SetRequest)
+		assert.Equal(t, "60", req.Metadata[metadataTTLInSeconds])
+		_, ok := store.items[testActorRuntime.constructActorStateKey(actorType, actorID, stateTTLIndexKey)]
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid TTL is rejected", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		fakeCallAndActivateActor(testActorRuntime, actorType, actorID)
+
+		err := testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Operations: []TransactionalOperation{upsertWithTTL("key1", "value1", "never")},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidStateTTL))
+	})
+
+	t.Run("reserved key is rejected", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		fakeCallAndActivateActor(testActorRuntime, actorType, actorID)
+
+		err := testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+			Operations: []TransactionalOperation{
+				{
+					Operation: Delete,
+					Request:   TransactionalDelete{Key: stateTTLIndexKey},
+				},
+			},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("expired keys are hidden and swept on deactivation", func(t *testing.T) {
+		store := &recordingStateStore{fakeStateStore: fakeStore().(*fakeStateStore)}
+		testActorRuntime := newTestActorsRuntimeWithStore(store, false)
+		fakeCallAndActivateActor(testActorRuntime, actorType, actorID)
+
+		err := testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+			Operations: []TransactionalOperation{
+				upsertWithTTL("key1", "value1", "60"),
+				{
+					Operation: Upsert,
+					Request:   TransactionalUpsert{Key: "key2", Value: "value2"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		indexKey := testActorRuntime.constructActorStateKey(actorType, actorID, stateTTLIndexKey)
+		assert.Contains(t, store.items, indexKey)
+
+		// Move the expiry of key1 into the past.
+		index, err := testActorRuntime.loadStateTTLIndex(actorType, actorID)
+		require.NoError(t, err)
+		index.expiries["key1"] = time.Now().Add(-time.Second)
+
+		res, err := testActorRuntime.GetState(ctx, &GetStateRequest{ActorType: actorType, ActorID: actorID, Key: "key1"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Data)
+
+		res, err = testActorRuntime.GetState(ctx, &GetStateRequest{ActorType: actorType, ActorID: actorID, Key: "key2"})
+		require.NoError(t, err)
+		assert.Equal(t, `"value2"`, string(res.Data))
+
+		testActorRuntime.sweepExpiredState(actorType, actorID)
+
+		assert.NotContains(t, store.items, testActorRuntime.constructActorStateKey(actorType, actorID, "key1"))
+		assert.Contains(t, store.items, testActorRuntime.constructActorStateKey(actorType, actorID, "key2"))
+		assert.NotContains(t, store.items, indexKey)
+		_, ok := testActorRuntime.stateTTLIndexes.Load(constructCompositeKey(actorType, actorID))
+		assert.False(t, ok)
+	})
+
+	t.Run("upsert without TTL clears the expiry", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		fakeCallAndActivateActor(testActorRuntime, actorType, actorID)
+
+		err := testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Operations: []TransactionalOperation{upsertWithTTL("key1", "value1", "60")},
+		})
+		require.NoError(t, err)
+		err = testActorRuntime.TransactionalStateOperation(ctx, &TransactionalRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+			Operations: []TransactionalOperation{
+				{
+					Operation: Upsert,
+					Request:   TransactionalUpsert{Key: "key1", Value: "value1"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		index, err := testActorRuntime.loadStateTTLIndex(actorType, actorID)
+		require.NoError(t, err)
+		assert.Empty(t, index.expiries)
+	})
+}
@@ -28,6 +28,7 @@ import (
 	nethttp "net/http"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -58,6 +59,7 @@ import (
 	diag_utils "github.com/bhojpur/application/pkg/diagnostics/utils"
 	"github.com/bhojpur/application/pkg/health"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/resiliency"
 	"github.com/bhojpur/application/pkg/utils"
 )
 
@@ -77,8 +79,10 @@ type Actors interface {
 	Init() error
 	Stop()
 	GetState(ctx context.Context, req *GetStateRequest) (*StateResponse, error)
+	QueryState(ctx context.Context, req *QueryStateRequest) (*QueryStateResponse, error)
 	TransactionalStateOperation(ctx context.Context, req *TransactionalRequest) error
 	GetReminder(ctx context.Context, req *GetReminderRequest) (*Reminder, error)
+	ListReminders(ctx context.Context, req *ListRemindersRequest) ([]Reminder, error)
 	CreateReminder(ctx context.Context, req *CreateReminderRequest) error
 	DeleteReminder(ctx context.Context, req *DeleteReminderRequest) error
 	RenameReminder(ctx context.Context, req *RenameReminderRequest) error
@@ -86,6 +90,7 @@ type Actors interface {
 	DeleteTimer(ctx context.Context, req *DeleteTimerRequest) error
 	IsActorHosted(ctx context.Context, req *ActorHostedRequest) bool
 	GetActiveActorsCount(ctx context.Context) []ActiveActorsCount
+	RegisterInternalActor(ctx context.Context, actorType string, actor InternalActor) error
 }
 
 type actorsRuntime struct {
@@ -98,11 +103,12 @@ type actorsRuntime struct {
 	actorsTable              *sync.Map
 	activeTimers             *sync.Map
 	activeTimersLock         *sync.RWMutex
-	activeReminders          *sync.Map
+	reminderScheduler        *reminderScheduler
 	remindersLock            *sync.RWMutex
 	remindersMigrationLock   *sync.Mutex
 	activeRemindersLock      *sync.RWMutex
 	reminders                map[string][]actorReminderReference
+	remindersEtags           map[string]string
 	evaluationLock           *sync.RWMutex
 	evaluationBusy           bool
 	evaluationChan           chan bool
@@ -111,6 +117,9 @@ type actorsRuntime struct {
 	tracingSpec              configuration.TracingSpec
 	reentrancyFeatureEnabled bool
 	actorTypeMetadataEnabled bool
+	resiliency               *resiliency.Resiliency
+	internalActors           map[string]InternalActor
+	stateTTLIndexes          *sync.Map
 }
 
 // ActiveActorsCount contain actorType and count of actors each type has.
@@ -150,7 +159,8 @@ func NewActors(
 	config Config,
 	certChain *app_credentials.CertChain,
 	tracingSpec configuration.TracingSpec,
-	features []configuration.FeatureSpec) Actors {
+	features []configuration.FeatureSpec,
+	resiliency *resiliency.Resiliency) Actors {
 	var transactionalStore state.TransactionalStore
 	if stateStore != nil {
 		features := stateStore.Features()
@@ -159,7 +169,7 @@ func NewActors(
 		}
 	}
 
-	return &actorsRuntime{
+	a := &actorsRuntime{
 		appChannel:               appChannel,
 		config:                   config,
 		store:                    stateStore,
@@ -168,11 +178,11 @@ func NewActors(
 		actorsTable:              &sync.Map{},
 		activeTimers:             &sync.Map{},
 		activeTimersLock:         &sync.RWMutex{},
-		activeReminders:          &sync.Map{},
 		remindersLock:            &sync.RWMutex{},
 		remindersMigrationLock:   &sync.Mutex{},
 		activeRemindersLock:      &sync.RWMutex{},
 		reminders:                map[string][]actorReminderReference{},
+		remindersEtags:           map[string]string{},
 		evaluationLock:           &sync.RWMutex{},
 		evaluationBusy:           false,
 		evaluationChan:           make(chan bool),
@@ -181,7 +191,12 @@ func NewActors(
 		tracingSpec:              tracingSpec,
 		reentrancyFeatureEnabled: configuration.IsFeatureEnabled(features, configuration.ActorReentrancy),
 		actorTypeMetadataEnabled: configuration.IsFeatureEnabled(features, configuration.ActorTypeMetadata),
+		resiliency:               resiliency,
+		internalActors:           map[string]InternalActor{},
+		stateTTLIndexes:          &sync.Map{},
 	}
+	a.reminderScheduler = newReminderScheduler(a.fireReminder, a.flushReminderTracks)
+	return a
 }
 
 func (a *actorsRuntime) Init() error {
@@ -189,7 +204,8 @@ func (a *actorsRuntime) Init() error {
 		return errors.New("actors: couldn't connect to placement service: address is empty")
 	}
 
-	if len(a.config.HostedActorTypes) > 0 {
+	hostedActorTypes := a.hostedActorTypes()
+	if len(hostedActorTypes) > 0 {
 		if a.store == nil {
 			log.Warn("actors: state store must be present to initialize the actor runtime")
 		} else {
@@ -210,7 +226,7 @@ func (a *actorsRuntime) Init() error {
 
 	a.placement = internal.NewActorPlacement(
 		a.config.PlacementAddresses, a.certChain,
-		a.config.AppID, hostname, a.config.HostedActorTypes,
+		a.config.AppID, hostname, hostedActorTypes,
 		appHealthFn,
 		afterTableUpdateFn)
 
@@ -232,6 +248,41 @@ func (a *actorsRuntime) Init() error {
 	return nil
 }
 
+// RegisterInternalActor registers an actor type implemented by the runtime.
+// Internal actors must be registered before the actor runtime is initialized
+// so that the placement service knows this host serves them.
+func (a *actorsRuntime) RegisterInternalActor(ctx context.Context, actorType string, actor InternalActor) error {
+	if !IsInternalActor(actorType) {
+		return errors.Errorf("internal actor type %s must start with %s", actorType, InternalActorTypePrefix)
+	}
+	if a.placement != nil {
+		return errors.Errorf("cannot register internal actor type %s after the actor runtime is initialized", actorType)
+	}
+	if _, ok := a.internalActors[actorType]; ok {
+		return errors.Errorf("internal actor type %s is already registered", actorType)
+	}
+
+	actor.SetActorRuntime(a)
+	a.internalActors[actorType] = actor
+	log.Debugf("registered internal actor type %s", actorType)
+	return nil
+}
+
+// hostedActorTypes returns the actor types of the application together with
+// the internal actor types served by the runtime.
+func (a *actorsRuntime) hostedActorTypes() []string {
+	if len(a.internalActors) == 0 {
+		return a.config.HostedActorTypes
+	}
+
+	types := make([]string, 0, len(a.config.HostedActorTypes)+len(a.internalActors))
+	types = append(types, a.config.HostedActorTypes...)
+	for actorType := range a.internalActors {
+		types = append(types, actorType)
+	}
+	return types
+}
+
 func (a *actorsRuntime) startAppHealthCheck(opts ...health.Option) {
 	if len(a.config.HostedActorTypes) == 0 {
 		return
@@ -254,6 +305,10 @@ func decomposeCompositeKey(compositeKey string) []string {
 }
 
 func (a *actorsRuntime) deactivateActor(actorType, actorID string) error {
+	if internalActor, ok := a.internalActors[actorType]; ok {
+		return a.deactivateInternalActor(internalActor, actorType, actorID)
+	}
+
 	req := invokev1.NewInvokeMethodRequest(fmt.Sprintf("actors/%s/%s", actorType, actorID))
 	req.WithHTTPExtension(nethttp.MethodDelete, "")
 	req.WithRawData(nil, invokev1.JSONContentType)
@@ -280,6 +335,21 @@ func (a *actorsRuntime) deactivateActor(actorType, actorID string) error {
 	return nil
 }
 
+func (a *actorsRuntime) deactivateInternalActor(internalActor InternalActor, actorType, actorID string) error {
+	// TODO Propagate context.
+	if err := internalActor.DeactivateActor(context.Background(), actorID); err != nil {
+		diag.DefaultMonitoring.ActorDeactivationFailed(actorType, "invoke")
+		return err
+	}
+
+	actorKey := constructCompositeKey(actorType, actorID)
+	a.actorsTable.Delete(actorKey)
+	diag.DefaultMonitoring.ActorDeactivated(actorType)
+	log.Debugf("deactivated internal actor type=%s, id=%s\n", actorType, actorID)
+
+	return nil
+}
+
 func (a *actorsRuntime) getActorTypeAndIDFromKey(key string) (string, string) {
 	arr := decomposeCompositeKey(key)
 	return arr[0], arr[1]
@@ -303,7 +373,9 @@ func (a *actorsRuntime) startDeactivationTicker(configuration Config) {
 						err := a.deactivateActor(actorType, actorID)
 						if err != nil {
 							log.Errorf("failed to deactivate actor %s: %s", actorKey, err)
+							return
 						}
+						a.sweepExpiredState(actorType, actorID)
 					}(key.(string))
 				}
 
@@ -338,12 +410,34 @@ func (a *actorsRuntime) Call(ctx context.Context, req *invokev1.InvokeMethodRequ
 }
 
 // callRemoteActorWithRetry will call a remote actor for the specified number of retries and will only retry in the case of transient failures.
+// When a resiliency policy is configured for the actor type, the policy replaces the built-in retries.
 func (a *actorsRuntime) callRemoteActorWithRetry(
 	ctx context.Context,
 	numRetries int,
 	backoffInterval time.Duration,
 	fn func(ctx context.Context, targetAddress, targetID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error),
 	targetAddress, targetID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	if a.resiliency.PolicyDefined(req.Actor().GetActorType(), resiliency.Actor) {
+		policy := a.resiliency.ActorPolicy(ctx, req.Actor().GetActorType(), req.Actor().GetActorId())
+		res, err := policy(func(ctx context.Context) (interface{}, error) {
+			resp, rErr := fn(ctx, targetAddress, targetID, req)
+			if rErr == nil {
+				return resp, nil
+			}
+
+			code := status.Code(rErr)
+			if code == codes.Unavailable || code == codes.Unauthenticated {
+				_, connErr := a.grpcConnectionFn(context.TODO(), targetAddress, targetID, a.config.Namespace, false, true, false)
+				if connErr != nil {
+					return nil, connErr
+				}
+			}
+			return resp, rErr
+		})
+		resp, _ := res.(*invokev1.InvokeMethodResponse)
+		return resp, err
+	}
+
 	for i := 0; i < numRetries; i++ {
 		resp, err := fn(ctx, targetAddress, targetID, req)
 		if err == nil {
@@ -403,6 +497,10 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 	}
 	defer act.unlock()
 
+	if internalActor, ok := a.internalActors[act.actorType]; ok {
+		return a.callInternalActor(ctx, internalActor, act.actorID, req)
+	}
+
 	// Replace method to actors method.
 	req.Message().Method = fmt.Sprintf("actors/%s/%s/method/%s", actorTypeID.GetActorType(), actorTypeID.GetActorId(), req.Message().Method)
 	// Original code overrides method with PUT. Why?
@@ -425,6 +523,33 @@ func (a *actorsRuntime) callLocalActor(ctx context.Context, req *invokev1.Invoke
 	return resp, nil
 }
 
+// callInternalActor dispatches a method or reminder invocation to an actor
+// implemented by the runtime. The caller must hold the actor lock.
+func (a *actorsRuntime) callInternalActor(ctx context.Context, internalActor InternalActor, actorID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	method := req.Message().Method
+	_, data := req.RawData()
+
+	if strings.HasPrefix(method, "remind/") {
+		var reminder ReminderResponse
+		if err := json.Unmarshal(data, &reminder); err != nil {
+			return nil, errors.Wrap(err, "error decoding reminder")
+		}
+		if err := internalActor.InvokeReminder(ctx, actorID, strings.TrimPrefix(method, "remind/"), reminder.Data); err != nil {
+			return nil, err
+		}
+		return invokev1.NewInvokeMethodResponse(nethttp.StatusOK, "OK", nil), nil
+	}
+
+	result, err := internalActor.InvokeMethod(ctx, actorID, method, data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := invokev1.NewInvokeMethodResponse(nethttp.StatusOK, "OK", nil)
+	resp.WithRawData(result, invokev1.JSONContentType)
+	return resp, nil
+}
+
 func (a *actorsRuntime) callRemoteActor(
 	ctx context.Context,
 	targetAddress, targetID string,
@@ -458,6 +583,20 @@ func (a *actorsRuntime) GetState(ctx context.Context, req *GetStateRequest) (*St
 	partitionKey := constructCompositeKey(a.config.AppID, req.ActorType, req.ActorID)
 	metadata := map[string]string{metadataPartitionKey: partitionKey}
 
+	if !a.config.NativeStateTTL {
+		index, err := a.loadStateTTLIndex(req.ActorType, req.ActorID)
+		if err != nil {
+			return nil, err
+		}
+		index.lock.Lock()
+		expired := index.expired(req.Key, time.Now())
+		index.lock.Unlock()
+		if expired {
+			// The key is deleted when the actor is deactivated.
+			return &StateResponse{}, nil
+		}
+	}
+
 	key := a.constructActorStateKey(req.ActorType, req.ActorID, req.Key)
 	resp, err := a.store.Get(&state.GetRequest{
 		Key:      key,
@@ -480,6 +619,26 @@ func (a *actorsRuntime) TransactionalStateOperation(ctx context.Context, req *Tr
 	partitionKey := constructCompositeKey(a.config.AppID, req.ActorType, req.ActorID)
 	metadata := map[string]string{metadataPartitionKey: partitionKey}
 
+	// Without native TTL support the expiry times are tracked in an index that is
+	// updated in the same transaction as the keys.
+	var ttlIndex *stateTTLIndex
+	var expiries map[string]time.Time
+	if !a.config.NativeStateTTL {
+		var err error
+		ttlIndex, err = a.loadStateTTLIndex(req.ActorType, req.ActorID)
+		if err != nil {
+			return err
+		}
+		ttlIndex.lock.Lock()
+		defer ttlIndex.lock.Unlock()
+
+		expiries = make(map[string]time.Time, len(ttlIndex.expiries))
+		for k, v := range ttlIndex.expiries {
+			expiries[k] = v
+		}
+	}
+	now := time.Now()
+
 	for _, o := range req.Operations {
 		switch o.Operation {
 		case Upsert:
@@ -488,23 +647,50 @@ func (a *actorsRuntime) TransactionalStateOperation(ctx context.Context, req *Tr
 			if err != nil {
 				return err
 			}
+			if upsert.Key == stateTTLIndexKey {
+				return errors.Errorf("actor state key %s is reserved", upsert.Key)
+			}
+			ttl, err := parseStateTTL(upsert.Metadata)
+			if err != nil {
+				return err
+			}
+
+			setMetadata := metadata
+			switch {
+			case ttl != nil && a.config.NativeStateTTL:
+				setMetadata = map[string]string{
+					metadataPartitionKey: partitionKey,
+					metadataTTLInSeconds: upsert.Metadata[metadataTTLInSeconds],
+				}
+			case ttl != nil:
+				expiries[upsert.Key] = now.Add(*ttl)
+			case !a.config.NativeStateTTL:
+				delete(expiries, upsert.Key)
+			}
+
 			key := a.constructActorStateKey(req.ActorType, req.ActorID, upsert.Key)
 			operations = append(operations, state.TransactionalStateOperation{
 				Request: state.SetRequest{
 					Key:      key,
 					Value:    upsert.Value,
-					Metadata: metadata,
+					Metadata: setMetadata,
 				},
 				Operation: state.Upsert,
 			})
 		case Delete:
-			var delete TransactionalDelete
-			err := mapstructure.Decode(o.Request, &delete)
+			var del TransactionalDelete
+			err := mapstructure.Decode(o.Request, &del)
 			if err != nil {
 				return err
 			}
+			if del.Key == stateTTLIndexKey {
+				return errors.Errorf("actor state key %s is reserved", del.Key)
+			}
+			if !a.config.NativeStateTTL {
+				delete(expiries, del.Key)
+			}
 
-			key := a.constructActorStateKey(req.ActorType, req.ActorID, delete.Key)
+			key := a.constructActorStateKey(req.ActorType, req.ActorID, del.Key)
 			operations = append(operations, state.TransactionalStateOperation{
 				Request: state.DeleteRequest{
 					Key:      key,
@@ -517,10 +703,21 @@ func (a *actorsRuntime) TransactionalStateOperation(ctx context.Context, req *Tr
 		}
 	}
 
+	if ttlIndex != nil && !reflect.DeepEqual(expiries, ttlIndex.expiries) {
+		indexOperation, err := a.stateTTLIndexOperation(req.ActorType, req.ActorID, expiries, metadata)
+		if err != nil {
+			return err
+		}
+		operations = append(operations, indexOperation)
+	}
+
 	err := a.transactionalStore.Multi(&state.TransactionalStateRequest{
 		Operations: operations,
 		Metadata:   metadata,
 	})
+	if err == nil && ttlIndex != nil {
+		ttlIndex.expiries = expiries
+	}
 	return err
 }
 
@@ -556,11 +753,7 @@ func (a *actorsRuntime) drainRebalancedActors() {
 					// r.reminder refers to the actual reminder struct that is saved in the db
 					if r.reminder.ActorType == actorType && r.reminder.ActorID == actorID {
 						reminderKey := constructCompositeKey(actorKey, r.reminder.Name)
-						stopChan, exists := a.activeReminders.Load(reminderKey)
-						if exists {
-							close(stopChan.(chan bool))
-							a.activeReminders.Delete(reminderKey)
-						}
+						a.reminderScheduler.remove(reminderKey)
 					}
 				}
 
@@ -580,6 +773,7 @@ func (a *actorsRuntime) drainRebalancedActors() {
 
 				// don't allow state changes
 				a.actorsTable.Delete(key)
+				a.stateTTLIndexes.Delete(key)
 
 				diag.DefaultMonitoring.ActorRebalanced(actorType)
 
@@ -608,71 +802,155 @@ func (a *actorsRuntime) evaluateReminders() {
 	a.evaluationChan = make(chan bool)
 
 	var wg sync.WaitGroup
-	for _, t := range a.config.HostedActorTypes {
-		vals, _, err := a.getRemindersForActorType(t, true)
+	for _, t := range a.hostedActorTypes() {
+		vals, err := a.loadRemindersForEvaluation(t)
 		if err != nil {
 			log.Errorf("error getting reminders for actor type %s: %s", t, err)
 		} else {
-			log.Debugf("loaded %d reminders for actor type %s", len(vals), t)
-			a.remindersLock.Lock()
-			a.reminders[t] = vals
-			a.remindersLock.Unlock()
+			log.Debugf("loaded %d local reminders for actor type %s", len(vals), t)
 
 			wg.Add(1)
 			go func(wg *sync.WaitGroup, reminders []actorReminderReference) {
 				defer wg.Done()
-
-				for i := range reminders {
-					r := reminders[i] // Make a copy since we will refer to this as a reference in this loop.
-					targetActorAddress, _ := a.placement.LookupActor(r.reminder.ActorType, r.reminder.ActorID)
-					if targetActorAddress == "" {
-						log.Warnf("did not find address for actor ID %s and actor type %s in reminder %s",
-							r.reminder.ActorID,
-							r.reminder.ActorType,
-							r.reminder.Name)
-						continue
-					}
-
-					if a.isActorLocal(targetActorAddress, a.config.HostAddress, a.config.Port) {
-						actorKey := constructCompositeKey(r.reminder.ActorType, r.reminder.ActorID)
-						reminderKey := constructCompositeKey(actorKey, r.reminder.Name)
-						_, exists := a.activeReminders.Load(reminderKey)
-
-						if !exists {
-							stop := make(chan bool)
-							a.activeReminders.Store(reminderKey, stop)
-							err := a.startReminder(&r.reminder, stop)
-							if err != nil {
-								log.Errorf("error starting reminder: %s", err)
-							} else {
-								log.Debugf("started reminder %s for actor ID %s and actor type %s",
-									r.reminder.Name,
-									r.reminder.ActorID,
-									r.reminder.ActorType)
-							}
-						} else {
-							log.Debugf("reminder %s already exists for actor ID %s and actor type %s",
-								r.reminder.Name,
-								r.reminder.ActorID,
-								r.reminder.ActorType)
-						}
-					}
-				}
+				a.scheduleLocalReminders(reminders)
 			}(&wg, vals)
 		}
 	}
 	wg.Wait()
+	a.unscheduleRemoteReminders()
 	close(a.evaluationChan)
 	a.evaluationBusy = false
 }
 
+// loadRemindersForEvaluation returns the reminders of an actor type that belong to actors
+// placed on this host. Every change to the reminders also updates the actor type metadata,
+// so the reminder partitions are only read again when the metadata ETag changed since the
+// last evaluation. The cache keeps all the reminders of the actor type, since it's also
+// used to create and delete reminders, and is filtered again on every placement change.
+func (a *actorsRuntime) loadRemindersForEvaluation(actorType string) ([]actorReminderReference, error) {
+	if a.store == nil {
+		return nil, errors.New("actors: state store does not exist or incorrectly configured")
+	}
+
+	actorMetadata, err := a.getActorTypeMetadata(actorType, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not read actor type metadata: %w", err)
+	}
+
+	if actorMetadata.Etag != nil {
+		a.remindersLock.RLock()
+		etag, ok := a.remindersEtags[actorType]
+		reminders := a.reminders[actorType]
+		a.remindersLock.RUnlock()
+		if ok && etag == *actorMetadata.Etag {
+			log.Debugf("reminders for actor type %s are unchanged since the last evaluation", actorType)
+			return a.localReminders(reminders), nil
+		}
+	}
+
+	reminders, _, err := a.readRemindersForActorType(actorType, actorMetadata, true)
+	if err != nil {
+		return nil, err
+	}
+
+	a.remindersLock.Lock()
+	a.reminders[actorType] = reminders
+	if actorMetadata.Etag != nil {
+		a.remindersEtags[actorType] = *actorMetadata.Etag
+	} else {
+		delete(a.remindersEtags, actorType)
+	}
+	a.remindersLock.Unlock()
+	return a.localReminders(reminders), nil
+}
+
+// localReminders returns the reminders of actors placed on this host.
+func (a *actorsRuntime) localReminders(reminders []actorReminderReference) []actorReminderReference {
+	local := []actorReminderReference{}
+	for _, r := range reminders {
+		targetActorAddress, _ := a.placement.LookupActor(r.reminder.ActorType, r.reminder.ActorID)
+		if targetActorAddress == "" {
+			log.Warnf("did not find address for actor ID %s and actor type %s in reminder %s",
+				r.reminder.ActorID,
+				r.reminder.ActorType,
+				r.reminder.Name)
+			continue
+		}
+		if a.isActorLocal(targetActorAddress, a.config.HostAddress, a.config.Port) {
+			local = append(local, r)
+		}
+	}
+	return local
+}
+
+// scheduleLocalReminders schedules the given reminders of local actors that aren't
+// scheduled yet. Their tracks are read from the state store in a single request.
+func (a *actorsRuntime) scheduleLocalReminders(reminders []actorReminderReference) {
+	local := []*Reminder{}
+	keys := []string{}
+	for i := range reminders {
+		r := reminders[i].reminder // Make a copy since we will refer to this as a reference in this loop.
+		reminderKey := constructCompositeKey(r.ActorType, r.ActorID, r.Name)
+		if a.reminderScheduler.has(reminderKey) {
+			log.Debugf("reminder %s already exists for actor ID %s and actor type %s",
+				r.Name,
+				r.ActorID,
+				r.ActorType)
+			continue
+		}
+		local = append(local, &r)
+		keys = append(keys, reminderKey)
+	}
+	if len(local) == 0 {
+		return
+	}
+
+	tracks, err := a.getReminderTracks(keys)
+	if err != nil {
+		log.Errorf("error getting reminder tracks: %s", err)
+		return
+	}
+
+	for i, r := range local {
+		err = a.startReminder(r, tracks[keys[i]])
+		if err != nil {
+			log.Errorf("error starting reminder: %s", err)
+		} else {
+			log.Debugf("started reminder %s for actor ID %s and actor type %s",
+				r.Name,
+				r.ActorID,
+				r.ActorType)
+		}
+	}
+}
+
+// unscheduleRemoteReminders stops the reminders of actors that were placed on another host.
+func (a *actorsRuntime) unscheduleRemoteReminders() {
+	for _, reminderKey := range a.reminderScheduler.keys() {
+		keys := decomposeCompositeKey(reminderKey)
+		if len(keys) < 3 {
+			continue
+		}
+		targetActorAddress, _ := a.placement.LookupActor(keys[0], keys[1])
+		if targetActorAddress != "" && !a.isActorLocal(targetActorAddress, a.config.HostAddress, a.config.Port) {
+			log.Debugf("reminder %s moved to %s", reminderKey, targetActorAddress)
+			a.reminderScheduler.remove(reminderKey)
+		}
+	}
+}
+
 func (a *actorsRuntime) getReminderTrack(actorKey, name string) (*ReminderTrack, error) {
 	if a.store == nil {
 		return nil, errors.New("actors: state store does not exist or incorrectly configured")
 	}
 
+	key := constructCompositeKey(actorKey, name)
+	if track, ok := a.reminderScheduler.pendingTrack(key); ok {
+		return &track, nil
+	}
+
 	resp, err := a.store.Get(&state.GetRequest{
-		Key: constructCompositeKey(actorKey, name),
+		Key: key,
 	})
 	if err != nil {
 		return nil, err
@@ -685,6 +963,58 @@ func (a *actorsRuntime) getReminderTrack(actorKey, name string) (*ReminderTrack,
 	return &track, nil
 }
 
+// getReminderTracks returns the tracks of the given reminder keys, reading the ones that
+// aren't pending a flush with a single bulk request.
+func (a *actorsRuntime) getReminderTracks(keys []string) (map[string]*ReminderTrack, error) {
+	if a.store == nil {
+		return nil, errors.New("actors: state store does not exist or incorrectly configured")
+	}
+
+	tracks := make(map[string]*ReminderTrack, len(keys))
+	getRequests := []state.GetRequest{}
+	for _, key := range keys {
+		if track, ok := a.reminderScheduler.pendingTrack(key); ok {
+			tracks[key] = &track
+			continue
+		}
+		getRequests = append(getRequests, state.GetRequest{Key: key})
+	}
+	if len(getRequests) == 0 {
+		return tracks, nil
+	}
+
+	bulkGet, bulkResponse, err := a.store.BulkGet(getRequests)
+	if err != nil {
+		return nil, err
+	}
+	if !bulkGet {
+		// if store doesn't support bulk get, fallback to call get() method one by one
+		bulkResponse = make([]state.BulkGetResponse, len(getRequests))
+		for i := range getRequests {
+			bulkResponse[i].Key = getRequests[i].Key
+			resp, err := a.store.Get(&getRequests[i])
+			if err != nil {
+				return nil, err
+			}
+			if resp != nil {
+				bulkResponse[i].Data = resp.Data
+			}
+		}
+	}
+
+	for _, resp := range bulkResponse {
+		if resp.Error != "" {
+			return nil, fmt.Errorf("could not get reminder track %s: %s", resp.Key, resp.Error)
+		}
+		track := ReminderTrack{
+			RepetitionLeft: -1,
+		}
+		json.Unmarshal(resp.Data, &track)
+		tracks[resp.Key] = &track
+	}
+	return tracks, nil
+}
+
 func (a *actorsRuntime) updateReminderTrack(actorKey, name string, repetition int, lastInvokeTime time.Time) error {
 	if a.store == nil {
 		return errors.New("actors: state store does not exist or incorrectly configured")
@@ -702,7 +1032,24 @@ func (a *actorsRuntime) updateReminderTrack(actorKey, name string, repetition in
 	return err
 }
 
-func (a *actorsRuntime) startReminder(reminder *Reminder, stopChannel chan bool) error {
+// flushReminderTracks writes the reminder tracks batched by the reminder scheduler.
+func (a *actorsRuntime) flushReminderTracks(tracks map[string]ReminderTrack) error {
+	if a.store == nil {
+		return errors.New("actors: state store does not exist or incorrectly configured")
+	}
+
+	setRequests := make([]state.SetRequest, 0, len(tracks))
+	for key, track := range tracks {
+		setRequests = append(setRequests, state.SetRequest{
+			Key:   key,
+			Value: track,
+		})
+	}
+	return a.store.BulkSet(setRequests)
+}
+
+// startReminder schedules a reminder. When track is nil, the reminder track is read from the state store.
+func (a *actorsRuntime) startReminder(reminder *Reminder, track *ReminderTrack) error {
 	actorKey := constructCompositeKey(reminder.ActorType, reminder.ActorID)
 	reminderKey := constructCompositeKey(actorKey, reminder.Name)
 
@@ -730,9 +1077,11 @@ func (a *actorsRuntime) startReminder(reminder *Reminder, stopChannel chan bool)
 		}
 	}
 
-	track, err := a.getReminderTrack(actorKey, reminder.Name)
-	if err != nil {
-		return errors.Wrap(err, "error getting reminder track")
+	if track == nil {
+		track, err = a.getReminderTrack(actorKey, reminder.Name)
+		if err != nil {
+			return errors.Wrap(err, "error getting reminder track")
+		}
 	}
 
 	if track != nil && len(track.LastFiredTime) != 0 {
@@ -747,81 +1096,68 @@ func (a *actorsRuntime) startReminder(reminder *Reminder, stopChannel chan bool)
 		nextTime = registeredTime
 	}
 
-	go func(reminder *Reminder, years int, months int, days int, period time.Duration, nextTime, ttl time.Time, repetitionsLeft int, stop chan bool) {
-		var (
-			ttlTimer, nextTimer *time.Timer
-			ttlTimerC           <-chan time.Time
-			err                 error
-		)
-		if !ttl.IsZero() {
-			ttlTimer = time.NewTimer(time.Until(ttl))
-			ttlTimerC = ttlTimer.C
-		}
-		nextTimer = time.NewTimer(time.Until(nextTime))
-		defer func() {
-			if nextTimer.Stop() {
-				<-nextTimer.C
-			}
-			if ttlTimer != nil && ttlTimer.Stop() {
-				<-ttlTimerC
-			}
-		}()
-	L:
-		for {
-			select {
-			case <-nextTimer.C:
-				// noop
-			case <-ttlTimerC:
-				// proceed with reminder deletion
-				log.Infof("reminder %s has expired", reminder.Name)
-				break L
-			case <-stop:
-				// reminder has been already deleted
-				log.Infof("reminder %s with parameters: dueTime: %s, period: %s, data: %v has been deleted.", reminder.Name, reminder.RegisteredTime, reminder.Period, reminder.Data)
-				return
-			}
+	a.reminderScheduler.schedule(&scheduledReminder{
+		key:             reminderKey,
+		reminder:        *reminder,
+		years:           years,
+		months:          months,
+		days:            days,
+		period:          period,
+		repeats:         repeats,
+		repetitionsLeft: repetitionsLeft,
+		nextTime:        nextTime,
+		ttl:             ttl,
+	})
+	return nil
+}
 
-			_, exists := a.activeReminders.Load(reminderKey)
-			if !exists {
-				log.Errorf("could not find active reminder with key: %s", reminderKey)
-				return
-			}
-			// if all repetitions are completed, proceed with reminder deletion
-			if repetitionsLeft == 0 {
-				log.Infof("reminder %q has completed %d repetitions", reminder.Name, repeats)
-				break L
-			}
-			if err = a.executeReminder(reminder); err != nil {
-				log.Errorf("error execution of reminder %q for actor type %s with id %s: %v",
-					reminder.Name, reminder.ActorType, reminder.ActorID, err)
-			}
-			if repetitionsLeft > 0 {
-				repetitionsLeft--
-			}
-			if err = a.updateReminderTrack(actorKey, reminder.Name, repetitionsLeft, nextTime); err != nil {
-				log.Errorf("error updating reminder track: %v", err)
-			}
-			// if reminder is not repetitive, proceed with reminder deletion
-			if years == 0 && months == 0 && days == 0 && period == 0 {
-				break L
-			}
-			nextTime = nextTime.AddDate(years, months, days).Add(period)
-			if nextTimer.Stop() {
-				<-nextTimer.C
-			}
-			nextTimer.Reset(time.Until(nextTime))
+// fireReminder is called by the reminder scheduler when a reminder is due. It invokes the
+// reminder and schedules its next occurrence, or deletes the reminder once it has expired
+// or completed its repetitions.
+func (a *actorsRuntime) fireReminder(r *scheduledReminder) {
+	reminder := &r.reminder
+	if !a.reminderScheduler.isScheduled(r) {
+		// reminder has been already deleted
+		log.Debugf("reminder %s with parameters: dueTime: %s, period: %s, data: %v has been deleted.", reminder.Name, reminder.RegisteredTime, reminder.Period, reminder.Data)
+		return
+	}
+
+	now := time.Now()
+	switch {
+	case !r.ttl.IsZero() && !now.Before(r.ttl):
+		log.Infof("reminder %s has expired", reminder.Name)
+	case r.repetitionsLeft == 0:
+		// if all repetitions are completed, proceed with reminder deletion
+		log.Infof("reminder %q has completed %d repetitions", reminder.Name, r.repeats)
+	default:
+		diag.DefaultMonitoring.ActorReminderFired(reminder.ActorType, now.Sub(r.nextTime))
+		if err := a.executeReminder(reminder); err != nil {
+			log.Errorf("error execution of reminder %q for actor type %s with id %s: %v",
+				reminder.Name, reminder.ActorType, reminder.ActorID, err)
+		}
+		if r.repetitionsLeft > 0 {
+			r.repetitionsLeft--
 		}
-		err = a.DeleteReminder(context.TODO(), &DeleteReminderRequest{
-			Name:      reminder.Name,
-			ActorID:   reminder.ActorID,
-			ActorType: reminder.ActorType,
+		a.reminderScheduler.queueTrack(r.key, ReminderTrack{
+			LastFiredTime:  r.nextTime.Format(time.RFC3339),
+			RepetitionLeft: r.repetitionsLeft,
 		})
-		if err != nil {
-			log.Errorf("error deleting reminder: %s", err)
+		// if reminder is repetitive, schedule the next occurrence
+		if r.years != 0 || r.months != 0 || r.days != 0 || r.period != 0 {
+			r.nextTime = r.nextTime.AddDate(r.years, r.months, r.days).Add(r.period)
+			a.reminderScheduler.reschedule(r)
+			return
 		}
-	}(reminder, years, months, days, period, nextTime, ttl, repetitionsLeft, stopChannel)
+	}
 
-	return nil
+	err := a.DeleteReminder(context.TODO(), &DeleteReminderRequest{
+		Name:      reminder.Name,
+		ActorID:   reminder.ActorID,
+		ActorType: reminder.ActorType,
+	})
+	if err != nil {
+		log.Errorf("error deleting reminder: %s", err)
+	}
 }
 
 func (a *actorsRuntime) executeReminder(reminder *Reminder) error {
@@ -1044,13 +1380,11 @@ func (a *actorsRuntime) CreateReminder(ctx context.Context, req *CreateReminderR
 		reminder.ExpirationTime = ttl.UTC().Format(time.RFC3339)
 	}
 
-	stop := make(chan bool)
-
-	err = a.storeReminder(ctx, reminder, stop)
+	err = a.storeReminder(ctx, reminder)
 	if err != nil {
 		return err
 	}
-	return a.startReminder(&reminder, stop)
+	return a.startReminder(&reminder, nil)
 }
 
 func (a *actorsRuntime) CreateTimer(ctx context.Context, req *CreateTimerRequest) error {
@@ -1362,6 +1696,11 @@ func (a *actorsRuntime) getRemindersForActorType(actorType string, migrate bool)
 		return nil, nil, fmt.Errorf("could not read actor type metadata: %w", merr)
 	}
 
+	return a.readRemindersForActorType(actorType, actorMetadata, migrate)
+}
+
+// readRemindersForActorType reads the reminders of an actor type from the partitions described by its metadata.
+func (a *actorsRuntime) readRemindersForActorType(actorType string, actorMetadata *ActorMetadata, migrate bool) ([]actorReminderReference, *ActorMetadata, error) {
 	log.Debugf(
 		"starting to read reminders for actor type %s (migrate=%t), with metadata id %s and %d partitions",
 		actorType, migrate, actorMetadata.ID, actorMetadata.RemindersMetadata.PartitionCount)
@@ -1525,11 +1864,8 @@ func (a *actorsRuntime) DeleteReminder(ctx context.Context, req *DeleteReminderR
 	actorKey := constructCompositeKey(req.ActorType, req.ActorID)
 	reminderKey := constructCompositeKey(actorKey, req.Name)
 
-	stop, exists := a.activeReminders.Load(reminderKey)
-	if exists {
+	if a.reminderScheduler.remove(reminderKey) {
 		log.Infof("Found reminder with key: %v. Deleting reminder", reminderKey)
-		close(stop.(chan bool))
-		a.activeReminders.Delete(reminderKey)
 	}
 
 	err := backoff.Retry(func() error {
@@ -1573,14 +1909,11 @@ func (a *actorsRuntime) DeleteReminder(ctx context.Context, req *DeleteReminderR
 		return err
 	}
 
-	err = a.store.Delete(&state.DeleteRequest{
-		Key: reminderKey,
+	return a.reminderScheduler.deleteTrack(reminderKey, func() error {
+		return a.store.Delete(&state.DeleteRequest{
+			Key: reminderKey,
+		})
 	})
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
 
 func (a *actorsRuntime) RenameReminder(ctx context.Context, req *RenameReminderRequest) error {
@@ -1626,23 +1959,15 @@ func (a *actorsRuntime) RenameReminder(ctx context.Context, req *RenameReminderR
 		ExpirationTime: oldReminder.ExpirationTime,
 	}
 
-	stop := make(chan bool)
-
-	err = a.storeReminder(ctx, reminder, stop)
+	err = a.storeReminder(ctx, reminder)
 	if err != nil {
 		return err
 	}
 
-	return a.startReminder(&reminder, stop)
+	return a.startReminder(&reminder, nil)
 }
 
-func (a *actorsRuntime) storeReminder(ctx context.Context, reminder Reminder, stopChannel chan bool) error {
-	// Store the reminder in active reminders list
-	actorKey := constructCompositeKey(reminder.ActorType, reminder.ActorID)
-	reminderKey := constructCompositeKey(actorKey, reminder.Name)
-
-	a.activeReminders.Store(reminderKey, stopChannel)
-
+func (a *actorsRuntime) storeReminder(ctx context.Context, reminder Reminder) error {
 	err := backoff.Retry(func() error {
 		reminders, actorMetadata, err2 := a.getRemindersForActorType(reminder.ActorType, false)
 		if err2 != nil {
@@ -1700,6 +2025,27 @@ func (a *actorsRuntime) GetReminder(ctx context.Context, req *GetReminderRequest
 	return nil, nil
 }
 
+func (a *actorsRuntime) ListReminders(ctx context.Context, req *ListRemindersRequest) ([]Reminder, error) {
+	reminders, _, err := a.getRemindersForActorType(req.ActorType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []Reminder{}
+	for _, r := range reminders {
+		if req.ActorID == "" || r.reminder.ActorID == req.ActorID {
+			list = append(list, r.reminder)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ActorID == list[j].ActorID {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].ActorID < list[j].ActorID
+	})
+	return list, nil
+}
+
 func (a *actorsRuntime) DeleteTimer(ctx context.Context, req *DeleteTimerRequest) error {
 	actorKey := constructCompositeKey(req.ActorType, req.ActorID)
 	timerKey := constructCompositeKey(actorKey, req.Name)
@@ -1734,6 +2080,7 @@ func (a *actorsRuntime) GetActiveActorsCount(ctx context.Context) []ActiveActors
 
 // Stop closes all network connections and resources used in actor runtime.
 func (a *actorsRuntime) Stop() {
+	a.reminderScheduler.stop()
 	if a.placement != nil {
 		a.placement.Stop()
 	}
@@ -38,6 +38,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/bhojpur/application/pkg/actors/internal"
 	"github.com/bhojpur/application/pkg/channel"
 	"github.com/bhojpur/application/pkg/config"
 	"github.com/bhojpur/application/pkg/health"
@@ -206,6 +207,11 @@ func (f *fakeStateStore) Set(req *state.SetRequest) error {
 }
 
 func (f *fakeStateStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		if err := f.Set(&req[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -272,7 +278,7 @@ func (b *runtimeBuilder) buildActorRuntime() *actorsRuntime {
 	tracingSpec := config.TracingSpec{SamplingRate: "1"}
 	store := fakeStore()
 
-	a := NewActors(store, b.appChannel, nil, *b.config, nil, tracingSpec, b.featureSpec)
+	a := NewActors(store, b.appChannel, nil, *b.config, nil, tracingSpec, b.featureSpec, nil)
 
 	return a.(*actorsRuntime)
 }
@@ -281,7 +287,7 @@ func newTestActorsRuntimeWithMock(appChannel channel.AppChannel) *actorsRuntime
 	spec := config.TracingSpec{SamplingRate: "1"}
 	store := fakeStore()
 	config := NewConfig("", TestAppID, []string{""}, 0, "", config.ApplicationConfig{})
-	a := NewActors(store, appChannel, nil, config, nil, spec, nil)
+	a := NewActors(store, appChannel, nil, config, nil, spec, nil, nil)
 
 	return a.(*actorsRuntime)
 }
@@ -305,7 +311,7 @@ func newTestActorsRuntimeWithMockAndActorMetadataPartition(appChannel channel.Ap
 			Name:    config.ActorTypeMetadata,
 			Enabled: true,
 		},
-	})
+	}, nil)
 
 	return a.(*actorsRuntime)
 }
@@ -532,6 +538,26 @@ func TestGetReminderTrack(t *testing.T) {
 	})
 }
 
+func TestLoadRemindersForEvaluation(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorType, actorID := getTestActorTypeAndID()
+	err := testActorsRuntime.CreateReminder(context.Background(), &CreateReminderRequest{
+		ActorID:   actorID,
+		ActorType: actorType,
+		Name:      "reminder0",
+		Period:    "1s",
+		DueTime:   "1s",
+	})
+	require.NoError(t, err)
+
+	// Without a placement table no actor is placed on this host.
+	testActorsRuntime.placement = internal.NewActorPlacement(nil, nil, TestAppID, "localhost:50001", []string{actorType}, nil, nil)
+	reminders, err := testActorsRuntime.loadRemindersForEvaluation(actorType)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+	assert.Len(t, testActorsRuntime.reminders[actorType], 1)
+}
+
 func TestCreateReminder(t *testing.T) {
 	numReminders := 100
 	appChannel := new(mockAppChannel)
@@ -1094,6 +1120,50 @@ func TestGetReminder(t *testing.T) {
 	assert.Equal(t, r.DueTime, "1s")
 }
 
+func TestListReminders(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorType, actorID := getTestActorTypeAndID()
+	ctx := context.Background()
+	for _, r := range []CreateReminderRequest{
+		createReminderData(actorID, actorType, "reminder2", "1s", "1s", "", "a"),
+		createReminderData(actorID, actorType, "reminder1", "1s", "1s", "", "b"),
+		createReminderData("other", actorType, "reminder1", "1s", "1s", "", "c"),
+	} {
+		r := r
+		assert.NoError(t, testActorsRuntime.CreateReminder(ctx, &r))
+	}
+
+	t.Run("reminders of an actor", func(t *testing.T) {
+		list, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.Equal(t, "reminder1", list[0].Name)
+		assert.Equal(t, "b", list[0].Data)
+		assert.Equal(t, "reminder2", list[1].Name)
+	})
+
+	t.Run("reminders of an actor type", func(t *testing.T) {
+		list, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{
+			ActorType: actorType,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, list, 3)
+		assert.Equal(t, "other", list[2].ActorID)
+	})
+
+	t.Run("no reminders", func(t *testing.T) {
+		list, err := testActorsRuntime.ListReminders(ctx, &ListRemindersRequest{
+			ActorType: actorType,
+			ActorID:   "missing",
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, list)
+	})
+}
+
 func TestDeleteTimer(t *testing.T) {
 	testActorsRuntime := newTestActorsRuntime()
 	actorType, actorID := getTestActorTypeAndID()
@@ -1623,6 +1693,93 @@ func TestCallLocalActor(t *testing.T) {
 	})
 }
 
+type fakeInternalActor struct {
+	actorsRuntime Actors
+	methods       []string
+	reminders     []string
+	deactivated   []string
+}
+
+func (f *fakeInternalActor) SetActorRuntime(actorsRuntime Actors) {
+	f.actorsRuntime = actorsRuntime
+}
+
+func (f *fakeInternalActor) InvokeMethod(ctx context.Context, actorID string, methodName string, data []byte) ([]byte, error) {
+	f.methods = append(f.methods, methodName)
+	return data, nil
+}
+
+func (f *fakeInternalActor) InvokeReminder(ctx context.Context, actorID string, reminderName string, data interface{}) error {
+	f.reminders = append(f.reminders, reminderName)
+	return nil
+}
+
+func (f *fakeInternalActor) DeactivateActor(ctx context.Context, actorID string) error {
+	f.deactivated = append(f.deactivated, actorID)
+	return nil
+}
+
+func TestRegisterInternalActor(t *testing.T) {
+	const internalActorType = InternalActorTypePrefix + "test"
+
+	t.Run("register successfully", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		internalActor := &fakeInternalActor{}
+
+		err := testActorRuntime.RegisterInternalActor(context.Background(), internalActorType, internalActor)
+		assert.NoError(t, err)
+		assert.Equal(t, testActorRuntime, internalActor.actorsRuntime)
+		assert.Contains(t, testActorRuntime.hostedActorTypes(), internalActorType)
+	})
+
+	t.Run("reject types without the internal prefix", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		err := testActorRuntime.RegisterInternalActor(context.Background(), "cat", &fakeInternalActor{})
+		assert.Error(t, err)
+	})
+
+	t.Run("reject duplicates", func(t *testing.T) {
+		testActorRuntime := newTestActorsRuntime()
+		assert.NoError(t, testActorRuntime.RegisterInternalActor(context.Background(), internalActorType, &fakeInternalActor{}))
+		assert.Error(t, testActorRuntime.RegisterInternalActor(context.Background(), internalActorType, &fakeInternalActor{}))
+	})
+}
+
+func TestCallInternalActor(t *testing.T) {
+	const (
+		internalActorType = InternalActorTypePrefix + "test"
+		testActorID       = "instance"
+	)
+
+	testActorRuntime := newTestActorsRuntime()
+	internalActor := &fakeInternalActor{}
+	assert.NoError(t, testActorRuntime.RegisterInternalActor(context.Background(), internalActorType, internalActor))
+
+	t.Run("invoke method", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("Echo").WithActor(internalActorType, testActorID)
+		req.WithRawData([]byte(`{"a":1}`), invokev1.JSONContentType)
+
+		resp, err := testActorRuntime.callLocalActor(context.Background(), req)
+		assert.NoError(t, err)
+		_, data := resp.RawData()
+		assert.Equal(t, []byte(`{"a":1}`), data)
+		assert.Equal(t, []string{"Echo"}, internalActor.methods)
+	})
+
+	t.Run("invoke reminder", func(t *testing.T) {
+		reminder := &Reminder{ActorType: internalActorType, ActorID: testActorID, Name: "wakeup"}
+		assert.NoError(t, testActorRuntime.executeReminder(reminder))
+		assert.Equal(t, []string{"wakeup"}, internalActor.reminders)
+		assert.Equal(t, []string{"Echo"}, internalActor.methods)
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		err := testActorRuntime.deactivateActor(internalActorType, testActorID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testActorID}, internalActor.deactivated)
+	})
+}
+
 func TestTransactionalState(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Single set request succeeds", func(t *testing.T) {
@@ -42,6 +42,10 @@ type Config struct {
 	Reentrancy                    app_config.ReentrancyConfig
 	RemindersStoragePartitions    int
 	EntityConfigs                 map[string]EntityConfig
+	// NativeStateTTL is set when the actor state store expires keys with the
+	// ttlInSeconds metadata itself, which the nativeTTL component metadata can
+	// override. Otherwise the runtime tracks and sweeps them.
+	NativeStateTTL bool
 }
 
 // Remap of app_config.EntityConfig but with more useful types for actors.go.
@@ -0,0 +1,49 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"strings"
+)
+
+// InternalActorTypePrefix is the prefix of the actor types that are implemented
+// by the runtime itself rather than by the user application.
+const InternalActorTypePrefix = "app.internal."
+
+// InternalActor is an actor whose methods and reminders are served by the
+// runtime. Internal actors are placed, locked and persisted like any other
+// actor, but calls to them never reach the application channel.
+type InternalActor interface {
+	// SetActorRuntime gives the internal actor access to the actor state and reminders.
+	SetActorRuntime(actorsRuntime Actors)
+	// InvokeMethod is called for every method invocation on an actor of this type.
+	InvokeMethod(ctx context.Context, actorID string, methodName string, data []byte) ([]byte, error)
+	// InvokeReminder is called when a reminder registered by an actor of this type fires.
+	InvokeReminder(ctx context.Context, actorID string, reminderName string, data interface{}) error
+	// DeactivateActor is called when an actor of this type is deactivated.
+	DeactivateActor(ctx context.Context, actorID string) error
+}
+
+// IsInternalActor returns true if the actor type is reserved for internal actors.
+func IsInternalActor(actorType string) bool {
+	return strings.HasPrefix(actorType, InternalActorTypePrefix)
+}
@@ -0,0 +1,347 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	diag "github.com/bhojpur/application/pkg/diagnostics"
+)
+
+const (
+	// reminderTrackFlushInterval is how often the pending reminder tracks are written to the state store.
+	reminderTrackFlushInterval = time.Second
+	// reminderTrackFlushBatchSize is the number of pending reminder tracks that triggers a flush
+	// before the flush interval elapses.
+	reminderTrackFlushBatchSize = 100
+)
+
+// scheduledReminder is a reminder owned by this host together with its firing schedule.
+type scheduledReminder struct {
+	key             string
+	reminder        Reminder
+	years           int
+	months          int
+	days            int
+	period          time.Duration
+	repeats         int
+	repetitionsLeft int
+	nextTime        time.Time
+	ttl             time.Time
+
+	// index is the position of the reminder in the queue, or -1 while it is firing.
+	index int
+}
+
+// dueTime returns the time the reminder must be processed next: when it fires or when it expires.
+func (r *scheduledReminder) dueTime() time.Time {
+	if !r.ttl.IsZero() && r.ttl.Before(r.nextTime) {
+		return r.ttl
+	}
+	return r.nextTime
+}
+
+// reminderQueue is a min-heap of reminders ordered by due time.
+type reminderQueue []*scheduledReminder
+
+func (q reminderQueue) Len() int { return len(q) }
+
+func (q reminderQueue) Less(i, j int) bool { return q[i].dueTime().Before(q[j].dueTime()) }
+
+func (q reminderQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *reminderQueue) Push(x interface{}) {
+	r := x.(*scheduledReminder)
+	r.index = len(*q)
+	*q = append(*q, r)
+}
+
+func (q *reminderQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	r := old[n-1]
+	old[n-1] = nil
+	r.index = -1
+	*q = old[:n-1]
+	return r
+}
+
+// reminderScheduler fires all the reminders owned by this host from a single goroutine
+// and batches the resulting reminder track updates.
+type reminderScheduler struct {
+	lock    sync.Mutex
+	queue   reminderQueue
+	entries map[string]*scheduledReminder
+
+	tracksLock sync.Mutex
+	tracks     map[string]ReminderTrack
+	// flushLock serializes flushes with the deletion of stored reminder tracks.
+	flushLock sync.Mutex
+
+	fireFn  func(r *scheduledReminder)
+	flushFn func(tracks map[string]ReminderTrack) error
+
+	wakeCh    chan struct{}
+	flushCh   chan struct{}
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// newReminderScheduler returns a scheduler that calls fireFn for every due reminder and
+// flushFn with the reminder tracks to persist.
+func newReminderScheduler(fireFn func(r *scheduledReminder), flushFn func(tracks map[string]ReminderTrack) error) *reminderScheduler {
+	return &reminderScheduler{
+		entries: map[string]*scheduledReminder{},
+		tracks:  map[string]ReminderTrack{},
+		fireFn:  fireFn,
+		flushFn: flushFn,
+		wakeCh:  make(chan struct{}, 1),
+		flushCh: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// schedule adds a reminder to the scheduler, replacing any reminder with the same key.
+func (s *reminderScheduler) schedule(r *scheduledReminder) {
+	s.startOnce.Do(func() { go s.run() })
+
+	s.lock.Lock()
+	if old, ok := s.entries[r.key]; ok && old.index >= 0 {
+		heap.Remove(&s.queue, old.index)
+	}
+	s.entries[r.key] = r
+	heap.Push(&s.queue, r)
+	depth := len(s.entries)
+	s.lock.Unlock()
+
+	diag.DefaultMonitoring.ReportActorReminderQueueDepth(depth)
+	s.wake()
+}
+
+// reschedule puts a reminder that has fired back in the queue, unless it was removed
+// or replaced while firing.
+func (s *reminderScheduler) reschedule(r *scheduledReminder) {
+	s.lock.Lock()
+	if s.entries[r.key] != r {
+		s.lock.Unlock()
+		return
+	}
+	heap.Push(&s.queue, r)
+	s.lock.Unlock()
+
+	s.wake()
+}
+
+// remove removes a reminder from the scheduler. It returns false if the reminder wasn't scheduled.
+func (s *reminderScheduler) remove(key string) bool {
+	s.lock.Lock()
+	r, ok := s.entries[key]
+	if ok {
+		delete(s.entries, key)
+		if r.index >= 0 {
+			heap.Remove(&s.queue, r.index)
+		}
+	}
+	depth := len(s.entries)
+	s.lock.Unlock()
+
+	if ok {
+		diag.DefaultMonitoring.ReportActorReminderQueueDepth(depth)
+	}
+	return ok
+}
+
+// isScheduled returns true if the given reminder is scheduled and hasn't been removed or replaced.
+func (s *reminderScheduler) isScheduled(r *scheduledReminder) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.entries[r.key] == r
+}
+
+// has returns true if a reminder with the given key is scheduled.
+func (s *reminderScheduler) has(key string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.entries[key]
+	return ok
+}
+
+// keys returns the keys of all scheduled reminders.
+func (s *reminderScheduler) keys() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := make([]string, 0, len(s.entries))
+	for k := range s.entries {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// len returns the number of scheduled reminders.
+func (s *reminderScheduler) len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.entries)
+}
+
+// queueTrack records a reminder track to be written with the next flush.
+func (s *reminderScheduler) queueTrack(key string, track ReminderTrack) {
+	s.tracksLock.Lock()
+	s.tracks[key] = track
+	full := len(s.tracks) >= reminderTrackFlushBatchSize
+	s.tracksLock.Unlock()
+
+	if full {
+		select {
+		case s.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// pendingTrack returns the reminder track that hasn't been written to the state store yet, if any.
+func (s *reminderScheduler) pendingTrack(key string) (ReminderTrack, bool) {
+	s.tracksLock.Lock()
+	defer s.tracksLock.Unlock()
+	track, ok := s.tracks[key]
+	return track, ok
+}
+
+// deleteTrack discards the pending track of a reminder and deletes the stored one with
+// deleteFn, so that a flush in progress can't write the track back.
+func (s *reminderScheduler) deleteTrack(key string, deleteFn func() error) error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	s.tracksLock.Lock()
+	delete(s.tracks, key)
+	s.tracksLock.Unlock()
+	return deleteFn()
+}
+
+// flushTracks writes the pending reminder tracks to the state store. Tracks that fail to
+// be written are kept for the next flush unless a newer track was queued in the meantime.
+func (s *reminderScheduler) flushTracks() {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	s.tracksLock.Lock()
+	if len(s.tracks) == 0 {
+		s.tracksLock.Unlock()
+		return
+	}
+	tracks := s.tracks
+	s.tracks = map[string]ReminderTrack{}
+	s.tracksLock.Unlock()
+
+	if err := s.flushFn(tracks); err != nil {
+		log.Errorf("error updating %d reminder tracks: %s", len(tracks), err)
+
+		s.tracksLock.Lock()
+		for k, v := range tracks {
+			if _, ok := s.tracks[k]; !ok {
+				s.tracks[k] = v
+			}
+		}
+		s.tracksLock.Unlock()
+	}
+}
+
+// stop stops firing reminders and flushes the pending reminder tracks.
+func (s *reminderScheduler) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		// Prevent the scheduler from starting after it was stopped.
+		s.startOnce.Do(func() { close(s.doneCh) })
+		<-s.doneCh
+		s.flushTracks()
+	})
+}
+
+func (s *reminderScheduler) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// popDue removes the reminders that are due from the queue and returns them with the
+// time until the next reminder is due, or a negative duration if the queue is empty.
+func (s *reminderScheduler) popDue(now time.Time) ([]*scheduledReminder, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var due []*scheduledReminder
+	for len(s.queue) > 0 && !s.queue[0].dueTime().After(now) {
+		due = append(due, heap.Pop(&s.queue).(*scheduledReminder))
+	}
+	if len(s.queue) == 0 {
+		return due, -1
+	}
+	return due, s.queue[0].dueTime().Sub(now)
+}
+
+func (s *reminderScheduler) run() {
+	defer close(s.doneCh)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	flushTicker := time.NewTicker(reminderTrackFlushInterval)
+	defer flushTicker.Stop()
+
+	for {
+		due, wait := s.popDue(time.Now())
+		for _, r := range due {
+			// A reminder is out of the queue while it fires, so it never runs concurrently with itself.
+			go s.fireFn(r)
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if wait >= 0 {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-timer.C:
+		case <-s.wakeCh:
+		case <-flushTicker.C:
+			s.flushTracks()
+		case <-s.flushCh:
+			s.flushTracks()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package actors
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheduledReminder(name string, nextTime time.Time) *scheduledReminder {
+	return &scheduledReminder{
+		key:      constructCompositeKey("cat", "1", name),
+		reminder: Reminder{ActorType: "cat", ActorID: "1", Name: name},
+		nextTime: nextTime,
+	}
+}
+
+func TestReminderSchedulerFiresInOrder(t *testing.T) {
+	var lock sync.Mutex
+	fired := []string{}
+	done := make(chan struct{})
+	s := newReminderScheduler(func(r *scheduledReminder) {
+		lock.Lock()
+		defer lock.Unlock()
+		fired = append(fired, r.reminder.Name)
+		if len(fired) == 3 {
+			close(done)
+		}
+	}, func(map[string]ReminderTrack) error { return nil })
+	defer s.stop()
+
+	now := time.Now()
+	s.schedule(newTestScheduledReminder("c", now.Add(150*time.Millisecond)))
+	s.schedule(newTestScheduledReminder("a", now.Add(50*time.Millisecond)))
+	s.schedule(newTestScheduledReminder("b", now.Add(100*time.Millisecond)))
+	assert.Equal(t, 3, s.len())
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("reminders did not fire")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{"a", "b", "c"}, fired)
+}
+
+func TestReminderSchedulerRemove(t *testing.T) {
+	fired := make(chan string, 2)
+	s := newReminderScheduler(func(r *scheduledReminder) {
+		fired <- r.reminder.Name
+	}, func(map[string]ReminderTrack) error { return nil })
+	defer s.stop()
+
+	r := newTestScheduledReminder("a", time.Now().Add(50*time.Millisecond))
+	s.schedule(r)
+	assert.True(t, s.has(r.key))
+	assert.True(t, s.remove(r.key))
+	assert.False(t, s.remove(r.key))
+	assert.False(t, s.isScheduled(r))
+
+	// A removed reminder isn't queued again after firing.
+	s.reschedule(r)
+	assert.Equal(t, 0, s.len())
+
+	select {
+	case name := <-fired:
+		t.Fatalf("removed reminder %s fired", name)
+	case <-time.After(150 * time.Millisecond):
+	}
+}
+
+func TestReminderSchedulerReplace(t *testing.T) {
+	fired := make(chan *scheduledReminder, 2)
+	s := newReminderScheduler(func(r *scheduledReminder) {
+		fired <- r
+	}, func(map[string]ReminderTrack) error { return nil })
+	defer s.stop()
+
+	old := newTestScheduledReminder("a", time.Now().Add(time.Hour))
+	s.schedule(old)
+	replacement := newTestScheduledReminder("a", time.Now())
+	s.schedule(replacement)
+	assert.Equal(t, 1, s.len())
+	assert.False(t, s.isScheduled(old))
+
+	select {
+	case r := <-fired:
+		assert.Same(t, replacement, r)
+	case <-time.After(time.Second):
+		t.Fatal("reminder did not fire")
+	}
+}
+
+func TestReminderSchedulerExpiry(t *testing.T) {
+	r := newTestScheduledReminder("a", time.Now().Add(time.Hour))
+	r.ttl = time.Now().Add(time.Minute)
+	assert.Equal(t, r.ttl, r.dueTime())
+
+	r.ttl = time.Now().Add(2 * time.Hour)
+	assert.Equal(t, r.nextTime, r.dueTime())
+}
+
+func TestReminderSchedulerTracks(t *testing.T) {
+	t.Run("tracks are flushed in a batch on stop", func(t *testing.T) {
+		var flushed map[string]ReminderTrack
+		s := newReminderScheduler(func(*scheduledReminder) {}, func(tracks map[string]ReminderTrack) error {
+			flushed = tracks
+			return nil
+		})
+
+		s.queueTrack("a", ReminderTrack{RepetitionLeft: 1})
+		s.queueTrack("b", ReminderTrack{RepetitionLeft: 2})
+		track, ok := s.pendingTrack("a")
+		require.True(t, ok)
+		assert.Equal(t, 1, track.RepetitionLeft)
+
+		s.stop()
+		assert.Len(t, flushed, 2)
+		_, ok = s.pendingTrack("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("failed flush keeps the tracks", func(t *testing.T) {
+		s := newReminderScheduler(func(*scheduledReminder) {}, func(map[string]ReminderTrack) error {
+			return errors.New("store unavailable")
+		})
+
+		s.queueTrack("a", ReminderTrack{RepetitionLeft: 1})
+		s.flushTracks()
+		_, ok := s.pendingTrack("a")
+		assert.True(t, ok)
+	})
+
+	t.Run("deleted tracks are not flushed", func(t *testing.T) {
+		var flushed map[string]ReminderTrack
+		s := newReminderScheduler(func(*scheduledReminder) {}, func(tracks map[string]ReminderTrack) error {
+			flushed = tracks
+			return nil
+		})
+
+		s.queueTrack("a", ReminderTrack{RepetitionLeft: 1})
+		deleted := false
+		err := s.deleteTrack("a", func() error {
+			deleted = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		s.flushTracks()
+		assert.Nil(t, flushed)
+	})
+}
+
+func TestGetReminderTracks(t *testing.T) {
+	testActorsRuntime := newTestActorsRuntime()
+	actorKey := constructCompositeKey(getTestActorTypeAndID())
+
+	err := testActorsRuntime.updateReminderTrack(actorKey, "stored", 3, time.Now())
+	require.NoError(t, err)
+	testActorsRuntime.reminderScheduler.queueTrack(constructCompositeKey(actorKey, "pending"), ReminderTrack{RepetitionLeft: 5})
+
+	tracks, err := testActorsRuntime.getReminderTracks([]string{
+		constructCompositeKey(actorKey, "stored"),
+		constructCompositeKey(actorKey, "pending"),
+		constructCompositeKey(actorKey, "missing"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, tracks[constructCompositeKey(actorKey, "stored")].RepetitionLeft)
+	assert.Equal(t, 5, tracks[constructCompositeKey(actorKey, "pending")].RepetitionLeft)
+	assert.Equal(t, -1, tracks[constructCompositeKey(actorKey, "missing")].RepetitionLeft)
+}
@@ -44,8 +44,9 @@ type TransactionalOperation struct {
 
 // TransactionalUpsert defines a key/value pair for an upsert operation.
 type TransactionalUpsert struct {
-	Key   string      `json:"key"`
-	Value interface{} `json:"value"`
+	Key      string            `json:"key"`
+	Value    interface{}       `json:"value"`
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // TransactionalDelete defined a delete operation.
@@ -0,0 +1,363 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: pkg/api/v1/internals/service_invocation_stream.proto
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internals
+
+import (
+	internals "github.com/bhojpur/api/pkg/core/v1/internals"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StreamPayload is a chunk of the data of a streamed request or response.
+type StreamPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The chunk of data.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The sequence number of the chunk, starting from 0.
+	Seq uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *StreamPayload) Reset() {
+	*x = StreamPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPayload) ProtoMessage() {}
+
+func (x *StreamPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPayload.ProtoReflect.Descriptor instead.
+func (*StreamPayload) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamPayload) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *StreamPayload) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// InternalInvokeRequestStream is a message of a streamed service invocation
+// request.
+type InternalInvokeRequestStream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The request, without its data. Set in the first message only.
+	Request *internals.InternalInvokeRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// A chunk of the request data.
+	Payload *StreamPayload `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *InternalInvokeRequestStream) Reset() {
+	*x = InternalInvokeRequestStream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InternalInvokeRequestStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalInvokeRequestStream) ProtoMessage() {}
+
+func (x *InternalInvokeRequestStream) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalInvokeRequestStream.ProtoReflect.Descriptor instead.
+func (*InternalInvokeRequestStream) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InternalInvokeRequestStream) GetRequest() *internals.InternalInvokeRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *InternalInvokeRequestStream) GetPayload() *StreamPayload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// InternalInvokeResponseStream is a message of a streamed service invocation
+// response.
+type InternalInvokeResponseStream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The response, without its data. Set in the first message only.
+	Response *internals.InternalInvokeResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// A chunk of the response data.
+	Payload *StreamPayload `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *InternalInvokeResponseStream) Reset() {
+	*x = InternalInvokeResponseStream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InternalInvokeResponseStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalInvokeResponseStream) ProtoMessage() {}
+
+func (x *InternalInvokeResponseStream) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalInvokeResponseStream.ProtoReflect.Descriptor instead.
+func (*InternalInvokeResponseStream) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InternalInvokeResponseStream) GetResponse() *internals.InternalInvokeResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *InternalInvokeResponseStream) GetPayload() *StreamPayload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_pkg_api_v1_internals_service_invocation_stream_proto protoreflect.FileDescriptor
+
+var file_pkg_api_v1_internals_service_invocation_stream_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x73, 0x1a, 0x2e, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76,
+	0x31, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x93, 0x01, 0x0a, 0x1b,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3d, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x76,
+	0x31, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x76, 0x31,
+	0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0x97, 0x01, 0x0a, 0x1c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x6e,
+	0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x40, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x76, 0x6f,
+	0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x32, 0x89, 0x01, 0x0a, 0x17,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x6e, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x6c, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x29, 0x2e, 0x76, 0x31, 0x2e,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x2a, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x76,
+	0x6f, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x68, 0x6f, 0x6a, 0x70, 0x75, 0x72, 0x2f, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x3b, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescOnce sync.Once
+	file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescData = file_pkg_api_v1_internals_service_invocation_stream_proto_rawDesc
+)
+
+func file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescGZIP() []byte {
+	file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescOnce.Do(func() {
+		file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescData)
+	})
+	return file_pkg_api_v1_internals_service_invocation_stream_proto_rawDescData
+}
+
+var file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_api_v1_internals_service_invocation_stream_proto_goTypes = []interface{}{
+	(*StreamPayload)(nil),                    // 0: v1.internals.StreamPayload
+	(*InternalInvokeRequestStream)(nil),      // 1: v1.internals.InternalInvokeRequestStream
+	(*InternalInvokeResponseStream)(nil),     // 2: v1.internals.InternalInvokeResponseStream
+	(*internals.InternalInvokeRequest)(nil),  // 3: v1.internals.InternalInvokeRequest
+	(*internals.InternalInvokeResponse)(nil), // 4: v1.internals.InternalInvokeResponse
+}
+var file_pkg_api_v1_internals_service_invocation_stream_proto_depIdxs = []int32{
+	3, // 0: v1.internals.InternalInvokeRequestStream.request:type_name -> v1.internals.InternalInvokeRequest
+	0, // 1: v1.internals.InternalInvokeRequestStream.payload:type_name -> v1.internals.StreamPayload
+	4, // 2: v1.internals.InternalInvokeResponseStream.response:type_name -> v1.internals.InternalInvokeResponse
+	0, // 3: v1.internals.InternalInvokeResponseStream.payload:type_name -> v1.internals.StreamPayload
+	1, // 4: v1.internals.ServiceInvocationStream.CallLocalStream:input_type -> v1.internals.InternalInvokeRequestStream
+	2, // 5: v1.internals.ServiceInvocationStream.CallLocalStream:output_type -> v1.internals.InternalInvokeResponseStream
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_v1_internals_service_invocation_stream_proto_init() }
+func file_pkg_api_v1_internals_service_invocation_stream_proto_init() {
+	if File_pkg_api_v1_internals_service_invocation_stream_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InternalInvokeRequestStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InternalInvokeResponseStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_api_v1_internals_service_invocation_stream_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_v1_internals_service_invocation_stream_proto_goTypes,
+		DependencyIndexes: file_pkg_api_v1_internals_service_invocation_stream_proto_depIdxs,
+		MessageInfos:      file_pkg_api_v1_internals_service_invocation_stream_proto_msgTypes,
+	}.Build()
+	File_pkg_api_v1_internals_service_invocation_stream_proto = out.File
+	file_pkg_api_v1_internals_service_invocation_stream_proto_rawDesc = nil
+	file_pkg_api_v1_internals_service_invocation_stream_proto_goTypes = nil
+	file_pkg_api_v1_internals_service_invocation_stream_proto_depIdxs = nil
+}
@@ -0,0 +1,133 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package internals
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ServiceInvocationStreamClient is the client API for ServiceInvocationStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ServiceInvocationStreamClient interface {
+	// Invokes a method of the specific service, streaming the request and response data.
+	CallLocalStream(ctx context.Context, opts ...grpc.CallOption) (ServiceInvocationStream_CallLocalStreamClient, error)
+}
+
+type serviceInvocationStreamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewServiceInvocationStreamClient(cc grpc.ClientConnInterface) ServiceInvocationStreamClient {
+	return &serviceInvocationStreamClient{cc}
+}
+
+func (c *serviceInvocationStreamClient) CallLocalStream(ctx context.Context, opts ...grpc.CallOption) (ServiceInvocationStream_CallLocalStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServiceInvocationStream_ServiceDesc.Streams[0], "/v1.internals.ServiceInvocationStream/CallLocalStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceInvocationStreamCallLocalStreamClient{stream}
+	return x, nil
+}
+
+type ServiceInvocationStream_CallLocalStreamClient interface {
+	Send(*InternalInvokeRequestStream) error
+	Recv() (*InternalInvokeResponseStream, error)
+	grpc.ClientStream
+}
+
+type serviceInvocationStreamCallLocalStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceInvocationStreamCallLocalStreamClient) Send(m *InternalInvokeRequestStream) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceInvocationStreamCallLocalStreamClient) Recv() (*InternalInvokeResponseStream, error) {
+	m := new(InternalInvokeResponseStream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ServiceInvocationStreamServer is the server API for ServiceInvocationStream service.
+// All implementations should embed UnimplementedServiceInvocationStreamServer
+// for forward compatibility
+type ServiceInvocationStreamServer interface {
+	// Invokes a method of the specific service, streaming the request and response data.
+	CallLocalStream(ServiceInvocationStream_CallLocalStreamServer) error
+}
+
+// UnimplementedServiceInvocationStreamServer should be embedded to have forward compatible implementations.
+type UnimplementedServiceInvocationStreamServer struct {
+}
+
+func (UnimplementedServiceInvocationStreamServer) CallLocalStream(ServiceInvocationStream_CallLocalStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CallLocalStream not implemented")
+}
+
+// UnsafeServiceInvocationStreamServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ServiceInvocationStreamServer will
+// result in compilation errors.
+type UnsafeServiceInvocationStreamServer interface {
+	mustEmbedUnimplementedServiceInvocationStreamServer()
+}
+
+func RegisterServiceInvocationStreamServer(s grpc.ServiceRegistrar, srv ServiceInvocationStreamServer) {
+	s.RegisterService(&ServiceInvocationStream_ServiceDesc, srv)
+}
+
+func _ServiceInvocationStream_CallLocalStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceInvocationStreamServer).CallLocalStream(&serviceInvocationStreamCallLocalStreamServer{stream})
+}
+
+type ServiceInvocationStream_CallLocalStreamServer interface {
+	Send(*InternalInvokeResponseStream) error
+	Recv() (*InternalInvokeRequestStream, error)
+	grpc.ServerStream
+}
+
+type serviceInvocationStreamCallLocalStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceInvocationStreamCallLocalStreamServer) Send(m *InternalInvokeResponseStream) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceInvocationStreamCallLocalStreamServer) Recv() (*InternalInvokeRequestStream, error) {
+	m := new(InternalInvokeRequestStream)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ServiceInvocationStream_ServiceDesc is the grpc.ServiceDesc for ServiceInvocationStream service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ServiceInvocationStream_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.internals.ServiceInvocationStream",
+	HandlerType: (*ServiceInvocationStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CallLocalStream",
+			Handler:       _ServiceInvocationStream_CallLocalStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/api/v1/internals/service_invocation_stream.proto",
+}
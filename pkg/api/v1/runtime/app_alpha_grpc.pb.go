@@ -0,0 +1,491 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package runtime
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ApplicationAlphaClient is the client API for ApplicationAlpha service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ApplicationAlphaClient interface {
+	// Publishes a batch of events to the specified topic.
+	BulkPublishEventAlpha1(ctx context.Context, in *BulkPublishRequest, opts ...grpc.CallOption) (*BulkPublishResponse, error)
+	// Tries to acquire a distributed lock on a resource.
+	TryLockAlpha1(ctx context.Context, in *TryLockRequest, opts ...grpc.CallOption) (*TryLockResponse, error)
+	// Releases a distributed lock held by the requesting owner.
+	UnlockAlpha1(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error)
+	// Starts a new workflow instance.
+	StartWorkflowAlpha1(ctx context.Context, in *StartWorkflowRequest, opts ...grpc.CallOption) (*StartWorkflowResponse, error)
+	// Gets the status of a workflow instance.
+	GetWorkflowAlpha1(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*GetWorkflowResponse, error)
+	// Terminates a running workflow instance.
+	TerminateWorkflowAlpha1(ctx context.Context, in *TerminateWorkflowRequest, opts ...grpc.CallOption) (*TerminateWorkflowResponse, error)
+	// Raises an event on a running workflow instance.
+	RaiseEventWorkflowAlpha1(ctx context.Context, in *RaiseEventWorkflowRequest, opts ...grpc.CallOption) (*RaiseEventWorkflowResponse, error)
+	// Executes a transaction on the state of an actor, honoring per-operation metadata such as ttlInSeconds.
+	ExecuteActorStateTransactionAlpha1(ctx context.Context, in *ExecuteActorStateTransactionRequestAlpha1, opts ...grpc.CallOption) (*ExecuteActorStateTransactionResponseAlpha1, error)
+	// Queries the state of all the actors of a type.
+	QueryActorStateAlpha1(ctx context.Context, in *QueryActorStateRequest, opts ...grpc.CallOption) (*QueryActorStateResponse, error)
+}
+
+type applicationAlphaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewApplicationAlphaClient(cc grpc.ClientConnInterface) ApplicationAlphaClient {
+	return &applicationAlphaClient{cc}
+}
+
+func (c *applicationAlphaClient) BulkPublishEventAlpha1(ctx context.Context, in *BulkPublishRequest, opts ...grpc.CallOption) (*BulkPublishResponse, error) {
+	out := new(BulkPublishResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/BulkPublishEventAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) TryLockAlpha1(ctx context.Context, in *TryLockRequest, opts ...grpc.CallOption) (*TryLockResponse, error) {
+	out := new(TryLockResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/TryLockAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) UnlockAlpha1(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error) {
+	out := new(UnlockResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/UnlockAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) StartWorkflowAlpha1(ctx context.Context, in *StartWorkflowRequest, opts ...grpc.CallOption) (*StartWorkflowResponse, error) {
+	out := new(StartWorkflowResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/StartWorkflowAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) GetWorkflowAlpha1(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*GetWorkflowResponse, error) {
+	out := new(GetWorkflowResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/GetWorkflowAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) TerminateWorkflowAlpha1(ctx context.Context, in *TerminateWorkflowRequest, opts ...grpc.CallOption) (*TerminateWorkflowResponse, error) {
+	out := new(TerminateWorkflowResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/TerminateWorkflowAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) RaiseEventWorkflowAlpha1(ctx context.Context, in *RaiseEventWorkflowRequest, opts ...grpc.CallOption) (*RaiseEventWorkflowResponse, error) {
+	out := new(RaiseEventWorkflowResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/RaiseEventWorkflowAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) ExecuteActorStateTransactionAlpha1(ctx context.Context, in *ExecuteActorStateTransactionRequestAlpha1, opts ...grpc.CallOption) (*ExecuteActorStateTransactionResponseAlpha1, error) {
+	out := new(ExecuteActorStateTransactionResponseAlpha1)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/ExecuteActorStateTransactionAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationAlphaClient) QueryActorStateAlpha1(ctx context.Context, in *QueryActorStateRequest, opts ...grpc.CallOption) (*QueryActorStateResponse, error) {
+	out := new(QueryActorStateResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.ApplicationAlpha/QueryActorStateAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplicationAlphaServer is the server API for ApplicationAlpha service.
+// All implementations should embed UnimplementedApplicationAlphaServer
+// for forward compatibility
+type ApplicationAlphaServer interface {
+	// Publishes a batch of events to the specified topic.
+	BulkPublishEventAlpha1(context.Context, *BulkPublishRequest) (*BulkPublishResponse, error)
+	// Tries to acquire a distributed lock on a resource.
+	TryLockAlpha1(context.Context, *TryLockRequest) (*TryLockResponse, error)
+	// Releases a distributed lock held by the requesting owner.
+	UnlockAlpha1(context.Context, *UnlockRequest) (*UnlockResponse, error)
+	// Starts a new workflow instance.
+	StartWorkflowAlpha1(context.Context, *StartWorkflowRequest) (*StartWorkflowResponse, error)
+	// Gets the status of a workflow instance.
+	GetWorkflowAlpha1(context.Context, *GetWorkflowRequest) (*GetWorkflowResponse, error)
+	// Terminates a running workflow instance.
+	TerminateWorkflowAlpha1(context.Context, *TerminateWorkflowRequest) (*TerminateWorkflowResponse, error)
+	// Raises an event on a running workflow instance.
+	RaiseEventWorkflowAlpha1(context.Context, *RaiseEventWorkflowRequest) (*RaiseEventWorkflowResponse, error)
+	// Executes a transaction on the state of an actor, honoring per-operation metadata such as ttlInSeconds.
+	ExecuteActorStateTransactionAlpha1(context.Context, *ExecuteActorStateTransactionRequestAlpha1) (*ExecuteActorStateTransactionResponseAlpha1, error)
+	// Queries the state of all the actors of a type.
+	QueryActorStateAlpha1(context.Context, *QueryActorStateRequest) (*QueryActorStateResponse, error)
+}
+
+// UnimplementedApplicationAlphaServer should be embedded to have forward compatible implementations.
+type UnimplementedApplicationAlphaServer struct {
+}
+
+func (UnimplementedApplicationAlphaServer) BulkPublishEventAlpha1(context.Context, *BulkPublishRequest) (*BulkPublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkPublishEventAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) TryLockAlpha1(context.Context, *TryLockRequest) (*TryLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TryLockAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) UnlockAlpha1(context.Context, *UnlockRequest) (*UnlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) StartWorkflowAlpha1(context.Context, *StartWorkflowRequest) (*StartWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartWorkflowAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) GetWorkflowAlpha1(context.Context, *GetWorkflowRequest) (*GetWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) TerminateWorkflowAlpha1(context.Context, *TerminateWorkflowRequest) (*TerminateWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateWorkflowAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) RaiseEventWorkflowAlpha1(context.Context, *RaiseEventWorkflowRequest) (*RaiseEventWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RaiseEventWorkflowAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) ExecuteActorStateTransactionAlpha1(context.Context, *ExecuteActorStateTransactionRequestAlpha1) (*ExecuteActorStateTransactionResponseAlpha1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteActorStateTransactionAlpha1 not implemented")
+}
+func (UnimplementedApplicationAlphaServer) QueryActorStateAlpha1(context.Context, *QueryActorStateRequest) (*QueryActorStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryActorStateAlpha1 not implemented")
+}
+
+// UnsafeApplicationAlphaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ApplicationAlphaServer will
+// result in compilation errors.
+type UnsafeApplicationAlphaServer interface {
+	mustEmbedUnimplementedApplicationAlphaServer()
+}
+
+func RegisterApplicationAlphaServer(s grpc.ServiceRegistrar, srv ApplicationAlphaServer) {
+	s.RegisterService(&ApplicationAlpha_ServiceDesc, srv)
+}
+
+func _ApplicationAlpha_BulkPublishEventAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkPublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).BulkPublishEventAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/BulkPublishEventAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).BulkPublishEventAlpha1(ctx, req.(*BulkPublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_TryLockAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TryLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).TryLockAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/TryLockAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).TryLockAlpha1(ctx, req.(*TryLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_UnlockAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).UnlockAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/UnlockAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).UnlockAlpha1(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_StartWorkflowAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).StartWorkflowAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/StartWorkflowAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).StartWorkflowAlpha1(ctx, req.(*StartWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_GetWorkflowAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).GetWorkflowAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/GetWorkflowAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).GetWorkflowAlpha1(ctx, req.(*GetWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_TerminateWorkflowAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).TerminateWorkflowAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/TerminateWorkflowAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).TerminateWorkflowAlpha1(ctx, req.(*TerminateWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_RaiseEventWorkflowAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaiseEventWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).RaiseEventWorkflowAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/RaiseEventWorkflowAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).RaiseEventWorkflowAlpha1(ctx, req.(*RaiseEventWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_ExecuteActorStateTransactionAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteActorStateTransactionRequestAlpha1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).ExecuteActorStateTransactionAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/ExecuteActorStateTransactionAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).ExecuteActorStateTransactionAlpha1(ctx, req.(*ExecuteActorStateTransactionRequestAlpha1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationAlpha_QueryActorStateAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryActorStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationAlphaServer).QueryActorStateAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.ApplicationAlpha/QueryActorStateAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationAlphaServer).QueryActorStateAlpha1(ctx, req.(*QueryActorStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ApplicationAlpha_ServiceDesc is the grpc.ServiceDesc for ApplicationAlpha service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ApplicationAlpha_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.runtime.ApplicationAlpha",
+	HandlerType: (*ApplicationAlphaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BulkPublishEventAlpha1",
+			Handler:    _ApplicationAlpha_BulkPublishEventAlpha1_Handler,
+		},
+		{
+			MethodName: "TryLockAlpha1",
+			Handler:    _ApplicationAlpha_TryLockAlpha1_Handler,
+		},
+		{
+			MethodName: "UnlockAlpha1",
+			Handler:    _ApplicationAlpha_UnlockAlpha1_Handler,
+		},
+		{
+			MethodName: "StartWorkflowAlpha1",
+			Handler:    _ApplicationAlpha_StartWorkflowAlpha1_Handler,
+		},
+		{
+			MethodName: "GetWorkflowAlpha1",
+			Handler:    _ApplicationAlpha_GetWorkflowAlpha1_Handler,
+		},
+		{
+			MethodName: "TerminateWorkflowAlpha1",
+			Handler:    _ApplicationAlpha_TerminateWorkflowAlpha1_Handler,
+		},
+		{
+			MethodName: "RaiseEventWorkflowAlpha1",
+			Handler:    _ApplicationAlpha_RaiseEventWorkflowAlpha1_Handler,
+		},
+		{
+			MethodName: "ExecuteActorStateTransactionAlpha1",
+			Handler:    _ApplicationAlpha_ExecuteActorStateTransactionAlpha1_Handler,
+		},
+		{
+			MethodName: "QueryActorStateAlpha1",
+			Handler:    _ApplicationAlpha_QueryActorStateAlpha1_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/api/v1/runtime/app_alpha.proto",
+}
+
+// AppCallbackAlphaClient is the client API for AppCallbackAlpha service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AppCallbackAlphaClient interface {
+	// Subscribes a batch of events from Pubsub.
+	OnBulkTopicEventAlpha1(ctx context.Context, in *TopicEventBulkRequest, opts ...grpc.CallOption) (*TopicEventBulkResponse, error)
+}
+
+type appCallbackAlphaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppCallbackAlphaClient(cc grpc.ClientConnInterface) AppCallbackAlphaClient {
+	return &appCallbackAlphaClient{cc}
+}
+
+func (c *appCallbackAlphaClient) OnBulkTopicEventAlpha1(ctx context.Context, in *TopicEventBulkRequest, opts ...grpc.CallOption) (*TopicEventBulkResponse, error) {
+	out := new(TopicEventBulkResponse)
+	err := c.cc.Invoke(ctx, "/v1.runtime.AppCallbackAlpha/OnBulkTopicEventAlpha1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppCallbackAlphaServer is the server API for AppCallbackAlpha service.
+// All implementations should embed UnimplementedAppCallbackAlphaServer
+// for forward compatibility
+type AppCallbackAlphaServer interface {
+	// Subscribes a batch of events from Pubsub.
+	OnBulkTopicEventAlpha1(context.Context, *TopicEventBulkRequest) (*TopicEventBulkResponse, error)
+}
+
+// UnimplementedAppCallbackAlphaServer should be embedded to have forward compatible implementations.
+type UnimplementedAppCallbackAlphaServer struct {
+}
+
+func (UnimplementedAppCallbackAlphaServer) OnBulkTopicEventAlpha1(context.Context, *TopicEventBulkRequest) (*TopicEventBulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnBulkTopicEventAlpha1 not implemented")
+}
+
+// UnsafeAppCallbackAlphaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AppCallbackAlphaServer will
+// result in compilation errors.
+type UnsafeAppCallbackAlphaServer interface {
+	mustEmbedUnimplementedAppCallbackAlphaServer()
+}
+
+func RegisterAppCallbackAlphaServer(s grpc.ServiceRegistrar, srv AppCallbackAlphaServer) {
+	s.RegisterService(&AppCallbackAlpha_ServiceDesc, srv)
+}
+
+func _AppCallbackAlpha_OnBulkTopicEventAlpha1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopicEventBulkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppCallbackAlphaServer).OnBulkTopicEventAlpha1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.runtime.AppCallbackAlpha/OnBulkTopicEventAlpha1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppCallbackAlphaServer).OnBulkTopicEventAlpha1(ctx, req.(*TopicEventBulkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AppCallbackAlpha_ServiceDesc is the grpc.ServiceDesc for AppCallbackAlpha service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AppCallbackAlpha_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.runtime.AppCallbackAlpha",
+	HandlerType: (*AppCallbackAlphaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "OnBulkTopicEventAlpha1",
+			Handler:    _AppCallbackAlpha_OnBulkTopicEventAlpha1_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/api/v1/runtime/app_alpha.proto",
+}
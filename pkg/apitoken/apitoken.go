@@ -0,0 +1,295 @@
+package apitoken
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/bhojpur/service/pkg/utils/logger"
+
+	"github.com/bhojpur/application/pkg/config"
+	"github.com/bhojpur/application/pkg/fswatcher"
+)
+
+var log = logger.NewLogger("app.runtime.apitoken")
+
+const (
+	// AccessRead allows the operations of a component that do not change it, such as
+	// getting state or secrets.
+	AccessRead = "read"
+	// AccessReadWrite allows every operation of a component.
+	AccessReadWrite = "readwrite"
+
+	// legacyTokenName is the name of the single token read from the environment.
+	legacyTokenName = "default"
+)
+
+// Token is a named API token along with the APIs and the components it may use.
+// A token without allow rules may call every API, and a token without scopes may use
+// every component.
+type Token struct {
+	Name    string                 `json:"name" yaml:"name"`
+	Token   string                 `json:"token" yaml:"token"`
+	Allowed []config.APIAccessRule `json:"allowed,omitempty" yaml:"allowed,omitempty"`
+	Scopes  []Scope                `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// Scope grants a token access to a component.
+type Scope struct {
+	Component string `json:"component" yaml:"component"`
+	Access    string `json:"access,omitempty" yaml:"access,omitempty"`
+}
+
+type tokenList struct {
+	Tokens []Token `json:"tokens" yaml:"tokens"`
+}
+
+// Parse reads a token list, in YAML or JSON, and validates it.
+func Parse(data []byte) ([]Token, error) {
+	var list tokenList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrap(err, "failed to parse api tokens")
+	}
+
+	names := map[string]bool{}
+	values := map[string]bool{}
+	for i, t := range list.Tokens {
+		if t.Name == "" {
+			return nil, fmt.Errorf("api token %d has no name", i)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("api token %q is defined more than once", t.Name)
+		}
+		names[t.Name] = true
+
+		if t.Token == "" {
+			return nil, fmt.Errorf("api token %q has no token value", t.Name)
+		}
+		if values[t.Token] {
+			return nil, fmt.Errorf("api token %q has the same token value as another token", t.Name)
+		}
+		values[t.Token] = true
+
+		for j, s := range t.Scopes {
+			if s.Component == "" {
+				return nil, fmt.Errorf("scope %d of api token %q has no component", j, t.Name)
+			}
+			switch s.Access {
+			case "":
+				list.Tokens[i].Scopes[j].Access = AccessReadWrite
+			case AccessRead, AccessReadWrite:
+			default:
+				return nil, fmt.Errorf("scope %d of api token %q has unknown access %q", j, t.Name, s.Access)
+			}
+		}
+	}
+	return list.Tokens, nil
+}
+
+// AllowedRules returns the allow rules of the token for protocol. restricted is false
+// when the token may call every API. A token restricted to the APIs of one protocol may
+// call no API of another protocol.
+func (t *Token) AllowedRules(protocol string) (rules []config.APIAccessRule, restricted bool) {
+	if len(t.Allowed) == 0 {
+		return nil, false
+	}
+	for _, rule := range t.Allowed {
+		if rule.Protocol == protocol {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, true
+}
+
+// ComponentAllowed returns whether the token may use component, write telling whether
+// the operation changes it.
+func (t *Token) ComponentAllowed(component string, write bool) bool {
+	if len(t.Scopes) == 0 {
+		return true
+	}
+	for _, s := range t.Scopes {
+		if s.Component == component {
+			return !write || s.Access == AccessReadWrite
+		}
+	}
+	return false
+}
+
+// Store holds the API tokens accepted by the API servers. The named tokens can be
+// replaced at any time while requests are served.
+type Store struct {
+	legacy string
+
+	lock   sync.RWMutex
+	named  bool
+	tokens []Token
+}
+
+// NewStore returns a Store accepting legacyToken, when set, with access to every API
+// and component. Named tokens are added with Update or loaded with WatchFile and Poll.
+func NewStore(legacyToken string) *Store {
+	return &Store{legacy: legacyToken}
+}
+
+// Enabled returns whether API requests must carry a token.
+func (s *Store) Enabled() bool {
+	if s == nil {
+		return false
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.legacy != "" || s.named
+}
+
+// Update replaces the named tokens.
+func (s *Store) Update(tokens []Token) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.named = true
+	s.tokens = tokens
+}
+
+// Authenticate returns the token whose value is value. Every token is compared in
+// constant time, so the response time does not tell which token nearly matched.
+func (s *Store) Authenticate(value string) (*Token, bool) {
+	if s == nil || value == "" {
+		return nil, false
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var found *Token
+	if s.legacy != "" && subtle.ConstantTimeCompare([]byte(value), []byte(s.legacy)) == 1 {
+		found = &Token{Name: legacyTokenName}
+	}
+	for i := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(s.tokens[i].Token)) == 1 && found == nil {
+			found = &s.tokens[i]
+		}
+	}
+	return found, found != nil
+}
+
+// WatchFile loads the token list in path and loads it again whenever the file changes,
+// until ctx is done. When the first load fails no named token is accepted until the
+// file is fixed.
+func (s *Store) WatchFile(ctx context.Context, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	changed := make(chan struct{})
+	go func() {
+		// Secret volumes and editors replace the file instead of writing it, so the
+		// directory is watched.
+		if err := fswatcher.WatchAll(ctx, filepath.Dir(path), changed); err != nil {
+			log.Errorf("error watching api tokens file %s: %s", path, err)
+		}
+	}()
+	return s.watch(ctx, func(context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, changed)
+}
+
+// Poll loads the token list returned by fetch and fetches it again every interval,
+// until ctx is done. When the first load fails no named token is accepted until fetch
+// returns a valid list.
+func (s *Store) Poll(ctx context.Context, interval time.Duration, fetch func(context.Context) ([]byte, error)) error {
+	changed := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				select {
+				case changed <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return s.watch(ctx, fetch, changed)
+}
+
+func (s *Store) watch(ctx context.Context, fetch func(context.Context) ([]byte, error), changed <-chan struct{}) error {
+	last, err := s.load(ctx, fetch)
+	if err != nil {
+		s.Update(nil)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-changed:
+				data, err := fetch(ctx)
+				if err != nil {
+					log.Errorf("failed to reload api tokens, keeping the current tokens: %s", err)
+					continue
+				}
+				if last != nil && bytes.Equal(data, last) {
+					continue
+				}
+				tokens, err := Parse(data)
+				if err != nil {
+					log.Errorf("failed to reload api tokens, keeping the current tokens: %s", err)
+					continue
+				}
+				last = data
+				s.Update(tokens)
+				log.Infof("reloaded %d api tokens", len(tokens))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return err
+}
+
+func (s *Store) load(ctx context.Context, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load api tokens")
+	}
+	tokens, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	s.Update(tokens)
+	log.Infof("loaded %d api tokens", len(tokens))
+	return data, nil
+}
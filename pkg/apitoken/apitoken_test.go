@@ -0,0 +1,212 @@
+package apitoken
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokensYAML = `
+tokens:
+- name: reader
+  token: reader-token
+  allowed:
+  - name: state
+    version: v1.0
+    protocol: http
+  scopes:
+  - component: store1
+    access: read
+  - component: store2
+- name: admin
+  token: admin-token
+`
+
+func TestParse(t *testing.T) {
+	t.Run("valid list", func(t *testing.T) {
+		tokens, err := Parse([]byte(tokensYAML))
+		require.NoError(t, err)
+		require.Len(t, tokens, 2)
+		assert.Equal(t, "reader", tokens[0].Name)
+		assert.Equal(t, "http", tokens[0].Allowed[0].Protocol)
+		assert.Equal(t, AccessRead, tokens[0].Scopes[0].Access)
+		assert.Equal(t, AccessReadWrite, tokens[0].Scopes[1].Access)
+	})
+
+	t.Run("json list", func(t *testing.T) {
+		tokens, err := Parse([]byte(`{"tokens":[{"name":"a","token":"b"}]}`))
+		require.NoError(t, err)
+		assert.Equal(t, "b", tokens[0].Token)
+	})
+
+	invalid := map[string]string{
+		"missing name":      `tokens: [{token: a}]`,
+		"missing token":     `tokens: [{name: a}]`,
+		"duplicate name":    `tokens: [{name: a, token: a}, {name: a, token: b}]`,
+		"duplicate token":   `tokens: [{name: a, token: a}, {name: b, token: a}]`,
+		"missing component": `tokens: [{name: a, token: a, scopes: [{access: read}]}]`,
+		"unknown access":    `tokens: [{name: a, token: a, scopes: [{component: c, access: write}]}]`,
+		"not a list":        `tokens: a`,
+	}
+	for name, doc := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	tokens, err := Parse([]byte(tokensYAML))
+	require.NoError(t, err)
+	reader, admin := tokens[0], tokens[1]
+
+	t.Run("allowed rules", func(t *testing.T) {
+		rules, restricted := reader.AllowedRules("http")
+		assert.True(t, restricted)
+		assert.Len(t, rules, 1)
+
+		rules, restricted = reader.AllowedRules("grpc")
+		assert.True(t, restricted)
+		assert.Empty(t, rules)
+
+		_, restricted = admin.AllowedRules("grpc")
+		assert.False(t, restricted)
+	})
+
+	t.Run("component scopes", func(t *testing.T) {
+		assert.True(t, reader.ComponentAllowed("store1", false))
+		assert.False(t, reader.ComponentAllowed("store1", true))
+		assert.True(t, reader.ComponentAllowed("store2", true))
+		assert.False(t, reader.ComponentAllowed("store3", false))
+		assert.True(t, admin.ComponentAllowed("store3", true))
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Run("nil store is disabled", func(t *testing.T) {
+		var s *Store
+		assert.False(t, s.Enabled())
+		_, ok := s.Authenticate("token")
+		assert.False(t, ok)
+	})
+
+	t.Run("legacy token", func(t *testing.T) {
+		s := NewStore("legacy")
+		assert.True(t, s.Enabled())
+
+		token, ok := s.Authenticate("legacy")
+		require.True(t, ok)
+		assert.Equal(t, legacyTokenName, token.Name)
+
+		_, ok = s.Authenticate("")
+		assert.False(t, ok)
+	})
+
+	t.Run("named tokens", func(t *testing.T) {
+		s := NewStore("")
+		assert.False(t, s.Enabled())
+
+		s.Update(nil)
+		assert.True(t, s.Enabled())
+		_, ok := s.Authenticate("admin-token")
+		assert.False(t, ok)
+
+		tokens, err := Parse([]byte(tokensYAML))
+		require.NoError(t, err)
+		s.Update(tokens)
+		token, ok := s.Authenticate("admin-token")
+		require.True(t, ok)
+		assert.Equal(t, "admin", token.Name)
+	})
+}
+
+func TestWatchFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "tokens.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(tokensYAML), 0o600))
+
+	s := NewStore("")
+	require.NoError(t, s.WatchFile(ctx, path))
+	_, ok := s.Authenticate("admin-token")
+	assert.True(t, ok)
+
+	t.Run("invalid update keeps the current tokens", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`tokens: [{name: a}]`), 0o600))
+		time.Sleep(time.Second * 2)
+		_, ok := s.Authenticate("admin-token")
+		assert.True(t, ok)
+	})
+
+	t.Run("rotated token", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`tokens: [{name: admin, token: new-admin-token}]`), 0o600))
+		assert.Eventually(t, func() bool {
+			_, ok := s.Authenticate("new-admin-token")
+			return ok
+		}, time.Second*5, time.Millisecond*100)
+		_, ok := s.Authenticate("admin-token")
+		assert.False(t, ok)
+	})
+}
+
+func TestPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("first load fails", func(t *testing.T) {
+		s := NewStore("")
+		err := s.Poll(ctx, time.Hour, func(context.Context) ([]byte, error) {
+			return []byte(`tokens: [{name: a}]`), nil
+		})
+		assert.Error(t, err)
+		assert.True(t, s.Enabled())
+	})
+
+	t.Run("rotated token", func(t *testing.T) {
+		docs := make(chan []byte, 1)
+		current := []byte(tokensYAML)
+		s := NewStore("")
+		require.NoError(t, s.Poll(ctx, time.Millisecond*10, func(context.Context) ([]byte, error) {
+			select {
+			case current = <-docs:
+			default:
+			}
+			return current, nil
+		}))
+		_, ok := s.Authenticate("admin-token")
+		assert.True(t, ok)
+
+		docs <- []byte(`tokens: [{name: admin, token: new-admin-token}]`)
+		assert.Eventually(t, func() bool {
+			_, ok := s.Authenticate("new-admin-token")
+			return ok
+		}, time.Second*5, time.Millisecond*10)
+	})
+}
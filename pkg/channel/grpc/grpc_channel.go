@@ -94,6 +94,11 @@ func (g *Channel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRe
 
 // invokeMethodV1 calls user applications using appctl v1.
 func (g *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	// The app callback service takes the whole request data in one message.
+	if err := req.BufferDataStream(); err != nil {
+		return nil, status.Errorf(codes.Internal, "error reading streamed request data: %s", err)
+	}
+
 	if g.ch != nil {
 		g.ch <- 1
 	}
@@ -58,10 +58,12 @@ const (
 // Channel is an HTTP implementation of an AppChannel.
 type Channel struct {
 	client              *fasthttp.Client
+	streamClient        *nethttp.Client
 	baseAddress         string
 	ch                  chan int
 	tracingSpec         config.TracingSpec
 	appHeaderToken      string
+	headers             map[string]string
 	json                jsoniter.API
 	maxResponseBodySize int
 }
@@ -82,6 +84,14 @@ func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec, sslEn
 			ReadBufferSize:            readBufferSize * 1024,
 			DisablePathNormalizing:    true,
 		},
+		// The fasthttp client buffers response bodies, so streamed requests
+		// are sent with net/http.
+		streamClient: &nethttp.Client{
+			Transport: &nethttp.Transport{
+				MaxIdleConnsPerHost: 1024,
+				ReadBufferSize:      readBufferSize * 1024,
+			},
+		},
 		baseAddress:         fmt.Sprintf("%s://%s:%d", scheme, channel.DefaultChannelAddress, port),
 		tracingSpec:         spec,
 		appHeaderToken:      auth.GetAppToken(),
@@ -91,6 +101,7 @@ func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec, sslEn
 
 	if sslEnabled {
 		c.client.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+		c.streamClient.Transport.(*nethttp.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
 	if maxConcurrency > 0 {
@@ -100,6 +111,33 @@ func CreateLocalChannel(port, maxConcurrency int, spec config.TracingSpec, sslEn
 	return c, nil
 }
 
+// CreateExternalChannel creates an HTTP AppChannel to a service without a sidecar at
+// baseURL. The headers are added to every request, and the app token is never sent.
+func CreateExternalChannel(baseURL string, headers map[string]string, tlsConfig *tls.Config, spec config.TracingSpec, maxRequestBodySize int, readBufferSize int) channel.AppChannel {
+	c := &Channel{
+		client: &fasthttp.Client{
+			MaxIdemponentCallAttempts: 0,
+			MaxResponseBodySize:       maxRequestBodySize * 1024 * 1024,
+			ReadBufferSize:            readBufferSize * 1024,
+			DisablePathNormalizing:    true,
+			TLSConfig:                 tlsConfig,
+		},
+		streamClient: &nethttp.Client{
+			Transport: &nethttp.Transport{
+				ReadBufferSize:  readBufferSize * 1024,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		baseAddress:         strings.TrimSuffix(baseURL, "/"),
+		tracingSpec:         spec,
+		headers:             headers,
+		json:                jsoniter.ConfigFastest,
+		maxResponseBodySize: maxRequestBodySize,
+	}
+
+	return c
+}
+
 // GetBaseAddress returns the application base address.
 func (h *Channel) GetBaseAddress() string {
 	return h.baseAddress
@@ -173,6 +211,10 @@ func (h *Channel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRe
 }
 
 func (h *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	if req.DataStream() != nil {
+		return h.invokeMethodStream(ctx, req)
+	}
+
 	channelReq := h.constructRequest(ctx, req)
 
 	if h.ch != nil {
@@ -209,37 +251,93 @@ func (h *Channel) invokeMethodV1(ctx context.Context, req *invokev1.InvokeMethod
 	return rsp, nil
 }
 
-func (h *Channel) constructRequest(ctx context.Context, req *invokev1.InvokeMethodRequest) *fasthttp.Request {
-	channelReq := fasthttp.AcquireRequest()
+// invokeMethodStream sends a request whose data is streamed to the app and
+// returns the response with its data streamed from the app. The response data
+// stream must be closed.
+func (h *Channel) invokeMethodStream(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	uri := h.methodURI(req)
+	if qs := req.EncodeHTTPQueryString(); qs != "" {
+		uri += "?" + qs
+	}
+	channelReq, err := nethttp.NewRequestWithContext(ctx, req.Message().HttpExtension.Verb.String(), uri, req.DataStream())
+	if err != nil {
+		return nil, err
+	}
+	h.setRequestHeaders(ctx, req, channelReq.Header.Set)
+	channelReq.Header.Set("Content-Type", req.Message().GetContentType())
 
-	// Construct app channel URI: VERB http://localhost:3000/method?query1=value1
-	var uri string
+	if h.ch != nil {
+		h.ch <- 1
+	}
+
+	// Emit metric when request is sent
+	verb := channelReq.Method
+	diag.DefaultHTTPMonitoring.ClientRequestStarted(ctx, verb, req.Message().Method, channelReq.ContentLength)
+	startRequest := time.Now()
+
+	// Send request to user application
+	resp, err := h.streamClient.Do(channelReq)
+	elapsedMs := float64(time.Since(startRequest) / time.Millisecond)
+
+	if h.ch != nil {
+		<-h.ch
+	}
+
+	if err != nil {
+		diag.DefaultHTTPMonitoring.ClientRequestCompleted(ctx, verb, req.Message().GetMethod(), strconv.Itoa(nethttp.StatusInternalServerError), 0, elapsedMs)
+		return nil, err
+	}
+
+	rsp := invokev1.NewInvokeMethodResponse(int32(resp.StatusCode), "", nil)
+	rsp.WithHTTPHeaders(resp.Header).WithDataStream(resp.Body, resp.Header.Get("Content-Type"))
+	diag.DefaultHTTPMonitoring.ClientRequestCompleted(ctx, verb, req.Message().GetMethod(), strconv.Itoa(resp.StatusCode), resp.ContentLength, elapsedMs)
+
+	return rsp, nil
+}
+
+// methodURI returns the app channel URI of a method: http://localhost:3000/method.
+func (h *Channel) methodURI(req *invokev1.InvokeMethodRequest) string {
 	method := req.Message().GetMethod()
 	if strings.HasPrefix(method, "/") {
-		uri = fmt.Sprintf("%s%s", h.baseAddress, method)
-	} else {
-		uri = fmt.Sprintf("%s/%s", h.baseAddress, method)
+		return fmt.Sprintf("%s%s", h.baseAddress, method)
 	}
-	channelReq.URI().Update(uri)
-	channelReq.URI().DisablePathNormalizing = true
-	channelReq.URI().SetQueryString(req.EncodeHTTPQueryString())
-	channelReq.Header.SetMethod(req.Message().HttpExtension.Verb.String())
+	return fmt.Sprintf("%s/%s", h.baseAddress, method)
+}
 
+// setRequestHeaders sets the headers of the request to the app: the caller
+// headers, the channel headers, the trace context and the app token.
+func (h *Channel) setRequestHeaders(ctx context.Context, req *invokev1.InvokeMethodRequest, setHeader func(string, string)) {
 	// Recover headers
-	invokev1.InternalMetadataToHTTPHeader(ctx, req.Metadata(), channelReq.Header.Set)
+	invokev1.InternalMetadataToHTTPHeader(ctx, req.Metadata(), setHeader)
+
+	for name, value := range h.headers {
+		setHeader(name, value)
+	}
 
 	// HTTP client needs to inject traceparent header for proper tracing stack.
 	span := diag_utils.SpanFromContext(ctx)
 	httpFormat := &tracecontext.HTTPFormat{}
 	tp, ts := httpFormat.SpanContextToHeaders(span.SpanContext())
-	channelReq.Header.Set("traceparent", tp)
+	setHeader("traceparent", tp)
 	if ts != "" {
-		channelReq.Header.Set("tracestate", ts)
+		setHeader("tracestate", ts)
 	}
 
 	if h.appHeaderToken != "" {
-		channelReq.Header.Set(auth.APITokenHeader, h.appHeaderToken)
+		setHeader(auth.APITokenHeader, h.appHeaderToken)
 	}
+}
+
+func (h *Channel) constructRequest(ctx context.Context, req *invokev1.InvokeMethodRequest) *fasthttp.Request {
+	channelReq := fasthttp.AcquireRequest()
+
+	// Construct app channel URI: VERB http://localhost:3000/method?query1=value1
+	channelReq.URI().Update(h.methodURI(req))
+	channelReq.URI().DisablePathNormalizing = true
+	channelReq.URI().SetQueryString(req.EncodeHTTPQueryString())
+	channelReq.Header.SetMethod(req.Message().HttpExtension.Verb.String())
+
+	h.setRequestHeaders(ctx, req, channelReq.Header.Set)
 
 	// Set Content body and types
 	contentType, body := req.RawData()
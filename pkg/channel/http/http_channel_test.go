@@ -24,8 +24,11 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"sync"
 	"testing"
 
@@ -137,6 +140,84 @@ func TestInvokeMethod(t *testing.T) {
 	server.Close()
 }
 
+func TestInvokeMethodDataStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Query", r.URL.RawQuery)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(r.Header.Get("Content-Type") + ":" + string(body)))
+	}))
+	defer server.Close()
+
+	c := Channel{
+		baseAddress:  server.URL,
+		client:       &fasthttp.Client{},
+		streamClient: &http.Client{},
+	}
+	req := invokev1.NewInvokeMethodRequest("method").
+		WithHTTPExtension(http.MethodPost, "param1=val1").
+		WithMetadata(map[string][]string{}).
+		WithDataStream(strings.NewReader("streamed data"), "text/plain")
+
+	response, err := c.InvokeMethod(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(http.StatusAccepted), response.Status().Code)
+	assert.Equal(t, "text/plain", response.Message().GetContentType())
+	assert.Equal(t, []string{"param1=val1"}, response.Headers()["X-Query"].GetValues())
+
+	body := response.DataStream()
+	if assert.NotNil(t, body) {
+		defer body.Close()
+		b, err := ioutil.ReadAll(body)
+		assert.NoError(t, err)
+		assert.Equal(t, "text/plain:streamed data", string(b))
+	}
+}
+
+func TestCreateExternalChannel(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-App-Token", r.Header.Get("app-api-token"))
+		w.Header().Set("X-Traceparent", r.Header.Get("traceparent"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	os.Setenv("APP_API_TOKEN", "app-token")
+	defer os.Unsetenv("APP_API_TOKEN")
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+	c := CreateExternalChannel(server.URL+"/api/", map[string]string{"Authorization": "Bearer secret"}, tlsConfig, config.TracingSpec{}, 4, 4)
+	assert.Equal(t, server.URL+"/api", c.GetBaseAddress())
+
+	for name, req := range map[string]*invokev1.InvokeMethodRequest{
+		"buffered": invokev1.NewInvokeMethodRequest("orders/1").
+			WithHTTPExtension(http.MethodGet, "").
+			WithMetadata(map[string][]string{"Host": {"localhost:3500"}}),
+		"streamed": invokev1.NewInvokeMethodRequest("orders/1").
+			WithHTTPExtension(http.MethodPost, "").
+			WithMetadata(map[string][]string{"Host": {"localhost:3500"}}).
+			WithDataStream(strings.NewReader("data"), "text/plain"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			response, err := c.InvokeMethod(context.Background(), req)
+			assert.NoError(t, err)
+			if body := response.DataStream(); body != nil {
+				body.Close()
+			}
+			assert.Equal(t, int32(http.StatusOK), response.Status().Code)
+			assert.Equal(t, []string{"/api/orders/1"}, response.Headers()["X-Path"].GetValues())
+			assert.Equal(t, []string{strings.TrimPrefix(server.URL, "https://")}, response.Headers()["X-Host"].GetValues())
+			assert.Equal(t, []string{"Bearer secret"}, response.Headers()["X-Authorization"].GetValues())
+			assert.Equal(t, []string{""}, response.Headers()["X-App-Token"].GetValues())
+			assert.NotEmpty(t, response.Headers()["X-Traceparent"].GetValues()[0])
+		})
+	}
+}
+
 func TestInvokeMethodMaxConcurrency(t *testing.T) {
 	ctx := context.Background()
 	t.Run("single concurrency", func(t *testing.T) {
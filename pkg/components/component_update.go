@@ -0,0 +1,51 @@
+package components
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "time"
+
+// UpdateOperation is the kind of change applied to a component or configuration at runtime.
+type UpdateOperation string
+
+const (
+	// UpdateOperationCreate is a component that was added.
+	UpdateOperationCreate UpdateOperation = "create"
+	// UpdateOperationUpdate is a component or configuration section that was changed.
+	UpdateOperationUpdate UpdateOperation = "update"
+	// UpdateOperationDelete is a component that was removed.
+	UpdateOperationDelete UpdateOperation = "delete"
+
+	// UpdateKindComponent is an update of a component manifest.
+	UpdateKindComponent = componentKind
+	// UpdateKindConfiguration is an update of the configuration file.
+	UpdateKindConfiguration = "Configuration"
+)
+
+// Update records the outcome of applying a change to a running sidecar.
+type Update struct {
+	Kind            string          `json:"kind"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type,omitempty"`
+	Operation       UpdateOperation `json:"operation"`
+	Time            time.Time       `json:"time"`
+	Error           string          `json:"error,omitempty"`
+	RestartRequired bool            `json:"restartRequired,omitempty"`
+}
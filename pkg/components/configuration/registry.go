@@ -45,6 +45,7 @@ func New(name string, factoryMethod func() configuration.Store) Configuration {
 type Registry interface {
 	Register(components ...Configuration)
 	Create(name, version string) (configuration.Store, error)
+	Has(name, version string) bool
 }
 
 type configurationStoreRegistry struct {
@@ -73,6 +74,12 @@ func (s *configurationStoreRegistry) Create(name, version string) (configuration
 	return nil, errors.Errorf("couldn't find Bhojpur Application runtime configuration store %s/%s", name, version)
 }
 
+// Has checks if a configuration store based on `name` exists in the registry.
+func (s *configurationStoreRegistry) Has(name, version string) bool {
+	_, ok := s.getConfigurationStore(name, version)
+	return ok
+}
+
 func (s *configurationStoreRegistry) getConfigurationStore(name, version string) (func() configuration.Store, bool) {
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
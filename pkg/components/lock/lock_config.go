@@ -0,0 +1,101 @@
+package lock
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	strategyKey = "keyPrefix"
+
+	strategyAppid     = "appid"
+	strategyStoreName = "name"
+	strategyNone      = "none"
+	strategyDefault   = strategyAppid
+
+	separator = "||"
+)
+
+var (
+	locksConfiguration     = map[string]*StoreConfiguration{}
+	locksConfigurationLock sync.RWMutex
+)
+
+type StoreConfiguration struct {
+	keyPrefixStrategy string
+}
+
+// SaveLockConfiguration stores the resource id prefix strategy of a lock store.
+func SaveLockConfiguration(storeName string, metadata map[string]string) error {
+	strategy := strings.ToLower(metadata[strategyKey])
+	if strategy == "" {
+		strategy = strategyDefault
+	} else if err := checkKeyIllegal(metadata[strategyKey]); err != nil {
+		return err
+	}
+
+	locksConfigurationLock.Lock()
+	locksConfiguration[storeName] = &StoreConfiguration{keyPrefixStrategy: strategy}
+	locksConfigurationLock.Unlock()
+	return nil
+}
+
+// GetModifiedLockKey returns the resource id to lock in the store, prefixed
+// according to the strategy of the store. By default locks are scoped to the app.
+func GetModifiedLockKey(key, storeName, appID string) (string, error) {
+	if err := checkKeyIllegal(key); err != nil {
+		return "", err
+	}
+	switch strategy := getKeyPrefixStrategy(storeName); strategy {
+	case strategyNone:
+		return key, nil
+	case strategyStoreName:
+		return fmt.Sprintf("%s%s%s", storeName, separator, key), nil
+	case strategyAppid:
+		if appID == "" {
+			return key, nil
+		}
+		return fmt.Sprintf("%s%s%s", appID, separator, key), nil
+	default:
+		return fmt.Sprintf("%s%s%s", strategy, separator, key), nil
+	}
+}
+
+func getKeyPrefixStrategy(storeName string) string {
+	locksConfigurationLock.RLock()
+	defer locksConfigurationLock.RUnlock()
+	if c, ok := locksConfiguration[storeName]; ok {
+		return c.keyPrefixStrategy
+	}
+	return strategyDefault
+}
+
+func checkKeyIllegal(key string) error {
+	if strings.Contains(key, separator) {
+		return errors.Errorf("input key/keyPrefix '%s' can't contain '%s'", key, separator)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package lock
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetModifiedLockKey(t *testing.T) {
+	require.NoError(t, SaveLockConfiguration("lock1", map[string]string{strategyKey: strategyNone}))
+	require.NoError(t, SaveLockConfiguration("lock2", map[string]string{strategyKey: strategyAppid}))
+	require.NoError(t, SaveLockConfiguration("lock3", map[string]string{strategyKey: strategyStoreName}))
+	require.NoError(t, SaveLockConfiguration("lock4", map[string]string{strategyKey: "other-fixed-prefix"}))
+	require.NoError(t, SaveLockConfiguration("lock5", map[string]string{}))
+
+	tests := []struct {
+		storeName string
+		expected  string
+	}{
+		{"lock1", "resource"},
+		{"lock2", "appA||resource"},
+		{"lock3", "lock3||resource"},
+		{"lock4", "other-fixed-prefix||resource"},
+		{"lock5", "appA||resource"},
+		{"unknown", "appA||resource"},
+	}
+	for _, tt := range tests {
+		key, err := GetModifiedLockKey("resource", tt.storeName, "appA")
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, key, tt.storeName)
+	}
+
+	_, err := GetModifiedLockKey("a||b", "lock1", "appA")
+	assert.Error(t, err)
+	assert.Error(t, SaveLockConfiguration("lock6", map[string]string{strategyKey: "a||b"}))
+}
@@ -0,0 +1,98 @@
+package lock
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/application/pkg/components"
+	"github.com/bhojpur/application/pkg/lock"
+)
+
+type Lock struct {
+	Name          string
+	FactoryMethod func() lock.Store
+}
+
+func New(name string, factoryMethod func() lock.Store) Lock {
+	return Lock{
+		Name:          name,
+		FactoryMethod: factoryMethod,
+	}
+}
+
+// Registry is an interface for a component that returns registered lock store implementations.
+type Registry interface {
+	Register(components ...Lock)
+	Create(name, version string) (lock.Store, error)
+	Has(name, version string) bool
+}
+
+type lockStoreRegistry struct {
+	lockStores map[string]func() lock.Store
+}
+
+// NewRegistry is used to create lock store registry.
+func NewRegistry() Registry {
+	return &lockStoreRegistry{
+		lockStores: map[string]func() lock.Store{},
+	}
+}
+
+// Register registers a new factory method that creates an instance of a lock store.
+// The key is the name of the lock store, eg. redis.
+func (s *lockStoreRegistry) Register(components ...Lock) {
+	for _, component := range components {
+		s.lockStores[createFullName(component.Name)] = component.FactoryMethod
+	}
+}
+
+func (s *lockStoreRegistry) Create(name, version string) (lock.Store, error) {
+	if method, ok := s.getLockStore(name, version); ok {
+		return method(), nil
+	}
+	return nil, errors.Errorf("couldn't find Bhojpur Application runtime lock store %s/%s", name, version)
+}
+
+// Has checks if a lock store based on `name` exists in the registry.
+func (s *lockStoreRegistry) Has(name, version string) bool {
+	_, ok := s.getLockStore(name, version)
+	return ok
+}
+
+func (s *lockStoreRegistry) getLockStore(name, version string) (func() lock.Store, bool) {
+	nameLower := strings.ToLower(name)
+	versionLower := strings.ToLower(version)
+	lockStoreFn, ok := s.lockStores[nameLower+"/"+versionLower]
+	if ok {
+		return lockStoreFn, true
+	}
+	if components.IsInitialVersion(versionLower) {
+		lockStoreFn, ok = s.lockStores[nameLower]
+	}
+	return lockStoreFn, ok
+}
+
+func createFullName(name string) string {
+	return strings.ToLower("lock." + name)
+}
@@ -42,6 +42,7 @@ type (
 	Registry interface {
 		Register(components ...Middleware)
 		Create(name, version string, metadata middleware.Metadata) (http_middleware.Middleware, error)
+		Has(name, version string) bool
 	}
 
 	httpMiddlewareRegistry struct {
@@ -86,6 +87,12 @@ func (p *httpMiddlewareRegistry) Create(name, version string, metadata middlewar
 	return nil, errors.Errorf("HTTP middleware %s/%s has not been registered", name, version)
 }
 
+// Has checks if a HTTP middleware based on `name` exists in the registry.
+func (p *httpMiddlewareRegistry) Has(name, version string) bool {
+	_, ok := p.getMiddleware(name, version)
+	return ok
+}
+
 func (p *httpMiddlewareRegistry) getMiddleware(name, version string) (FactoryMethod, bool) {
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
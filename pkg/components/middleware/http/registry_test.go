@@ -84,6 +84,11 @@ func TestRegistry(t *testing.T) {
 		pV2, e = testRegistry.Create(strings.ToUpper(componentName), "V2", metadata)
 		assert.NoError(t, e)
 		assert.Equal(t, fmt.Sprintf("%v", mockV2), fmt.Sprintf("%v", pV2))
+
+		// check lookup without instantiation
+		assert.True(t, testRegistry.Has(componentName, "v1"))
+		assert.True(t, testRegistry.Has(componentName, "v2"))
+		assert.False(t, testRegistry.Has(componentName, "v3"))
 	})
 
 	t.Run("middleware is not registered", func(t *testing.T) {
@@ -99,6 +104,7 @@ func TestRegistry(t *testing.T) {
 		expectedError := errors.Errorf("HTTP middleware %s/v1 has not been registered", componentName)
 
 		// assert
+		assert.False(t, testRegistry.Has(componentName, "v1"))
 		assert.Nil(t, p)
 		assert.Equal(t, expectedError.Error(), actualError.Error())
 	})
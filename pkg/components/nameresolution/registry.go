@@ -41,6 +41,7 @@ type (
 	Registry interface {
 		Register(components ...NameResolution)
 		Create(name, version string) (nr.Resolver, error)
+		Has(name, version string) bool
 	}
 
 	nameResolutionRegistry struct {
@@ -78,6 +79,12 @@ func (s *nameResolutionRegistry) Create(name, version string) (nr.Resolver, erro
 	return nil, errors.Errorf("couldn't find Bhojpur Application runtime name resolver %s/%s", name, version)
 }
 
+// Has checks if a name resolver based on `name` exists in the registry.
+func (s *nameResolutionRegistry) Has(name, version string) bool {
+	_, ok := s.getResolver(createFullName(name), version)
+	return ok
+}
+
 func (s *nameResolutionRegistry) getResolver(name, version string) (func() nr.Resolver, bool) {
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
@@ -74,6 +74,11 @@ func TestRegistry(t *testing.T) {
 		pV2, e = testRegistry.Create(strings.ToUpper(resolverName), "V2")
 		assert.NoError(t, e)
 		assert.Same(t, mockV2, pV2)
+
+		// check lookup without instantiation
+		assert.True(t, testRegistry.Has(resolverName, "v1"))
+		assert.True(t, testRegistry.Has(resolverName, "v2"))
+		assert.False(t, testRegistry.Has(resolverName, "v3"))
 	})
 
 	t.Run("name resolver is not registered", func(t *testing.T) {
@@ -86,6 +91,7 @@ func TestRegistry(t *testing.T) {
 		expectedError := errors.Errorf("couldn't find name resolver %s/v1", resolverName)
 
 		// assert
+		assert.False(t, testRegistry.Has(resolverName, "v1"))
 		assert.Nil(t, p)
 		assert.Equal(t, expectedError.Error(), actualError.Error())
 	})
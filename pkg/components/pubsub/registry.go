@@ -41,6 +41,7 @@ type (
 	Registry interface {
 		Register(components ...PubSub)
 		Create(name, version string) (pubsub.PubSub, error)
+		Has(name, version string) bool
 	}
 
 	pubSubRegistry struct {
@@ -78,6 +79,12 @@ func (p *pubSubRegistry) Create(name, version string) (pubsub.PubSub, error) {
 	return nil, errors.Errorf("couldn't find Bhojpur Application runtime message bus %s/%s", name, version)
 }
 
+// Has checks if a pub/sub based on `name` exists in the registry.
+func (p *pubSubRegistry) Has(name, version string) bool {
+	_, ok := p.getPubSub(name, version)
+	return ok
+}
+
 func (p *pubSubRegistry) getPubSub(name, version string) (func() pubsub.PubSub, bool) {
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
@@ -89,6 +89,11 @@ func TestCreatePubSub(t *testing.T) {
 		pV2, e = testRegistry.Create(strings.ToUpper(componentName), "V2")
 		assert.NoError(t, e)
 		assert.Same(t, mockPubSubV2, pV2)
+
+		// check lookup without instantiation
+		assert.True(t, testRegistry.Has(componentName, "v1"))
+		assert.True(t, testRegistry.Has(componentName, "v2"))
+		assert.False(t, testRegistry.Has(componentName, "v3"))
 	})
 
 	t.Run("pubsub messagebus is not registered", func(t *testing.T) {
@@ -98,6 +103,7 @@ func TestCreatePubSub(t *testing.T) {
 		p, actualError := testRegistry.Create(createFullName(PubSubName), "v1")
 		expectedError := errors.Errorf("couldn't find message bus %s/v1", createFullName(PubSubName))
 		// assert
+		assert.False(t, testRegistry.Has(createFullName(PubSubName), "v1"))
 		assert.Nil(t, p)
 		assert.Equal(t, expectedError.Error(), actualError.Error())
 	})
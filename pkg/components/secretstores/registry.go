@@ -41,6 +41,7 @@ type (
 	Registry interface {
 		Register(components ...SecretStore)
 		Create(name, version string) (secretstores.SecretStore, error)
+		Has(name, version string) bool
 	}
 
 	secretStoreRegistry struct {
@@ -79,6 +80,12 @@ func (s *secretStoreRegistry) Create(name, version string) (secretstores.SecretS
 	return nil, errors.Errorf("couldn't find Bhojpur Application runtime secret store %s/%s", name, version)
 }
 
+// Has checks if a secret store based on `name` exists in the registry.
+func (s *secretStoreRegistry) Has(name, version string) bool {
+	_, ok := s.getSecretStore(name, version)
+	return ok
+}
+
 func (s *secretStoreRegistry) getSecretStore(name, version string) (func() secretstores.SecretStore, bool) {
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
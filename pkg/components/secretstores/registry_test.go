@@ -75,6 +75,11 @@ func TestRegistry(t *testing.T) {
 		pV2, e = testRegistry.Create(strings.ToUpper(componentName), "V2")
 		assert.NoError(t, e)
 		assert.Same(t, mockV2, pV2)
+
+		// check lookup without instantiation
+		assert.True(t, testRegistry.Has(componentName, "v1"))
+		assert.True(t, testRegistry.Has(componentName, "v2"))
+		assert.False(t, testRegistry.Has(componentName, "v3"))
 	})
 
 	t.Run("secret store is not registered", func(t *testing.T) {
@@ -88,6 +93,7 @@ func TestRegistry(t *testing.T) {
 		expectedError := errors.Errorf("couldn't find secret store %s/v1", componentName)
 
 		// assert
+		assert.False(t, testRegistry.Has(componentName, "v1"))
 		assert.Nil(t, p)
 		assert.Equal(t, expectedError.Error(), actualError.Error())
 	})
@@ -23,6 +23,7 @@ package components
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,11 +33,13 @@ import (
 
 	config "github.com/bhojpur/application/pkg/config/modes"
 	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	httpendpoint_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/httpendpoint/v1alpha1"
 )
 
 const (
-	yamlSeparator = "\n---"
-	componentKind = "Component"
+	yamlSeparator    = "\n---"
+	componentKind    = "Component"
+	httpEndpointKind = "HTTPEndpoint"
 )
 
 // StandaloneComponents loads components in a standalone mode environment.
@@ -72,6 +75,40 @@ func (s *StandaloneComponents) LoadComponents() ([]components_v1alpha1.Component
 	return list, nil
 }
 
+// LoadComponentsStrict loads the components like LoadComponents, but fails when a file cannot
+// be read or parsed instead of skipping it. A file that is being written is not mistaken for
+// the removal of its components.
+func (s *StandaloneComponents) LoadComponentsStrict() ([]components_v1alpha1.Component, error) {
+	files, err := os.ReadDir(s.config.ComponentsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []components_v1alpha1.Component{}
+
+	for _, file := range files {
+		if file.IsDir() || !s.isYaml(file.Name()) {
+			continue
+		}
+
+		path := filepath.Join(s.config.ComponentsPath, file.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(b)) == 0 {
+			return nil, fmt.Errorf("components file %s is empty", path)
+		}
+		components, errors := s.decodeYaml(b)
+		if len(errors) > 0 {
+			return nil, fmt.Errorf("error parsing components yaml resource in %s: %w", path, errors[0])
+		}
+		list = append(list, components...)
+	}
+
+	return list, nil
+}
+
 func (s *StandaloneComponents) loadComponentsFromFile(filename string) []components_v1alpha1.Component {
 	var errors []error
 
@@ -90,6 +127,36 @@ func (s *StandaloneComponents) loadComponentsFromFile(filename string) []compone
 	return components
 }
 
+// LoadHTTPEndpoints loads the HTTP endpoints defined next to the components in a given directory.
+func (s *StandaloneComponents) LoadHTTPEndpoints() ([]httpendpoint_v1alpha1.HTTPEndpoint, error) {
+	files, err := os.ReadDir(s.config.ComponentsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []httpendpoint_v1alpha1.HTTPEndpoint{}
+
+	for _, file := range files {
+		if file.IsDir() || !s.isYaml(file.Name()) {
+			continue
+		}
+
+		path := filepath.Join(s.config.ComponentsPath, file.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			log.Warnf("Bhojpur Application runtime load HTTP endpoints error when reading file %s : %s", path, err)
+			continue
+		}
+		endpoints, errors := s.decodeHTTPEndpoints(b)
+		for _, err := range errors {
+			log.Warnf("Bhojpur Application runtime load HTTP endpoints error when parsing yaml resource in %s : %s", path, err)
+		}
+		list = append(list, endpoints...)
+	}
+
+	return list, nil
+}
+
 // isYaml checks whether the file is yaml or not.
 func (s *StandaloneComponents) isYaml(fileName string) bool {
 	extension := strings.ToLower(filepath.Ext(fileName))
@@ -102,6 +169,36 @@ func (s *StandaloneComponents) isYaml(fileName string) bool {
 // decodeYaml decodes the yaml document.
 func (s *StandaloneComponents) decodeYaml(b []byte) ([]components_v1alpha1.Component, []error) {
 	list := []components_v1alpha1.Component{}
+	errors := s.decodeKind(b, componentKind, func(doc []byte) error {
+		var comp components_v1alpha1.Component
+		comp.Spec = components_v1alpha1.ComponentSpec{}
+		if err := yaml.Unmarshal(doc, &comp); err != nil {
+			return err
+		}
+		list = append(list, comp)
+		return nil
+	})
+
+	return list, errors
+}
+
+// decodeHTTPEndpoints decodes the HTTP endpoints of the yaml document.
+func (s *StandaloneComponents) decodeHTTPEndpoints(b []byte) ([]httpendpoint_v1alpha1.HTTPEndpoint, []error) {
+	list := []httpendpoint_v1alpha1.HTTPEndpoint{}
+	errors := s.decodeKind(b, httpEndpointKind, func(doc []byte) error {
+		var endpoint httpendpoint_v1alpha1.HTTPEndpoint
+		if err := yaml.Unmarshal(doc, &endpoint); err != nil {
+			return err
+		}
+		list = append(list, endpoint)
+		return nil
+	})
+
+	return list, errors
+}
+
+// decodeKind calls decode with each resource of kind in the yaml document.
+func (s *StandaloneComponents) decodeKind(b []byte, kind string, decode func([]byte) error) []error {
 	errors := []error{}
 	scanner := bufio.NewScanner(bytes.NewReader(b))
 	scanner.Split(s.splitYamlDoc)
@@ -130,22 +227,16 @@ func (s *StandaloneComponents) decodeYaml(b []byte) ([]components_v1alpha1.Compo
 			continue
 		}
 
-		if ti.Kind != componentKind {
+		if ti.Kind != kind {
 			continue
 		}
 
-		var comp components_v1alpha1.Component
-		comp.Spec = components_v1alpha1.ComponentSpec{}
-		if err := yaml.Unmarshal(scannerBytes, &comp); err != nil {
+		if err := decode(scannerBytes); err != nil {
 			errors = append(errors, err)
-
-			continue
 		}
-
-		list = append(list, comp)
 	}
 
-	return list, errors
+	return errors
 }
 
 // splitYamlDoc - splits the yaml docs.
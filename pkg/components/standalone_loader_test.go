@@ -303,3 +303,95 @@ spec:
 	assert.Equal(t, "prop3", components[1].Spec.Metadata[0].Name)
 	assert.Equal(t, "value3", components[1].Spec.Metadata[0].Value.String())
 }
+
+func TestLoadHTTPEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	request := &StandaloneComponents{
+		config: config.StandaloneConfig{
+			ComponentsPath: dir,
+		},
+	}
+	yaml := `
+apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   type: state.couchbase
+---
+apiVersion: bhojpur.net/v1alpha1
+kind: HTTPEndpoint
+metadata:
+   name: billing
+spec:
+   baseUrl: https://billing.example.com/api
+   headers:
+   - name: Authorization
+     secretKeyRef:
+        name: billing
+        key: token
+   clientTLS:
+      rootCA:
+         value: ca
+auth:
+   secretStore: local
+scopes:
+- app1
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "resources.yaml"), []byte(yaml), fs.FileMode(0644)))
+
+	endpoints, err := request.LoadHTTPEndpoints()
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "billing", endpoints[0].Name)
+	assert.Equal(t, "https://billing.example.com/api", endpoints[0].Spec.BaseURL)
+	assert.Equal(t, "token", endpoints[0].Spec.Headers[0].SecretKeyRef.Key)
+	assert.Equal(t, "ca", endpoints[0].Spec.ClientTLS.RootCA.Value)
+	assert.Equal(t, "local", endpoints[0].Auth.SecretStore)
+	assert.Equal(t, []string{"app1"}, endpoints[0].Scopes)
+
+	components, err := request.LoadComponents()
+	assert.NoError(t, err)
+	assert.Len(t, components, 1)
+}
+
+func TestLoadComponentsStrict(t *testing.T) {
+	valid := `
+apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+   name: statestore
+spec:
+   type: state.couchbase
+`
+
+	t.Run("valid yaml content", func(t *testing.T) {
+		dir := t.TempDir()
+		request := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "statestore.yaml"), []byte(valid), fs.FileMode(0644)))
+
+		components, err := request.LoadComponentsStrict()
+		assert.NoError(t, err)
+		assert.Len(t, components, 1)
+	})
+
+	t.Run("invalid yaml fails", func(t *testing.T) {
+		dir := t.TempDir()
+		request := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "statestore.yaml"), []byte(valid), fs.FileMode(0644)))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "pubsub.yaml"), []byte("kind: Component\nmetadata: [\n"), fs.FileMode(0644)))
+
+		components, err := request.LoadComponentsStrict()
+		assert.Error(t, err)
+		assert.Nil(t, components)
+	})
+
+	t.Run("empty file fails", func(t *testing.T) {
+		dir := t.TempDir()
+		request := NewStandaloneComponents(config.StandaloneConfig{ComponentsPath: dir})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "statestore.yaml"), nil, fs.FileMode(0644)))
+
+		_, err := request.LoadComponentsStrict()
+		assert.Error(t, err)
+	})
+}
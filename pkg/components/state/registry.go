@@ -45,6 +45,7 @@ func New(name string, factoryMethod func() state.Store) State {
 type Registry interface {
 	Register(components ...State)
 	Create(name, version string) (state.Store, error)
+	Has(name, version string) bool
 }
 
 type stateStoreRegistry struct {
@@ -73,6 +74,12 @@ func (s *stateStoreRegistry) Create(name, version string) (state.Store, error) {
 	return nil, errors.Errorf("couldn't find Bhojpur Application runtime state store %s/%s", name, version)
 }
 
+// Has checks if a state store based on `name` exists in the registry.
+func (s *stateStoreRegistry) Has(name, version string) bool {
+	_, ok := s.getStateStore(name, version)
+	return ok
+}
+
 func (s *stateStoreRegistry) getStateStore(name, version string) (func() state.Store, bool) {
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
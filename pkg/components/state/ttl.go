@@ -0,0 +1,63 @@
+package state
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strconv"
+
+	"github.com/bhojpur/service/pkg/state"
+)
+
+// ttlStateStores are the state store types that expire keys with the ttlInSeconds
+// request metadata.
+var ttlStateStores = map[string]struct{}{
+	"state.redis":             {},
+	"state.azure.cosmosdb":    {},
+	"state.cassandra":         {},
+	"state.memcached":         {},
+	"state.oracledatabase":    {},
+	"state.oci.objectstorage": {},
+}
+
+const (
+	// FeatureTTL is the feature of state stores that expire keys with the ttlInSeconds
+	// request metadata.
+	FeatureTTL state.Feature = "TTL"
+
+	// NativeTTLMetadata is the component metadata overriding whether a state store expires
+	// keys with the ttlInSeconds request metadata. Set it to "true" for a store that isn't
+	// known to do so, or to "false" to keep the runtime-managed TTL indexes for one that is.
+	NativeTTLMetadata = "nativeTTL"
+)
+
+// SupportsTTL returns true if the state store of the given component type expires keys natively.
+// The nativeTTL component metadata takes precedence, then FeatureTTL reported by the store and
+// the state store types known to support TTL.
+func SupportsTTL(componentType string, store state.Store, props map[string]string) bool {
+	if nativeTTL, err := strconv.ParseBool(props[NativeTTLMetadata]); err == nil {
+		return nativeTTL
+	}
+	if store != nil && FeatureTTL.IsPresent(store.Features()) {
+		return true
+	}
+	_, ok := ttlStateStores[componentType]
+	return ok
+}
@@ -0,0 +1,60 @@
+package state
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bhojpur/service/pkg/state"
+)
+
+type featuresStateStore struct {
+	state.Store
+	features []state.Feature
+}
+
+func (s *featuresStateStore) Features() []state.Feature {
+	return s.features
+}
+
+func TestSupportsTTL(t *testing.T) {
+	ttlStore := &featuresStateStore{features: []state.Feature{state.FeatureETag, FeatureTTL}}
+	store := &featuresStateStore{features: []state.Feature{state.FeatureETag, state.FeatureTransactional}}
+
+	t.Run("known state store types", func(t *testing.T) {
+		assert.True(t, SupportsTTL("state.redis", store, nil))
+		assert.True(t, SupportsTTL("state.cassandra", nil, nil))
+		assert.False(t, SupportsTTL("state.mongodb", store, nil))
+	})
+
+	t.Run("store feature", func(t *testing.T) {
+		assert.True(t, SupportsTTL("state.mongodb", ttlStore, nil))
+	})
+
+	t.Run("component metadata", func(t *testing.T) {
+		assert.True(t, SupportsTTL("state.mongodb", store, map[string]string{NativeTTLMetadata: "true"}))
+		assert.False(t, SupportsTTL("state.redis", store, map[string]string{NativeTTLMetadata: "false"}))
+		assert.False(t, SupportsTTL("state.mongodb", ttlStore, map[string]string{NativeTTLMetadata: "false"}))
+		assert.True(t, SupportsTTL("state.redis", store, map[string]string{NativeTTLMetadata: "invalid"}))
+	})
+}
@@ -68,9 +68,17 @@ type Configuration struct {
 
 // AccessControlList is an in-memory access control list config for fast lookup.
 type AccessControlList struct {
-	DefaultAction string
-	TrustDomain   string
-	PolicySpec    map[string]AccessControlListPolicySpec
+	DefaultAction    string
+	TrustDomain      string
+	PolicySpec       map[string]AccessControlListPolicySpec
+	CallerIdentifier CallerIdentifier
+}
+
+// CallerIdentifier identifies the caller of a service invocation from the call
+// context and the request metadata, when callers are not identified by the SPIFFE
+// id of their mTLS certificate.
+type CallerIdentifier interface {
+	IdentifyCaller(ctx context.Context, metadata map[string][]string) (*SpiffeID, error)
 }
 
 // AccessControlListPolicySpec is an in-memory access control list config per app for fast lookup.
@@ -99,6 +107,9 @@ type ConfigurationSpec struct {
 	NameResolutionSpec NameResolutionSpec `json:"nameResolution,omitempty" yaml:"nameResolution,omitempty"`
 	Features           []FeatureSpec      `json:"features,omitempty" yaml:"features,omitempty"`
 	APISpec            APISpec            `json:"api,omitempty" yaml:"api,omitempty"`
+	ResiliencySpec     ResiliencySpec     `json:"resiliency,omitempty" yaml:"resiliency,omitempty"`
+	RecordingSpec      RecordingSpec      `json:"recording,omitempty" yaml:"recording,omitempty"`
+	RateLimitSpec      RateLimitSpec      `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
 }
 
 type SecretsSpec struct {
@@ -120,6 +131,17 @@ type PipelineSpec struct {
 // APISpec describes the configuration for Bhojpur Application APIs.
 type APISpec struct {
 	Allowed []APIAccessRule `json:"allowed,omitempty"`
+	Tokens  APITokensSpec   `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+}
+
+// APITokensSpec describes where the named API tokens are loaded from. The token list is
+// read from a file, or from a secret store, and reloaded when it changes.
+type APITokensSpec struct {
+	File            string `json:"file,omitempty" yaml:"file,omitempty"`
+	SecretStore     string `json:"secretStore,omitempty" yaml:"secretStore,omitempty"`
+	SecretName      string `json:"secretName,omitempty" yaml:"secretName,omitempty"`
+	SecretKey       string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+	RefreshInterval string `json:"refreshInterval,omitempty" yaml:"refreshInterval,omitempty"`
 }
 
 // APIAccessRule describes an access rule for allowing a Bhojpur Application API to be enabled and accessible by an app.
@@ -149,6 +171,7 @@ type TracingSpec struct {
 	SamplingRate string     `json:"samplingRate" yaml:"samplingRate"`
 	Stdout       bool       `json:"stdout" yaml:"stdout"`
 	Zipkin       ZipkinSpec `json:"zipkin" yaml:"zipkin"`
+	Otel         OtelSpec   `json:"otel" yaml:"otel"`
 }
 
 // ZipkinSpec defines Zipkin trace configurations.
@@ -156,6 +179,15 @@ type ZipkinSpec struct {
 	EndpointAddress string `json:"endpointAddress" yaml:"endpointAddress"`
 }
 
+// OtelSpec defines OpenTelemetry (OTLP) trace configurations.
+type OtelSpec struct {
+	EndpointAddress string `json:"endpointAddress" yaml:"endpointAddress"`
+	// Protocol is either "grpc" (default) or "http".
+	Protocol string            `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Insecure bool              `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
 // MetricSpec configuration for metrics.
 type MetricSpec struct {
 	Enabled bool `json:"enabled" yaml:"enabled"`
@@ -179,9 +211,47 @@ type AppOperation struct {
 
 // AccessControlSpec is the spec object in ConfigurationSpec.
 type AccessControlSpec struct {
-	DefaultAction string          `json:"defaultAction" yaml:"defaultAction"`
-	TrustDomain   string          `json:"trustDomain" yaml:"trustDomain"`
-	AppPolicies   []AppPolicySpec `json:"policies" yaml:"policies"`
+	DefaultAction  string             `json:"defaultAction" yaml:"defaultAction"`
+	TrustDomain    string             `json:"trustDomain" yaml:"trustDomain"`
+	AppPolicies    []AppPolicySpec    `json:"policies" yaml:"policies"`
+	CallerIdentity CallerIdentitySpec `json:"callerIdentity,omitempty" yaml:"callerIdentity,omitempty"`
+}
+
+// Caller identity sources of the access control policies.
+const (
+	CallerIdentitySPIFFE   = "spiffe"
+	CallerIdentityJWT      = "jwt"
+	CallerIdentityAPIToken = "apiToken"
+	CallerIdentityHeader   = "header"
+)
+
+// CallerIdentitySpec selects how the callers of service invocations are identified
+// for the access control policies. By default callers are identified by the SPIFFE id
+// of their mTLS certificate.
+type CallerIdentitySpec struct {
+	Source string             `json:"source,omitempty" yaml:"source,omitempty"`
+	JWT    JWTIdentitySpec    `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+	Header HeaderIdentitySpec `json:"header,omitempty" yaml:"header,omitempty"`
+}
+
+// JWTIdentitySpec identifies callers by the bearer JWT of the Authorization header,
+// verified with the keys of a JWKS read from a file or fetched from a URL. The app id
+// and namespace of the caller are read from the claims, "sub" and "namespace" by default.
+type JWTIdentitySpec struct {
+	JWKSFile       string `json:"jwksFile,omitempty" yaml:"jwksFile,omitempty"`
+	JWKSURL        string `json:"jwksURL,omitempty" yaml:"jwksURL,omitempty"`
+	Issuer         string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	Audience       string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	AppIDClaim     string `json:"appIdClaim,omitempty" yaml:"appIdClaim,omitempty"`
+	NamespaceClaim string `json:"namespaceClaim,omitempty" yaml:"namespaceClaim,omitempty"`
+}
+
+// HeaderIdentitySpec identifies callers by the app id in a header, trusted only when
+// the request carries the shared secret read from an environment variable.
+type HeaderIdentitySpec struct {
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+	SecretHeader string `json:"secretHeader,omitempty" yaml:"secretHeader,omitempty"`
+	SecretEnvVar string `json:"secretEnvVar,omitempty" yaml:"secretEnvVar,omitempty"`
 }
 
 type NameResolutionSpec struct {
@@ -196,6 +266,94 @@ type MTLSSpec struct {
 	AllowedClockSkew string `json:"allowedClockSkew" yaml:"allowedClockSkew"`
 }
 
+// RecordingSpec configures the recording of service invocations, app channel calls
+// and published events to a local file, to be replayed with `appctl replay`.
+// The file is rotated once it reaches MaxSizeMB, keeping MaxBackups rotated files.
+type RecordingSpec struct {
+	Enabled    bool          `json:"enabled" yaml:"enabled"`
+	Path       string        `json:"path,omitempty" yaml:"path,omitempty"`
+	MaxSizeMB  int           `json:"maxSizeMB,omitempty" yaml:"maxSizeMB,omitempty"`
+	MaxBackups int           `json:"maxBackups,omitempty" yaml:"maxBackups,omitempty"`
+	Redact     RedactionSpec `json:"redact,omitempty" yaml:"redact,omitempty"`
+}
+
+// RedactionSpec lists the values masked before they are written to a recording.
+// Headers and Fields match header and JSON field names case insensitively and
+// Patterns are regular expressions matched against the bodies.
+type RedactionSpec struct {
+	Headers  []string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Fields   []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+}
+
+// RateLimitSpec limits the service invocations handled by the sidecar per caller app.
+type RateLimitSpec struct {
+	Policies []RateLimitPolicySpec `json:"policies,omitempty" yaml:"policies,omitempty"`
+}
+
+// RateLimitPolicySpec sets a token bucket rate limit and a cap on concurrent requests
+// for the calls of an app to the methods starting with a prefix. An empty or "*"
+// CallerAppID applies the policy to each caller separately, and an empty MethodPrefix
+// to all methods. A zero limit is not enforced.
+type RateLimitPolicySpec struct {
+	CallerAppID       string `json:"callerAppId,omitempty" yaml:"callerAppId,omitempty"`
+	MethodPrefix      string `json:"methodPrefix,omitempty" yaml:"methodPrefix,omitempty"`
+	RequestsPerSecond int    `json:"requestsPerSecond,omitempty" yaml:"requestsPerSecond,omitempty"`
+	Burst             int    `json:"burst,omitempty" yaml:"burst,omitempty"`
+	MaxConcurrency    int    `json:"maxConcurrency,omitempty" yaml:"maxConcurrency,omitempty"`
+}
+
+// ResiliencySpec defines the named resiliency policies and the targets they apply to.
+type ResiliencySpec struct {
+	Policies PoliciesSpec `json:"policies" yaml:"policies"`
+	Targets  TargetsSpec  `json:"targets" yaml:"targets"`
+}
+
+// PoliciesSpec holds the named timeout, retry and circuit breaker policies.
+type PoliciesSpec struct {
+	Timeouts        map[string]string             `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	Retries         map[string]RetrySpec          `json:"retries,omitempty" yaml:"retries,omitempty"`
+	CircuitBreakers map[string]CircuitBreakerSpec `json:"circuitBreakers,omitempty" yaml:"circuitBreakers,omitempty"`
+}
+
+// RetrySpec defines a constant or exponential retry policy.
+type RetrySpec struct {
+	Policy      string `json:"policy" yaml:"policy"`
+	Duration    string `json:"duration,omitempty" yaml:"duration,omitempty"`
+	MaxInterval string `json:"maxInterval,omitempty" yaml:"maxInterval,omitempty"`
+	MaxRetries  *int   `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`
+}
+
+// CircuitBreakerSpec defines a circuit breaker policy. Trip is an expression
+// evaluated against the breaker counters, e.g. "consecutiveFailures > 5".
+type CircuitBreakerSpec struct {
+	MaxRequests int    `json:"maxRequests,omitempty" yaml:"maxRequests,omitempty"`
+	Interval    string `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Timeout     string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Trip        string `json:"trip,omitempty" yaml:"trip,omitempty"`
+}
+
+// TargetsSpec maps app IDs, actor types and components to named policies.
+type TargetsSpec struct {
+	Apps       map[string]EndpointPolicyNames  `json:"apps,omitempty" yaml:"apps,omitempty"`
+	Actors     map[string]EndpointPolicyNames  `json:"actors,omitempty" yaml:"actors,omitempty"`
+	Components map[string]ComponentPolicyNames `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// EndpointPolicyNames references the policies applied to an app or actor type.
+type EndpointPolicyNames struct {
+	Timeout        string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retry          string `json:"retry,omitempty" yaml:"retry,omitempty"`
+	CircuitBreaker string `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
+}
+
+// ComponentPolicyNames references the policies applied to calls made to a component
+// and to deliveries from a component to the app.
+type ComponentPolicyNames struct {
+	Outbound EndpointPolicyNames `json:"outbound,omitempty" yaml:"outbound,omitempty"`
+	Inbound  EndpointPolicyNames `json:"inbound,omitempty" yaml:"inbound,omitempty"`
+}
+
 // SpiffeID represents the separated fields in a spiffe id.
 type SpiffeID struct {
 	TrustDomain string
@@ -110,6 +110,20 @@ func TestMetricSpecForStandAlone(t *testing.T) {
 	}
 }
 
+func TestResiliencySpecForStandAlone(t *testing.T) {
+	config, _, err := LoadStandaloneConfiguration("./testdata/resiliency_config.yaml")
+	assert.NoError(t, err)
+
+	spec := config.Spec.ResiliencySpec
+	assert.Equal(t, "5s", spec.Policies.Timeouts["general"])
+	assert.Equal(t, "exponential", spec.Policies.Retries["important"].Policy)
+	assert.Equal(t, 5, *spec.Policies.Retries["important"].MaxRetries)
+	assert.Equal(t, "consecutiveFailures > 8", spec.Policies.CircuitBreakers["simpleCB"].Trip)
+	assert.Equal(t, "simpleCB", spec.Targets.Apps["appB"].CircuitBreaker)
+	assert.Equal(t, "general", spec.Targets.Actors["myActorType"].Timeout)
+	assert.Equal(t, "important", spec.Targets.Components["statestore"].Outbound.Retry)
+}
+
 func TestSortAndValidateSecretsConfigration(t *testing.T) {
 	testCases := []struct {
 		name          string
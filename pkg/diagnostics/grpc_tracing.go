@@ -26,7 +26,6 @@ import (
 	"strings"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 	"go.opencensus.io/trace/propagation"
 	"google.golang.org/grpc"
@@ -113,12 +112,12 @@ func GRPCTraceStreamServerInterceptor(appID string, spec config.TracingSpec) grp
 		ctx := ss.Context()
 		md, _ := metadata.FromIncomingContext(ctx)
 
-		vals := md.Get(GRPCProxyAppIDKey)
-		if len(vals) == 0 {
-			return errors.Errorf("cannot proxy request: missing %s metadata", GRPCProxyAppIDKey)
+		// Streams without the proxy metadata are served by this app, like the
+		// streamed service invocations between sidecars.
+		targetID := appID
+		if vals := md.Get(GRPCProxyAppIDKey); len(vals) > 0 {
+			targetID = vals[0]
 		}
-
-		targetID := vals[0]
 		wrapped := grpc_middleware.WrapServerStream(ss)
 		sc, _ := SpanContextFromIncomingGRPCMetadata(ctx)
 		sampler := diag_utils.TraceSampler(spec.SamplingRate)
@@ -0,0 +1,409 @@
+package diagnostics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/service/pkg/utils/logger"
+
+	"github.com/bhojpur/application/pkg/config"
+)
+
+var log = logger.NewLogger("app.runtime.diagnostics")
+
+const (
+	// OtelProtocolGRPC exports spans with OTLP over gRPC.
+	OtelProtocolGRPC = "grpc"
+	// OtelProtocolHTTP exports spans with OTLP over HTTP using protobuf payloads.
+	OtelProtocolHTTP = "http"
+
+	otelHTTPTracesPath     = "/v1/traces"
+	otelInstrumentationLib = "github.com/bhojpur/application"
+	otelQueueSize          = 2048
+	otelMaxBatchSize       = 512
+	otelFlushInterval      = 5 * time.Second
+	otelExportTimeout      = 10 * time.Second
+)
+
+// otelClient uploads a batch of spans to an OTLP collector.
+type otelClient interface {
+	upload(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error
+	close() error
+}
+
+// OtelExporter is an open census exporter that batches spans and sends them
+// to an OpenTelemetry collector using OTLP.
+type OtelExporter struct {
+	client   otelClient
+	resource *resourcepb.Resource
+
+	spans     chan *tracepb.Span
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+var _ trace.Exporter = &OtelExporter{}
+
+// NewOtelExporter returns an exporter that sends the spans of the given service to the
+// collector configured in spec.
+func NewOtelExporter(serviceName string, spec config.OtelSpec) (*OtelExporter, error) {
+	if spec.EndpointAddress == "" {
+		return nil, errors.New("otel endpoint address is required")
+	}
+
+	var client otelClient
+	var err error
+	switch strings.ToLower(spec.Protocol) {
+	case "", OtelProtocolGRPC:
+		client, err = newOtelGRPCClient(spec)
+	case OtelProtocolHTTP:
+		client = newOtelHTTPClient(spec)
+	default:
+		err = errors.Errorf("unsupported otel protocol %q, must be %q or %q", spec.Protocol, OtelProtocolGRPC, OtelProtocolHTTP)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &OtelExporter{
+		client: client,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				otelStringAttribute(string(semconv.ServiceNameKey), serviceName),
+			},
+		},
+		spans:  make(chan *tracepb.Span, otelQueueSize),
+		stopCh: make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e, nil
+}
+
+// ExportSpan implements the open census exporter interface.
+// Spans are dropped when the queue is full so that tracing never blocks requests.
+func (e *OtelExporter) ExportSpan(sd *trace.SpanData) {
+	select {
+	case e.spans <- otelSpan(sd):
+	default:
+		log.Debugf("otel exporter queue is full, dropping span %s", sd.SpanID)
+	}
+}
+
+// Close flushes the queued spans and closes the connection to the collector.
+func (e *OtelExporter) Close() error {
+	var err error
+	e.closeOnce.Do(func() {
+		close(e.stopCh)
+		e.wg.Wait()
+		err = e.client.close()
+	})
+	return err
+}
+
+func (e *OtelExporter) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(otelFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*tracepb.Span, 0, otelMaxBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.upload(batch); err != nil {
+			log.Warnf("failed to export %d spans to otel collector: %s", len(batch), err)
+		}
+		batch = make([]*tracepb.Span, 0, otelMaxBatchSize)
+	}
+
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) >= otelMaxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stopCh:
+			for {
+				select {
+				case span := <-e.spans:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *OtelExporter) upload(spans []*tracepb.Span) error {
+	ctx, cancel := context.WithTimeout(context.Background(), otelExportTimeout)
+	defer cancel()
+
+	return e.client.upload(ctx, &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: e.resource,
+			InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: otelInstrumentationLib},
+				Spans:                  spans,
+			}},
+		}},
+	})
+}
+
+type otelGRPCClient struct {
+	conn    *grpc.ClientConn
+	client  coltracepb.TraceServiceClient
+	headers metadata.MD
+}
+
+func newOtelGRPCClient(spec config.OtelSpec) (*otelGRPCClient, error) {
+	creds := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	if spec.Insecure {
+		creds = grpc.WithInsecure()
+	}
+
+	// The gRPC target is a host:port, strip the scheme people tend to copy from HTTP endpoints.
+	target := strings.TrimPrefix(strings.TrimPrefix(spec.EndpointAddress, "http://"), "https://")
+	conn, err := grpc.Dial(target, creds)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to otel collector %s", target)
+	}
+	return &otelGRPCClient{
+		conn:    conn,
+		client:  coltracepb.NewTraceServiceClient(conn),
+		headers: metadata.New(spec.Headers),
+	}, nil
+}
+
+func (c *otelGRPCClient) upload(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	if len(c.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	}
+	_, err := c.client.Export(ctx, req)
+	return err
+}
+
+func (c *otelGRPCClient) close() error {
+	return c.conn.Close()
+}
+
+type otelHTTPClient struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newOtelHTTPClient(spec config.OtelSpec) *otelHTTPClient {
+	url := spec.EndpointAddress
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		if spec.Insecure {
+			url = "http://" + url
+		} else {
+			url = "https://" + url
+		}
+	}
+	if !strings.HasSuffix(url, otelHTTPTracesPath) {
+		url = strings.TrimSuffix(url, "/") + otelHTTPTracesPath
+	}
+
+	return &otelHTTPClient{
+		url:     url,
+		headers: spec.Headers,
+		client:  &http.Client{},
+	}
+}
+
+func (c *otelHTTPClient) upload(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range c.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otel collector %s returned status %d", c.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *otelHTTPClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// otelSpan converts an open census span into an OTLP span. Trace and span IDs are kept
+// as is, so spans stay correlated with the W3C trace context propagated by the runtime.
+func otelSpan(sd *trace.SpanData) *tracepb.Span {
+	span := &tracepb.Span{
+		TraceId:                sd.TraceID[:],
+		SpanId:                 sd.SpanID[:],
+		Name:                   sd.Name,
+		Kind:                   otelSpanKind(sd.SpanKind),
+		StartTimeUnixNano:      uint64(sd.StartTime.UnixNano()),
+		EndTimeUnixNano:        uint64(sd.EndTime.UnixNano()),
+		Attributes:             otelAttributes(sd.Attributes),
+		DroppedAttributesCount: uint32(sd.DroppedAttributeCount),
+		DroppedEventsCount:     uint32(sd.DroppedAnnotationCount + sd.DroppedMessageEventCount),
+		DroppedLinksCount:      uint32(sd.DroppedLinkCount),
+		Status:                 otelStatus(sd.Status),
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanId = sd.ParentSpanID[:]
+	}
+	if sd.Tracestate != nil {
+		entries := make([]string, 0, len(sd.Tracestate.Entries()))
+		for _, entry := range sd.Tracestate.Entries() {
+			entries = append(entries, entry.Key+"="+entry.Value)
+		}
+		span.TraceState = strings.Join(entries, ",")
+	}
+
+	for _, annotation := range sd.Annotations {
+		span.Events = append(span.Events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(annotation.Time.UnixNano()),
+			Name:         annotation.Message,
+			Attributes:   otelAttributes(annotation.Attributes),
+		})
+	}
+	for _, event := range sd.MessageEvents {
+		name := "message"
+		switch event.EventType {
+		case trace.MessageEventTypeSent:
+			name = "message sent"
+		case trace.MessageEventTypeRecv:
+			name = "message received"
+		}
+		span.Events = append(span.Events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(event.Time.UnixNano()),
+			Name:         name,
+			Attributes: []*commonpb.KeyValue{
+				otelIntAttribute("message.id", event.MessageID),
+				otelIntAttribute("message.uncompressed_size", event.UncompressedByteSize),
+				otelIntAttribute("message.compressed_size", event.CompressedByteSize),
+			},
+		})
+	}
+	for _, link := range sd.Links {
+		traceID, spanID := link.TraceID, link.SpanID
+		span.Links = append(span.Links, &tracepb.Span_Link{
+			TraceId:    traceID[:],
+			SpanId:     spanID[:],
+			Attributes: otelAttributes(link.Attributes),
+		})
+	}
+	return span
+}
+
+func otelSpanKind(kind int) tracepb.Span_SpanKind {
+	switch kind {
+	case trace.SpanKindServer:
+		return tracepb.Span_SPAN_KIND_SERVER
+	case trace.SpanKindClient:
+		return tracepb.Span_SPAN_KIND_CLIENT
+	default:
+		return tracepb.Span_SPAN_KIND_INTERNAL
+	}
+}
+
+func otelStatus(status trace.Status) *tracepb.Status {
+	// Open census uses gRPC status codes, where 0 is OK.
+	if status.Code == trace.StatusCodeOK {
+		return &tracepb.Status{Code: tracepb.Status_STATUS_CODE_UNSET}
+	}
+	return &tracepb.Status{
+		Code:    tracepb.Status_STATUS_CODE_ERROR,
+		Message: status.Message,
+	}
+}
+
+func otelAttributes(attributes map[string]interface{}) []*commonpb.KeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	kvs := make([]*commonpb.KeyValue, 0, len(attributes))
+	for k, v := range attributes {
+		switch value := v.(type) {
+		case string:
+			kvs = append(kvs, otelStringAttribute(k, value))
+		case bool:
+			kvs = append(kvs, &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: value}}})
+		case int64:
+			kvs = append(kvs, otelIntAttribute(k, value))
+		case float64:
+			kvs = append(kvs, &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: value}}})
+		default:
+			kvs = append(kvs, otelStringAttribute(k, fmt.Sprintf("%v", value)))
+		}
+	}
+	return kvs
+}
+
+func otelStringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func otelIntAttribute(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}}}
+}
@@ -0,0 +1,206 @@
+package diagnostics
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/application/pkg/config"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type fakeTraceCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	lock    sync.Mutex
+	spans   []*tracepb.Span
+	headers metadata.MD
+}
+
+func (c *fakeTraceCollector) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.headers, _ = metadata.FromIncomingContext(ctx)
+	c.collect(req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeTraceCollector) collect(req *coltracepb.ExportTraceServiceRequest) {
+	for _, rs := range req.ResourceSpans {
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			c.spans = append(c.spans, ils.Spans...)
+		}
+	}
+}
+
+// testSpanData returns a server span that continues the trace of testTraceparent.
+func testSpanData(t *testing.T) *trace.SpanData {
+	parent, ok := SpanContextFromW3CString(testTraceparent)
+	require.True(t, ok)
+
+	return &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID:      parent.TraceID,
+			SpanID:       trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceOptions: parent.TraceOptions,
+		},
+		ParentSpanID: parent.SpanID,
+		SpanKind:     trace.SpanKindServer,
+		Name:         "CallLocal/myapp/method",
+		StartTime:    time.Now().Add(-time.Second),
+		EndTime:      time.Now(),
+		Attributes: map[string]interface{}{
+			appAPISpanAttributeKey: "POST /v1.0/invoke/myapp/method/method",
+			"http.status_code":     int64(500),
+			"error":                true,
+		},
+		Status: trace.Status{Code: trace.StatusCodeInternal, Message: "failed"},
+	}
+}
+
+func assertTestSpan(t *testing.T, span *tracepb.Span) {
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", hex.EncodeToString(span.TraceId))
+	assert.Equal(t, "00f067aa0ba902b7", hex.EncodeToString(span.ParentSpanId))
+	assert.Equal(t, "0102030405060708", hex.EncodeToString(span.SpanId))
+	assert.Equal(t, "CallLocal/myapp/method", span.Name)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, span.Kind)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, span.Status.Code)
+	assert.Equal(t, "failed", span.Status.Message)
+	assert.Len(t, span.Attributes, 3)
+}
+
+func TestOtelExporterGRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	collector := &fakeTraceCollector{}
+	coltracepb.RegisterTraceServiceServer(server, collector)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	exporter, err := NewOtelExporter("myapp", config.OtelSpec{
+		EndpointAddress: lis.Addr().String(),
+		Insecure:        true,
+		Headers:         map[string]string{"api-key": "secret"},
+	})
+	require.NoError(t, err)
+
+	exporter.ExportSpan(testSpanData(t))
+	require.NoError(t, exporter.Close())
+
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	require.Len(t, collector.spans, 1)
+	assertTestSpan(t, collector.spans[0])
+	assert.Equal(t, []string{"secret"}, collector.headers.Get("api-key"))
+}
+
+func TestOtelExporterHTTP(t *testing.T) {
+	collector := &fakeTraceCollector{}
+	var path, contentType, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		apiKey = r.Header.Get("api-key")
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &coltracepb.ExportTraceServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		collector.collect(req)
+	}))
+	defer server.Close()
+
+	exporter, err := NewOtelExporter("myapp", config.OtelSpec{
+		EndpointAddress: server.URL,
+		Protocol:        OtelProtocolHTTP,
+		Headers:         map[string]string{"api-key": "secret"},
+	})
+	require.NoError(t, err)
+
+	exporter.ExportSpan(testSpanData(t))
+	require.NoError(t, exporter.Close())
+
+	assert.Equal(t, otelHTTPTracesPath, path)
+	assert.Equal(t, "application/x-protobuf", contentType)
+	assert.Equal(t, "secret", apiKey)
+	require.Len(t, collector.spans, 1)
+	assertTestSpan(t, collector.spans[0])
+}
+
+func TestNewOtelExporter(t *testing.T) {
+	t.Run("endpoint is required", func(t *testing.T) {
+		_, err := NewOtelExporter("myapp", config.OtelSpec{})
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported protocol", func(t *testing.T) {
+		_, err := NewOtelExporter("myapp", config.OtelSpec{EndpointAddress: "localhost:4317", Protocol: "thrift"})
+		assert.Error(t, err)
+	})
+
+	t.Run("http endpoint defaults", func(t *testing.T) {
+		assert.Equal(t, "https://collector:4318/v1/traces", newOtelHTTPClient(config.OtelSpec{EndpointAddress: "collector:4318"}).url)
+		assert.Equal(t, "http://collector:4318/v1/traces", newOtelHTTPClient(config.OtelSpec{EndpointAddress: "collector:4318", Insecure: true}).url)
+		assert.Equal(t, "http://collector:4318/v1/traces", newOtelHTTPClient(config.OtelSpec{EndpointAddress: "http://collector:4318/v1/traces"}).url)
+	})
+}
+
+func TestOtelSpan(t *testing.T) {
+	t.Run("root span has no parent", func(t *testing.T) {
+		sd := testSpanData(t)
+		sd.ParentSpanID = trace.SpanID{}
+		assert.Nil(t, otelSpan(sd).ParentSpanId)
+	})
+
+	t.Run("ok status is unset", func(t *testing.T) {
+		sd := testSpanData(t)
+		sd.Status = trace.Status{Code: trace.StatusCodeOK}
+		assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, otelSpan(sd).Status.Code)
+	})
+
+	t.Run("annotations become events", func(t *testing.T) {
+		sd := testSpanData(t)
+		sd.Annotations = []trace.Annotation{{Time: time.Now(), Message: "retrying"}}
+		span := otelSpan(sd)
+		require.Len(t, span.Events, 1)
+		assert.Equal(t, "retrying", span.Events[0].Name)
+	})
+}
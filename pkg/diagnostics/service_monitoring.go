@@ -22,6 +22,7 @@ package diagnostics
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
@@ -39,6 +40,9 @@ var (
 	trustDomainKey  = tag.MustNewKey("trustDomain")
 	namespaceKey    = tag.MustNewKey("namespace")
 	policyActionKey = tag.MustNewKey("policyAction")
+	policyKey       = tag.MustNewKey("policy")
+	targetKey       = tag.MustNewKey("target")
+	callerAppIDKey  = tag.MustNewKey("caller_app_id")
 )
 
 // serviceMetrics holds Bhojpur Application runtime metric monitoring methods.
@@ -62,6 +66,8 @@ type serviceMetrics struct {
 	actorDeactivationTotal       *stats.Int64Measure
 	actorDeactivationFailedTotal *stats.Int64Measure
 	actorPendingCalls            *stats.Int64Measure
+	actorReminderQueueDepth      *stats.Int64Measure
+	actorReminderLateness        *stats.Float64Measure
 
 	// Access Control Lists for Service Invocation metrics
 	appPolicyActionAllowed    *stats.Int64Measure
@@ -69,6 +75,12 @@ type serviceMetrics struct {
 	appPolicyActionBlocked    *stats.Int64Measure
 	globalPolicyActionBlocked *stats.Int64Measure
 
+	// Resiliency metrics
+	circuitBreakerState *stats.Int64Measure
+
+	// Service invocation rate limit metrics
+	serviceInvocationRateLimited *stats.Int64Measure
+
 	appID   string
 	ctx     context.Context
 	enabled bool
@@ -138,6 +150,14 @@ func newServiceMetrics() *serviceMetrics {
 			"runtime/actor/pending_actor_calls",
 			"The number of pending actor calls waiting to acquire the per-actor lock.",
 			stats.UnitDimensionless),
+		actorReminderQueueDepth: stats.Int64(
+			"runtime/actor/reminder_queue_depth",
+			"The number of reminders scheduled on this host.",
+			stats.UnitDimensionless),
+		actorReminderLateness: stats.Float64(
+			"runtime/actor/reminder_lateness",
+			"The delay between the due time of a reminder and the time it fired.",
+			stats.UnitMilliseconds),
 
 		// Access Control Lists for service invocation
 		appPolicyActionAllowed: stats.Int64(
@@ -157,6 +177,18 @@ func newServiceMetrics() *serviceMetrics {
 			"The number of requests blocked by the global action specified in the access control policy.",
 			stats.UnitDimensionless),
 
+		// Resiliency
+		circuitBreakerState: stats.Int64(
+			"runtime/resiliency/circuit_breaker_state",
+			"The state of a circuit breaker: 0 for closed, 1 for half-open and 2 for open.",
+			stats.UnitDimensionless),
+
+		// Service invocation rate limits
+		serviceInvocationRateLimited: stats.Int64(
+			"runtime/service_invocation/rate_limited_total",
+			"The number of service invocations rejected by a rate limit policy.",
+			stats.UnitDimensionless),
+
 		// TODO: use the correct context for each request
 		ctx:     context.Background(),
 		enabled: false,
@@ -184,11 +216,17 @@ func (s *serviceMetrics) Init(appID string) error {
 		diag_utils.NewMeasureView(s.actorDeactivationTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorDeactivationFailedTotal, []tag.Key{appIDKey, actorTypeKey}, view.Count()),
 		diag_utils.NewMeasureView(s.actorPendingCalls, []tag.Key{appIDKey, actorTypeKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.actorReminderQueueDepth, []tag.Key{appIDKey}, view.LastValue()),
+		diag_utils.NewMeasureView(s.actorReminderLateness, []tag.Key{appIDKey, actorTypeKey}, defaultLatencyDistribution),
 
 		diag_utils.NewMeasureView(s.appPolicyActionAllowed, []tag.Key{appIDKey, trustDomainKey, namespaceKey, operationKey, httpMethodKey, policyActionKey}, view.LastValue()),
 		diag_utils.NewMeasureView(s.globalPolicyActionAllowed, []tag.Key{appIDKey, trustDomainKey, namespaceKey, operationKey, httpMethodKey, policyActionKey}, view.LastValue()),
 		diag_utils.NewMeasureView(s.appPolicyActionBlocked, []tag.Key{appIDKey, trustDomainKey, namespaceKey, operationKey, httpMethodKey, policyActionKey}, view.LastValue()),
 		diag_utils.NewMeasureView(s.globalPolicyActionBlocked, []tag.Key{appIDKey, trustDomainKey, namespaceKey, operationKey, httpMethodKey, policyActionKey}, view.LastValue()),
+
+		diag_utils.NewMeasureView(s.circuitBreakerState, []tag.Key{appIDKey, policyKey, targetKey}, view.LastValue()),
+
+		diag_utils.NewMeasureView(s.serviceInvocationRateLimited, []tag.Key{appIDKey, callerAppIDKey, operationKey, failReasonKey}, view.Count()),
 	)
 }
 
@@ -318,6 +356,26 @@ func (s *serviceMetrics) ReportActorPendingCalls(actorType string, pendingLocks
 	}
 }
 
+// ReportActorReminderQueueDepth records the number of reminders scheduled on this host.
+func (s *serviceMetrics) ReportActorReminderQueueDepth(depth int) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID),
+			s.actorReminderQueueDepth.M(int64(depth)))
+	}
+}
+
+// ActorReminderFired records how late a reminder fired after its due time.
+func (s *serviceMetrics) ActorReminderFired(actorType string, lateness time.Duration) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, actorTypeKey, actorType),
+			s.actorReminderLateness.M(float64(lateness.Milliseconds())))
+	}
+}
+
 // RequestAllowedByAppAction records the requests allowed due to a match with the action specified in the access control policy for the app.
 func (s *serviceMetrics) RequestAllowedByAppAction(appID, trustDomain, namespace, operation, httpverb string, policyAction bool) {
 	if s.enabled {
@@ -381,3 +439,23 @@ func (s *serviceMetrics) RequestBlockedByGlobalAction(appID, trustDomain, namesp
 			s.globalPolicyActionBlocked.M(1))
 	}
 }
+
+// ReportCircuitBreakerState records the current state of the circuit breaker for a resiliency target.
+func (s *serviceMetrics) ReportCircuitBreakerState(policy, target string, state int64) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, policyKey, policy, targetKey, target),
+			s.circuitBreakerState.M(state))
+	}
+}
+
+// ServiceInvocationRateLimited records metric when a service invocation is rejected by a rate limit policy.
+func (s *serviceMetrics) ServiceInvocationRateLimited(callerAppID, operation, reason string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diag_utils.WithTags(appIDKey, s.appID, callerAppIDKey, callerAppID, operationKey, operation, failReasonKey, reason),
+			s.serviceInvocationRateLimited.M(1))
+	}
+}
@@ -29,7 +29,17 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Watch sends to eventCh when files in dir are created or written.
 func Watch(ctx context.Context, dir string, eventCh chan<- struct{}) error {
+	return watch(ctx, dir, fsnotify.Create|fsnotify.Write, eventCh)
+}
+
+// WatchAll sends to eventCh when files in dir are created, written, removed or renamed.
+func WatchAll(ctx context.Context, dir string, eventCh chan<- struct{}) error {
+	return watch(ctx, dir, fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename, eventCh)
+}
+
+func watch(ctx context.Context, dir string, ops fsnotify.Op, eventCh chan<- struct{}) error {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return errors.Wrap(err, "failed to create watcher")
@@ -45,12 +55,15 @@ LOOP:
 		select {
 		// watch for events
 		case event := <-watcher.Events:
-			if event.Op&fsnotify.Create == fsnotify.Create ||
-				event.Op&fsnotify.Write == fsnotify.Write {
+			if event.Op&ops != 0 {
 				if strings.Contains(event.Name, dir) {
 					// give time for other updates to occur
 					time.Sleep(time.Second * 1)
-					eventCh <- struct{}{}
+					select {
+					case eventCh <- struct{}{}:
+					case <-ctx.Done():
+						break LOOP
+					}
 				}
 			}
 		case err = <-watcher.Errors:
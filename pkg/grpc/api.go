@@ -21,15 +21,20 @@ package grpc
 // THE SOFTWARE.
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/bhojpur/service/pkg/configuration"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/golang/protobuf/ptypes/empty"
 	jsoniter "github.com/json-iterator/go"
 	"google.golang.org/grpc"
@@ -43,7 +48,10 @@ import (
 	commonv1pb "github.com/bhojpur/api/pkg/core/v1/common"
 	internalv1pb "github.com/bhojpur/api/pkg/core/v1/internals"
 	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	internalv1streampb "github.com/bhojpur/application/pkg/api/v1/internals"
+	runtimev1alphapb "github.com/bhojpur/application/pkg/api/v1/runtime"
 	"github.com/bhojpur/application/pkg/channel"
+	lock_loader "github.com/bhojpur/application/pkg/components/lock"
 	state_loader "github.com/bhojpur/application/pkg/components/state"
 	"github.com/bhojpur/application/pkg/concurrency"
 	"github.com/bhojpur/application/pkg/config"
@@ -51,10 +59,15 @@ import (
 	diag_utils "github.com/bhojpur/application/pkg/diagnostics/utils"
 	"github.com/bhojpur/application/pkg/encryption"
 	components_v1alpha "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	"github.com/bhojpur/application/pkg/lock"
 	"github.com/bhojpur/application/pkg/messages"
 	"github.com/bhojpur/application/pkg/messaging"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/ratelimit"
+	"github.com/bhojpur/application/pkg/resiliency"
+	"github.com/bhojpur/application/pkg/runtime/compstore"
 	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
+	"github.com/bhojpur/application/pkg/workflows"
 	"github.com/bhojpur/service/pkg/bindings"
 	svc_metadata "github.com/bhojpur/service/pkg/metadata"
 	"github.com/bhojpur/service/pkg/pubsub"
@@ -72,9 +85,19 @@ type API interface {
 	// AppInternal Service methods
 	CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error)
 	CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error)
+	CallLocalStream(stream internalv1streampb.ServiceInvocationStream_CallLocalStreamServer) error
 
 	// Bhojpur Application Service methods
 	PublishEvent(ctx context.Context, in *runtimev1pb.PublishEventRequest) (*emptypb.Empty, error)
+	BulkPublishEventAlpha1(ctx context.Context, in *runtimev1alphapb.BulkPublishRequest) (*runtimev1alphapb.BulkPublishResponse, error)
+	TryLockAlpha1(ctx context.Context, in *runtimev1alphapb.TryLockRequest) (*runtimev1alphapb.TryLockResponse, error)
+	UnlockAlpha1(ctx context.Context, in *runtimev1alphapb.UnlockRequest) (*runtimev1alphapb.UnlockResponse, error)
+	StartWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.StartWorkflowRequest) (*runtimev1alphapb.StartWorkflowResponse, error)
+	GetWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.GetWorkflowRequest) (*runtimev1alphapb.GetWorkflowResponse, error)
+	TerminateWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.TerminateWorkflowRequest) (*runtimev1alphapb.TerminateWorkflowResponse, error)
+	RaiseEventWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.RaiseEventWorkflowRequest) (*runtimev1alphapb.RaiseEventWorkflowResponse, error)
+	ExecuteActorStateTransactionAlpha1(ctx context.Context, in *runtimev1alphapb.ExecuteActorStateTransactionRequestAlpha1) (*runtimev1alphapb.ExecuteActorStateTransactionResponseAlpha1, error)
+	QueryActorStateAlpha1(ctx context.Context, in *runtimev1alphapb.QueryActorStateRequest) (*runtimev1alphapb.QueryActorStateResponse, error)
 	InvokeService(ctx context.Context, in *runtimev1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error)
 	InvokeBinding(ctx context.Context, in *runtimev1pb.InvokeBindingRequest) (*runtimev1pb.InvokeBindingResponse, error)
 	GetState(ctx context.Context, in *runtimev1pb.GetStateRequest) (*runtimev1pb.GetStateResponse, error)
@@ -112,11 +135,7 @@ type api struct {
 	actor                      actors.Actors
 	directMessaging            messaging.DirectMessaging
 	appChannel                 channel.AppChannel
-	stateStores                map[string]state.Store
-	transactionalStateStores   map[string]state.TransactionalStore
-	secretStores               map[string]secretstores.SecretStore
-	secretsConfiguration       map[string]config.SecretsScope
-	configurationStores        map[string]configuration.Store
+	compStore                  *compstore.ComponentStore
 	configurationSubscribe     map[string]chan struct{} // store map[storeName||key1,key2] -> stopChan
 	configurationSubscribeLock sync.Mutex
 	pubsubAdapter              runtime_pubsub.Adapter
@@ -128,15 +147,14 @@ type api struct {
 	extendedMetadata           sync.Map
 	components                 []components_v1alpha.Component
 	shutdown                   func()
+	resiliency                 *resiliency.Resiliency
+	rateLimiter                *ratelimit.Limiter
 }
 
 // NewAPI returns a new Bhojpur Application runtime gRPC API.
 func NewAPI(
 	appID string, appChannel channel.AppChannel,
-	stateStores map[string]state.Store,
-	secretStores map[string]secretstores.SecretStore,
-	secretsConfiguration map[string]config.SecretsScope,
-	configurationStores map[string]configuration.Store,
+	compStore *compstore.ComponentStore,
 	pubsubAdapter runtime_pubsub.Adapter,
 	directMessaging messaging.DirectMessaging,
 	actor actors.Actors,
@@ -145,31 +163,24 @@ func NewAPI(
 	accessControlList *config.AccessControlList,
 	appProtocol string,
 	getComponentsFn func() []components_v1alpha.Component,
-	shutdown func()) API {
-	transactionalStateStores := map[string]state.TransactionalStore{}
-	for key, store := range stateStores {
-		if state.FeatureTransactional.IsPresent(store.Features()) {
-			transactionalStateStores[key] = store.(state.TransactionalStore)
-		}
-	}
-
+	shutdown func(),
+	resiliency *resiliency.Resiliency,
+	rateLimiter *ratelimit.Limiter) API {
 	return &api{
-		directMessaging:          directMessaging,
-		actor:                    actor,
-		id:                       appID,
-		appChannel:               appChannel,
-		pubsubAdapter:            pubsubAdapter,
-		stateStores:              stateStores,
-		transactionalStateStores: transactionalStateStores,
-		secretStores:             secretStores,
-		configurationStores:      configurationStores,
-		configurationSubscribe:   make(map[string]chan struct{}),
-		secretsConfiguration:     secretsConfiguration,
-		sendToOutputBindingFn:    sendToOutputBindingFn,
-		tracingSpec:              tracingSpec,
-		accessControlList:        accessControlList,
-		appProtocol:              appProtocol,
-		shutdown:                 shutdown,
+		directMessaging:        directMessaging,
+		actor:                  actor,
+		id:                     appID,
+		appChannel:             appChannel,
+		pubsubAdapter:          pubsubAdapter,
+		compStore:              compStore,
+		configurationSubscribe: make(map[string]chan struct{}),
+		sendToOutputBindingFn:  sendToOutputBindingFn,
+		tracingSpec:            tracingSpec,
+		accessControlList:      accessControlList,
+		appProtocol:            appProtocol,
+		shutdown:               shutdown,
+		resiliency:             resiliency,
+		rateLimiter:            rateLimiter,
 	}
 }
 
@@ -186,23 +197,14 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 		return nil, status.Errorf(codes.InvalidArgument, messages.ErrInternalInvokeRequest, err.Error())
 	}
 
-	if a.accessControlList != nil {
-		// An access control policy has been specified for the app. Apply the policies.
-		operation := req.Message().Method
-		var httpVerb commonv1pb.HTTPExtension_Verb
-		// Get the http verb in case the application protocol is http
-		if a.appProtocol == config.HTTPProtocol && req.Metadata() != nil && len(req.Metadata()) > 0 {
-			httpExt := req.Message().GetHttpExtension()
-			if httpExt != nil {
-				httpVerb = httpExt.GetVerb()
-			}
-		}
-		callAllowed, errMsg := acl.ApplyAccessControlPolicies(ctx, operation, httpVerb, a.appProtocol, a.accessControlList)
-
-		if !callAllowed {
-			return nil, status.Errorf(codes.PermissionDenied, errMsg)
-		}
+	if err = a.checkAccessControl(ctx, req); err != nil {
+		return nil, err
+	}
+	release, err := a.rateLimiter.Allow(callerAppID(ctx, req), req.Message().Method)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
 	resp, err := a.appChannel.InvokeMethod(ctx, req)
 	if err != nil {
@@ -212,6 +214,110 @@ func (a *api) CallLocal(ctx context.Context, in *internalv1pb.InternalInvokeRequ
 	return resp.Proto(), err
 }
 
+// CallLocalStream is used for internal calls from the Bhojpur Application
+// runtime to the app when the request and response data are streamed in chunks.
+func (a *api) CallLocalStream(stream internalv1streampb.ServiceInvocationStream_CallLocalStreamServer) error {
+	if a.appChannel == nil {
+		return status.Error(codes.Internal, messages.ErrChannelNotFound)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.GetRequest() == nil {
+		return status.Errorf(codes.InvalidArgument, messages.ErrInternalInvokeRequest, "request is missing from the first message")
+	}
+	req, err := invokev1.InternalInvokeRequest(first.GetRequest())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, messages.ErrInternalInvokeRequest, err.Error())
+	}
+
+	ctx := stream.Context()
+	if err = a.checkAccessControl(ctx, req); err != nil {
+		return err
+	}
+	release, err := a.rateLimiter.Allow(callerAppID(ctx, req), req.Message().Method)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// The chunks are only received as fast as the app reads them, which lets
+	// gRPC flow control throttle the caller.
+	data := invokev1.NewStreamPayloadReader(func() (*internalv1streampb.StreamPayload, error) {
+		msg, recvErr := stream.Recv()
+		if recvErr != nil {
+			return nil, recvErr
+		}
+		return msg.GetPayload(), nil
+	}, nil)
+	req.WithDataStream(data, req.Message().GetContentType())
+
+	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	if err != nil {
+		return status.Errorf(codes.Internal, messages.ErrChannelInvoke, err)
+	}
+
+	var body io.Reader
+	if respData := resp.DataStream(); respData != nil {
+		defer respData.Close()
+		body = respData
+	} else {
+		_, raw := resp.RawData()
+		body = bytes.NewReader(raw)
+		resp.Message().Data = nil
+	}
+
+	if err = stream.Send(&internalv1streampb.InternalInvokeResponseStream{Response: resp.Proto()}); err != nil {
+		return err
+	}
+	return invokev1.SendStreamPayloads(body, func(payload *internalv1streampb.StreamPayload) error {
+		return stream.Send(&internalv1streampb.InternalInvokeResponseStream{Payload: payload})
+	})
+}
+
+// callerAppID returns the app id of the caller of a call received from another
+// app, from its SPIFFE id or else from the header set by its sidecar.
+func callerAppID(ctx context.Context, req *invokev1.InvokeMethodRequest) string {
+	if spiffeID, err := acl.GetAndParseSpiffeID(ctx); err == nil && spiffeID != nil {
+		return spiffeID.AppID
+	}
+	if values := req.Metadata()[invokev1.CallerIDHeader].GetValues(); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// checkAccessControl applies the access control policies of the app, if any,
+// to a call received from another app.
+func (a *api) checkAccessControl(ctx context.Context, req *invokev1.InvokeMethodRequest) error {
+	if a.accessControlList == nil {
+		return nil
+	}
+
+	// An access control policy has been specified for the app. Apply the policies.
+	operation := req.Message().Method
+	var httpVerb commonv1pb.HTTPExtension_Verb
+	// Get the http verb in case the application protocol is http
+	if a.appProtocol == config.HTTPProtocol && req.Metadata() != nil && len(req.Metadata()) > 0 {
+		httpExt := req.Message().GetHttpExtension()
+		if httpExt != nil {
+			httpVerb = httpExt.GetVerb()
+		}
+	}
+	md := make(map[string][]string, len(req.Metadata()))
+	for k, v := range req.Metadata() {
+		md[k] = v.GetValues()
+	}
+	callAllowed, errMsg := acl.ApplyAccessControlPolicies(ctx, operation, httpVerb, a.appProtocol, a.accessControlList, md)
+
+	if !callAllowed {
+		return status.Errorf(codes.PermissionDenied, errMsg)
+	}
+	return nil
+}
+
 // CallActor invokes a virtual actor.
 func (a *api) CallActor(ctx context.Context, in *internalv1pb.InternalInvokeRequest) (*internalv1pb.InternalInvokeResponse, error) {
 	req, err := invokev1.InternalInvokeRequest(in)
@@ -325,6 +431,155 @@ func (a *api) PublishEvent(ctx context.Context, in *runtimev1pb.PublishEventRequ
 	return &emptypb.Empty{}, nil
 }
 
+func (a *api) BulkPublishEventAlpha1(ctx context.Context, in *runtimev1alphapb.BulkPublishRequest) (*runtimev1alphapb.BulkPublishResponse, error) {
+	if a.pubsubAdapter == nil {
+		err := status.Error(codes.FailedPrecondition, messages.ErrPubsubNotConfigured)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.BulkPublishResponse{}, err
+	}
+
+	pubsubName := in.PubsubName
+	if pubsubName == "" {
+		err := status.Error(codes.InvalidArgument, messages.ErrPubsubEmpty)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.BulkPublishResponse{}, err
+	}
+
+	thepubsub := a.pubsubAdapter.GetPubSub(pubsubName)
+	if thepubsub == nil {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrPubsubNotFound, pubsubName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.BulkPublishResponse{}, err
+	}
+
+	topic := in.Topic
+	if topic == "" {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrTopicEmpty, pubsubName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.BulkPublishResponse{}, err
+	}
+
+	if len(in.Entries) == 0 {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrPubsubBulkEntriesEmpty, topic, pubsubName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.BulkPublishResponse{}, err
+	}
+
+	span := diag_utils.SpanFromContext(ctx)
+	// Populate W3C traceparent to cloudevent envelope
+	corID := diag.SpanContextToW3CString(span.SpanContext())
+	// Populate W3C tracestate to cloudevent envelope
+	traceState := diag.TraceStateToW3CString(span.SpanContext())
+
+	features := thepubsub.Features()
+	req := runtime_pubsub.BulkPublishRequest{
+		PubsubName: pubsubName,
+		Topic:      topic,
+		Entries:    make([]runtime_pubsub.BulkPublishEntry, len(in.Entries)),
+		Metadata:   in.Metadata,
+	}
+	entryIDs := make(map[string]struct{}, len(in.Entries))
+	for i, entry := range in.Entries {
+		if entry.EntryId == "" {
+			err := status.Errorf(codes.InvalidArgument, messages.ErrPubsubBulkEntryIDEmpty, topic, pubsubName)
+			apiServerLogger.Debug(err)
+			return &runtimev1alphapb.BulkPublishResponse{}, err
+		}
+		if _, ok := entryIDs[entry.EntryId]; ok {
+			err := status.Errorf(codes.InvalidArgument, messages.ErrPubsubBulkEntryIDDup, entry.EntryId, topic, pubsubName)
+			apiServerLogger.Debug(err)
+			return &runtimev1alphapb.BulkPublishResponse{}, err
+		}
+		entryIDs[entry.EntryId] = struct{}{}
+
+		entryMetadata := in.Metadata
+		if len(entry.Metadata) > 0 {
+			entryMetadata = make(map[string]string, len(in.Metadata)+len(entry.Metadata))
+			for k, v := range in.Metadata {
+				entryMetadata[k] = v
+			}
+			for k, v := range entry.Metadata {
+				entryMetadata[k] = v
+			}
+		}
+
+		rawPayload, metaErr := svc_metadata.IsRawPayload(entryMetadata)
+		if metaErr != nil {
+			err := status.Errorf(codes.InvalidArgument, messages.ErrMetadataGet, metaErr.Error())
+			apiServerLogger.Debug(err)
+			return &runtimev1alphapb.BulkPublishResponse{}, err
+		}
+
+		data := entry.Event
+		if data == nil {
+			data = []byte{}
+		}
+
+		if !rawPayload {
+			envelope, err := runtime_pubsub.NewCloudEvent(&runtime_pubsub.CloudEvent{
+				ID:              a.id,
+				Topic:           topic,
+				DataContentType: entry.ContentType,
+				Data:            data,
+				TraceID:         corID,
+				TraceState:      traceState,
+				Pubsub:          pubsubName,
+			})
+			if err != nil {
+				err = status.Errorf(codes.InvalidArgument, messages.ErrPubsubCloudEventCreation, err.Error())
+				apiServerLogger.Debug(err)
+				return &runtimev1alphapb.BulkPublishResponse{}, err
+			}
+
+			pubsub.ApplyMetadata(envelope, features, entryMetadata)
+
+			data, err = jsoniter.ConfigFastest.Marshal(envelope)
+			if err != nil {
+				err = status.Errorf(codes.InvalidArgument, messages.ErrPubsubCloudEventsSer, topic, pubsubName, err.Error())
+				apiServerLogger.Debug(err)
+				return &runtimev1alphapb.BulkPublishResponse{}, err
+			}
+		}
+
+		req.Entries[i] = runtime_pubsub.BulkPublishEntry{
+			EntryID:  entry.EntryId,
+			Data:     data,
+			Metadata: entry.Metadata,
+		}
+	}
+
+	res, err := a.pubsubAdapter.BulkPublish(&req)
+	if err != nil {
+		nerr := status.Errorf(codes.Internal, messages.ErrPubsubPublishMessage, topic, pubsubName, err.Error())
+		if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+			nerr = status.Errorf(codes.PermissionDenied, err.Error())
+		}
+
+		if errors.As(err, &runtime_pubsub.NotFoundError{}) {
+			nerr = status.Errorf(codes.NotFound, err.Error())
+		}
+		apiServerLogger.Debug(nerr)
+		return &runtimev1alphapb.BulkPublishResponse{}, nerr
+	}
+
+	// Failed entries are reported in the statuses rather than as an error, so the
+	// caller knows which entries to publish again.
+	resp := &runtimev1alphapb.BulkPublishResponse{
+		Statuses: make([]*runtimev1alphapb.BulkPublishResponseEntry, len(res.Statuses)),
+	}
+	for i, s := range res.Statuses {
+		resp.Statuses[i] = &runtimev1alphapb.BulkPublishResponseEntry{
+			EntryId: s.EntryID,
+			Status:  runtimev1alphapb.BulkPublishResponseEntry_SUCCESS,
+		}
+		if s.Error != nil {
+			resp.Statuses[i].Status = runtimev1alphapb.BulkPublishResponseEntry_FAILED
+			resp.Statuses[i].Error = s.Error.Error()
+		}
+	}
+	return resp, nil
+}
+
 func (a *api) InvokeService(ctx context.Context, in *runtimev1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error) {
 	req := invokev1.FromInvokeRequestMessage(in.GetMessage())
 
@@ -413,7 +668,13 @@ func (a *api) GetBulkState(ctx context.Context, in *runtimev1pb.GetBulkStateRequ
 		}
 		reqs[i] = r
 	}
-	bulkGet, responses, err := store.BulkGet(reqs)
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		bulkGet, responses, err := store.BulkGet(reqs)
+		return bulkGetResult{bulkGet: bulkGet, responses: responses}, err
+	})
+	result, _ := res.(bulkGetResult)
+	bulkGet, responses := result.bulkGet, result.responses
 
 	// if store supports bulk get
 	if bulkGet {
@@ -440,7 +701,11 @@ func (a *api) GetBulkState(ctx context.Context, in *runtimev1pb.GetBulkStateRequ
 	for i := 0; i < n; i++ {
 		fn := func(param interface{}) {
 			req := param.(*state.GetRequest)
-			r, err := store.Get(req)
+			policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+			res, err := policy(func(ctx context.Context) (interface{}, error) {
+				return store.Get(req)
+			})
+			r, _ := res.(*state.GetResponse)
 			item := &runtimev1pb.BulkStateItem{
 				Key: state_loader.GetOriginalStateKey(req.Key),
 			}
@@ -479,14 +744,15 @@ func (a *api) GetBulkState(ctx context.Context, in *runtimev1pb.GetBulkStateRequ
 }
 
 func (a *api) getStateStore(name string) (state.Store, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		return nil, status.Error(codes.FailedPrecondition, messages.ErrStateStoresNotConfigured)
 	}
 
-	if a.stateStores[name] == nil {
+	store, _ := a.compStore.GetStateStore(name)
+	if store == nil {
 		return nil, status.Errorf(codes.InvalidArgument, messages.ErrStateStoreNotFound, name)
 	}
-	return a.stateStores[name], nil
+	return store, nil
 }
 
 func (a *api) GetState(ctx context.Context, in *runtimev1pb.GetStateRequest) (*runtimev1pb.GetStateResponse, error) {
@@ -507,7 +773,11 @@ func (a *api) GetState(ctx context.Context, in *runtimev1pb.GetStateRequest) (*r
 		},
 	}
 
-	getResponse, err := store.Get(&req)
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return store.Get(&req)
+	})
+	getResponse, _ := res.(*state.GetResponse)
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrStateGet, in.Key, in.StoreName, err.Error())
 		apiServerLogger.Debug(err)
@@ -574,7 +844,10 @@ func (a *api) SaveState(ctx context.Context, in *runtimev1pb.SaveStateRequest) (
 		reqs = append(reqs, req)
 	}
 
-	err = store.BulkSet(reqs)
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	_, err = policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(store.BulkSet(reqs))
+	})
 	if err != nil {
 		err = a.stateErrorResponse(err, messages.ErrStateSave, in.StoreName, err.Error())
 		apiServerLogger.Debug(err)
@@ -613,7 +886,11 @@ func (a *api) QueryStateAlpha1(ctx context.Context, in *runtimev1pb.QueryStateRe
 	}
 	req.Metadata = in.GetMetadata()
 
-	resp, err := querier.Query(&req)
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return querier.Query(&req)
+	})
+	resp, _ := res.(*state.QueryResponse)
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrStateQuery, in.GetStoreName(), err.Error())
 		apiServerLogger.Debug(err)
@@ -678,7 +955,10 @@ func (a *api) DeleteState(ctx context.Context, in *runtimev1pb.DeleteStateReques
 		}
 	}
 
-	err = store.Delete(&req)
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	_, err = policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(store.Delete(&req))
+	})
 	if err != nil {
 		err = a.stateErrorResponse(err, messages.ErrStateDelete, in.Key, err.Error())
 		apiServerLogger.Debug(err)
@@ -715,7 +995,10 @@ func (a *api) DeleteBulkState(ctx context.Context, in *runtimev1pb.DeleteBulkSta
 		}
 		reqs = append(reqs, req)
 	}
-	err = store.BulkDelete(reqs)
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	_, err = policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(store.BulkDelete(reqs))
+	})
 	if err != nil {
 		apiServerLogger.Debug(err)
 		return &emptypb.Empty{}, err
@@ -724,7 +1007,7 @@ func (a *api) DeleteBulkState(ctx context.Context, in *runtimev1pb.DeleteBulkSta
 }
 
 func (a *api) GetSecret(ctx context.Context, in *runtimev1pb.GetSecretRequest) (*runtimev1pb.GetSecretResponse, error) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+	if a.compStore.SecretStoresLen() == 0 {
 		err := status.Error(codes.FailedPrecondition, messages.ErrSecretStoreNotConfigured)
 		apiServerLogger.Debug(err)
 		return &runtimev1pb.GetSecretResponse{}, err
@@ -732,7 +1015,8 @@ func (a *api) GetSecret(ctx context.Context, in *runtimev1pb.GetSecretRequest) (
 
 	secretStoreName := in.StoreName
 
-	if a.secretStores[secretStoreName] == nil {
+	secretStore, _ := a.compStore.GetSecretStore(secretStoreName)
+	if secretStore == nil {
 		err := status.Errorf(codes.InvalidArgument, messages.ErrSecretStoreNotFound, secretStoreName)
 		apiServerLogger.Debug(err)
 		return &runtimev1pb.GetSecretResponse{}, err
@@ -749,7 +1033,7 @@ func (a *api) GetSecret(ctx context.Context, in *runtimev1pb.GetSecretRequest) (
 		Metadata: in.Metadata,
 	}
 
-	getResponse, err := a.secretStores[secretStoreName].GetSecret(req)
+	getResponse, err := secretStore.GetSecret(req)
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrSecretGet, req.Name, secretStoreName, err.Error())
 		apiServerLogger.Debug(err)
@@ -764,7 +1048,7 @@ func (a *api) GetSecret(ctx context.Context, in *runtimev1pb.GetSecretRequest) (
 }
 
 func (a *api) GetBulkSecret(ctx context.Context, in *runtimev1pb.GetBulkSecretRequest) (*runtimev1pb.GetBulkSecretResponse, error) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+	if a.compStore.SecretStoresLen() == 0 {
 		err := status.Error(codes.FailedPrecondition, messages.ErrSecretStoreNotConfigured)
 		apiServerLogger.Debug(err)
 		return &runtimev1pb.GetBulkSecretResponse{}, err
@@ -772,7 +1056,8 @@ func (a *api) GetBulkSecret(ctx context.Context, in *runtimev1pb.GetBulkSecretRe
 
 	secretStoreName := in.StoreName
 
-	if a.secretStores[secretStoreName] == nil {
+	secretStore, _ := a.compStore.GetSecretStore(secretStoreName)
+	if secretStore == nil {
 		err := status.Errorf(codes.InvalidArgument, messages.ErrSecretStoreNotFound, secretStoreName)
 		apiServerLogger.Debug(err)
 		return &runtimev1pb.GetBulkSecretResponse{}, err
@@ -782,7 +1067,7 @@ func (a *api) GetBulkSecret(ctx context.Context, in *runtimev1pb.GetBulkSecretRe
 		Metadata: in.Metadata,
 	}
 
-	getResponse, err := a.secretStores[secretStoreName].BulkGetSecret(req)
+	getResponse, err := secretStore.BulkGetSecret(req)
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrBulkSecretGet, secretStoreName, err.Error())
 		apiServerLogger.Debug(err)
@@ -816,7 +1101,7 @@ func extractEtag(req *commonv1pb.StateItem) (bool, string) {
 }
 
 func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.ExecuteStateTransactionRequest) (*emptypb.Empty, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		err := status.Error(codes.FailedPrecondition, messages.ErrStateStoresNotConfigured)
 		apiServerLogger.Debug(err)
 		return &emptypb.Empty{}, err
@@ -824,14 +1109,15 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 
 	storeName := in.StoreName
 
-	if a.stateStores[storeName] == nil {
+	store, _ := a.compStore.GetStateStore(storeName)
+	if store == nil {
 		err := status.Errorf(codes.InvalidArgument, messages.ErrStateStoreNotFound, storeName)
 		apiServerLogger.Debug(err)
 		return &emptypb.Empty{}, err
 	}
 
-	transactionalStore, ok := a.transactionalStateStores[storeName]
-	if !ok {
+	transactionalStore, ok := store.(state.TransactionalStore)
+	if !ok || !state.FeatureTransactional.IsPresent(store.Features()) {
 		err := status.Errorf(codes.Unimplemented, messages.ErrStateStoreNotSupported, storeName)
 		apiServerLogger.Debug(err)
 		return &emptypb.Empty{}, err
@@ -921,9 +1207,12 @@ func (a *api) ExecuteStateTransaction(ctx context.Context, in *runtimev1pb.Execu
 		}
 	}
 
-	err := transactionalStore.Multi(&state.TransactionalStateRequest{
-		Operations: operations,
-		Metadata:   in.Metadata,
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, storeName)
+	_, err := policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(transactionalStore.Multi(&state.TransactionalStateRequest{
+			Operations: operations,
+			Metadata:   in.Metadata,
+		}))
 	})
 	if err != nil {
 		err = status.Errorf(codes.Internal, messages.ErrStateTransaction, err.Error())
@@ -940,6 +1229,12 @@ func (a *api) RegisterActorTimer(ctx context.Context, in *runtimev1pb.RegisterAc
 		return &emptypb.Empty{}, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return &emptypb.Empty{}, err
+	}
+
 	req := &actors.CreateTimerRequest{
 		Name:      in.Name,
 		ActorID:   in.ActorId,
@@ -964,6 +1259,12 @@ func (a *api) UnregisterActorTimer(ctx context.Context, in *runtimev1pb.Unregist
 		return &emptypb.Empty{}, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return &emptypb.Empty{}, err
+	}
+
 	req := &actors.DeleteTimerRequest{
 		Name:      in.Name,
 		ActorID:   in.ActorId,
@@ -981,6 +1282,12 @@ func (a *api) RegisterActorReminder(ctx context.Context, in *runtimev1pb.Registe
 		return &emptypb.Empty{}, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return &emptypb.Empty{}, err
+	}
+
 	req := &actors.CreateReminderRequest{
 		Name:      in.Name,
 		ActorID:   in.ActorId,
@@ -1004,6 +1311,12 @@ func (a *api) UnregisterActorReminder(ctx context.Context, in *runtimev1pb.Unreg
 		return &emptypb.Empty{}, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return &emptypb.Empty{}, err
+	}
+
 	req := &actors.DeleteReminderRequest{
 		Name:      in.Name,
 		ActorID:   in.ActorId,
@@ -1021,6 +1334,12 @@ func (a *api) RenameActorReminder(ctx context.Context, in *runtimev1pb.RenameAct
 		return &emptypb.Empty{}, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return &emptypb.Empty{}, err
+	}
+
 	req := &actors.RenameReminderRequest{
 		OldName:   in.OldName,
 		ActorID:   in.ActorId,
@@ -1039,6 +1358,12 @@ func (a *api) GetActorState(ctx context.Context, in *runtimev1pb.GetActorStateRe
 		return nil, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return nil, err
+	}
+
 	actorType := in.ActorType
 	actorID := in.ActorId
 	key := in.Key
@@ -1073,14 +1398,6 @@ func (a *api) GetActorState(ctx context.Context, in *runtimev1pb.GetActorStateRe
 }
 
 func (a *api) ExecuteActorStateTransaction(ctx context.Context, in *runtimev1pb.ExecuteActorStateTransactionRequest) (*emptypb.Empty, error) {
-	if a.actor == nil {
-		err := status.Errorf(codes.Internal, messages.ErrActorRuntimeNotFound)
-		apiServerLogger.Debug(err)
-		return &emptypb.Empty{}, err
-	}
-
-	actorType := in.ActorType
-	actorID := in.ActorId
 	actorOps := []actors.TransactionalOperation{}
 
 	for _, op := range in.Operations {
@@ -1117,6 +1434,111 @@ func (a *api) ExecuteActorStateTransaction(ctx context.Context, in *runtimev1pb.
 		actorOps = append(actorOps, actorOp)
 	}
 
+	return &emptypb.Empty{}, a.executeActorStateTransaction(ctx, in.ActorType, in.ActorId, actorOps)
+}
+
+// ExecuteActorStateTransactionAlpha1 behaves like ExecuteActorStateTransaction
+// but also passes the metadata of each operation, such as ttlInSeconds, to the actor runtime.
+func (a *api) ExecuteActorStateTransactionAlpha1(ctx context.Context, in *runtimev1alphapb.ExecuteActorStateTransactionRequestAlpha1) (*runtimev1alphapb.ExecuteActorStateTransactionResponseAlpha1, error) {
+	actorOps := []actors.TransactionalOperation{}
+
+	for _, op := range in.Operations {
+		var actorOp actors.TransactionalOperation
+		switch state.OperationType(op.OperationType) {
+		case state.Upsert:
+			actorOp = actors.TransactionalOperation{
+				Operation: actors.Upsert,
+				Request: map[string]interface{}{
+					"key":      op.Key,
+					"value":    op.Value,
+					"metadata": op.Metadata,
+				},
+			}
+		case state.Delete:
+			actorOp = actors.TransactionalOperation{
+				Operation: actors.Delete,
+				Request: map[string]interface{}{
+					"key": op.Key,
+				},
+			}
+		default:
+			err := status.Errorf(codes.Unimplemented, messages.ErrNotSupportedStateOperation, op.OperationType)
+			apiServerLogger.Debug(err)
+			return &runtimev1alphapb.ExecuteActorStateTransactionResponseAlpha1{}, err
+		}
+
+		actorOps = append(actorOps, actorOp)
+	}
+
+	return &runtimev1alphapb.ExecuteActorStateTransactionResponseAlpha1{}, a.executeActorStateTransaction(ctx, in.ActorType, in.ActorId, actorOps)
+}
+
+func (a *api) QueryActorStateAlpha1(ctx context.Context, in *runtimev1alphapb.QueryActorStateRequest) (*runtimev1alphapb.QueryActorStateResponse, error) {
+	ret := &runtimev1alphapb.QueryActorStateResponse{}
+
+	if a.actor == nil {
+		err := status.Errorf(codes.Internal, messages.ErrActorRuntimeNotFound)
+		apiServerLogger.Debug(err)
+		return ret, err
+	}
+
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return ret, err
+	}
+
+	req := actors.QueryStateRequest{
+		ActorType: in.ActorType,
+		Metadata:  in.Metadata,
+	}
+	if err := jsoniter.Unmarshal([]byte(in.Query), &req.Query); err != nil {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrMalformedRequest, err.Error())
+		apiServerLogger.Debug(err)
+		return ret, err
+	}
+
+	resp, err := a.actor.QueryState(ctx, &req)
+	if errors.Is(err, actors.ErrActorStateQueryNotSupported) {
+		err = status.Errorf(codes.Unimplemented, messages.ErrNotFound, "Query")
+		apiServerLogger.Debug(err)
+		return ret, err
+	} else if err != nil {
+		err = status.Errorf(codes.Internal, messages.ErrActorStateQuery, err)
+		apiServerLogger.Debug(err)
+		return ret, err
+	}
+	if resp == nil {
+		return ret, nil
+	}
+
+	ret.Token = resp.Token
+	ret.Metadata = resp.Metadata
+	ret.Results = make([]*runtimev1alphapb.QueryActorStateItem, len(resp.Results))
+	for i := range resp.Results {
+		ret.Results[i] = &runtimev1alphapb.QueryActorStateItem{
+			ActorId: resp.Results[i].ActorID,
+			Key:     resp.Results[i].Key,
+			Data:    resp.Results[i].Data,
+		}
+	}
+
+	return ret, nil
+}
+
+func (a *api) executeActorStateTransaction(ctx context.Context, actorType, actorID string, actorOps []actors.TransactionalOperation) error {
+	if a.actor == nil {
+		err := status.Errorf(codes.Internal, messages.ErrActorRuntimeNotFound)
+		apiServerLogger.Debug(err)
+		return err
+	}
+
+	if actors.IsInternalActor(actorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, actorType)
+		apiServerLogger.Debug(err)
+		return err
+	}
+
 	hosted := a.actor.IsActorHosted(ctx, &actors.ActorHostedRequest{
 		ActorType: actorType,
 		ActorID:   actorID,
@@ -1125,7 +1547,7 @@ func (a *api) ExecuteActorStateTransaction(ctx context.Context, in *runtimev1pb.
 	if !hosted {
 		err := status.Errorf(codes.Internal, messages.ErrActorInstanceMissing)
 		apiServerLogger.Debug(err)
-		return &emptypb.Empty{}, err
+		return err
 	}
 
 	req := actors.TransactionalRequest{
@@ -1135,13 +1557,17 @@ func (a *api) ExecuteActorStateTransaction(ctx context.Context, in *runtimev1pb.
 	}
 
 	err := a.actor.TransactionalStateOperation(ctx, &req)
-	if err != nil {
+	if errors.Is(err, actors.ErrInvalidStateTTL) {
+		err = status.Errorf(codes.InvalidArgument, err.Error())
+		apiServerLogger.Debug(err)
+		return err
+	} else if err != nil {
 		err = status.Errorf(codes.Internal, fmt.Sprintf(messages.ErrActorStateTransactionSave, err))
 		apiServerLogger.Debug(err)
-		return &emptypb.Empty{}, err
+		return err
 	}
 
-	return &emptypb.Empty{}, nil
+	return nil
 }
 
 func (a *api) InvokeActor(ctx context.Context, in *runtimev1pb.InvokeActorRequest) (*runtimev1pb.InvokeActorResponse, error) {
@@ -1151,6 +1577,12 @@ func (a *api) InvokeActor(ctx context.Context, in *runtimev1pb.InvokeActorReques
 		return &runtimev1pb.InvokeActorResponse{}, err
 	}
 
+	if actors.IsInternalActor(in.ActorType) {
+		err := status.Errorf(codes.InvalidArgument, messages.ErrActorTypeReserved, in.ActorType)
+		apiServerLogger.Debug(err)
+		return &runtimev1pb.InvokeActorResponse{}, err
+	}
+
 	req := invokev1.NewInvokeMethodRequest(in.Method)
 	req.WithActor(in.ActorType, in.ActorId)
 	req.WithRawData(in.Data, "")
@@ -1169,7 +1601,7 @@ func (a *api) InvokeActor(ctx context.Context, in *runtimev1pb.InvokeActorReques
 }
 
 func (a *api) isSecretAllowed(storeName, key string) bool {
-	if config, ok := a.secretsConfiguration[storeName]; ok {
+	if config, ok := a.compStore.GetSecretsConfiguration(storeName); ok {
 		return config.IsSecretAllowed(key)
 	}
 	// By default, if a configuration is not defined for a secret store, return true.
@@ -1237,14 +1669,15 @@ func stringValueOrEmpty(value *string) string {
 }
 
 func (a *api) getConfigurationStore(name string) (configuration.Store, error) {
-	if a.configurationStores == nil || len(a.configurationStores) == 0 {
+	if a.compStore.ConfigurationStoresLen() == 0 {
 		return nil, status.Error(codes.FailedPrecondition, messages.ErrConfigurationStoresNotConfigured)
 	}
 
-	if a.configurationStores[name] == nil {
+	store, _ := a.compStore.GetConfigurationStore(name)
+	if store == nil {
 		return nil, status.Errorf(codes.InvalidArgument, messages.ErrConfigurationStoreNotFound, name)
 	}
-	return a.configurationStores[name], nil
+	return store, nil
 }
 
 func (a *api) GetConfigurationAlpha1(ctx context.Context, in *runtimev1pb.GetConfigurationRequest) (*runtimev1pb.GetConfigurationResponse, error) {
@@ -1283,6 +1716,12 @@ func (a *api) GetConfigurationAlpha1(ctx context.Context, in *runtimev1pb.GetCon
 	return response, nil
 }
 
+// bulkGetResult carries the outcome of a BulkGet call through a resiliency policy.
+type bulkGetResult struct {
+	bulkGet   bool
+	responses []state.BulkGetResponse
+}
+
 type configurationEventHandler struct {
 	api          *api
 	storeName    string
@@ -1385,3 +1824,224 @@ func (a *api) UnsubscribeConfigurationAlpha1(ctx context.Context, request *runti
 		Ok: true,
 	}, nil
 }
+
+func (a *api) getLockStore(name string) (lock.Store, error) {
+	if a.compStore.LockStoresLen() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, messages.ErrLockStoresNotConfigured)
+	}
+
+	store, _ := a.compStore.GetLockStore(name)
+	if store == nil {
+		return nil, status.Errorf(codes.InvalidArgument, messages.ErrLockStoreNotFound, name)
+	}
+	return store, nil
+}
+
+func (a *api) TryLockAlpha1(ctx context.Context, in *runtimev1alphapb.TryLockRequest) (*runtimev1alphapb.TryLockResponse, error) {
+	store, err := a.getLockStore(in.StoreName)
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TryLockResponse{}, err
+	}
+
+	if in.ResourceId == "" {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrResourceIDEmpty, in.StoreName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TryLockResponse{}, err
+	}
+	if in.LockOwner == "" {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrLockOwnerEmpty, in.StoreName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TryLockResponse{}, err
+	}
+	if in.ExpiryInSeconds <= 0 {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrExpiryInSecondsNotPositive, in.StoreName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TryLockResponse{}, err
+	}
+
+	resourceID, err := lock_loader.GetModifiedLockKey(in.ResourceId, in.StoreName, a.id)
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrTryLockFailed, in.StoreName, err.Error())
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TryLockResponse{}, err
+	}
+	req := lock.TryLockRequest{
+		ResourceID:      resourceID,
+		LockOwner:       in.LockOwner,
+		ExpiryInSeconds: in.ExpiryInSeconds,
+	}
+
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		resp, err := store.TryLock(ctx, &req)
+		// A failed attempt may still have acquired the lock, so acquiring is never retried.
+		return resp, backoff.Permanent(err)
+	})
+	resp, _ := res.(*lock.TryLockResponse)
+	if err != nil {
+		err = status.Errorf(codes.Internal, messages.ErrTryLockFailed, in.StoreName, err.Error())
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TryLockResponse{}, err
+	}
+
+	return &runtimev1alphapb.TryLockResponse{
+		Success: resp.Success,
+	}, nil
+}
+
+func (a *api) UnlockAlpha1(ctx context.Context, in *runtimev1alphapb.UnlockRequest) (*runtimev1alphapb.UnlockResponse, error) {
+	store, err := a.getLockStore(in.StoreName)
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.UnlockResponse{}, err
+	}
+
+	if in.ResourceId == "" {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrResourceIDEmpty, in.StoreName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.UnlockResponse{}, err
+	}
+	if in.LockOwner == "" {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrLockOwnerEmpty, in.StoreName)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.UnlockResponse{}, err
+	}
+
+	resourceID, err := lock_loader.GetModifiedLockKey(in.ResourceId, in.StoreName, a.id)
+	if err != nil {
+		err = status.Errorf(codes.InvalidArgument, messages.ErrUnlockFailed, in.StoreName, err.Error())
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.UnlockResponse{}, err
+	}
+	req := lock.UnlockRequest{
+		ResourceID: resourceID,
+		LockOwner:  in.LockOwner,
+	}
+
+	policy := a.resiliency.ComponentOutboundPolicy(ctx, in.StoreName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return store.Unlock(ctx, &req)
+	})
+	resp, _ := res.(*lock.UnlockResponse)
+	if err != nil {
+		err = status.Errorf(codes.Internal, messages.ErrUnlockFailed, in.StoreName, err.Error())
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.UnlockResponse{
+			Status: runtimev1alphapb.UnlockResponse_INTERNAL_ERROR,
+		}, err
+	}
+
+	return &runtimev1alphapb.UnlockResponse{
+		Status: runtimev1alphapb.UnlockResponse_Status(resp.Status),
+	}, nil
+}
+
+func (a *api) getWorkflowClient() (*workflows.Client, error) {
+	if a.actor == nil {
+		return nil, status.Errorf(codes.Internal, messages.ErrActorRuntimeNotFound)
+	}
+	return workflows.NewClient(a.actor, a.id), nil
+}
+
+// workflowError converts the errors of the workflows client to gRPC status errors.
+func workflowError(instanceID string, err error, format string, args ...interface{}) error {
+	switch {
+	case errors.Is(err, workflows.ErrInvalidRequest):
+		return status.Errorf(codes.InvalidArgument, messages.ErrMalformedRequest, err)
+	case errors.Is(err, workflows.ErrInstanceNotFound):
+		return status.Errorf(codes.NotFound, messages.ErrWorkflowInstanceNotFound, instanceID)
+	case errors.Is(err, workflows.ErrInstanceExists):
+		return status.Errorf(codes.AlreadyExists, messages.ErrWorkflowInstanceExists, instanceID)
+	default:
+		return status.Errorf(codes.Internal, format, append(args, err)...)
+	}
+}
+
+func (a *api) StartWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.StartWorkflowRequest) (*runtimev1alphapb.StartWorkflowResponse, error) {
+	client, err := a.getWorkflowClient()
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.StartWorkflowResponse{}, err
+	}
+
+	var definition *workflows.Definition
+	if len(in.Definition) > 0 {
+		definition = &workflows.Definition{}
+		if err = json.Unmarshal(in.Definition, definition); err != nil {
+			err = status.Errorf(codes.InvalidArgument, messages.ErrMalformedRequest, err)
+			apiServerLogger.Debug(err)
+			return &runtimev1alphapb.StartWorkflowResponse{}, err
+		}
+	}
+
+	_, err = client.Start(ctx, in.InstanceId, definition, in.Input)
+	if err != nil {
+		err = workflowError(in.InstanceId, err, messages.ErrWorkflowStart, in.InstanceId)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.StartWorkflowResponse{}, err
+	}
+
+	return &runtimev1alphapb.StartWorkflowResponse{
+		InstanceId: in.InstanceId,
+	}, nil
+}
+
+func (a *api) GetWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.GetWorkflowRequest) (*runtimev1alphapb.GetWorkflowResponse, error) {
+	client, err := a.getWorkflowClient()
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.GetWorkflowResponse{}, err
+	}
+
+	s, err := client.Get(ctx, in.InstanceId)
+	if err != nil {
+		err = workflowError(in.InstanceId, err, messages.ErrWorkflowGet, in.InstanceId)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.GetWorkflowResponse{}, err
+	}
+
+	return &runtimev1alphapb.GetWorkflowResponse{
+		InstanceId:    s.InstanceID,
+		WorkflowName:  s.WorkflowName,
+		RuntimeStatus: string(s.RuntimeStatus),
+		CreatedAt:     s.CreatedAt.Format(time.RFC3339),
+		LastUpdatedAt: s.LastUpdatedAt.Format(time.RFC3339),
+		CurrentStep:   s.CurrentStep,
+		Input:         s.Input,
+		Output:        s.Output,
+		Error:         s.Error,
+	}, nil
+}
+
+func (a *api) TerminateWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.TerminateWorkflowRequest) (*runtimev1alphapb.TerminateWorkflowResponse, error) {
+	client, err := a.getWorkflowClient()
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TerminateWorkflowResponse{}, err
+	}
+
+	_, err = client.Terminate(ctx, in.InstanceId, in.Reason)
+	if err != nil {
+		err = workflowError(in.InstanceId, err, messages.ErrWorkflowTerminate, in.InstanceId)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.TerminateWorkflowResponse{}, err
+	}
+	return &runtimev1alphapb.TerminateWorkflowResponse{}, nil
+}
+
+func (a *api) RaiseEventWorkflowAlpha1(ctx context.Context, in *runtimev1alphapb.RaiseEventWorkflowRequest) (*runtimev1alphapb.RaiseEventWorkflowResponse, error) {
+	client, err := a.getWorkflowClient()
+	if err != nil {
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.RaiseEventWorkflowResponse{}, err
+	}
+
+	_, err = client.RaiseEvent(ctx, in.InstanceId, in.EventName, in.EventData)
+	if err != nil {
+		err = workflowError(in.InstanceId, err, messages.ErrWorkflowRaiseEvent, in.EventName, in.InstanceId)
+		apiServerLogger.Debug(err)
+		return &runtimev1alphapb.RaiseEventWorkflowResponse{}, err
+	}
+	return &runtimev1alphapb.RaiseEventWorkflowResponse{}, nil
+}
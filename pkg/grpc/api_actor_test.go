@@ -26,12 +26,14 @@ import (
 
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 
-	"github.com/bhojpur/application/pkg/actors"
 	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	"github.com/bhojpur/application/pkg/actors"
+	runtimev1alphapb "github.com/bhojpur/application/pkg/api/v1/runtime"
 	appt "github.com/bhojpur/application/pkg/testing"
 )
 
@@ -225,6 +227,186 @@ func TestExecuteActorStateTransaction(t *testing.T) {
 	})
 }
 
+func TestExecuteActorStateTransactionAlpha1(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+
+	t.Run("Save actor state with TTL - OK", func(t *testing.T) {
+		data := []byte("{ \"data\": 123 }")
+		mockActors := new(appt.MockActors)
+		mockActors.On("TransactionalStateOperation", &actors.TransactionalRequest{
+			ActorID:   "fakeActorID",
+			ActorType: "fakeActorType",
+			Operations: []actors.TransactionalOperation{
+				{
+					Operation: "upsert",
+					Request: map[string]interface{}{
+						"key":      "key1",
+						"value":    data,
+						"metadata": map[string]string{"ttlInSeconds": "60"},
+					},
+				},
+			},
+		}).Return(nil)
+
+		mockActors.On("IsActorHosted", &actors.ActorHostedRequest{
+			ActorID:   "fakeActorID",
+			ActorType: "fakeActorType",
+		}).Return(true)
+
+		server := startTestServerAlphaAPI(port, &api{
+			id:    "fakeAPI",
+			actor: mockActors,
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+		// act
+		res, err := client.ExecuteActorStateTransactionAlpha1(context.TODO(),
+			&runtimev1alphapb.ExecuteActorStateTransactionRequestAlpha1{
+				ActorId:   "fakeActorID",
+				ActorType: "fakeActorType",
+				Operations: []*runtimev1alphapb.TransactionalActorStateOperationAlpha1{
+					{
+						OperationType: "upsert",
+						Key:           "key1",
+						Value:         data,
+						Metadata:      map[string]string{"ttlInSeconds": "60"},
+					},
+				},
+			})
+
+		// assert
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		mockActors.AssertNumberOfCalls(t, "TransactionalStateOperation", 1)
+	})
+
+	t.Run("Save actor state with invalid TTL - InvalidArgument", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("TransactionalStateOperation", mock.Anything).Return(actors.ErrInvalidStateTTL)
+		mockActors.On("IsActorHosted", mock.Anything).Return(true)
+
+		server := startTestServerAlphaAPI(port, &api{
+			id:    "fakeAPI",
+			actor: mockActors,
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+		// act
+		_, err := client.ExecuteActorStateTransactionAlpha1(context.TODO(),
+			&runtimev1alphapb.ExecuteActorStateTransactionRequestAlpha1{
+				ActorId:   "fakeActorID",
+				ActorType: "fakeActorType",
+				Operations: []*runtimev1alphapb.TransactionalActorStateOperationAlpha1{
+					{
+						OperationType: "upsert",
+						Key:           "key1",
+						Metadata:      map[string]string{"ttlInSeconds": "0"},
+					},
+				},
+			})
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestQueryActorStateAlpha1(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+
+	t.Run("Query actor state - OK", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("QueryState", mock.MatchedBy(func(req *actors.QueryStateRequest) bool {
+			return req.ActorType == "fakeActorType" && req.Query.Page.Limit == 2
+		})).Return(&actors.QueryStateResponse{
+			Results: []actors.QueryStateItem{
+				{ActorID: "fakeActorID", Key: "status", Data: []byte(`"pending"`)},
+			},
+			Token: "2",
+		}, nil)
+
+		server := startTestServerAlphaAPI(port, &api{
+			id:    "fakeAPI",
+			actor: mockActors,
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+		// act
+		res, err := client.QueryActorStateAlpha1(context.TODO(), &runtimev1alphapb.QueryActorStateRequest{
+			ActorType: "fakeActorType",
+			Query:     `{"filter":{"EQ":{"value":"pending"}},"page":{"limit":2}}`,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "2", res.Token)
+		assert.Len(t, res.Results, 1)
+		assert.Equal(t, "fakeActorID", res.Results[0].ActorId)
+		assert.Equal(t, []byte(`"pending"`), res.Results[0].Data)
+	})
+
+	t.Run("Query actor state - malformed query", func(t *testing.T) {
+		server := startTestServerAlphaAPI(port, &api{
+			id:    "fakeAPI",
+			actor: new(appt.MockActors),
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+		// act
+		_, err := client.QueryActorStateAlpha1(context.TODO(), &runtimev1alphapb.QueryActorStateRequest{
+			ActorType: "fakeActorType",
+			Query:     `{`,
+		})
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Query actor state - not supported", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("QueryState", mock.Anything).Return(nil, actors.ErrActorStateQueryNotSupported)
+
+		server := startTestServerAlphaAPI(port, &api{
+			id:    "fakeAPI",
+			actor: mockActors,
+		})
+		defer server.Stop()
+
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+		// act
+		_, err := client.QueryActorStateAlpha1(context.TODO(), &runtimev1alphapb.QueryActorStateRequest{
+			ActorType: "fakeActorType",
+			Query:     `{}`,
+		})
+
+		// assert
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
 func TestUnregisterActorReminder(t *testing.T) {
 	t.Run("actors not initialized", func(t *testing.T) {
 		port, _ := freeport.GetFreePort()
@@ -25,8 +25,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -36,6 +40,7 @@ import (
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.opencensus.io/trace"
 	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
@@ -49,21 +54,28 @@ import (
 	commonv1pb "github.com/bhojpur/api/pkg/core/v1/common"
 	internalv1pb "github.com/bhojpur/api/pkg/core/v1/internals"
 	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	internalv1streampb "github.com/bhojpur/application/pkg/api/v1/internals"
+	runtimev1alphapb "github.com/bhojpur/application/pkg/api/v1/runtime"
+	"github.com/bhojpur/application/pkg/apitoken"
+	http_channel "github.com/bhojpur/application/pkg/channel/http"
 	channelt "github.com/bhojpur/application/pkg/channel/testing"
 	"github.com/bhojpur/application/pkg/config"
 	diag "github.com/bhojpur/application/pkg/diagnostics"
 	diag_utils "github.com/bhojpur/application/pkg/diagnostics/utils"
 	"github.com/bhojpur/application/pkg/encryption"
 	components_v1alpha "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	lock_inmemory "github.com/bhojpur/application/pkg/lock/inmemory"
 	"github.com/bhojpur/application/pkg/messages"
+	"github.com/bhojpur/application/pkg/messaging"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/ratelimit"
+	"github.com/bhojpur/application/pkg/runtime/compstore"
 	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
 	appt "github.com/bhojpur/application/pkg/testing"
 	testtrace "github.com/bhojpur/application/pkg/testing/trace"
 	"github.com/bhojpur/service/pkg/bindings"
 	"github.com/bhojpur/service/pkg/configuration"
 	"github.com/bhojpur/service/pkg/pubsub"
-	"github.com/bhojpur/service/pkg/secretstores"
 	"github.com/bhojpur/service/pkg/state"
 	"github.com/bhojpur/service/pkg/utils/logger"
 )
@@ -174,6 +186,24 @@ func startTestServerWithTracing(port int) (*grpc.Server, *string) {
 	return server, &buffer
 }
 
+func startTestServerAlphaAPI(port int, srv API) *grpc.Server {
+	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+
+	server := grpc.NewServer()
+	go func() {
+		runtimev1pb.RegisterApplicationServer(server, srv)
+		runtimev1alphapb.RegisterApplicationAlphaServer(server, srv)
+		if err := server.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
+	// wait until server starts
+	time.Sleep(maxGRPCServerUptime)
+
+	return server
+}
+
 func startTestServerAPI(port int, srv runtimev1pb.ApplicationServer) *grpc.Server {
 	lis, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
 
@@ -197,6 +227,7 @@ func startInternalServer(port int, testAPIServer *api) *grpc.Server {
 	server := grpc.NewServer()
 	go func() {
 		internalv1pb.RegisterServiceInvocationServer(server, testAPIServer)
+		internalv1streampb.RegisterServiceInvocationStreamServer(server, testAPIServer)
 		if err := server.Serve(lis); err != nil {
 			panic(err)
 		}
@@ -214,7 +245,7 @@ func startAppAPIServer(port int, testAPIServer *api, token string) *grpc.Server
 	opts := []grpc.ServerOption{}
 	if token != "" {
 		opts = append(opts,
-			grpc.UnaryInterceptor(setAPIAuthenticationMiddlewareUnary(token, "app-api-token")),
+			grpc.UnaryInterceptor(setAPIAuthenticationMiddlewareUnary(apitoken.NewStore(token), "app-api-token")),
 		)
 	}
 
@@ -240,6 +271,132 @@ func createTestClient(port int) *grpc.ClientConn {
 	return conn
 }
 
+func compStoreWithStateStore(name string, store state.Store) *compstore.ComponentStore {
+	compStore := compstore.New()
+	compStore.AddStateStore(name, store)
+	return compStore
+}
+
+func compStoreWithConfigurationStore(name string, store configuration.Store) *compstore.ComponentStore {
+	compStore := compstore.New()
+	compStore.AddConfigurationStore(name, store)
+	return compStore
+}
+
+func TestCallLocalStream(t *testing.T) {
+	// The app echoes the upper-cased request body back in small pieces.
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/upload", r.URL.Path)
+		assert.Equal(t, "name=big", r.URL.RawQuery)
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		data, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		upper := []byte(strings.ToUpper(string(data)))
+		for len(upper) > 0 {
+			n := 1000
+			if n > len(upper) {
+				n = len(upper)
+			}
+			w.Write(upper[:n])
+			w.(http.Flusher).Flush()
+			upper = upper[n:]
+		}
+	}))
+	defer app.Close()
+	appPort, _ := strconv.Atoi(app.URL[strings.LastIndex(app.URL, ":")+1:])
+	appChannel, err := http_channel.CreateLocalChannel(appPort, 0, config.TracingSpec{}, false, 4, 4)
+	assert.NoError(t, err)
+
+	// Direct messaging buffers request data up to 1MB instead of streaming it.
+	body := strings.Repeat("abcdefghij", (2<<20)/10+7)
+	newRequest := func() *invokev1.InvokeMethodRequest {
+		return invokev1.NewInvokeMethodRequest("upload").
+			WithHTTPExtension(http.MethodPut, "name=big").
+			WithMetadata(map[string][]string{}).
+			WithDataStream(strings.NewReader(body), "text/plain")
+	}
+
+	t.Run("appchannel is not ready", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startInternalServer(port, &api{id: "fakeAPI"})
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		stream, err := internalv1streampb.NewServiceInvocationStreamClient(clientConn).CallLocalStream(context.Background())
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("request missing from the first message", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startInternalServer(port, &api{id: "fakeAPI", appChannel: appChannel})
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		stream, err := internalv1streampb.NewServiceInvocationStreamClient(clientConn).CallLocalStream(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, stream.Send(&internalv1streampb.InternalInvokeRequestStream{Payload: &internalv1streampb.StreamPayload{Data: []byte("a")}}))
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("denied by access control", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startInternalServer(port, &api{
+			id:                "fakeAPI",
+			appChannel:        appChannel,
+			appProtocol:       config.HTTPProtocol,
+			accessControlList: &config.AccessControlList{DefaultAction: config.DenyAccess, TrustDomain: "public"},
+		})
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		resolver := new(appt.MockResolver)
+		resolver.On("ResolveID", mock.Anything).Return(fmt.Sprintf("localhost:%d", port), nil)
+		dm := messaging.NewDirectMessaging("caller", "default", port, "", nil, func(context.Context, string, string, string, bool, bool, bool, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return clientConn, nil
+		}, resolver, config.TracingSpec{}, 4, nil, 4, true, nil, "", nil)
+
+		_, err := dm.Invoke(context.Background(), "callee", newRequest())
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("streams the request and response data", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+		server := startInternalServer(port, &api{id: "fakeAPI", appChannel: appChannel})
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		resolver := new(appt.MockResolver)
+		resolver.On("ResolveID", mock.Anything).Return(fmt.Sprintf("localhost:%d", port), nil)
+		dm := messaging.NewDirectMessaging("caller", "default", port, "", nil, func(context.Context, string, string, string, bool, bool, bool, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return clientConn, nil
+		}, resolver, config.TracingSpec{}, 4, nil, 4, true, nil, "", nil)
+
+		resp, err := dm.Invoke(context.Background(), "callee", newRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(http.StatusCreated), resp.Status().Code)
+		assert.Equal(t, "text/plain", resp.Message().GetContentType())
+
+		data := resp.DataStream()
+		if assert.NotNil(t, data) {
+			b, err := ioutil.ReadAll(data)
+			assert.NoError(t, err)
+			assert.NoError(t, data.Close())
+			assert.Equal(t, len(body), len(b))
+			assert.True(t, strings.ToUpper(body) == string(b), "response data does not match")
+		}
+	})
+}
+
 func TestCallActorWithTracing(t *testing.T) {
 	port, _ := freeport.GetFreePort()
 
@@ -338,6 +495,46 @@ func TestCallLocal(t *testing.T) {
 		_, err := client.CallLocal(context.Background(), request)
 		assert.Equal(t, codes.Internal, status.Code(err))
 	})
+
+	t.Run("caller is over the rate limit", func(t *testing.T) {
+		port, _ := freeport.GetFreePort()
+
+		fakeResp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		mockAppChannel := new(channelt.MockAppChannel)
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(fakeResp, nil)
+		rateLimiter, err := ratelimit.New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{
+			{CallerAppID: "caller", RequestsPerSecond: 1, Burst: 1},
+		}})
+		require.NoError(t, err)
+		fakeAPI := &api{
+			id:          "fakeAPI",
+			appChannel:  mockAppChannel,
+			rateLimiter: rateLimiter,
+		}
+		server := startInternalServer(port, fakeAPI)
+		defer server.Stop()
+		clientConn := createTestClient(port)
+		defer clientConn.Close()
+
+		client := internalv1pb.NewServiceInvocationClient(clientConn)
+		newRequest := func(callerID string) *internalv1pb.InternalInvokeRequest {
+			return invokev1.NewInvokeMethodRequest("method").
+				WithMetadata(map[string][]string{invokev1.CallerIDHeader: {callerID}}).
+				Proto()
+		}
+
+		_, err = client.CallLocal(context.Background(), newRequest("caller"))
+		assert.NoError(t, err)
+
+		_, err = client.CallLocal(context.Background(), newRequest("caller"))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		retryAfter, ok := ratelimit.RetryAfterFromError(err)
+		assert.True(t, ok)
+		assert.Greater(t, retryAfter, time.Duration(0))
+
+		_, err = client.CallLocal(context.Background(), newRequest("other"))
+		assert.NoError(t, err)
+	})
 }
 
 func mustMarshalAny(msg proto.Message) *anypb.Any {
@@ -657,7 +854,7 @@ func TestInvokeServiceFromGRPCResponse(t *testing.T) {
 
 func TestSecretStoreNotConfigured(t *testing.T) {
 	port, _ := freeport.GetFreePort()
-	server := startAppAPIServer(port, &api{id: "fakeAPI"}, "")
+	server := startAppAPIServer(port, &api{id: "fakeAPI", compStore: compstore.New()}, "")
 	defer server.Stop()
 
 	clientConn := createTestClient(port)
@@ -670,26 +867,27 @@ func TestSecretStoreNotConfigured(t *testing.T) {
 
 func TestGetSecret(t *testing.T) {
 	fakeStore := appt.FakeSecretStore{}
-	fakeStores := map[string]secretstores.SecretStore{
-		"store1": fakeStore,
-		"store2": fakeStore,
-		"store3": fakeStore,
-		"store4": fakeStore,
+	compStore := compstore.New()
+	for _, name := range []string{"store1", "store2", "store3", "store4"} {
+		compStore.AddSecretStore(name, fakeStore)
 	}
-	secretsConfiguration := map[string]config.SecretsScope{
-		"store1": {
+	compStore.SetSecretsConfigurations([]config.SecretsScope{
+		{
+			StoreName:     "store1",
 			DefaultAccess: config.AllowAccess,
 			DeniedSecrets: []string{"not-allowed"},
 		},
-		"store2": {
+		{
+			StoreName:      "store2",
 			DefaultAccess:  config.DenyAccess,
 			AllowedSecrets: []string{goodKey},
 		},
-		"store3": {
+		{
+			StoreName:      "store3",
 			DefaultAccess:  config.AllowAccess,
 			AllowedSecrets: []string{"error-key", goodKey},
 		},
-	}
+	})
 	expectedResponse := "life is good"
 	storeName := "store1"
 	deniedStoreName := "store2"
@@ -769,9 +967,8 @@ func TestGetSecret(t *testing.T) {
 	}
 	// Setup the Bhojpur Application runtime API server
 	fakeAPI := &api{
-		id:                   "fakeAPI",
-		secretStores:         fakeStores,
-		secretsConfiguration: secretsConfiguration,
+		id:        "fakeAPI",
+		compStore: compStore,
 	}
 	// Run test server
 	port, _ := freeport.GetFreePort()
@@ -806,15 +1003,15 @@ func TestGetSecret(t *testing.T) {
 
 func TestGetBulkSecret(t *testing.T) {
 	fakeStore := appt.FakeSecretStore{}
-	fakeStores := map[string]secretstores.SecretStore{
-		"store1": fakeStore,
-	}
-	secretsConfiguration := map[string]config.SecretsScope{
-		"store1": {
+	compStore := compstore.New()
+	compStore.AddSecretStore("store1", fakeStore)
+	compStore.SetSecretsConfigurations([]config.SecretsScope{
+		{
+			StoreName:     "store1",
 			DefaultAccess: config.AllowAccess,
 			DeniedSecrets: []string{"not-allowed"},
 		},
-	}
+	})
 	expectedResponse := "life is good"
 
 	testCases := []struct {
@@ -835,9 +1032,8 @@ func TestGetBulkSecret(t *testing.T) {
 	}
 	// Setup the Bhojpur Application runtime API server
 	fakeAPI := &api{
-		id:                   "fakeAPI",
-		secretStores:         fakeStores,
-		secretsConfiguration: secretsConfiguration,
+		id:        "fakeAPI",
+		compStore: compStore,
 	}
 	// Run test server
 	port, _ := freeport.GetFreePort()
@@ -871,7 +1067,7 @@ func TestGetBulkSecret(t *testing.T) {
 
 func TestGetStateWhenStoreNotConfigured(t *testing.T) {
 	port, _ := freeport.GetFreePort()
-	server := startAppAPIServer(port, &api{id: "fakeAPI"}, "")
+	server := startAppAPIServer(port, &api{id: "fakeAPI", compStore: compstore.New()}, "")
 	defer server.Stop()
 
 	clientConn := createTestClient(port)
@@ -898,8 +1094,8 @@ func TestSaveState(t *testing.T) {
 	})).Return(errors.New("failed to save state with error-key"))
 
 	fakeAPI := &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -983,8 +1179,8 @@ func TestGetState(t *testing.T) {
 		errors.New("failed to get state with error-key"))
 
 	fakeAPI := &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -1096,8 +1292,8 @@ func TestGetConfiguration(t *testing.T) {
 		nil,
 		errors.New("failed to get state with error-key"))
 	fakeAPI := &api{
-		id:                  "fakeAPI",
-		configurationStores: map[string]configuration.Store{"store1": fakeConfigurationStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithConfigurationStore("store1", fakeConfigurationStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -1246,7 +1442,7 @@ func TestSubscribeConfiguration(t *testing.T) {
 	fakeAPI := &api{
 		configurationSubscribe: make(map[string]chan struct{}),
 		id:                     "fakeAPI",
-		configurationStores:    map[string]configuration.Store{"store1": fakeConfigurationStore},
+		compStore:              compStoreWithConfigurationStore("store1", fakeConfigurationStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -1431,7 +1627,7 @@ func TestUnSubscribeConfiguration(t *testing.T) {
 	fakeAPI := &api{
 		configurationSubscribe: make(map[string]chan struct{}),
 		id:                     "fakeAPI",
-		configurationStores:    map[string]configuration.Store{"store1": fakeConfigurationStore},
+		compStore:              compStoreWithConfigurationStore("store1", fakeConfigurationStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -1542,8 +1738,8 @@ func TestGetBulkState(t *testing.T) {
 		errors.New("failed to get state with error-key"))
 
 	fakeAPI := &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -1653,8 +1849,8 @@ func TestDeleteState(t *testing.T) {
 	})).Return(errors.New("failed to delete state with key2"))
 
 	fakeAPI := &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -1779,6 +1975,356 @@ func TestPublishTopic(t *testing.T) {
 	assert.Equal(t, codes.PermissionDenied, status.Code(err))
 }
 
+func TestBulkPublishEventAlpha1(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+
+	srv := &api{
+		pubsubAdapter: &appt.MockPubSubAdapter{
+			BulkPublishFn: func(req *runtime_pubsub.BulkPublishRequest) (runtime_pubsub.BulkPublishResponse, error) {
+				switch req.Topic {
+				case "err-not-found":
+					return runtime_pubsub.BulkPublishResponse{}, runtime_pubsub.NotFoundError{PubsubName: "errnotfound"}
+				case "err-not-allowed":
+					return runtime_pubsub.BulkPublishResponse{}, runtime_pubsub.NotAllowedError{Topic: req.Topic, ID: "test"}
+				}
+
+				res := runtime_pubsub.BulkPublishResponse{}
+				for _, entry := range req.Entries {
+					status := runtime_pubsub.BulkPublishResponseEntry{EntryID: entry.EntryID, Status: runtime_pubsub.PublishSucceeded}
+					if req.Topic == "error-topic" && entry.EntryID == "2" {
+						status.Status = runtime_pubsub.PublishFailed
+						status.Error = errors.New("error when publish")
+					}
+					res.Statuses = append(res.Statuses, status)
+				}
+				return res, nil
+			},
+			GetPubSubFn: func(pubsubName string) pubsub.PubSub {
+				return &appt.MockPubSub{}
+			},
+		},
+	}
+	server := startTestServerAlphaAPI(port, srv)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+	entries := []*runtimev1alphapb.BulkPublishRequestEntry{
+		{EntryId: "1", Event: []byte("first"), ContentType: "text/plain"},
+		{EntryId: "2", Event: []byte("second"), ContentType: "text/plain"},
+	}
+
+	t.Run("missing pubsub name", func(t *testing.T) {
+		_, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("missing topic", func(t *testing.T) {
+		_, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("no entries", func(t *testing.T) {
+		_, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+			Topic:      "topic",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("duplicate entry ids", func(t *testing.T) {
+		_, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+			Topic:      "topic",
+			Entries: []*runtimev1alphapb.BulkPublishRequestEntry{
+				{EntryId: "1", Event: []byte("first")},
+				{EntryId: "1", Event: []byte("second")},
+			},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("all entries published", func(t *testing.T) {
+		res, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+			Topic:      "topic",
+			Entries:    entries,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res.Statuses, 2)
+		for _, s := range res.Statuses {
+			assert.Equal(t, runtimev1alphapb.BulkPublishResponseEntry_SUCCESS, s.Status)
+		}
+	})
+
+	t.Run("failed entries are reported", func(t *testing.T) {
+		res, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+			Topic:      "error-topic",
+			Entries:    entries,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res.Statuses, 2)
+		assert.Equal(t, runtimev1alphapb.BulkPublishResponseEntry_SUCCESS, res.Statuses[0].Status)
+		assert.Equal(t, runtimev1alphapb.BulkPublishResponseEntry_FAILED, res.Statuses[1].Status)
+		assert.NotEmpty(t, res.Statuses[1].Error)
+	})
+
+	t.Run("pubsub not found", func(t *testing.T) {
+		_, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+			Topic:      "err-not-found",
+			Entries:    entries,
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("topic not allowed", func(t *testing.T) {
+		_, err := client.BulkPublishEventAlpha1(context.Background(), &runtimev1alphapb.BulkPublishRequest{
+			PubsubName: "pubsub",
+			Topic:      "err-not-allowed",
+			Entries:    entries,
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestLockAPIAlpha1(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+
+	compStore := compstore.New()
+	compStore.AddLockStore("lockstore", lock_inmemory.NewInMemoryLock())
+	srv := &api{
+		id:        "fakeAPI",
+		compStore: compStore,
+	}
+	server := startTestServerAlphaAPI(port, srv)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+	t.Run("lock is acquired by one owner at a time", func(t *testing.T) {
+		resp, err := client.TryLockAlpha1(context.Background(), &runtimev1alphapb.TryLockRequest{
+			StoreName:       "lockstore",
+			ResourceId:      "res1",
+			LockOwner:       "owner1",
+			ExpiryInSeconds: 10,
+		})
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+
+		resp, err = client.TryLockAlpha1(context.Background(), &runtimev1alphapb.TryLockRequest{
+			StoreName:       "lockstore",
+			ResourceId:      "res1",
+			LockOwner:       "owner2",
+			ExpiryInSeconds: 10,
+		})
+		assert.NoError(t, err)
+		assert.False(t, resp.Success)
+	})
+
+	t.Run("lock is released by its owner only", func(t *testing.T) {
+		resp, err := client.UnlockAlpha1(context.Background(), &runtimev1alphapb.UnlockRequest{
+			StoreName:  "lockstore",
+			ResourceId: "res1",
+			LockOwner:  "owner2",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, runtimev1alphapb.UnlockResponse_LOCK_BELONGS_TO_OTHERS, resp.Status)
+
+		resp, err = client.UnlockAlpha1(context.Background(), &runtimev1alphapb.UnlockRequest{
+			StoreName:  "lockstore",
+			ResourceId: "res1",
+			LockOwner:  "owner1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, runtimev1alphapb.UnlockResponse_SUCCESS, resp.Status)
+
+		resp, err = client.UnlockAlpha1(context.Background(), &runtimev1alphapb.UnlockRequest{
+			StoreName:  "lockstore",
+			ResourceId: "res1",
+			LockOwner:  "owner1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, runtimev1alphapb.UnlockResponse_LOCK_DOES_NOT_EXIST, resp.Status)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		requests := []*runtimev1alphapb.TryLockRequest{
+			{StoreName: "nostore", ResourceId: "res1", LockOwner: "owner1", ExpiryInSeconds: 10},
+			{StoreName: "lockstore", LockOwner: "owner1", ExpiryInSeconds: 10},
+			{StoreName: "lockstore", ResourceId: "res1", ExpiryInSeconds: 10},
+			{StoreName: "lockstore", ResourceId: "res1", LockOwner: "owner1"},
+		}
+		for _, req := range requests {
+			_, err := client.TryLockAlpha1(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+
+		_, err := client.UnlockAlpha1(context.Background(), &runtimev1alphapb.UnlockRequest{
+			StoreName:  "lockstore",
+			ResourceId: "res1",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("lock store not configured", func(t *testing.T) {
+		srv.compStore = compstore.New()
+		defer func() { srv.compStore = compStore }()
+
+		_, err := client.TryLockAlpha1(context.Background(), &runtimev1alphapb.TryLockRequest{
+			StoreName:       "lockstore",
+			ResourceId:      "res1",
+			LockOwner:       "owner1",
+			ExpiryInSeconds: 10,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestWorkflowAPIAlpha1(t *testing.T) {
+	port, _ := freeport.GetFreePort()
+
+	mockActors := new(appt.MockActors)
+	srv := &api{
+		id:    "fakeAPI",
+		actor: mockActors,
+	}
+	server := startTestServerAlphaAPI(port, srv)
+	defer server.Stop()
+
+	clientConn := createTestClient(port)
+	defer clientConn.Close()
+
+	client := runtimev1alphapb.NewApplicationAlphaClient(clientConn)
+
+	actorResponse := func(body string) *invokev1.InvokeMethodResponse {
+		resp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		resp.WithRawData([]byte(body), "application/json")
+		return resp
+	}
+	callTo := func(instanceID, method string) interface{} {
+		return mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+			return req.Message().Method == method && req.Actor().GetActorId() == instanceID
+		})
+	}
+	definition := []byte(`{"name": "wf", "steps": [{"name": "sleep", "timer": {"duration": "1m"}}]}`)
+	stateBody := `{"state":{"instanceId":"wf-1","workflowName":"wf","runtimeStatus":"COMPLETED","createdAt":"2022-01-02T03:04:05Z","output":{"a":1}}}`
+
+	mockActors.On("Call", callTo("wf-1", "Start")).Return(actorResponse(stateBody), nil)
+	mockActors.On("Call", callTo("wf-2", "Start")).Return(actorResponse(`{"code":"AlreadyExists"}`), nil)
+	mockActors.On("Call", callTo("wf-1", "GetStatus")).Return(actorResponse(stateBody), nil)
+	mockActors.On("Call", callTo("wf-2", "GetStatus")).Return(actorResponse(`{"code":"NotFound"}`), nil)
+	mockActors.On("Call", callTo("wf-1", "Terminate")).Return(actorResponse(stateBody), nil)
+	mockActors.On("Call", callTo("wf-1", "RaiseEvent")).Return(actorResponse(stateBody), nil)
+	mockActors.On("Call", callTo("wf-2", "RaiseEvent")).Return(nil, errors.New("placement not ready"))
+
+	t.Run("start workflow", func(t *testing.T) {
+		resp, err := client.StartWorkflowAlpha1(context.Background(), &runtimev1alphapb.StartWorkflowRequest{
+			InstanceId: "wf-1",
+			Definition: definition,
+			Input:      []byte(`{"a":1}`),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "wf-1", resp.InstanceId)
+
+		_, err = client.StartWorkflowAlpha1(context.Background(), &runtimev1alphapb.StartWorkflowRequest{
+			InstanceId: "wf-2",
+			Definition: definition,
+		})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("start workflow with invalid request", func(t *testing.T) {
+		requests := []*runtimev1alphapb.StartWorkflowRequest{
+			{InstanceId: "wf-3", Definition: []byte(`not json`)},
+			{InstanceId: "wf-3"},
+			{InstanceId: "", Definition: definition},
+			{InstanceId: "wf-3", Definition: []byte(`{"name": "wf"}`)},
+		}
+		for _, req := range requests {
+			_, err := client.StartWorkflowAlpha1(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("get workflow", func(t *testing.T) {
+		resp, err := client.GetWorkflowAlpha1(context.Background(), &runtimev1alphapb.GetWorkflowRequest{InstanceId: "wf-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "wf", resp.WorkflowName)
+		assert.Equal(t, "COMPLETED", resp.RuntimeStatus)
+		assert.Equal(t, "2022-01-02T03:04:05Z", resp.CreatedAt)
+		assert.Equal(t, []byte(`{"a":1}`), resp.Output)
+
+		_, err = client.GetWorkflowAlpha1(context.Background(), &runtimev1alphapb.GetWorkflowRequest{InstanceId: "wf-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("terminate workflow", func(t *testing.T) {
+		_, err := client.TerminateWorkflowAlpha1(context.Background(), &runtimev1alphapb.TerminateWorkflowRequest{InstanceId: "wf-1", Reason: "test"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("raise event", func(t *testing.T) {
+		_, err := client.RaiseEventWorkflowAlpha1(context.Background(), &runtimev1alphapb.RaiseEventWorkflowRequest{
+			InstanceId: "wf-1",
+			EventName:  "approved",
+			EventData:  []byte(`true`),
+		})
+		assert.NoError(t, err)
+
+		_, err = client.RaiseEventWorkflowAlpha1(context.Background(), &runtimev1alphapb.RaiseEventWorkflowRequest{
+			InstanceId: "wf-1",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.RaiseEventWorkflowAlpha1(context.Background(), &runtimev1alphapb.RaiseEventWorkflowRequest{
+			InstanceId: "wf-2",
+			EventName:  "approved",
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("actor runtime is not initialized", func(t *testing.T) {
+		srv.actor = nil
+		defer func() { srv.actor = mockActors }()
+
+		_, err := client.GetWorkflowAlpha1(context.Background(), &runtimev1alphapb.GetWorkflowRequest{InstanceId: "wf-1"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestInternalActorTypesAreReserved(t *testing.T) {
+	mockActors := new(appt.MockActors)
+	srv := &api{
+		id:    "fakeAPI",
+		actor: mockActors,
+	}
+	const actorType = "app.internal.workflow.fakeAPI"
+
+	_, err := srv.InvokeActor(context.Background(), &runtimev1pb.InvokeActorRequest{ActorType: actorType, ActorId: "wf-1", Method: "Start"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.GetActorState(context.Background(), &runtimev1pb.GetActorStateRequest{ActorType: actorType, ActorId: "wf-1", Key: "history"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.ExecuteActorStateTransaction(context.Background(), &runtimev1pb.ExecuteActorStateTransactionRequest{ActorType: actorType, ActorId: "wf-1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.RegisterActorReminder(context.Background(), &runtimev1pb.RegisterActorReminderRequest{ActorType: actorType, ActorId: "wf-1", Name: "run-1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mockActors.AssertNumberOfCalls(t, "Call", 0)
+}
+
 func TestShutdownEndpoints(t *testing.T) {
 	port, _ := freeport.GetFreePort()
 
@@ -1832,7 +2378,7 @@ func TestInvokeBinding(t *testing.T) {
 
 func TestTransactionStateStoreNotConfigured(t *testing.T) {
 	port, _ := freeport.GetFreePort()
-	server := startAppAPIServer(port, &api{id: "fakeAPI"}, "")
+	server := startAppAPIServer(port, &api{id: "fakeAPI", compStore: compstore.New()}, "")
 	defer server.Stop()
 
 	clientConn := createTestClient(port)
@@ -1847,8 +2393,8 @@ func TestTransactionStateStoreNotImplemented(t *testing.T) {
 	fakeStore := &appt.MockStateStore{}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	}, "")
 	defer server.Stop()
 
@@ -1883,13 +2429,9 @@ func TestExecuteStateTransaction(t *testing.T) {
 		return matchKeyFn(req, "error-key")
 	})).Return(errors.New("error to execute with key2"))
 
-	var fakeTransactionalStore state.TransactionalStore = fakeStore
 	fakeAPI := &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
-		transactionalStateStores: map[string]state.TransactionalStore{
-			"store1": fakeTransactionalStore,
-		},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	}
 	port, _ := freeport.GetFreePort()
 	server := startAppAPIServer(port, fakeAPI, "")
@@ -2186,8 +2728,8 @@ func TestQueryState(t *testing.T) {
 	})).Return(nil, errors.New("Query error"))
 
 	server := startTestServerAPI(port, &api{
-		id:          "fakeAPI",
-		stateStores: map[string]state.Store{"store1": fakeStore},
+		id:        "fakeAPI",
+		compStore: compStoreWithStateStore("store1", fakeStore),
 	})
 	defer server.Stop()
 
@@ -2233,8 +2775,8 @@ func TestStateStoreQuerierNotImplemented(t *testing.T) {
 	server := startAppAPIServer(
 		port,
 		&api{
-			id:          "fakeAPI",
-			stateStores: map[string]state.Store{"store1": &appt.MockStateStore{}},
+			id:        "fakeAPI",
+			compStore: compStoreWithStateStore("store1", &appt.MockStateStore{}),
 		},
 		"")
 	defer server.Stop()
@@ -2259,8 +2801,8 @@ func TestStateStoreQuerierEncrypted(t *testing.T) {
 	server := startAppAPIServer(
 		port,
 		&api{
-			id:          "fakeAPI",
-			stateStores: map[string]state.Store{storeName: &mockStateStoreQuerier{}},
+			id:        "fakeAPI",
+			compStore: compStoreWithStateStore(storeName, &mockStateStoreQuerier{}),
 		},
 		"")
 	defer server.Stop()
@@ -2283,8 +2825,8 @@ func TestGetConfigurationAlpha1(t *testing.T) {
 		server := startAppAPIServer(
 			port,
 			&api{
-				id:                  "fakeAPI",
-				configurationStores: map[string]configuration.Store{"store1": &mockConfigStore{}},
+				id:        "fakeAPI",
+				compStore: compStoreWithConfigurationStore("store1", &mockConfigStore{}),
 			},
 			"")
 		defer server.Stop()
@@ -2317,7 +2859,7 @@ func TestSubscribeConfigurationAlpha1(t *testing.T) {
 			port,
 			&api{
 				id:                         "fakeAPI",
-				configurationStores:        map[string]configuration.Store{"store1": &mockConfigStore{}},
+				compStore:                  compStoreWithConfigurationStore("store1", &mockConfigStore{}),
 				configurationSubscribe:     make(map[string]chan struct{}),
 				configurationSubscribeLock: sync.Mutex{},
 			},
@@ -22,34 +22,101 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	"github.com/bhojpur/application/pkg/apitoken"
 	v1 "github.com/bhojpur/application/pkg/messaging/v1"
 )
 
-func setAPIAuthenticationMiddlewareUnary(apiToken, authHeader string) grpc.UnaryServerInterceptor {
+func setAPIAuthenticationMiddlewareUnary(tokens *apitoken.Store, authHeader string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			err := v1.ErrorFromHTTPResponseCode(http.StatusUnauthorized, "missing metadata in request")
+		apiToken, err := authenticateRequest(ctx, tokens, authHeader, info.FullMethod)
+		if err != nil {
 			return nil, err
 		}
-
-		token := md.Get(authHeader)
-		if len(token) == 0 {
-			err := v1.ErrorFromHTTPResponseCode(http.StatusUnauthorized, "missing api token in request metadata")
+		if err := authorizeComponent(apiToken, info.FullMethod, req); err != nil {
 			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		if token[0] != apiToken {
-			err := v1.ErrorFromHTTPResponseCode(http.StatusUnauthorized, "authentication error: api token mismatch")
-			return nil, err
+func setAPIAuthenticationMiddlewareStream(tokens *apitoken.Store, authHeader string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		apiToken, err := authenticateRequest(stream.Context(), tokens, authHeader, apiMethod(info.FullMethod))
+		if err != nil {
+			return err
 		}
+		return handler(srv, &authorizedServerStream{ServerStream: stream, token: apiToken, fullMethod: info.FullMethod})
+	}
+}
 
-		md.Set(authHeader, "")
-		return handler(ctx, req)
+// authenticateRequest returns the API token of a request and checks that it may call the method.
+func authenticateRequest(ctx context.Context, tokens *apitoken.Store, authHeader, fullMethod string) (*apitoken.Token, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		err := v1.ErrorFromHTTPResponseCode(http.StatusUnauthorized, "missing metadata in request")
+		return nil, err
+	}
+
+	token := md.Get(authHeader)
+	if len(token) == 0 {
+		err := v1.ErrorFromHTTPResponseCode(http.StatusUnauthorized, "missing api token in request metadata")
+		return nil, err
+	}
+
+	apiToken, ok := tokens.Authenticate(token[0])
+	if !ok {
+		err := v1.ErrorFromHTTPResponseCode(http.StatusUnauthorized, "authentication error: api token mismatch")
+		return nil, err
+	}
+
+	if rules, restricted := apiToken.AllowedRules(protocol); restricted && !methodMatches(fullMethod, rules) {
+		err := v1.ErrorFromHTTPResponseCode(http.StatusForbidden, fmt.Sprintf("authorization error: api token %s is not allowed to call %s", apiToken.Name, fullMethod))
+		return nil, err
+	}
+
+	md.Set(authHeader, "")
+	return apiToken, nil
+}
+
+// authorizeComponent checks that the API token may use the component named by a request.
+func authorizeComponent(apiToken *apitoken.Token, fullMethod string, req interface{}) error {
+	if component, ok := requestComponent(req); ok && !apiToken.ComponentAllowed(component, !readOnlyMethods[fullMethod]) {
+		return v1.ErrorFromHTTPResponseCode(http.StatusForbidden, fmt.Sprintf("authorization error: api token %s is not allowed to use component %s", apiToken.Name, component))
+	}
+	return nil
+}
+
+// authorizedServerStream checks the component named by each message received on a stream,
+// since the requests of a stream aren't known when it's opened.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	token      *apitoken.Token
+	fullMethod string
+}
+
+func (s *authorizedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return authorizeComponent(s.token, s.fullMethod, m)
+}
+
+// requestComponent returns the component named by a request.
+func requestComponent(req interface{}) (string, bool) {
+	switch r := req.(type) {
+	case interface{ GetStoreName() string }:
+		return r.GetStoreName(), true
+	case interface{ GetPubsubName() string }:
+		return r.GetPubsubName(), true
+	case *runtimev1pb.InvokeBindingRequest:
+		return r.GetName(), true
 	}
+	return "", false
 }
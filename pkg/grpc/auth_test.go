@@ -0,0 +1,181 @@
+package grpc
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	"github.com/bhojpur/application/pkg/apitoken"
+	"github.com/bhojpur/application/pkg/config"
+)
+
+func TestSetAPIAuthenticationMiddlewareUnary(t *testing.T) {
+	h := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	tokens := apitoken.NewStore("legacy-token")
+	tokens.Update([]apitoken.Token{
+		{
+			Name:    "reader",
+			Token:   "reader-token",
+			Allowed: []config.APIAccessRule{{Name: "state", Version: "v1", Protocol: "grpc"}},
+			Scopes:  []apitoken.Scope{{Component: "store1", Access: apitoken.AccessRead}},
+		},
+		{
+			Name:    "publisher",
+			Token:   "publisher-token",
+			Allowed: []config.APIAccessRule{{Name: "publish", Version: "v1", Protocol: "http"}},
+		},
+	})
+	f := setAPIAuthenticationMiddlewareUnary(tokens, "app-api-token")
+
+	call := func(token, method string, req interface{}) codes.Code {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("app-api-token", token))
+		_, err := f(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, h)
+		return status.Code(err)
+	}
+
+	t.Run("legacy token may call every method", func(t *testing.T) {
+		assert.Equal(t, codes.OK, call("legacy-token", "/v1.runtime.Application/SaveState", &runtimev1pb.SaveStateRequest{StoreName: "store2"}))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		assert.Equal(t, codes.Unauthenticated, call("other-token", "/v1.runtime.Application/GetState", &runtimev1pb.GetStateRequest{StoreName: "store1"}))
+	})
+
+	t.Run("read in scope", func(t *testing.T) {
+		assert.Equal(t, codes.OK, call("reader-token", "/v1.runtime.Application/GetState", &runtimev1pb.GetStateRequest{StoreName: "store1"}))
+		assert.Equal(t, codes.OK, call("reader-token", "/v1.runtime.Application/GetBulkState", &runtimev1pb.GetBulkStateRequest{StoreName: "store1"}))
+	})
+
+	t.Run("write to read only component", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call("reader-token", "/v1.runtime.Application/SaveState", &runtimev1pb.SaveStateRequest{StoreName: "store1"}))
+		assert.Equal(t, codes.PermissionDenied, call("reader-token", "/v1.runtime.Application/DeleteState", &runtimev1pb.DeleteStateRequest{StoreName: "store1"}))
+	})
+
+	t.Run("component out of scope", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call("reader-token", "/v1.runtime.Application/GetState", &runtimev1pb.GetStateRequest{StoreName: "store2"}))
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call("reader-token", "/v1.runtime.Application/InvokeBinding", &runtimev1pb.InvokeBindingRequest{Name: "store1"}))
+	})
+
+	t.Run("token restricted to another protocol", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call("publisher-token", "/v1.runtime.Application/PublishEvent", &runtimev1pb.PublishEventRequest{PubsubName: "pubsub"}))
+	})
+}
+
+// fakeServerStream is a server stream receiving the given requests.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*runtimev1pb.SubscribeConfigurationRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if len(s.requests) == 0 {
+		return io.EOF
+	}
+	m.(*runtimev1pb.SubscribeConfigurationRequest).StoreName = s.requests[0].StoreName
+	s.requests = s.requests[1:]
+	return nil
+}
+
+func TestSetAPIAuthenticationMiddlewareStream(t *testing.T) {
+	h := func(srv interface{}, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(&runtimev1pb.SubscribeConfigurationRequest{}); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	tokens := apitoken.NewStore("legacy-token")
+	tokens.Update([]apitoken.Token{
+		{
+			Name:    "reader",
+			Token:   "reader-token",
+			Allowed: []config.APIAccessRule{{Name: "state", Version: "v1", Protocol: "grpc"}},
+		},
+		{
+			Name:   "config-reader",
+			Token:  "config-reader-token",
+			Scopes: []apitoken.Scope{{Component: "config1", Access: apitoken.AccessRead}},
+		},
+	})
+	f := setAPIAuthenticationMiddlewareStream(tokens, "app-api-token")
+
+	call := func(md metadata.MD, method string, requests ...*runtimev1pb.SubscribeConfigurationRequest) codes.Code {
+		stream := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), md), requests: requests}
+		err := f(nil, stream, &grpc.StreamServerInfo{FullMethod: method}, h)
+		return status.Code(err)
+	}
+	subscribe := "/v1.runtime.Application/SubscribeConfigurationAlpha1"
+
+	t.Run("missing token", func(t *testing.T) {
+		assert.Equal(t, codes.Unauthenticated, call(metadata.MD{}, subscribe))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		assert.Equal(t, codes.Unauthenticated, call(metadata.Pairs("app-api-token", "other-token"), subscribe))
+	})
+
+	t.Run("legacy token may open every stream", func(t *testing.T) {
+		assert.Equal(t, codes.OK, call(metadata.Pairs("app-api-token", "legacy-token"), subscribe, &runtimev1pb.SubscribeConfigurationRequest{StoreName: "config2"}))
+		assert.Equal(t, codes.OK, call(metadata.Pairs("app-api-token", "legacy-token"), "/app.Service/Method"))
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call(metadata.Pairs("app-api-token", "reader-token"), subscribe))
+	})
+
+	t.Run("proxied invocation not allowed", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call(metadata.Pairs("app-api-token", "reader-token"), "/app.Service/Method"))
+	})
+
+	t.Run("component in scope", func(t *testing.T) {
+		assert.Equal(t, codes.OK, call(metadata.Pairs("app-api-token", "config-reader-token"), subscribe, &runtimev1pb.SubscribeConfigurationRequest{StoreName: "config1"}))
+	})
+
+	t.Run("component out of scope", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, call(metadata.Pairs("app-api-token", "config-reader-token"), subscribe,
+			&runtimev1pb.SubscribeConfigurationRequest{StoreName: "config1"},
+			&runtimev1pb.SubscribeConfigurationRequest{StoreName: "config2"}))
+	})
+}
@@ -23,6 +23,7 @@ package grpc
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"google.golang.org/grpc"
 
@@ -38,14 +39,27 @@ var endpoints = map[string][]string{
 		"/v1.runtime.Application/GetState",
 		"/v1.runtime.Application/GetBulkState",
 		"/v1.runtime.Application/SaveState",
-		"/v1.runtime.Application/QueryState",
-		"/v1.runtime.application/DeleteState",
+		"/v1.runtime.Application/QueryStateAlpha1",
+		"/v1.runtime.Application/DeleteState",
 		"/v1.runtime.Application/DeleteBulkState",
 		"/v1.runtime.Application/ExecuteStateTransaction",
 	},
 	"publish.v1": {
 		"/v1.runtime.Application/PublishEvent",
 	},
+	"publish.v1alpha1": {
+		"/v1.runtime.ApplicationAlpha/BulkPublishEventAlpha1",
+	},
+	"lock.v1alpha1": {
+		"/v1.runtime.ApplicationAlpha/TryLockAlpha1",
+		"/v1.runtime.ApplicationAlpha/UnlockAlpha1",
+	},
+	"workflows.v1alpha1": {
+		"/v1.runtime.ApplicationAlpha/StartWorkflowAlpha1",
+		"/v1.runtime.ApplicationAlpha/GetWorkflowAlpha1",
+		"/v1.runtime.ApplicationAlpha/TerminateWorkflowAlpha1",
+		"/v1.runtime.ApplicationAlpha/RaiseEventWorkflowAlpha1",
+	},
 	"bindings.v1": {
 		"/v1.runtime.Application/InvokeBinding",
 	},
@@ -63,6 +77,10 @@ var endpoints = map[string][]string{
 		"/v1.runtime.Application/ExecuteActorStateTransaction",
 		"/v1.runtime.Application/InvokeActor",
 	},
+	"actors.v1alpha1": {
+		"/v1.runtime.ApplicationAlpha/ExecuteActorStateTransactionAlpha1",
+		"/v1.runtime.ApplicationAlpha/QueryActorStateAlpha1",
+	},
 	"metadata.v1": {
 		"/v1.runtime.Application/GetMetadata",
 		"/v1.runtime.Application/SetMetadata",
@@ -72,6 +90,21 @@ var endpoints = map[string][]string{
 	},
 }
 
+// invokeMethod is the method that proxied service invocations are checked against.
+const invokeMethod = "/v1.runtime.Application/InvokeService"
+
+// readOnlyMethods are the methods that do not change the component they name.
+var readOnlyMethods = map[string]bool{
+	"/v1.runtime.Application/GetState":                       true,
+	"/v1.runtime.Application/GetBulkState":                   true,
+	"/v1.runtime.Application/QueryStateAlpha1":               true,
+	"/v1.runtime.Application/GetSecret":                      true,
+	"/v1.runtime.Application/GetBulkSecret":                  true,
+	"/v1.runtime.Application/GetConfigurationAlpha1":         true,
+	"/v1.runtime.Application/SubscribeConfigurationAlpha1":   true,
+	"/v1.runtime.Application/UnsubscribeConfigurationAlpha1": true,
+}
+
 const protocol = "grpc"
 
 func setAPIEndpointsMiddlewareUnary(rules []config.APIAccessRule) grpc.UnaryServerInterceptor {
@@ -88,17 +121,52 @@ func setAPIEndpointsMiddlewareUnary(rules []config.APIAccessRule) grpc.UnaryServ
 			return handler(ctx, req)
 		}
 
-		for _, rule := range grpcRules {
-			if list, ok := endpoints[rule.Name+"."+rule.Version]; ok {
-				for _, method := range list {
-					if method == info.FullMethod {
-						return handler(ctx, req)
-					}
-				}
-			}
+		if methodMatches(info.FullMethod, grpcRules) {
+			return handler(ctx, req)
 		}
 
 		err := v1.ErrorFromHTTPResponseCode(http.StatusNotImplemented, "requested Bhojpur Application runtime endpoint is not available")
 		return nil, err
 	}
 }
+
+func setAPIEndpointsMiddlewareStream(rules []config.APIAccessRule) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		var grpcRules []config.APIAccessRule
+
+		for _, rule := range rules {
+			if rule.Protocol == protocol {
+				grpcRules = append(grpcRules, rule)
+			}
+		}
+
+		if len(grpcRules) == 0 || methodMatches(apiMethod(info.FullMethod), grpcRules) {
+			return handler(srv, stream)
+		}
+
+		return v1.ErrorFromHTTPResponseCode(http.StatusNotImplemented, "requested Bhojpur Application runtime endpoint is not available")
+	}
+}
+
+// apiMethod returns the runtime method a stream is checked against. Streams to other services
+// are service invocations proxied to an app.
+func apiMethod(fullMethod string) string {
+	if strings.HasPrefix(fullMethod, "/v1.runtime.") {
+		return fullMethod
+	}
+	return invokeMethod
+}
+
+// methodMatches returns whether one of the gRPC rules allows the full method name.
+func methodMatches(fullMethod string, rules []config.APIAccessRule) bool {
+	for _, rule := range rules {
+		if list, ok := endpoints[rule.Name+"."+rule.Version]; ok {
+			for _, method := range list {
+				if method == fullMethod {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -27,9 +27,33 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 
+	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	runtimev1alphapb "github.com/bhojpur/application/pkg/api/v1/runtime"
 	"github.com/bhojpur/application/pkg/config"
 )
 
+func TestEndpointsExist(t *testing.T) {
+	methods := map[string]bool{}
+	for _, desc := range []grpc.ServiceDesc{runtimev1pb.Application_ServiceDesc, runtimev1alphapb.ApplicationAlpha_ServiceDesc} {
+		for _, m := range desc.Methods {
+			methods["/"+desc.ServiceName+"/"+m.MethodName] = true
+		}
+		for _, s := range desc.Streams {
+			methods["/"+desc.ServiceName+"/"+s.StreamName] = true
+		}
+	}
+
+	// A misspelled method can never be allowed by a rule.
+	for api, list := range endpoints {
+		for _, method := range list {
+			assert.True(t, methods[method], "%s lists unknown method %s", api, method)
+		}
+	}
+	for method := range readOnlyMethods {
+		assert.True(t, methods[method], "unknown read-only method %s", method)
+	}
+}
+
 func TestSetAPIEndpointsMiddlewareUnary(t *testing.T) {
 	h := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return nil, nil
@@ -316,3 +340,30 @@ func TestSetAPIEndpointsMiddlewareUnary(t *testing.T) {
 		}
 	})
 }
+
+func TestSetAPIEndpointsMiddlewareStream(t *testing.T) {
+	h := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	call := func(rules []config.APIAccessRule, method string) error {
+		f := setAPIEndpointsMiddlewareStream(rules)
+		return f(nil, nil, &grpc.StreamServerInfo{FullMethod: method}, h)
+	}
+
+	t.Run("no grpc rules", func(t *testing.T) {
+		rules := []config.APIAccessRule{{Name: "state", Version: "v1", Protocol: "http"}}
+		assert.NoError(t, call(rules, "/v1.runtime.Application/SubscribeConfigurationAlpha1"))
+		assert.NoError(t, call(rules, "/app.Service/Method"))
+	})
+
+	t.Run("runtime stream not allowed", func(t *testing.T) {
+		rules := []config.APIAccessRule{{Name: "invoke", Version: "v1", Protocol: "grpc"}}
+		assert.Error(t, call(rules, "/v1.runtime.Application/SubscribeConfigurationAlpha1"))
+	})
+
+	t.Run("proxied invocation allowed by the invoke rule", func(t *testing.T) {
+		assert.NoError(t, call([]config.APIAccessRule{{Name: "invoke", Version: "v1", Protocol: "grpc"}}, "/app.Service/Method"))
+		assert.Error(t, call([]config.APIAccessRule{{Name: "state", Version: "v1", Protocol: "grpc"}}, "/app.Service/Method"))
+	})
+}
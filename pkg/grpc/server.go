@@ -38,6 +38,9 @@ import (
 
 	internalv1pb "github.com/bhojpur/api/pkg/core/v1/internals"
 	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	internalv1streampb "github.com/bhojpur/application/pkg/api/v1/internals"
+	runtimev1alphapb "github.com/bhojpur/application/pkg/api/v1/runtime"
+	"github.com/bhojpur/application/pkg/apitoken"
 	"github.com/bhojpur/application/pkg/config"
 	diag "github.com/bhojpur/application/pkg/diagnostics"
 	diag_utils "github.com/bhojpur/application/pkg/diagnostics/utils"
@@ -73,7 +76,7 @@ type server struct {
 	kind               string
 	logger             logger.Logger
 	maxConnectionAge   *time.Duration
-	authToken          string
+	apiTokens          *apitoken.Store
 	apiSpec            config.APISpec
 	proxy              messaging.Proxy
 }
@@ -84,7 +87,7 @@ var (
 )
 
 // NewAPIServer returns a new user facing Bhojpur Application gRPC API server.
-func NewAPIServer(api API, config ServerConfig, tracingSpec config.TracingSpec, metricSpec config.MetricSpec, apiSpec config.APISpec, proxy messaging.Proxy) Server {
+func NewAPIServer(api API, config ServerConfig, tracingSpec config.TracingSpec, metricSpec config.MetricSpec, apiSpec config.APISpec, apiTokens *apitoken.Store, proxy messaging.Proxy) Server {
 	return &server{
 		api:         api,
 		config:      config,
@@ -92,7 +95,7 @@ func NewAPIServer(api API, config ServerConfig, tracingSpec config.TracingSpec,
 		metricSpec:  metricSpec,
 		kind:        apiServer,
 		logger:      apiServerLogger,
-		authToken:   auth.GetAPIToken(),
+		apiTokens:   apiTokens,
 		apiSpec:     apiSpec,
 		proxy:       proxy,
 	}
@@ -155,8 +158,10 @@ func (s *server) StartNonBlocking() error {
 
 		if s.kind == internalServer {
 			internalv1pb.RegisterServiceInvocationServer(server, s.api)
+			internalv1streampb.RegisterServiceInvocationStreamServer(server, s.api)
 		} else if s.kind == apiServer {
 			runtimev1pb.RegisterApplicationServer(server, s.api)
+			runtimev1alphapb.RegisterApplicationAlphaServer(server, s.api)
 		}
 
 		go func(server *grpc_go.Server, l net.Listener) {
@@ -204,18 +209,20 @@ func (s *server) getMiddlewareOptions() []grpc_go.ServerOption {
 	if len(s.apiSpec.Allowed) > 0 {
 		s.logger.Info("enabled API access list on gRPC server")
 		intr = append(intr, setAPIEndpointsMiddlewareUnary(s.apiSpec.Allowed))
+		intrStream = append(intrStream, setAPIEndpointsMiddlewareStream(s.apiSpec.Allowed))
 	}
 
-	if s.authToken != "" {
+	if s.apiTokens.Enabled() {
 		s.logger.Info("enabled token authentication on gRPC server")
-		intr = append(intr, setAPIAuthenticationMiddlewareUnary(s.authToken, auth.APITokenHeader))
+		intr = append(intr, setAPIAuthenticationMiddlewareUnary(s.apiTokens, auth.APITokenHeader))
+		intrStream = append(intrStream, setAPIAuthenticationMiddlewareStream(s.apiTokens, auth.APITokenHeader))
 	}
 
 	if diag_utils.IsTracingEnabled(s.tracingSpec.SamplingRate) {
 		s.logger.Info("enabled gRPC tracing middleware")
 		intr = append(intr, diag.GRPCTraceUnaryServerInterceptor(s.config.AppID, s.tracingSpec))
 
-		if s.proxy != nil {
+		if s.proxy != nil || s.kind == internalServer {
 			intrStream = append(intrStream, diag.GRPCTraceStreamServerInterceptor(s.config.AppID, s.tracingSpec))
 		}
 	}
@@ -233,7 +240,7 @@ func (s *server) getMiddlewareOptions() []grpc_go.ServerOption {
 		grpc_go.UnaryInterceptor(chain),
 	)
 
-	if s.proxy != nil {
+	if len(intrStream) > 0 {
 		chainStream := grpc_middleware.ChainStreamServer(
 			intrStream...,
 		)
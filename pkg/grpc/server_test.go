@@ -86,7 +86,7 @@ func TestGetMiddlewareOptions(t *testing.T) {
 		assert.Equal(t, 1, len(serverOption))
 	})
 
-	t.Run("should have api access rules middleware", func(t *testing.T) {
+	t.Run("should have api access rules middleware on unary and stream calls", func(t *testing.T) {
 		fakeServer := &server{
 			config: ServerConfig{},
 			tracingSpec: config.TracingSpec{
@@ -106,7 +106,7 @@ func TestGetMiddlewareOptions(t *testing.T) {
 
 		serverOption := fakeServer.getMiddlewareOptions()
 
-		assert.Equal(t, 1, len(serverOption))
+		assert.Equal(t, 2, len(serverOption))
 	})
 }
 
@@ -115,7 +115,7 @@ func TestClose(t *testing.T) {
 	require.NoError(t, err)
 	serverConfig := NewServerConfig("test", "127.0.0.1", port, []string{"127.0.0.1"}, "test", "test", 4, "", 4)
 	a := &api{}
-	server := NewAPIServer(a, serverConfig, config.TracingSpec{}, config.MetricSpec{}, config.APISpec{}, nil)
+	server := NewAPIServer(a, serverConfig, config.TracingSpec{}, config.MetricSpec{}, config.APISpec{}, nil, nil)
 	require.NoError(t, server.StartNonBlocking())
 	app_testing.WaitForListeningAddress(t, 5*time.Second, fmt.Sprintf("127.0.0.1:%d", port))
 	assert.NoError(t, server.Close())
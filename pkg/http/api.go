@@ -21,12 +21,16 @@ package http
 // THE SOFTWARE.
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/fasthttp/router"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/mitchellh/mapstructure"
@@ -39,6 +43,8 @@ import (
 	"github.com/bhojpur/application/pkg/actors"
 	"github.com/bhojpur/application/pkg/channel"
 	"github.com/bhojpur/application/pkg/channel/http"
+	"github.com/bhojpur/application/pkg/components"
+	lock_loader "github.com/bhojpur/application/pkg/components/lock"
 	state_loader "github.com/bhojpur/application/pkg/components/state"
 	"github.com/bhojpur/application/pkg/concurrency"
 	"github.com/bhojpur/application/pkg/config"
@@ -46,11 +52,17 @@ import (
 	diag_utils "github.com/bhojpur/application/pkg/diagnostics/utils"
 	"github.com/bhojpur/application/pkg/encryption"
 	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	"github.com/bhojpur/application/pkg/lock"
 	"github.com/bhojpur/application/pkg/messages"
 	"github.com/bhojpur/application/pkg/messaging"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/ratelimit"
+	"github.com/bhojpur/application/pkg/resiliency"
+	"github.com/bhojpur/application/pkg/runtime/compstore"
 	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
+	"github.com/bhojpur/application/pkg/workflows"
 	"github.com/bhojpur/service/pkg/bindings"
+	"github.com/bhojpur/service/pkg/contenttype"
 	svc_metadata "github.com/bhojpur/service/pkg/metadata"
 	"github.com/bhojpur/service/pkg/pubsub"
 	"github.com/bhojpur/service/pkg/secretstores"
@@ -69,25 +81,25 @@ type API interface {
 }
 
 type api struct {
-	endpoints                []Endpoint
-	publicEndpoints          []Endpoint
-	directMessaging          messaging.DirectMessaging
-	appChannel               channel.AppChannel
-	getComponentsFn          func() []components_v1alpha1.Component
-	stateStores              map[string]state.Store
-	transactionalStateStores map[string]state.TransactionalStore
-	secretStores             map[string]secretstores.SecretStore
-	secretsConfiguration     map[string]config.SecretsScope
-	json                     jsoniter.API
-	actor                    actors.Actors
-	pubsubAdapter            runtime_pubsub.Adapter
-	sendToOutputBindingFn    func(name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error)
-	id                       string
-	extendedMetadata         sync.Map
-	readyStatus              bool
-	outboundReadyStatus      bool
-	tracingSpec              config.TracingSpec
-	shutdown                 func()
+	endpoints             []Endpoint
+	publicEndpoints       []Endpoint
+	directMessaging       messaging.DirectMessaging
+	appChannel            channel.AppChannel
+	getComponentsFn       func() []components_v1alpha1.Component
+	getComponentUpdatesFn func() []components.Update
+	compStore             *compstore.ComponentStore
+	json                  jsoniter.API
+	actor                 actors.Actors
+	pubsubAdapter         runtime_pubsub.Adapter
+	sendToOutputBindingFn func(name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error)
+	id                    string
+	extendedMetadata      sync.Map
+	readyStatus           bool
+	outboundReadyStatus   bool
+	tracingSpec           config.TracingSpec
+	shutdown              func()
+	resiliency            *resiliency.Resiliency
+	rateLimiter           *ratelimit.Limiter
 }
 
 type registeredComponent struct {
@@ -101,6 +113,22 @@ type metadata struct {
 	ActiveActorsCount    []actors.ActiveActorsCount  `json:"actors"`
 	Extended             map[interface{}]interface{} `json:"extended"`
 	RegisteredComponents []registeredComponent       `json:"components"`
+	ComponentUpdates     []components.Update         `json:"componentUpdates,omitempty"`
+}
+
+// bulkGetResult carries the outcome of a BulkGet call through a resiliency policy.
+type bulkGetResult struct {
+	bulkGet   bool
+	responses []state.BulkGetResponse
+}
+
+type startWorkflowRequest struct {
+	Definition *workflows.Definition `json:"definition"`
+	Input      json.RawMessage       `json:"input,omitempty"`
+}
+
+type terminateWorkflowRequest struct {
+	Reason string `json:"reason,omitempty"`
 }
 
 const (
@@ -122,6 +150,8 @@ const (
 	traceparentHeader    = "traceparent"
 	tracestateHeader     = "tracestate"
 	appAppID             = "app-app-id"
+	instanceIDParam      = "instanceId"
+	eventNameParam       = "eventName"
 )
 
 // NewAPI returns a new API.
@@ -130,41 +160,38 @@ func NewAPI(
 	appChannel channel.AppChannel,
 	directMessaging messaging.DirectMessaging,
 	getComponentsFn func() []components_v1alpha1.Component,
-	stateStores map[string]state.Store,
-	secretStores map[string]secretstores.SecretStore,
-	secretsConfiguration map[string]config.SecretsScope,
+	getComponentUpdatesFn func() []components.Update,
+	compStore *compstore.ComponentStore,
 	pubsubAdapter runtime_pubsub.Adapter,
 	actor actors.Actors,
 	sendToOutputBindingFn func(name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error),
 	tracingSpec config.TracingSpec,
-	shutdown func()) API {
-	transactionalStateStores := map[string]state.TransactionalStore{}
-	for key, store := range stateStores {
-		if state.FeatureTransactional.IsPresent(store.Features()) {
-			transactionalStateStores[key] = store.(state.TransactionalStore)
-		}
-	}
+	shutdown func(),
+	resiliency *resiliency.Resiliency,
+	rateLimiter *ratelimit.Limiter) API {
 	api := &api{
-		appChannel:               appChannel,
-		getComponentsFn:          getComponentsFn,
-		directMessaging:          directMessaging,
-		stateStores:              stateStores,
-		transactionalStateStores: transactionalStateStores,
-		secretStores:             secretStores,
-		secretsConfiguration:     secretsConfiguration,
-		json:                     jsoniter.ConfigFastest,
-		actor:                    actor,
-		pubsubAdapter:            pubsubAdapter,
-		sendToOutputBindingFn:    sendToOutputBindingFn,
-		id:                       appID,
-		tracingSpec:              tracingSpec,
-		shutdown:                 shutdown,
+		appChannel:            appChannel,
+		getComponentsFn:       getComponentsFn,
+		getComponentUpdatesFn: getComponentUpdatesFn,
+		directMessaging:       directMessaging,
+		compStore:             compStore,
+		json:                  jsoniter.ConfigFastest,
+		actor:                 actor,
+		pubsubAdapter:         pubsubAdapter,
+		sendToOutputBindingFn: sendToOutputBindingFn,
+		id:                    appID,
+		tracingSpec:           tracingSpec,
+		shutdown:              shutdown,
+		resiliency:            resiliency,
+		rateLimiter:           rateLimiter,
 	}
 
 	metadataEndpoints := api.constructMetadataEndpoints()
 	healthEndpoints := api.constructHealthzEndpoints()
 
 	api.endpoints = append(api.endpoints, api.constructStateEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructLockEndpoints()...)
+	api.endpoints = append(api.endpoints, api.constructWorkflowEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructSecretEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructPubSubEndpoints()...)
 	api.endpoints = append(api.endpoints, api.constructActorEndpoints()...)
@@ -259,6 +286,52 @@ func (a *api) constructSecretEndpoints() []Endpoint {
 	}
 }
 
+func (a *api) constructLockEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fasthttp.MethodPost, fasthttp.MethodPut},
+			Route:   "lock/{storeName}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onTryLock,
+		},
+		{
+			Methods: []string{fasthttp.MethodPost, fasthttp.MethodPut},
+			Route:   "unlock/{storeName}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onUnlock,
+		},
+	}
+}
+
+func (a *api) constructWorkflowEndpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Methods: []string{fasthttp.MethodPost},
+			Route:   "workflows/{instanceId}/start",
+			Version: apiVersionV1alpha1,
+			Handler: a.onStartWorkflow,
+		},
+		{
+			Methods: []string{fasthttp.MethodGet},
+			Route:   "workflows/{instanceId}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onGetWorkflow,
+		},
+		{
+			Methods: []string{fasthttp.MethodPost},
+			Route:   "workflows/{instanceId}/terminate",
+			Version: apiVersionV1alpha1,
+			Handler: a.onTerminateWorkflow,
+		},
+		{
+			Methods: []string{fasthttp.MethodPost},
+			Route:   "workflows/{instanceId}/raiseEvent/{eventName}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onRaiseWorkflowEvent,
+		},
+	}
+}
+
 func (a *api) constructPubSubEndpoints() []Endpoint {
 	return []Endpoint{
 		{
@@ -267,6 +340,12 @@ func (a *api) constructPubSubEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onPublish,
 		},
+		{
+			Methods: []string{fasthttp.MethodPost, fasthttp.MethodPut},
+			Route:   "publish/bulk/{pubsubname}/{topic:*}",
+			Version: apiVersionV1alpha1,
+			Handler: a.onBulkPublish,
+		},
 	}
 }
 
@@ -350,6 +429,24 @@ func (a *api) constructActorEndpoints() []Endpoint {
 			Version: apiVersionV1,
 			Handler: a.onRenameActorReminder,
 		},
+		{
+			Methods: []string{fasthttp.MethodPost, fasthttp.MethodPut},
+			Route:   "actors/{actorType}/state/query",
+			Version: apiVersionV1alpha1,
+			Handler: a.onQueryActorState,
+		},
+		{
+			Methods: []string{fasthttp.MethodGet},
+			Route:   "actors/{actorType}/reminders",
+			Version: apiVersionV1alpha1,
+			Handler: a.onListActorReminders,
+		},
+		{
+			Methods: []string{fasthttp.MethodGet},
+			Route:   "actors/{actorType}/{actorId}/reminders",
+			Version: apiVersionV1alpha1,
+			Handler: a.onListActorReminders,
+		},
 	}
 }
 
@@ -501,7 +598,13 @@ func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
 		}
 		reqs[i] = r
 	}
-	bulkGet, responses, err := store.BulkGet(reqs)
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		bulkGet, responses, err := store.BulkGet(reqs)
+		return bulkGetResult{bulkGet: bulkGet, responses: responses}, err
+	})
+	result, _ := res.(bulkGetResult)
+	bulkGet, responses := result.bulkGet, result.responses
 
 	if bulkGet {
 		// if store supports bulk get
@@ -543,7 +646,11 @@ func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
 					Metadata: metadata,
 				}
 
-				resp, err := store.Get(gr)
+				policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+				res, err := policy(func(ctx context.Context) (interface{}, error) {
+					return store.Get(gr)
+				})
+				resp, _ := res.(*state.GetResponse)
 				if err != nil {
 					log.Debugf("bulk get: error getting key %s: %s", r.Key, err)
 					r.Error = err.Error()
@@ -577,7 +684,7 @@ func (a *api) onBulkGetState(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) getStateStoreWithRequestValidation(reqCtx *fasthttp.RequestCtx) (state.Store, string, error) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", messages.ErrStateStoresNotConfigured)
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
 		log.Debug(msg)
@@ -586,13 +693,14 @@ func (a *api) getStateStoreWithRequestValidation(reqCtx *fasthttp.RequestCtx) (s
 
 	storeName := a.getStateStoreName(reqCtx)
 
-	if a.stateStores[storeName] == nil {
+	store, _ := a.compStore.GetStateStore(storeName)
+	if store == nil {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf(messages.ErrStateStoreNotFound, storeName))
 		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
 		log.Debug(msg)
 		return nil, "", errors.New(msg.Message)
 	}
-	return a.stateStores[storeName], storeName, nil
+	return store, storeName, nil
 }
 
 func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
@@ -621,7 +729,11 @@ func (a *api) onGetState(reqCtx *fasthttp.RequestCtx) {
 		Metadata: metadata,
 	}
 
-	resp, err := store.Get(&req)
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return store.Get(&req)
+	})
+	resp, _ := res.(*state.GetResponse)
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_GET", fmt.Sprintf(messages.ErrStateGet, key, storeName, err.Error()))
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
@@ -696,7 +808,10 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 		req.ETag = &etag
 	}
 
-	err = store.Delete(&req)
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	_, err = policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(store.Delete(&req))
+	})
 	if err != nil {
 		statusCode, errMsg, resp := a.stateErrorResponse(err, "ERR_STATE_DELETE")
 		resp.Message = fmt.Sprintf(messages.ErrStateDelete, key, errMsg)
@@ -708,6 +823,256 @@ func (a *api) onDeleteState(reqCtx *fasthttp.RequestCtx) {
 	respond(reqCtx, withEmpty())
 }
 
+func (a *api) getLockStoreWithRequestValidation(reqCtx *fasthttp.RequestCtx) (lock.Store, string, error) {
+	if a.compStore.LockStoresLen() == 0 {
+		msg := NewErrorResponse("ERR_LOCK_STORE_NOT_CONFIGURED", messages.ErrLockStoresNotConfigured)
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return nil, "", errors.New(msg.Message)
+	}
+
+	storeName := reqCtx.UserValue(storeNameParam).(string)
+
+	store, _ := a.compStore.GetLockStore(storeName)
+	if store == nil {
+		msg := NewErrorResponse("ERR_LOCK_STORE_NOT_FOUND", fmt.Sprintf(messages.ErrLockStoreNotFound, storeName))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return nil, "", errors.New(msg.Message)
+	}
+	return store, storeName, nil
+}
+
+func (a *api) onTryLock(reqCtx *fasthttp.RequestCtx) {
+	store, storeName, err := a.getLockStoreWithRequestValidation(reqCtx)
+	if err != nil {
+		log.Debug(err)
+		return
+	}
+
+	req := lock.TryLockRequest{}
+	err = a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	var validationErr string
+	switch {
+	case req.ResourceID == "":
+		validationErr = fmt.Sprintf(messages.ErrResourceIDEmpty, storeName)
+	case req.LockOwner == "":
+		validationErr = fmt.Sprintf(messages.ErrLockOwnerEmpty, storeName)
+	case req.ExpiryInSeconds <= 0:
+		validationErr = fmt.Sprintf(messages.ErrExpiryInSecondsNotPositive, storeName)
+	}
+	if validationErr != "" {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", validationErr)
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	req.ResourceID, err = lock_loader.GetModifiedLockKey(req.ResourceID, storeName, a.id)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(err)
+		return
+	}
+
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		resp, err := store.TryLock(ctx, &req)
+		// A failed attempt may still have acquired the lock, so acquiring is never retried.
+		return resp, backoff.Permanent(err)
+	})
+	resp, _ := res.(*lock.TryLockResponse)
+	if err != nil {
+		msg := NewErrorResponse("ERR_TRY_LOCK", fmt.Sprintf(messages.ErrTryLockFailed, storeName, err))
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+
+	b, _ := a.json.Marshal(resp)
+	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+}
+
+func (a *api) onUnlock(reqCtx *fasthttp.RequestCtx) {
+	store, storeName, err := a.getLockStoreWithRequestValidation(reqCtx)
+	if err != nil {
+		log.Debug(err)
+		return
+	}
+
+	req := lock.UnlockRequest{}
+	err = a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	var validationErr string
+	switch {
+	case req.ResourceID == "":
+		validationErr = fmt.Sprintf(messages.ErrResourceIDEmpty, storeName)
+	case req.LockOwner == "":
+		validationErr = fmt.Sprintf(messages.ErrLockOwnerEmpty, storeName)
+	}
+	if validationErr != "" {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", validationErr)
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	req.ResourceID, err = lock_loader.GetModifiedLockKey(req.ResourceID, storeName, a.id)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(err)
+		return
+	}
+
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return store.Unlock(ctx, &req)
+	})
+	resp, _ := res.(*lock.UnlockResponse)
+	if err != nil {
+		msg := NewErrorResponse("ERR_UNLOCK", fmt.Sprintf(messages.ErrUnlockFailed, storeName, err))
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+
+	b, _ := a.json.Marshal(resp)
+	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+}
+
+func (a *api) onStartWorkflow(reqCtx *fasthttp.RequestCtx) {
+	client := a.getWorkflowClient(reqCtx)
+	if client == nil {
+		return
+	}
+	instanceID := reqCtx.UserValue(instanceIDParam).(string)
+
+	req := startWorkflowRequest{}
+	err := a.json.Unmarshal(reqCtx.PostBody(), &req)
+	if err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	workflowState, err := client.Start(reqCtx, instanceID, req.Definition, req.Input)
+	if err != nil {
+		a.respondWorkflowError(reqCtx, instanceID, "ERR_WORKFLOW_START", fmt.Sprintf(messages.ErrWorkflowStart, instanceID, err), err)
+		return
+	}
+
+	b, _ := a.json.Marshal(workflowState)
+	respond(reqCtx, withJSON(fasthttp.StatusAccepted, b))
+}
+
+func (a *api) onGetWorkflow(reqCtx *fasthttp.RequestCtx) {
+	client := a.getWorkflowClient(reqCtx)
+	if client == nil {
+		return
+	}
+	instanceID := reqCtx.UserValue(instanceIDParam).(string)
+
+	workflowState, err := client.Get(reqCtx, instanceID)
+	if err != nil {
+		a.respondWorkflowError(reqCtx, instanceID, "ERR_WORKFLOW_GET", fmt.Sprintf(messages.ErrWorkflowGet, instanceID, err), err)
+		return
+	}
+
+	b, _ := a.json.Marshal(workflowState)
+	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+}
+
+func (a *api) onTerminateWorkflow(reqCtx *fasthttp.RequestCtx) {
+	client := a.getWorkflowClient(reqCtx)
+	if client == nil {
+		return
+	}
+	instanceID := reqCtx.UserValue(instanceIDParam).(string)
+
+	req := terminateWorkflowRequest{}
+	if body := reqCtx.PostBody(); len(body) > 0 {
+		err := a.json.Unmarshal(body, &req)
+		if err != nil {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err))
+			respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+			log.Debug(msg)
+			return
+		}
+	}
+
+	workflowState, err := client.Terminate(reqCtx, instanceID, req.Reason)
+	if err != nil {
+		a.respondWorkflowError(reqCtx, instanceID, "ERR_WORKFLOW_TERMINATE", fmt.Sprintf(messages.ErrWorkflowTerminate, instanceID, err), err)
+		return
+	}
+
+	b, _ := a.json.Marshal(workflowState)
+	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+}
+
+func (a *api) onRaiseWorkflowEvent(reqCtx *fasthttp.RequestCtx) {
+	client := a.getWorkflowClient(reqCtx)
+	if client == nil {
+		return
+	}
+	instanceID := reqCtx.UserValue(instanceIDParam).(string)
+	eventName := reqCtx.UserValue(eventNameParam).(string)
+
+	_, err := client.RaiseEvent(reqCtx, instanceID, eventName, reqCtx.PostBody())
+	if err != nil {
+		a.respondWorkflowError(reqCtx, instanceID, "ERR_WORKFLOW_RAISE_EVENT", fmt.Sprintf(messages.ErrWorkflowRaiseEvent, eventName, instanceID, err), err)
+		return
+	}
+
+	respond(reqCtx, with(fasthttp.StatusAccepted, nil))
+}
+
+func (a *api) getWorkflowClient(reqCtx *fasthttp.RequestCtx) *workflows.Client {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", messages.ErrActorRuntimeNotFound)
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return nil
+	}
+	return workflows.NewClient(a.actor, a.id)
+}
+
+func (a *api) respondWorkflowError(reqCtx *fasthttp.RequestCtx, instanceID, errorCode, message string, err error) {
+	var msg ErrorResponse
+	code := fasthttp.StatusInternalServerError
+	switch {
+	case errors.Is(err, workflows.ErrInvalidRequest):
+		msg = NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err))
+		code = fasthttp.StatusBadRequest
+	case errors.Is(err, workflows.ErrInstanceNotFound):
+		msg = NewErrorResponse("ERR_WORKFLOW_INSTANCE_NOT_FOUND", fmt.Sprintf(messages.ErrWorkflowInstanceNotFound, instanceID))
+		code = fasthttp.StatusNotFound
+	case errors.Is(err, workflows.ErrInstanceExists):
+		msg = NewErrorResponse("ERR_WORKFLOW_INSTANCE_EXISTS", fmt.Sprintf(messages.ErrWorkflowInstanceExists, instanceID))
+		code = fasthttp.StatusConflict
+	default:
+		msg = NewErrorResponse(errorCode, message)
+	}
+	respond(reqCtx, withError(code, msg))
+	log.Debug(msg)
+}
+
 func (a *api) onGetSecret(reqCtx *fasthttp.RequestCtx) {
 	store, secretStoreName, err := a.getSecretStoreWithRequestValidation(reqCtx)
 	if err != nil {
@@ -789,7 +1154,7 @@ func (a *api) onBulkGetSecret(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) getSecretStoreWithRequestValidation(reqCtx *fasthttp.RequestCtx) (secretstores.SecretStore, string, error) {
-	if a.secretStores == nil || len(a.secretStores) == 0 {
+	if a.compStore.SecretStoresLen() == 0 {
 		msg := NewErrorResponse("ERR_SECRET_STORES_NOT_CONFIGURED", messages.ErrSecretStoreNotConfigured)
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
 		return nil, "", errors.New(msg.Message)
@@ -797,12 +1162,13 @@ func (a *api) getSecretStoreWithRequestValidation(reqCtx *fasthttp.RequestCtx) (
 
 	secretStoreName := reqCtx.UserValue(secretStoreNameParam).(string)
 
-	if a.secretStores[secretStoreName] == nil {
+	store, _ := a.compStore.GetSecretStore(secretStoreName)
+	if store == nil {
 		msg := NewErrorResponse("ERR_SECRET_STORE_NOT_FOUND", fmt.Sprintf(messages.ErrSecretStoreNotFound, secretStoreName))
 		respond(reqCtx, withError(fasthttp.StatusUnauthorized, msg))
 		return nil, "", errors.New(msg.Message)
 	}
-	return a.secretStores[secretStoreName], secretStoreName, nil
+	return store, secretStoreName, nil
 }
 
 func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
@@ -861,7 +1227,10 @@ func (a *api) onPostState(reqCtx *fasthttp.RequestCtx) {
 		}
 	}
 
-	err = store.BulkSet(reqs)
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	_, err = policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(store.BulkSet(reqs))
+	})
 	if err != nil {
 		storeName := a.getStateStoreName(reqCtx)
 
@@ -894,6 +1263,17 @@ func (a *api) stateErrorResponse(err error, errorCode string) (int, string, Erro
 	return fasthttp.StatusInternalServerError, message, r
 }
 
+// requestContext returns a plain context carrying the trace span of the request. The
+// *fasthttp.RequestCtx is recycled once the handler returns, so it must not be handed to
+// operations that may keep running after a resiliency timeout.
+func requestContext(reqCtx *fasthttp.RequestCtx) context.Context {
+	ctx := context.Background()
+	if span := diag_utils.SpanFromContext(reqCtx); span != nil {
+		ctx = trace.NewContext(ctx, span)
+	}
+	return ctx
+}
+
 // etagError checks if the error from the state store is an etag error and returns a bool for indication,
 // an status code and an error message.
 func (a *api) etagError(err error) (bool, int, string) {
@@ -932,9 +1312,30 @@ func (a *api) onDirectMessage(reqCtx *fasthttp.RequestCtx) {
 		return
 	}
 
+	// Calls made through the HTTP API are always made by the app itself, the
+	// caller id header is set by the app and can't be trusted.
+	release, err := a.rateLimiter.Allow(a.id, invokeMethodName)
+	if err != nil {
+		retryAfter, _ := ratelimit.RetryAfterFromError(err)
+		msg := NewErrorResponse("ERR_DIRECT_INVOKE_RATE_LIMITED", fmt.Sprintf(messages.ErrDirectInvoke, targetID, err))
+		respond(reqCtx, withRetryAfter(retryAfter), withError(fasthttp.StatusTooManyRequests, msg))
+		return
+	}
+	defer func() {
+		if release != nil {
+			release()
+		}
+	}()
+
 	// Construct internal invoke method request
 	req := invokev1.NewInvokeMethodRequest(invokeMethodName).WithHTTPExtension(verb, reqCtx.QueryArgs().String())
-	req.WithRawData(reqCtx.Request.Body(), string(reqCtx.Request.Header.ContentType()))
+	if bodyStream := reqCtx.RequestBodyStream(); bodyStream != nil {
+		// The server streams request bodies. Direct messaging buffers the small
+		// ones and streams the others to the target app.
+		req.WithDataStream(bodyStream, string(reqCtx.Request.Header.ContentType()))
+	} else {
+		req.WithRawData(reqCtx.Request.Body(), string(reqCtx.Request.Header.ContentType()))
+	}
 	// Save headers to internal metadata
 	req.WithFastHTTPHeaders(&reqCtx.Request.Header)
 
@@ -944,15 +1345,39 @@ func (a *api) onDirectMessage(reqCtx *fasthttp.RequestCtx) {
 		// Allowlists policies that are applied on the callee side can return a Permission Denied error.
 		// For everything else, treat it as a gRPC transport error
 		statusCode := fasthttp.StatusInternalServerError
-		if status.Code(err) == codes.PermissionDenied {
+		var options []option
+		switch status.Code(err) {
+		case codes.PermissionDenied:
 			statusCode = invokev1.HTTPStatusFromCode(codes.PermissionDenied)
+		case codes.ResourceExhausted:
+			// Rate limit policies applied on the callee side reject calls with a retry hint.
+			statusCode = fasthttp.StatusTooManyRequests
+			if retryAfter, ok := ratelimit.RetryAfterFromError(err); ok {
+				options = append(options, withRetryAfter(retryAfter))
+			}
 		}
 		msg := NewErrorResponse("ERR_DIRECT_INVOKE", fmt.Sprintf(messages.ErrDirectInvoke, targetID, err))
-		respond(reqCtx, withError(statusCode, msg))
+		respond(reqCtx, append(options, withError(statusCode, msg))...)
 		return
 	}
 
 	invokev1.InternalMetadataToHTTPHeader(reqCtx, resp.Headers(), reqCtx.Response.Header.Set)
+	if respData := resp.DataStream(); respData != nil {
+		if resp.IsHTTPResponse() {
+			reqCtx.Response.Header.SetContentType(resp.Message().GetContentType())
+			reqCtx.Response.SetStatusCode(int(resp.Status().Code))
+			// fasthttp closes the stream once the response is written, which
+			// completes the call for the rate limits.
+			reqCtx.Response.SetBodyStream(&releaseOnClose{ReadCloser: respData, release: release}, -1)
+			release = nil
+			return
+		}
+		if err = resp.BufferDataStream(); err != nil {
+			msg := NewErrorResponse("ERR_MALFORMED_RESPONSE", err.Error())
+			respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+			return
+		}
+	}
 	contentType, body := resp.RawData()
 	reqCtx.Response.Header.SetContentType(contentType)
 
@@ -971,6 +1396,18 @@ func (a *api) onDirectMessage(reqCtx *fasthttp.RequestCtx) {
 	respond(reqCtx, with(statusCode, body))
 }
 
+// releaseOnClose calls release when the response data stream is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
+}
+
 // findTargetID tries to find ID of the target service from the following three places:
 // 1. {id} in the URL's path.
 // 2. Basic authentication, http://app-app-id:<service-id>@localhost:3500/path.
@@ -1005,6 +1442,12 @@ func (a *api) onCreateActorReminder(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	name := reqCtx.UserValue(nameParam).(string)
 
@@ -1039,6 +1482,12 @@ func (a *api) onRenameActorReminder(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	name := reqCtx.UserValue(nameParam).(string)
 
@@ -1074,6 +1523,12 @@ func (a *api) onCreateActorTimer(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	name := reqCtx.UserValue(nameParam).(string)
 
@@ -1109,6 +1564,12 @@ func (a *api) onDeleteActorReminder(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	name := reqCtx.UserValue(nameParam).(string)
 
@@ -1137,6 +1598,12 @@ func (a *api) onActorStateTransaction(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	body := reqCtx.PostBody()
 
@@ -1168,7 +1635,11 @@ func (a *api) onActorStateTransaction(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	err = a.actor.TransactionalStateOperation(reqCtx, &req)
-	if err != nil {
+	if errors.Is(err, actors.ErrInvalidStateTTL) {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", err.Error())
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+	} else if err != nil {
 		msg := NewErrorResponse("ERR_ACTOR_STATE_TRANSACTION_SAVE", fmt.Sprintf(messages.ErrActorStateTransactionSave, err))
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
 		log.Debug(msg)
@@ -1186,6 +1657,12 @@ func (a *api) onGetActorReminder(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	name := reqCtx.UserValue(nameParam).(string)
 
@@ -1211,6 +1688,45 @@ func (a *api) onGetActorReminder(reqCtx *fasthttp.RequestCtx) {
 	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
 }
 
+func (a *api) onListActorReminders(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", messages.ErrActorRuntimeNotFound)
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+
+	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+	// The actor ID is only set when listing the reminders of a single actor.
+	actorID, _ := reqCtx.UserValue(actorIDParam).(string)
+
+	resp, err := a.actor.ListReminders(reqCtx, &actors.ListRemindersRequest{
+		ActorType: actorType,
+		ActorID:   actorID,
+	})
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_REMINDER_LIST", fmt.Sprintf(messages.ErrActorReminderList, err))
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+	b, err := a.json.Marshal(resp)
+	if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_REMINDER_LIST", fmt.Sprintf(messages.ErrActorReminderList, err))
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+
+	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+}
+
 func (a *api) onDeleteActorTimer(reqCtx *fasthttp.RequestCtx) {
 	if a.actor == nil {
 		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", messages.ErrActorRuntimeNotFound)
@@ -1220,6 +1736,12 @@ func (a *api) onDeleteActorTimer(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	name := reqCtx.UserValue(nameParam).(string)
 
@@ -1247,6 +1769,12 @@ func (a *api) onDirectActorMessage(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	verb := strings.ToUpper(string(reqCtx.Method()))
 	method := reqCtx.UserValue(methodParam).(string)
@@ -1284,6 +1812,65 @@ func (a *api) onDirectActorMessage(reqCtx *fasthttp.RequestCtx) {
 	respond(reqCtx, with(statusCode, body))
 }
 
+func (a *api) onQueryActorState(reqCtx *fasthttp.RequestCtx) {
+	if a.actor == nil {
+		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", messages.ErrActorRuntimeNotFound)
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+
+	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	req := actors.QueryStateRequest{
+		ActorType: actorType,
+		Metadata:  getMetadataFromRequest(reqCtx),
+	}
+	if err := a.json.Unmarshal(reqCtx.PostBody(), &req.Query); err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err.Error()))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
+
+	resp, err := a.actor.QueryState(reqCtx, &req)
+	if errors.Is(err, actors.ErrActorStateQueryNotSupported) {
+		msg := NewErrorResponse("ERR_METHOD_NOT_FOUND", fmt.Sprintf(messages.ErrNotFound, "Query"))
+		respond(reqCtx, withError(fasthttp.StatusNotFound, msg))
+		log.Debug(msg)
+		return
+	} else if err != nil {
+		msg := NewErrorResponse("ERR_ACTOR_STATE_QUERY", fmt.Sprintf(messages.ErrActorStateQuery, err))
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		log.Debug(msg)
+		return
+	}
+	if resp == nil || len(resp.Results) == 0 {
+		respond(reqCtx, withEmpty())
+		return
+	}
+
+	qresp := QueryActorStateResponse{
+		Results:  make([]QueryActorStateItem, len(resp.Results)),
+		Token:    resp.Token,
+		Metadata: resp.Metadata,
+	}
+	for i := range resp.Results {
+		qresp.Results[i].ActorID = resp.Results[i].ActorID
+		qresp.Results[i].Key = resp.Results[i].Key
+		qresp.Results[i].Data = jsoniter.RawMessage(resp.Results[i].Data)
+	}
+
+	b, _ := a.json.Marshal(qresp)
+	respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+}
+
 func (a *api) onGetActorState(reqCtx *fasthttp.RequestCtx) {
 	if a.actor == nil {
 		msg := NewErrorResponse("ERR_ACTOR_RUNTIME_NOT_FOUND", messages.ErrActorRuntimeNotFound)
@@ -1293,6 +1880,12 @@ func (a *api) onGetActorState(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	actorType := reqCtx.UserValue(actorTypeParam).(string)
+	if actors.IsInternalActor(actorType) {
+		msg := NewErrorResponse("ERR_ACTOR_TYPE_RESERVED", fmt.Sprintf(messages.ErrActorTypeReserved, actorType))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+		return
+	}
 	actorID := reqCtx.UserValue(actorIDParam).(string)
 	key := reqCtx.UserValue(stateKeyParam).(string)
 
@@ -1361,6 +1954,9 @@ func (a *api) onGetMetadata(reqCtx *fasthttp.RequestCtx) {
 		Extended:             temp,
 		RegisteredComponents: registeredComponents,
 	}
+	if a.getComponentUpdatesFn != nil {
+		mtd.ComponentUpdates = a.getComponentUpdatesFn()
+	}
 
 	mtdBytes, err := a.json.Marshal(mtd)
 	if err != nil {
@@ -1512,6 +2108,208 @@ func (a *api) onPublish(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+func (a *api) onBulkPublish(reqCtx *fasthttp.RequestCtx) {
+	if a.pubsubAdapter == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_CONFIGURED", messages.ErrPubsubNotConfigured)
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	pubsubName := reqCtx.UserValue(pubsubnameparam).(string)
+	if pubsubName == "" {
+		msg := NewErrorResponse("ERR_PUBSUB_EMPTY", messages.ErrPubsubEmpty)
+		respond(reqCtx, withError(fasthttp.StatusNotFound, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	thepubsub := a.pubsubAdapter.GetPubSub(pubsubName)
+	if thepubsub == nil {
+		msg := NewErrorResponse("ERR_PUBSUB_NOT_FOUND", fmt.Sprintf(messages.ErrPubsubNotFound, pubsubName))
+		respond(reqCtx, withError(fasthttp.StatusNotFound, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	topic := reqCtx.UserValue(topicParam).(string)
+	if topic == "" {
+		msg := NewErrorResponse("ERR_TOPIC_EMPTY", fmt.Sprintf(messages.ErrTopicEmpty, pubsubName))
+		respond(reqCtx, withError(fasthttp.StatusNotFound, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	var entries []BulkPublishRequestEntry
+	if err := a.json.Unmarshal(reqCtx.PostBody(), &entries); err != nil {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrMalformedRequest, err))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	if len(entries) == 0 {
+		msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrPubsubBulkEntriesEmpty, topic, pubsubName))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	metadata := getMetadataFromRequest(reqCtx)
+
+	// Extract trace context from context.
+	span := diag_utils.SpanFromContext(reqCtx)
+	// Populate W3C traceparent to cloudevent envelope
+	corID := diag.SpanContextToW3CString(span.SpanContext())
+	// Populate W3C tracestate to cloudevent envelope
+	traceState := diag.TraceStateToW3CString(span.SpanContext())
+
+	features := thepubsub.Features()
+	req := runtime_pubsub.BulkPublishRequest{
+		PubsubName: pubsubName,
+		Topic:      topic,
+		Entries:    make([]runtime_pubsub.BulkPublishEntry, len(entries)),
+		Metadata:   metadata,
+	}
+	entryIDs := make(map[string]struct{}, len(entries))
+	for i, entry := range entries {
+		if entry.EntryID == "" {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrPubsubBulkEntryIDEmpty, topic, pubsubName))
+			respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+			log.Debug(msg)
+
+			return
+		}
+		if _, ok := entryIDs[entry.EntryID]; ok {
+			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf(messages.ErrPubsubBulkEntryIDDup, entry.EntryID, topic, pubsubName))
+			respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+			log.Debug(msg)
+
+			return
+		}
+		entryIDs[entry.EntryID] = struct{}{}
+
+		entryMetadata := metadata
+		if len(entry.Metadata) > 0 {
+			entryMetadata = make(map[string]string, len(metadata)+len(entry.Metadata))
+			for k, v := range metadata {
+				entryMetadata[k] = v
+			}
+			for k, v := range entry.Metadata {
+				entryMetadata[k] = v
+			}
+		}
+
+		rawPayload, metaErr := svc_metadata.IsRawPayload(entryMetadata)
+		if metaErr != nil {
+			msg := NewErrorResponse("ERR_PUBSUB_REQUEST_METADATA",
+				fmt.Sprintf(messages.ErrMetadataGet, metaErr.Error()))
+			respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+			log.Debug(msg)
+
+			return
+		}
+
+		data := []byte(entry.Event)
+		// String events are sent as JSON strings, so the quotes are not part of the payload.
+		var s string
+		if contenttype.IsStringContentType(entry.ContentType) && a.json.Unmarshal(entry.Event, &s) == nil {
+			data = []byte(s)
+		}
+
+		if !rawPayload {
+			envelope, err := runtime_pubsub.NewCloudEvent(&runtime_pubsub.CloudEvent{
+				ID:              a.id,
+				Topic:           topic,
+				DataContentType: entry.ContentType,
+				Data:            data,
+				TraceID:         corID,
+				TraceState:      traceState,
+				Pubsub:          pubsubName,
+			})
+			if err != nil {
+				msg := NewErrorResponse("ERR_PUBSUB_CLOUD_EVENTS_SER",
+					fmt.Sprintf(messages.ErrPubsubCloudEventCreation, err.Error()))
+				respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+				log.Debug(msg)
+
+				return
+			}
+
+			pubsub.ApplyMetadata(envelope, features, entryMetadata)
+
+			data, err = a.json.Marshal(envelope)
+			if err != nil {
+				msg := NewErrorResponse("ERR_PUBSUB_CLOUD_EVENTS_SER",
+					fmt.Sprintf(messages.ErrPubsubCloudEventsSer, topic, pubsubName, err.Error()))
+				respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+				log.Debug(msg)
+
+				return
+			}
+		}
+
+		req.Entries[i] = runtime_pubsub.BulkPublishEntry{
+			EntryID:  entry.EntryID,
+			Data:     data,
+			Metadata: entry.Metadata,
+		}
+	}
+
+	res, err := a.pubsubAdapter.BulkPublish(&req)
+	if err != nil {
+		status := fasthttp.StatusInternalServerError
+		msg := NewErrorResponse("ERR_PUBSUB_PUBLISH_MESSAGE",
+			fmt.Sprintf(messages.ErrPubsubPublishMessage, topic, pubsubName, err.Error()))
+
+		if errors.As(err, &runtime_pubsub.NotAllowedError{}) {
+			msg = NewErrorResponse("ERR_PUBSUB_FORBIDDEN", err.Error())
+			status = fasthttp.StatusForbidden
+		}
+
+		if errors.As(err, &runtime_pubsub.NotFoundError{}) {
+			msg = NewErrorResponse("ERR_PUBSUB_NOT_FOUND", err.Error())
+			status = fasthttp.StatusBadRequest
+		}
+
+		respond(reqCtx, withError(status, msg))
+		log.Debug(msg)
+
+		return
+	}
+
+	resp := BulkPublishResponse{
+		Statuses: make([]BulkPublishResponseEntry, len(res.Statuses)),
+	}
+	failed := 0
+	for i, s := range res.Statuses {
+		resp.Statuses[i] = BulkPublishResponseEntry{
+			EntryID: s.EntryID,
+			Status:  string(s.Status),
+		}
+		if s.Error != nil {
+			failed++
+			resp.Statuses[i].Error = s.Error.Error()
+		}
+	}
+	if failed > 0 {
+		log.Debugf(messages.ErrPubsubBulkPublishMessage, topic, pubsubName, failed, len(res.Statuses))
+	}
+
+	b, _ := a.json.Marshal(resp)
+	if failed > 0 {
+		respond(reqCtx, withJSON(fasthttp.StatusInternalServerError, b))
+	} else {
+		respond(reqCtx, withJSON(fasthttp.StatusOK, b))
+	}
+}
+
 // GetStatusCodeFromMetadata extracts the http status code from the metadata if it exists.
 func GetStatusCodeFromMetadata(metadata map[string]string) int {
 	code := metadata[http.HTTPStatusCode]
@@ -1559,7 +2357,7 @@ func getMetadataFromRequest(reqCtx *fasthttp.RequestCtx) map[string]string {
 }
 
 func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
-	if a.stateStores == nil || len(a.stateStores) == 0 {
+	if a.compStore.StateStoresLen() == 0 {
 		msg := NewErrorResponse("ERR_STATE_STORES_NOT_CONFIGURED", messages.ErrStateStoresNotConfigured)
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
 		log.Debug(msg)
@@ -1567,7 +2365,7 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 	}
 
 	storeName := reqCtx.UserValue(storeNameParam).(string)
-	_, ok := a.stateStores[storeName]
+	store, ok := a.compStore.GetStateStore(storeName)
 	if !ok {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_FOUND", fmt.Sprintf(messages.ErrStateStoreNotFound, storeName))
 		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
@@ -1575,8 +2373,8 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 		return
 	}
 
-	transactionalStore, ok := a.transactionalStateStores[storeName]
-	if !ok {
+	transactionalStore, ok := store.(state.TransactionalStore)
+	if !ok || !state.FeatureTransactional.IsPresent(store.Features()) {
 		msg := NewErrorResponse("ERR_STATE_STORE_NOT_SUPPORTED", fmt.Sprintf(messages.ErrStateStoreNotSupported, storeName))
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
 		log.Debug(msg)
@@ -1682,9 +2480,12 @@ func (a *api) onPostStateTransaction(reqCtx *fasthttp.RequestCtx) {
 		}
 	}
 
-	err := transactionalStore.Multi(&state.TransactionalStateRequest{
-		Operations: operations,
-		Metadata:   req.Metadata,
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	_, err := policy(func(ctx context.Context) (interface{}, error) {
+		return nil, state_loader.PermanentError(transactionalStore.Multi(&state.TransactionalStateRequest{
+			Operations: operations,
+			Metadata:   req.Metadata,
+		}))
 	})
 
 	if err != nil {
@@ -1727,7 +2528,11 @@ func (a *api) onQueryState(reqCtx *fasthttp.RequestCtx) {
 	}
 	req.Metadata = getMetadataFromRequest(reqCtx)
 
-	resp, err := querier.Query(&req)
+	policy := a.resiliency.ComponentOutboundPolicy(requestContext(reqCtx), storeName)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return querier.Query(&req)
+	})
+	resp, _ := res.(*state.QueryResponse)
 	if err != nil {
 		msg := NewErrorResponse("ERR_STATE_QUERY", fmt.Sprintf(messages.ErrStateQuery, storeName, err.Error()))
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
@@ -1756,7 +2561,7 @@ func (a *api) onQueryState(reqCtx *fasthttp.RequestCtx) {
 }
 
 func (a *api) isSecretAllowed(storeName, key string) bool {
-	if config, ok := a.secretsConfiguration[storeName]; ok {
+	if config, ok := a.compStore.GetSecretsConfiguration(storeName); ok {
 		return config.IsSecretAllowed(key)
 	}
 	// By default, if a configuration is not defined for a secret store, return true.
@@ -40,6 +40,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/fasthttputil"
 	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -52,26 +53,264 @@ import (
 	"github.com/bhojpur/service/pkg/bindings"
 	"github.com/bhojpur/service/pkg/middleware"
 	"github.com/bhojpur/service/pkg/pubsub"
-	"github.com/bhojpur/service/pkg/secretstores"
 	"github.com/bhojpur/service/pkg/state"
 	"github.com/bhojpur/service/pkg/utils/logger"
 
 	"github.com/bhojpur/application/pkg/actors"
+	"github.com/bhojpur/application/pkg/apitoken"
 	"github.com/bhojpur/application/pkg/channel/http"
+	"github.com/bhojpur/application/pkg/components"
 	http_middleware_loader "github.com/bhojpur/application/pkg/components/middleware/http"
 	"github.com/bhojpur/application/pkg/config"
 	diag "github.com/bhojpur/application/pkg/diagnostics"
 	"github.com/bhojpur/application/pkg/encryption"
 	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	"github.com/bhojpur/application/pkg/lock"
+	lock_inmemory "github.com/bhojpur/application/pkg/lock/inmemory"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
 	http_middleware "github.com/bhojpur/application/pkg/middleware/http"
+	"github.com/bhojpur/application/pkg/ratelimit"
+	"github.com/bhojpur/application/pkg/runtime/compstore"
 	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
+	auth "github.com/bhojpur/application/pkg/runtime/security"
 	appt "github.com/bhojpur/application/pkg/testing"
 	testtrace "github.com/bhojpur/application/pkg/testing/trace"
 )
 
 var invalidJSON = []byte{0x7b, 0x7b}
 
+func TestLockEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	compStore := compstore.New()
+	compStore.AddLockStore("lockstore", lock_inmemory.NewInMemoryLock())
+	testAPI := &api{
+		id:        "fakeAPI",
+		compStore: compStore,
+		json:      jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructLockEndpoints())
+
+	lockPath := fmt.Sprintf("%s/lock/lockstore", apiVersionV1alpha1)
+	unlockPath := fmt.Sprintf("%s/unlock/lockstore", apiVersionV1alpha1)
+
+	t.Run("Lock is acquired by one owner at a time - 200", func(t *testing.T) {
+		// act
+		resp := fakeServer.DoRequest("POST", lockPath, []byte(`{"resourceId": "res1", "lockOwner": "owner1", "expiryInSeconds": 10}`), nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"success":true}`, string(resp.RawBody))
+
+		// act
+		resp = fakeServer.DoRequest("POST", lockPath, []byte(`{"resourceId": "res1", "lockOwner": "owner2", "expiryInSeconds": 10}`), nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `{"success":false}`, string(resp.RawBody))
+	})
+
+	t.Run("Lock is released by its owner only - 200", func(t *testing.T) {
+		// act
+		resp := fakeServer.DoRequest("POST", unlockPath, []byte(`{"resourceId": "res1", "lockOwner": "owner2"}`), nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, fmt.Sprintf(`{"status":%d}`, lock.LockBelongsToOthers), string(resp.RawBody))
+
+		// act
+		resp = fakeServer.DoRequest("POST", unlockPath, []byte(`{"resourceId": "res1", "lockOwner": "owner1"}`), nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, fmt.Sprintf(`{"status":%d}`, lock.Success), string(resp.RawBody))
+
+		// act
+		resp = fakeServer.DoRequest("POST", unlockPath, []byte(`{"resourceId": "res1", "lockOwner": "owner1"}`), nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, fmt.Sprintf(`{"status":%d}`, lock.LockDoesNotExist), string(resp.RawBody))
+	})
+
+	t.Run("Lock with invalid request - 400", func(t *testing.T) {
+		bodies := []string{
+			`{"lockOwner": "owner1", "expiryInSeconds": 10}`,
+			`{"resourceId": "res1", "expiryInSeconds": 10}`,
+			`{"resourceId": "res1", "lockOwner": "owner1"}`,
+			`{"resourceId": "a||b", "lockOwner": "owner1", "expiryInSeconds": 10}`,
+			`not json`,
+		}
+		for _, body := range bodies {
+			// act
+			resp := fakeServer.DoRequest("POST", lockPath, []byte(body), nil)
+			// assert
+			assert.Equal(t, 400, resp.StatusCode, body)
+			assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"], body)
+		}
+	})
+
+	t.Run("Unlock with invalid request - 400", func(t *testing.T) {
+		// act
+		resp := fakeServer.DoRequest("POST", unlockPath, []byte(`{"resourceId": "res1"}`), nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Lock store not found - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/lock/nostore", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"resourceId": "res1", "lockOwner": "owner1", "expiryInSeconds": 10}`), nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_LOCK_STORE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Lock store not configured - 500", func(t *testing.T) {
+		testAPI.compStore = compstore.New()
+		defer func() { testAPI.compStore = compStore }()
+		// act
+		resp := fakeServer.DoRequest("POST", lockPath, []byte(`{"resourceId": "res1", "lockOwner": "owner1", "expiryInSeconds": 10}`), nil)
+		// assert
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_LOCK_STORE_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
+func TestWorkflowEndpoints(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		id:   "fakeAPI",
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructWorkflowEndpoints())
+
+	actorResponse := func(body string) *invokev1.InvokeMethodResponse {
+		resp := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+		resp.WithRawData([]byte(body), "application/json")
+		return resp
+	}
+	callTo := func(method string) interface{} {
+		return mock.MatchedBy(func(req *invokev1.InvokeMethodRequest) bool {
+			return req.Message().Method == method && req.Actor().GetActorId() == "wf-1"
+		})
+	}
+	startBody := []byte(`{"definition": {"name": "wf", "steps": [{"name": "sleep", "timer": {"duration": "1m"}}]}, "input": {"a": 1}}`)
+	stateBody := `{"state":{"instanceId":"wf-1","workflowName":"wf","runtimeStatus":"RUNNING"}}`
+
+	t.Run("Actor runtime is not initialized - 500", func(t *testing.T) {
+		// act
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/workflows/wf-1", apiVersionV1alpha1), nil, nil)
+		// assert
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_ACTOR_RUNTIME_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Start workflow - 202", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("Start")).Return(actorResponse(stateBody), nil)
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/workflows/wf-1/start", apiVersionV1alpha1), startBody, nil)
+		// assert
+		assert.Equal(t, 202, resp.StatusCode)
+		assert.Contains(t, string(resp.RawBody), `"runtimeStatus":"RUNNING"`)
+		mockActors.AssertNumberOfCalls(t, "Call", 1)
+	})
+
+	t.Run("Start workflow with invalid definition - 400", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		testAPI.actor = mockActors
+		bodies := []string{
+			`{"definition": {"name": "wf"}}`,
+			`{"input": 1}`,
+			`not json`,
+		}
+		for _, body := range bodies {
+			// act
+			resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/workflows/wf-1/start", apiVersionV1alpha1), []byte(body), nil)
+			// assert
+			assert.Equal(t, 400, resp.StatusCode, body)
+			assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"], body)
+		}
+		mockActors.AssertNumberOfCalls(t, "Call", 0)
+	})
+
+	t.Run("Start existing workflow - 409", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("Start")).Return(actorResponse(`{"code":"AlreadyExists"}`), nil)
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/workflows/wf-1/start", apiVersionV1alpha1), startBody, nil)
+		// assert
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_INSTANCE_EXISTS", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Get workflow - 200", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("GetStatus")).Return(actorResponse(stateBody), nil)
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/workflows/wf-1", apiVersionV1alpha1), nil, nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Contains(t, string(resp.RawBody), `"instanceId":"wf-1"`)
+	})
+
+	t.Run("Get unknown workflow - 404", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("GetStatus")).Return(actorResponse(`{"code":"NotFound"}`), nil)
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("GET", fmt.Sprintf("%s/workflows/wf-1", apiVersionV1alpha1), nil, nil)
+		// assert
+		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_INSTANCE_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Terminate workflow - 200", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("Terminate")).Return(actorResponse(stateBody), nil)
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/workflows/wf-1/terminate", apiVersionV1alpha1), []byte(`{"reason": "test"}`), nil)
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		_, data := mockActors.Calls[0].Arguments.Get(0).(*invokev1.InvokeMethodRequest).RawData()
+		assert.JSONEq(t, `{"reason":"test"}`, string(data))
+	})
+
+	t.Run("Raise event - 202", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("RaiseEvent")).Return(actorResponse(stateBody), nil)
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/workflows/wf-1/raiseEvent/approved", apiVersionV1alpha1), []byte(`{"by": "alice"}`), nil)
+		// assert
+		assert.Equal(t, 202, resp.StatusCode)
+		_, data := mockActors.Calls[0].Arguments.Get(0).(*invokev1.InvokeMethodRequest).RawData()
+		assert.JSONEq(t, `{"name":"approved","data":{"by":"alice"}}`, string(data))
+	})
+
+	t.Run("Actor call fails - 500", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		mockActors.On("Call", callTo("RaiseEvent")).Return(nil, errors.New("placement not ready"))
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", fmt.Sprintf("%s/workflows/wf-1/raiseEvent/approved", apiVersionV1alpha1), nil, nil)
+		// assert
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_WORKFLOW_RAISE_EVENT", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
 func TestPubSubEndpoints(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 	testAPI := &api{
@@ -234,6 +473,141 @@ func TestPubSubEndpoints(t *testing.T) {
 	fakeServer.Shutdown()
 }
 
+func TestBulkPubSubEndpoint(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	var published *runtime_pubsub.BulkPublishRequest
+	testAPI := &api{
+		pubsubAdapter: &appt.MockPubSubAdapter{
+			BulkPublishFn: func(req *runtime_pubsub.BulkPublishRequest) (runtime_pubsub.BulkPublishResponse, error) {
+				if req.PubsubName == "errnotfound" {
+					return runtime_pubsub.BulkPublishResponse{}, runtime_pubsub.NotFoundError{PubsubName: "errnotfound"}
+				}
+
+				if req.PubsubName == "errnotallowed" {
+					return runtime_pubsub.BulkPublishResponse{}, runtime_pubsub.NotAllowedError{Topic: req.Topic, ID: "test"}
+				}
+
+				published = req
+				res := runtime_pubsub.BulkPublishResponse{}
+				for _, entry := range req.Entries {
+					status := runtime_pubsub.BulkPublishResponseEntry{EntryID: entry.EntryID, Status: runtime_pubsub.PublishSucceeded}
+					if req.PubsubName == "errorpubsub" && entry.EntryID == "2" {
+						status.Status = runtime_pubsub.PublishFailed
+						status.Error = fmt.Errorf("Error from pubsub %s", req.PubsubName)
+					}
+					res.Statuses = append(res.Statuses, status)
+				}
+				return res, nil
+			},
+			GetPubSubFn: func(pubsubName string) pubsub.PubSub {
+				return &appt.MockPubSub{}
+			},
+		},
+		json: jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructPubSubEndpoints())
+
+	body := []byte(`[
+		{"entryId": "1", "event": {"key": "value"}, "contentType": "application/json"},
+		{"entryId": "2", "event": "text", "contentType": "text/plain", "metadata": {"rawPayload": "true"}}
+	]`)
+
+	t.Run("Bulk publish successfully - 200 OK", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/pubsubname/topic", apiVersionV1alpha1)
+		testMethods := []string{"POST", "PUT"}
+		for _, method := range testMethods {
+			// act
+			resp := fakeServer.DoRequest(method, apiPath, body, nil)
+			// assert
+			assert.Equal(t, 200, resp.StatusCode, "failed to bulk publish with %s", method)
+			var res BulkPublishResponse
+			assert.NoError(t, json.Unmarshal(resp.RawBody, &res))
+			assert.Len(t, res.Statuses, 2)
+			for _, s := range res.Statuses {
+				assert.Equal(t, string(runtime_pubsub.PublishSucceeded), s.Status)
+				assert.Empty(t, s.Error)
+			}
+		}
+
+		require.Len(t, published.Entries, 2)
+		// Entries are wrapped in a cloud event unless the raw payload is requested.
+		var envelope map[string]interface{}
+		assert.NoError(t, json.Unmarshal(published.Entries[0].Data, &envelope))
+		assert.Equal(t, "topic", envelope["topic"])
+		assert.Equal(t, []byte("text"), published.Entries[1].Data)
+	})
+
+	t.Run("Bulk publish partially failed - 500 with statuses", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/errorpubsub/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		// assert
+		assert.Equal(t, 500, resp.StatusCode)
+		var res BulkPublishResponse
+		assert.NoError(t, json.Unmarshal(resp.RawBody, &res))
+		require.Len(t, res.Statuses, 2)
+		assert.Equal(t, string(runtime_pubsub.PublishSucceeded), res.Statuses[0].Status)
+		assert.Equal(t, string(runtime_pubsub.PublishFailed), res.Statuses[1].Status)
+		assert.NotEmpty(t, res.Statuses[1].Error)
+	})
+
+	t.Run("Bulk publish without entries - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/pubsubname/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("[]"), nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Bulk publish with missing entry id - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/pubsubname/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`[{"event": "text"}]`), nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Bulk publish with duplicate entry ids - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/pubsubname/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`[{"entryId": "1", "event": "a"}, {"entryId": "1", "event": "b"}]`), nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Bulk publish with malformed body - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/pubsubname/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte("{\"key\": \"value\"}"), nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Bulk publish to a topic that is not allowed - 403", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/errnotallowed/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		// assert
+		assert.Equal(t, 403, resp.StatusCode)
+		assert.Equal(t, "ERR_PUBSUB_FORBIDDEN", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Bulk publish to a pubsub that is not found - 400", func(t *testing.T) {
+		apiPath := fmt.Sprintf("%s/publish/bulk/errnotfound/topic", apiVersionV1alpha1)
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, body, nil)
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, "ERR_PUBSUB_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	fakeServer.Shutdown()
+}
+
 func TestShutdownEndpoints(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 
@@ -743,9 +1117,78 @@ func TestV1DirectMessagingEndpoints(t *testing.T) {
 		assert.Equal(t, "ERR_DIRECT_INVOKE", resp.ErrorBody["errorCode"])
 	})
 
+	t.Run("Invoke returns error - 429 ResourceExhausted", func(t *testing.T) {
+		apiPath := "v1.0/invoke/fakeAppID/method/fakeMethod"
+		fakeData := []byte("fakeData")
+
+		mockDirectMessaging.Calls = nil // reset call count
+
+		limitErr := &ratelimit.Error{CallerAppID: "caller", Method: "fakeMethod", Reason: ratelimit.ReasonRate, RetryAfter: 1200 * time.Millisecond}
+		mockDirectMessaging.On("Invoke",
+			mock.MatchedBy(func(a context.Context) bool {
+				return true
+			}), mock.MatchedBy(func(b string) bool {
+				return b == "fakeAppID"
+			}), mock.MatchedBy(func(c *invokev1.InvokeMethodRequest) bool {
+				return true
+			})).Return(nil, status.FromProto(status.Convert(limitErr).Proto()).Err()).Once()
+
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, fakeData, nil)
+
+		// assert
+		mockDirectMessaging.AssertNumberOfCalls(t, "Invoke", 1)
+		assert.Equal(t, 429, resp.StatusCode)
+		assert.Equal(t, "2", resp.RawHeader.Get("Retry-After"))
+		assert.Equal(t, "ERR_DIRECT_INVOKE", resp.ErrorBody["errorCode"])
+	})
+
 	fakeServer.Shutdown()
 }
 
+func TestV1DirectMessagingEndpointsWithRateLimit(t *testing.T) {
+	fakeDirectMessageResponse := invokev1.NewInvokeMethodResponse(200, "OK", nil)
+	fakeDirectMessageResponse.WithRawData([]byte("fakeDirectMessageResponse"), "application/json")
+
+	mockDirectMessaging := new(appt.MockDirectMessaging)
+	mockDirectMessaging.On("Invoke", mock.Anything, "fakeAppID", mock.Anything).Return(fakeDirectMessageResponse, nil)
+
+	rateLimiter, err := ratelimit.New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{
+		{CallerAppID: "self", MethodPrefix: "orders", RequestsPerSecond: 1, Burst: 1},
+	}})
+	require.NoError(t, err)
+
+	fakeServer := newFakeHTTPServer()
+	testAPI := &api{
+		id:              "self",
+		directMessaging: mockDirectMessaging,
+		rateLimiter:     rateLimiter,
+		json:            jsoniter.ConfigFastest,
+	}
+	fakeServer.StartServer(testAPI.constructDirectMessagingEndpoints())
+	defer fakeServer.Shutdown()
+
+	t.Run("calls of the app over the limit - 429", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/invoke/fakeAppID/method/orders/1", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		resp = fakeServer.DoRequest("POST", "v1.0/invoke/fakeAppID/method/orders/2", nil, nil)
+		assert.Equal(t, 429, resp.StatusCode)
+		assert.Equal(t, "1", resp.RawHeader.Get("Retry-After"))
+		assert.Equal(t, "ERR_DIRECT_INVOKE_RATE_LIMITED", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("caller id header doesn't bypass the limit", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/invoke/fakeAppID/method/orders/3", nil, nil, invokev1.CallerIDHeader, "other")
+		assert.Equal(t, 429, resp.StatusCode)
+	})
+
+	t.Run("other methods are not limited", func(t *testing.T) {
+		resp := fakeServer.DoRequest("POST", "v1.0/invoke/fakeAppID/method/payments", nil, nil)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+}
+
 func TestV1DirectMessagingEndpointsWithTracer(t *testing.T) {
 	headerMetadata := map[string][]string{
 		"Accept-Encoding":  {"gzip"},
@@ -874,6 +1317,7 @@ func TestV1ActorEndpoints(t *testing.T) {
 			"v1.0/actors/fakeActorType/fakeActorID/reminders/reminder1": {"POST", "PUT", "GET", "DELETE", "PATCH"},
 			"v1.0/actors/fakeActorType/fakeActorID/method/method1":      {"POST", "PUT", "GET", "DELETE"},
 			"v1.0/actors/fakeActorType/fakeActorID/timers/timer1":       {"POST", "PUT", "DELETE"},
+			"v1.0-alpha1/actors/fakeActorType/state/query":              {"POST", "PUT"},
 		}
 		testAPI.actor = nil
 
@@ -889,12 +1333,38 @@ func TestV1ActorEndpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Internal actor types are reserved - 400", func(t *testing.T) {
+		mockActors := new(appt.MockActors)
+		testAPI.actor = mockActors
+		apisAndMethods := map[string][]string{
+			"v1.0/actors/app.internal.workflow.fakeAPI/wf-1/state/key1":          {"GET"},
+			"v1.0/actors/app.internal.workflow.fakeAPI/wf-1/state":               {"POST", "PUT"},
+			"v1.0/actors/app.internal.workflow.fakeAPI/wf-1/reminders/reminder1": {"POST", "PUT", "GET", "DELETE", "PATCH"},
+			"v1.0/actors/app.internal.workflow.fakeAPI/wf-1/method/method1":      {"POST", "PUT", "GET", "DELETE"},
+			"v1.0/actors/app.internal.workflow.fakeAPI/wf-1/timers/timer1":       {"POST", "PUT", "DELETE"},
+			"v1.0-alpha1/actors/app.internal.workflow.fakeAPI/state/query":       {"POST", "PUT"},
+		}
+
+		for apiPath, testMethods := range apisAndMethods {
+			for _, method := range testMethods {
+				// act
+				resp := fakeServer.DoRequest(method, apiPath, fakeData, nil)
+
+				// assert
+				assert.Equal(t, 400, resp.StatusCode, apiPath)
+				assert.Equal(t, "ERR_ACTOR_TYPE_RESERVED", resp.ErrorBody["errorCode"])
+			}
+		}
+		mockActors.AssertNumberOfCalls(t, "Call", 0)
+	})
+
 	t.Run("All PUT/POST APIs - 400 for invalid JSON", func(t *testing.T) {
 		testAPI.actor = new(appt.MockActors)
 		apiPaths := []string{
 			"v1.0/actors/fakeActorType/fakeActorID/reminders/reminder1",
 			"v1.0/actors/fakeActorType/fakeActorID/state",
 			"v1.0/actors/fakeActorType/fakeActorID/timers/timer1",
+			"v1.0-alpha1/actors/fakeActorType/state/query",
 		}
 
 		for _, apiPath := range apiPaths {
@@ -1165,6 +1635,108 @@ func TestV1ActorEndpoints(t *testing.T) {
 		assert.Equal(t, "ERR_ACTOR_STATE_TRANSACTION_SAVE", resp.ErrorBody["errorCode"])
 	})
 
+	t.Run("Query - 200 OK", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/actors/fakeActorType/state/query"
+
+		mockActors := new(appt.MockActors)
+		mockActors.On("QueryState", mock.MatchedBy(func(req *actors.QueryStateRequest) bool {
+			return req.ActorType == "fakeActorType" && req.Query.Page.Limit == 2
+		})).Return(&actors.QueryStateResponse{
+			Results: []actors.QueryStateItem{
+				{ActorID: "fakeActorID", Key: "status", Data: []byte(`"pending"`)},
+			},
+			Token: "2",
+		}, nil)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{"filter":{"EQ":{"value":"pending"}},"page":{"limit":2}}`), nil)
+
+		// assert
+		assert.Equal(t, 200, resp.StatusCode)
+		var qresp QueryActorStateResponse
+		require.NoError(t, jsoniter.ConfigFastest.Unmarshal(resp.RawBody, &qresp))
+		assert.Equal(t, "2", qresp.Token)
+		require.Len(t, qresp.Results, 1)
+		assert.Equal(t, "fakeActorID", qresp.Results[0].ActorID)
+		assert.Equal(t, "status", qresp.Results[0].Key)
+		assert.Equal(t, `"pending"`, string(qresp.Results[0].Data))
+	})
+
+	t.Run("Query - 204 No Content when nothing matches", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/actors/fakeActorType/state/query"
+
+		mockActors := new(appt.MockActors)
+		mockActors.On("QueryState", mock.Anything).Return(&actors.QueryStateResponse{}, nil)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{}`), nil)
+
+		// assert
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
+	t.Run("Query - 404 when the state store can't be queried", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/actors/fakeActorType/state/query"
+
+		mockActors := new(appt.MockActors)
+		mockActors.On("QueryState", mock.Anything).Return(nil, actors.ErrActorStateQueryNotSupported)
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("POST", apiPath, []byte(`{}`), nil)
+
+		// assert
+		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, "ERR_METHOD_NOT_FOUND", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Transaction - 400 when ttlInSeconds is invalid", func(t *testing.T) {
+		apiPath := "v1.0/actors/fakeActorType/fakeActorID/state"
+
+		testTransactionalOperations := []actors.TransactionalOperation{
+			{
+				Operation: actors.Upsert,
+				Request: map[string]interface{}{
+					"key":   "fakeKey1",
+					"value": fakeBodyObject,
+					"metadata": map[string]interface{}{
+						"ttlInSeconds": "-1",
+					},
+				},
+			},
+		}
+
+		mockActors := new(appt.MockActors)
+		mockActors.On("TransactionalStateOperation", &actors.TransactionalRequest{
+			ActorID:    "fakeActorID",
+			ActorType:  "fakeActorType",
+			Operations: testTransactionalOperations,
+		}).Return(actors.ErrInvalidStateTTL)
+
+		mockActors.On("IsActorHosted", &actors.ActorHostedRequest{
+			ActorID:   "fakeActorID",
+			ActorType: "fakeActorType",
+		}).Return(true)
+
+		testAPI.actor = mockActors
+
+		// act
+		inputBodyBytes, err := json.Marshal(testTransactionalOperations)
+
+		assert.NoError(t, err)
+		resp := fakeServer.DoRequest("POST", apiPath, inputBodyBytes, nil)
+
+		// assert
+		assert.Equal(t, 400, resp.StatusCode)
+		mockActors.AssertNumberOfCalls(t, "TransactionalStateOperation", 1)
+		assert.Equal(t, "ERR_MALFORMED_REQUEST", resp.ErrorBody["errorCode"])
+	})
+
 	t.Run("Reminder Create - 204 No Content", func(t *testing.T) {
 		apiPath := "v1.0/actors/fakeActorType/fakeActorID/reminders/reminder1"
 
@@ -1277,6 +1849,59 @@ func TestV1ActorEndpoints(t *testing.T) {
 		mockActors.AssertNumberOfCalls(t, "RenameReminder", 1)
 	})
 
+	t.Run("Reminder List - 200 OK", func(t *testing.T) {
+		for apiPath, actorID := range map[string]string{
+			"v1.0-alpha1/actors/fakeActorType/fakeActorID/reminders": "fakeActorID",
+			"v1.0-alpha1/actors/fakeActorType/reminders":             "",
+		} {
+			reminderRequest := actors.ListRemindersRequest{
+				ActorType: "fakeActorType",
+				ActorID:   actorID,
+			}
+			reminders := []actors.Reminder{
+				{ActorID: "fakeActorID", ActorType: "fakeActorType", Name: "reminder1", Period: "1s", DueTime: "1s"},
+			}
+
+			mockActors := new(appt.MockActors)
+
+			mockActors.On("ListReminders", &reminderRequest).Return(reminders, nil)
+
+			testAPI.actor = mockActors
+
+			// act
+			resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+			// assert
+			assert.Equal(t, 200, resp.StatusCode)
+			var list []actors.Reminder
+			assert.NoError(t, json.Unmarshal(resp.RawBody, &list))
+			assert.Equal(t, reminders, list)
+			mockActors.AssertNumberOfCalls(t, "ListReminders", 1)
+		}
+	})
+
+	t.Run("Reminder List - 500 on upstream actor error", func(t *testing.T) {
+		apiPath := "v1.0-alpha1/actors/fakeActorType/fakeActorID/reminders"
+		reminderRequest := actors.ListRemindersRequest{
+			ActorType: "fakeActorType",
+			ActorID:   "fakeActorID",
+		}
+
+		mockActors := new(appt.MockActors)
+
+		mockActors.On("ListReminders", &reminderRequest).Return(nil, errors.New("UPSTREAM_ERROR"))
+
+		testAPI.actor = mockActors
+
+		// act
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		// assert
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, "ERR_ACTOR_REMINDER_LIST", resp.ErrorBody["errorCode"])
+		mockActors.AssertNumberOfCalls(t, "ListReminders", 1)
+	})
+
 	t.Run("Reminder Delete - 204 No Content", func(t *testing.T) {
 		apiPath := "v1.0/actors/fakeActorType/fakeActorID/reminders/reminder1"
 		reminderRequest := actors.DeleteReminderRequest{
@@ -1640,6 +2265,41 @@ func TestV1MetadataEndpoint(t *testing.T) {
 		mockActors.AssertNumberOfCalls(t, "GetActiveActorsCount", 1)
 	})
 
+	t.Run("Metadata - component updates", func(t *testing.T) {
+		apiPath := "v1.0/metadata"
+		mockActors := new(appt.MockActors)
+
+		mockActors.On("GetActiveActorsCount")
+
+		testAPI.actor = mockActors
+		testAPI.getComponentUpdatesFn = func() []components.Update {
+			return []components.Update{
+				{
+					Kind:      components.UpdateKindComponent,
+					Name:      "MockComponent1Name",
+					Type:      "mock.component1Type",
+					Operation: components.UpdateOperationUpdate,
+					Error:     "init failed",
+				},
+			}
+		}
+		defer func() {
+			testAPI.getComponentUpdatesFn = nil
+		}()
+
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		var body struct {
+			ComponentUpdates []components.Update `json:"componentUpdates"`
+		}
+		require.NoError(t, json.Unmarshal(resp.RawBody, &body))
+		require.Len(t, body.ComponentUpdates, 1)
+		assert.Equal(t, "MockComponent1Name", body.ComponentUpdates[0].Name)
+		assert.Equal(t, components.UpdateOperationUpdate, body.ComponentUpdates[0].Operation)
+		assert.Equal(t, "init failed", body.ComponentUpdates[0].Error)
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -1900,6 +2560,82 @@ func TestAPIToken(t *testing.T) {
 	})
 }
 
+func TestScopedAPITokens(t *testing.T) {
+	fakeServer := newFakeHTTPServer()
+	var fakeStore state.Store = fakeStateStoreQuerier{}
+	compStore := compstore.New()
+	compStore.AddStateStore("store1", fakeStore)
+	compStore.AddStateStore("store2", fakeStore)
+	testAPI := &api{
+		compStore: compStore,
+		json:      jsoniter.ConfigFastest,
+	}
+
+	tokens := apitoken.NewStore("")
+	tokens.Update([]apitoken.Token{
+		{
+			Name:    "reader",
+			Token:   "reader-token",
+			Allowed: []config.APIAccessRule{{Name: "state", Version: apiVersionV1, Protocol: protocol}},
+			Scopes:  []apitoken.Scope{{Component: "store1", Access: apitoken.AccessRead}},
+		},
+		{
+			Name:    "secrets",
+			Token:   "secrets-token",
+			Allowed: []config.APIAccessRule{{Name: "secrets", Version: apiVersionV1, Protocol: protocol}},
+		},
+		{
+			Name:  "admin",
+			Token: "admin-token",
+		},
+	})
+	fakeServer.StartServerWithAPITokens(tokens, testAPI.constructStateEndpoints())
+	defer fakeServer.Shutdown()
+
+	t.Run("unknown token - 401", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("GET", "v1.0/state/store1/good-key", "other-token", nil)
+		assert.Equal(t, 401, resp.StatusCode)
+	})
+
+	t.Run("read in scope - 200", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("GET", "v1.0/state/store1/good-key", "reader-token", nil)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("bulk read in scope - 200", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("POST", "v1.0/state/store1/bulk", "reader-token", []byte(`{"keys":["good-key"]}`))
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+
+	t.Run("write to read only component - 403", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("POST", "v1.0/state/store1", "reader-token", []byte(`[{"key":"good-key","value":"v"}]`))
+		assert.Equal(t, 403, resp.StatusCode)
+	})
+
+	t.Run("component out of scope - 403", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("GET", "v1.0/state/store2/good-key", "reader-token", nil)
+		assert.Equal(t, 403, resp.StatusCode)
+	})
+
+	t.Run("api not allowed - 403", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("GET", "v1.0/state/store1/good-key", "secrets-token", nil)
+		assert.Equal(t, 403, resp.StatusCode)
+	})
+
+	t.Run("unrestricted token - 204", func(t *testing.T) {
+		resp := fakeServer.DoRequestWithAPIToken("POST", "v1.0/state/store2", "admin-token", []byte(`[{"key":"good-key","value":"v"}]`))
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
+	t.Run("rotated token - 401", func(t *testing.T) {
+		tokens.Update([]apitoken.Token{{Name: "admin", Token: "new-admin-token"}})
+		resp := fakeServer.DoRequestWithAPIToken("GET", "v1.0/state/store1/good-key", "admin-token", nil)
+		assert.Equal(t, 401, resp.StatusCode)
+		resp = fakeServer.DoRequestWithAPIToken("GET", "v1.0/state/store1/good-key", "new-admin-token", nil)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+}
+
 func TestEmptyPipelineWithTracer(t *testing.T) {
 	fakeHeaderMetadata := map[string][]string{
 		"Accept-Encoding":  {"gzip"},
@@ -2170,10 +2906,14 @@ func (f *fakeHTTPServer) StartServerWithTracing(spec config.TracingSpec, endpoin
 }
 
 func (f *fakeHTTPServer) StartServerWithAPIToken(endpoints []Endpoint) {
-	router := f.getRouter(endpoints)
+	f.StartServerWithAPITokens(apitoken.NewStore(auth.GetAPIToken()), endpoints)
+}
+
+func (f *fakeHTTPServer) StartServerWithAPITokens(tokens *apitoken.Store, endpoints []Endpoint) {
+	router := (&server{apiTokens: tokens}).getRouter(endpoints)
 	f.ln = fasthttputil.NewInmemoryListener()
 	go func() {
-		if err := fasthttp.Serve(f.ln, useAPIAuthentication(router.Handler)); err != nil {
+		if err := fasthttp.Serve(f.ln, useAPIAuthentication(router.Handler, tokens)); err != nil {
 			panic(fmt.Errorf("failed to serve: %v", err))
 		}
 	}()
@@ -2300,16 +3040,11 @@ func TestV1StateEndpoints(t *testing.T) {
 	etag := "`~!@#$%^&*()_+-={}[]|\\:\";'<>?,./'"
 	fakeServer := newFakeHTTPServer()
 	var fakeStore state.Store = fakeStateStoreQuerier{}
-	fakeStores := map[string]state.Store{
-		"store1": fakeStore,
-	}
-	fakeTransactionalStores := map[string]state.TransactionalStore{
-		"store1": fakeStore.(state.TransactionalStore),
-	}
+	compStore := compstore.New()
+	compStore.AddStateStore("store1", fakeStore)
 	testAPI := &api{
-		stateStores:              fakeStores,
-		transactionalStateStores: fakeTransactionalStores,
-		json:                     jsoniter.ConfigFastest,
+		compStore: compStore,
+		json:      jsoniter.ConfigFastest,
 	}
 	fakeServer.StartServer(testAPI.constructStateEndpoints())
 	storeName := "store1"
@@ -2325,12 +3060,12 @@ func TestV1StateEndpoints(t *testing.T) {
 
 		for apiPath, testMethods := range apisAndMethods {
 			for _, method := range testMethods {
-				testAPI.stateStores = nil
+				testAPI.compStore = compstore.New()
 				resp := fakeServer.DoRequest(method, apiPath, nil, nil)
 				// assert
 				assert.Equal(t, 500, resp.StatusCode, apiPath)
 				assert.Equal(t, "ERR_STATE_STORES_NOT_CONFIGURED", resp.ErrorBody["errorCode"])
-				testAPI.stateStores = fakeStores
+				testAPI.compStore = compStore
 
 				// act
 				resp = fakeServer.DoRequest(method, apiPath, nil, nil)
@@ -2602,8 +3337,10 @@ func TestV1StateEndpoints(t *testing.T) {
 
 func TestStateStoreQuerierNotImplemented(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
+	compStore := compstore.New()
+	compStore.AddStateStore("store1", fakeStateStore{})
 	testAPI := &api{
-		stateStores: map[string]state.Store{"store1": fakeStateStore{}},
+		compStore: compStore,
 	}
 	fakeServer.StartServer(testAPI.constructStateEndpoints())
 
@@ -2615,8 +3352,10 @@ func TestStateStoreQuerierNotImplemented(t *testing.T) {
 
 func TestStateStoreQuerierNotEnabled(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
+	compStore := compstore.New()
+	compStore.AddStateStore("store1", fakeStateStoreQuerier{})
 	testAPI := &api{
-		stateStores: map[string]state.Store{"store1": fakeStateStoreQuerier{}},
+		compStore: compStore,
 	}
 	fakeServer.StartServer(testAPI.constructStateEndpoints())
 
@@ -2628,8 +3367,10 @@ func TestStateStoreQuerierNotEnabled(t *testing.T) {
 func TestStateStoreQuerierEncrypted(t *testing.T) {
 	storeName := "encrypted-store1"
 	fakeServer := newFakeHTTPServer()
+	compStore := compstore.New()
+	compStore.AddStateStore(storeName, fakeStateStoreQuerier{})
 	testAPI := &api{
-		stateStores: map[string]state.Store{storeName: fakeStateStoreQuerier{}},
+		compStore: compStore,
 	}
 	encryption.AddEncryptedStateStore(storeName, encryption.ComponentEncryptionKeys{})
 	fakeServer.StartServer(testAPI.constructStateEndpoints())
@@ -2786,31 +3527,31 @@ func (c fakeStateStoreQuerier) Query(req *state.QueryRequest) (*state.QueryRespo
 func TestV1SecretEndpoints(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 	fakeStore := appt.FakeSecretStore{}
-	fakeStores := map[string]secretstores.SecretStore{
-		"store1": fakeStore,
-		"store2": fakeStore,
-		"store3": fakeStore,
-		"store4": fakeStore,
+	compStore := compstore.New()
+	for _, name := range []string{"store1", "store2", "store3", "store4"} {
+		compStore.AddSecretStore(name, fakeStore)
 	}
-	secretsConfiguration := map[string]config.SecretsScope{
-		"store1": {
+	compStore.SetSecretsConfigurations([]config.SecretsScope{
+		{
+			StoreName:     "store1",
 			DefaultAccess: config.AllowAccess,
 			DeniedSecrets: []string{"not-allowed"},
 		},
-		"store2": {
+		{
+			StoreName:      "store2",
 			DefaultAccess:  config.DenyAccess,
 			AllowedSecrets: []string{"good-key"},
 		},
-		"store3": {
+		{
+			StoreName:      "store3",
 			DefaultAccess:  config.AllowAccess,
 			AllowedSecrets: []string{"good-key"},
 		},
-	}
+	})
 
 	testAPI := &api{
-		secretsConfiguration: secretsConfiguration,
-		secretStores:         fakeStores,
-		json:                 jsoniter.ConfigFastest,
+		compStore: compStore,
+		json:      jsoniter.ConfigFastest,
 	}
 	fakeServer.StartServer(testAPI.constructSecretEndpoints())
 	storeName := "store1"
@@ -2903,14 +3644,14 @@ func TestV1SecretEndpoints(t *testing.T) {
 	t.Run("Get secret - 500 for secret store not congfigured", func(t *testing.T) {
 		apiPath := fmt.Sprintf("v1.0/secrets/%s/good-key", unrestrictedStore)
 		// act
-		testAPI.secretStores = nil
+		testAPI.compStore = compstore.New()
 
 		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
 		// assert
 		assert.Equal(t, 500, resp.StatusCode, "reading existing key should succeed")
 		assert.Equal(t, "ERR_SECRET_STORES_NOT_CONFIGURED", resp.ErrorBody["errorCode"], apiPath)
 
-		testAPI.secretStores = fakeStores
+		testAPI.compStore = compStore
 	})
 
 	t.Run("Get Bulk secret - Good Key default allow", func(t *testing.T) {
@@ -2954,17 +3695,12 @@ func TestV1TransactionEndpoints(t *testing.T) {
 	fakeServer := newFakeHTTPServer()
 	var fakeStore state.Store = fakeStateStoreQuerier{}
 	fakeStoreNonTransactional := new(appt.MockStateStore)
-	fakeStores := map[string]state.Store{
-		"store1":                fakeStore,
-		"storeNonTransactional": fakeStoreNonTransactional,
-	}
-	fakeTransactionalStores := map[string]state.TransactionalStore{
-		"store1": fakeStore.(state.TransactionalStore),
-	}
+	compStore := compstore.New()
+	compStore.AddStateStore("store1", fakeStore)
+	compStore.AddStateStore("storeNonTransactional", fakeStoreNonTransactional)
 	testAPI := &api{
-		stateStores:              fakeStores,
-		transactionalStateStores: fakeTransactionalStores,
-		json:                     jsoniter.ConfigFastest,
+		compStore: compStore,
+		json:      jsoniter.ConfigFastest,
 	}
 	fakeServer.StartServer(testAPI.constructStateEndpoints())
 	fakeBodyObject := map[string]interface{}{"data": "fakeData"}
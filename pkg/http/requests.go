@@ -20,6 +20,10 @@ package http
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+import (
+	jsoniter "github.com/json-iterator/go"
+)
+
 // OutputBindingRequest is the request object to invoke an output binding.
 type OutputBindingRequest struct {
 	Metadata  map[string]string `json:"metadata"`
@@ -33,3 +37,11 @@ type BulkGetRequest struct {
 	Keys        []string          `json:"keys"`
 	Parallelism int               `json:"parallelism"`
 }
+
+// BulkPublishRequestEntry is a single event of a bulk publish request.
+type BulkPublishRequestEntry struct {
+	EntryID     string              `json:"entryId"`
+	Event       jsoniter.RawMessage `json:"event"`
+	ContentType string              `json:"contentType"`
+	Metadata    map[string]string   `json:"metadata,omitempty"`
+}
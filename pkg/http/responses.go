@@ -22,9 +22,13 @@ package http
 
 import (
 	"encoding/json"
+	"strconv"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/valyala/fasthttp"
+
+	"github.com/bhojpur/application/pkg/ratelimit"
 )
 
 const (
@@ -57,6 +61,32 @@ type QueryItem struct {
 	Error string              `json:"error,omitempty"`
 }
 
+// QueryActorStateResponse is the response object for querying actor state.
+type QueryActorStateResponse struct {
+	Results  []QueryActorStateItem `json:"results"`
+	Token    string                `json:"token,omitempty"`
+	Metadata map[string]string     `json:"metadata,omitempty"`
+}
+
+// QueryActorStateItem is an object representing an actor state key in query results.
+type QueryActorStateItem struct {
+	ActorID string              `json:"actorId"`
+	Key     string              `json:"key"`
+	Data    jsoniter.RawMessage `json:"data"`
+}
+
+// BulkPublishResponse is the response object for a bulk publish operation.
+type BulkPublishResponse struct {
+	Statuses []BulkPublishResponseEntry `json:"statuses"`
+}
+
+// BulkPublishResponseEntry is the status of a single entry of a bulk publish operation.
+type BulkPublishResponseEntry struct {
+	EntryID string `json:"entryId"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
 type option = func(ctx *fasthttp.RequestCtx)
 
 // withEtag sets etag header.
@@ -77,6 +107,13 @@ func withMetadata(metadata map[string]string) option {
 	}
 }
 
+// withRetryAfter sets the Retry-After header.
+func withRetryAfter(retryAfter time.Duration) option {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set(fasthttp.HeaderRetryAfter, strconv.Itoa(ratelimit.RetryAfterSeconds(retryAfter)))
+	}
+}
+
 // withJSON overrides the content-type with application/json.
 func withJSON(code int, obj []byte) option {
 	return func(ctx *fasthttp.RequestCtx) {
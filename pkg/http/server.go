@@ -36,6 +36,7 @@ import (
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/pprofhandler"
 
+	"github.com/bhojpur/application/pkg/apitoken"
 	"github.com/bhojpur/application/pkg/config"
 	cors_app "github.com/bhojpur/application/pkg/cors"
 	diag "github.com/bhojpur/application/pkg/diagnostics"
@@ -47,7 +48,19 @@ import (
 
 var log = logger.NewLogger("app.runtime.http")
 
-const protocol = "http"
+const (
+	protocol = "http"
+
+	// apiTokenUserValue is the request user value holding the token the request was
+	// authenticated with.
+	apiTokenUserValue = "apiToken"
+)
+
+// readOnlyRoutes are the routes served on POST that do not change their component.
+var readOnlyRoutes = map[string]bool{
+	"state/{storeName}/bulk":  true,
+	"state/{storeName}/query": true,
+}
 
 // Server is an interface for the Bhojpur Application HTTP server.
 type Server interface {
@@ -62,12 +75,13 @@ type server struct {
 	pipeline           http_middleware.Pipeline
 	api                API
 	apiSpec            config.APISpec
+	apiTokens          *apitoken.Store
 	servers            []*fasthttp.Server
 	profilingListeners []net.Listener
 }
 
 // NewServer returns a new Bhojpur Application runtime HTTP server.
-func NewServer(api API, config ServerConfig, tracingSpec config.TracingSpec, metricSpec config.MetricSpec, pipeline http_middleware.Pipeline, apiSpec config.APISpec) Server {
+func NewServer(api API, config ServerConfig, tracingSpec config.TracingSpec, metricSpec config.MetricSpec, pipeline http_middleware.Pipeline, apiSpec config.APISpec, apiTokens *apitoken.Store) Server {
 	return &server{
 		api:         api,
 		config:      config,
@@ -75,6 +89,7 @@ func NewServer(api API, config ServerConfig, tracingSpec config.TracingSpec, met
 		metricSpec:  metricSpec,
 		pipeline:    pipeline,
 		apiSpec:     apiSpec,
+		apiTokens:   apiTokens,
 	}
 }
 
@@ -84,7 +99,8 @@ func (s *server) StartNonBlocking() error {
 		useAPIAuthentication(
 			s.useCors(
 				s.useComponents(
-					s.useRouter())))
+					s.useRouter())),
+			s.apiTokens)
 
 	handler = s.useMetrics(handler)
 	handler = s.useTracing(handler)
@@ -244,24 +260,68 @@ func (s *server) useCors(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return corsHandler.CorsMiddleware(next)
 }
 
-func useAPIAuthentication(next fasthttp.RequestHandler) fasthttp.RequestHandler {
-	token := auth.GetAPIToken()
-	if token == "" {
+func useAPIAuthentication(next fasthttp.RequestHandler, tokens *apitoken.Store) fasthttp.RequestHandler {
+	if !tokens.Enabled() {
 		return next
 	}
 	log.Info("enabled token authentication on http server")
 
 	return func(ctx *fasthttp.RequestCtx) {
 		v := ctx.Request.Header.Peek(auth.APITokenHeader)
-		if auth.ExcludedRoute(string(ctx.Request.URI().FullURI())) || string(v) == token {
+		if auth.ExcludedRoute(string(ctx.Request.URI().FullURI())) {
 			ctx.Request.Header.Del(auth.APITokenHeader)
 			next(ctx)
+		} else if token, ok := tokens.Authenticate(string(v)); ok {
+			ctx.Request.Header.Del(auth.APITokenHeader)
+			ctx.SetUserValue(apiTokenUserValue, token)
+			next(ctx)
 		} else {
 			ctx.Error("invalid api token", http.StatusUnauthorized)
 		}
 	}
 }
 
+// useAPITokenScopes rejects the requests to endpoint made with a token that is not allowed
+// to call it, or to use the component it names.
+func useAPITokenScopes(e Endpoint, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		token, ok := ctx.UserValue(apiTokenUserValue).(*apitoken.Token)
+		if !ok {
+			next(ctx)
+			return
+		}
+
+		if rules, restricted := token.AllowedRules(protocol); restricted && !endpointMatches(e, rules) {
+			ctx.Error(fmt.Sprintf("api token %s is not allowed to call this endpoint", token.Name), http.StatusForbidden)
+			return
+		}
+		if component, ok := endpointComponent(e, ctx); ok && !token.ComponentAllowed(component, endpointWrites(e, ctx)) {
+			ctx.Error(fmt.Sprintf("api token %s is not allowed to use component %s", token.Name, component), http.StatusForbidden)
+			return
+		}
+		next(ctx)
+	}
+}
+
+// endpointComponent returns the component named in the path of a request to endpoint.
+func endpointComponent(e Endpoint, ctx *fasthttp.RequestCtx) (string, bool) {
+	params := []string{storeNameParam, secretStoreNameParam, pubsubnameparam}
+	if strings.HasPrefix(e.Route, "bindings/") {
+		params = append(params, nameParam)
+	}
+	for _, param := range params {
+		if component, ok := ctx.UserValue(param).(string); ok {
+			return component, true
+		}
+	}
+	return "", false
+}
+
+// endpointWrites returns whether a request to endpoint may change its component.
+func endpointWrites(e Endpoint, ctx *fasthttp.RequestCtx) bool {
+	return !ctx.IsGet() && !ctx.IsHead() && !readOnlyRoutes[e.Route]
+}
+
 func (s *server) getCorsHandler(allowedOrigins []string) *cors.CorsHandler {
 	return cors.NewCorsHandler(cors.Options{
 		AllowedOrigins: allowedOrigins,
@@ -318,12 +378,17 @@ func (s *server) getRouter(endpoints []Endpoint) *routing.Router {
 }
 
 func (s *server) handle(e Endpoint, parameterFinder *regexp.Regexp, path string, router *routing.Router) {
+	handler := e.Handler
+	if s.apiTokens.Enabled() {
+		handler = useAPITokenScopes(e, handler)
+	}
+
 	for _, m := range e.Methods {
 		pathIncludesParameters := parameterFinder.MatchString(path)
 		if pathIncludesParameters && !e.KeepParamUnescape {
-			router.Handle(m, path, s.unescapeRequestParametersHandler(e.Handler))
+			router.Handle(m, path, s.unescapeRequestParametersHandler(handler))
 		} else {
-			router.Handle(m, path, e.Handler)
+			router.Handle(m, path, handler)
 		}
 	}
 }
@@ -340,7 +405,12 @@ func (s *server) endpointAllowed(endpoint Endpoint) bool {
 		return true
 	}
 
-	for _, rule := range httpRules {
+	return endpointMatches(endpoint, httpRules)
+}
+
+// endpointMatches returns whether one of the HTTP rules allows endpoint.
+func endpointMatches(endpoint Endpoint, rules []config.APIAccessRule) bool {
+	for _, rule := range rules {
 		if (strings.Index(endpoint.Route, rule.Name) == 0 && endpoint.Version == rule.Version) || endpoint.Route == "healthz" {
 			return true
 		}
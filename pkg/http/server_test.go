@@ -114,6 +114,11 @@ func TestAllowedAPISpec(t *testing.T) {
 						Version:  "v1.0",
 						Protocol: "http",
 					},
+					{
+						Name:     "publish",
+						Version:  "v1.0-alpha1",
+						Protocol: "http",
+					},
 				},
 			},
 		}
@@ -668,7 +673,7 @@ func TestClose(t *testing.T) {
 	require.NoError(t, err)
 	serverConfig := NewServerConfig("test", "127.0.0.1", port, []string{"127.0.0.1"}, nil, 0, "", false, 4, "", 4, false)
 	a := &api{}
-	server := NewServer(a, serverConfig, config.TracingSpec{}, config.MetricSpec{}, http_middleware.Pipeline{}, config.APISpec{})
+	server := NewServer(a, serverConfig, config.TracingSpec{}, config.MetricSpec{}, http_middleware.Pipeline{}, config.APISpec{}, nil)
 	require.NoError(t, server.StartNonBlocking())
 	app_testing.WaitForListeningAddress(t, 5*time.Second, fmt.Sprintf("127.0.0.1:%d", port))
 	assert.NoError(t, server.Close())
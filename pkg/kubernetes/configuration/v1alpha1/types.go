@@ -60,11 +60,146 @@ type ConfigurationSpec struct {
 	Features []FeatureSpec `json:"features,omitempty"`
 	// +optional
 	APISpec APISpec `json:"api,omitempty"`
+	// +optional
+	ResiliencySpec *ResiliencySpec `json:"resiliency,omitempty" yaml:",omitempty"`
+	// +optional
+	RecordingSpec *RecordingSpec `json:"recording,omitempty" yaml:",omitempty"`
+	// +optional
+	RateLimitSpec *RateLimitSpec `json:"rateLimit,omitempty" yaml:",omitempty"`
+}
+
+// RateLimitSpec limits the service invocations handled by the sidecar per caller app.
+type RateLimitSpec struct {
+	// +optional
+	Policies []RateLimitPolicySpec `json:"policies,omitempty"`
+}
+
+// RateLimitPolicySpec sets the rate and concurrency limits of a caller app on methods starting with a prefix.
+type RateLimitPolicySpec struct {
+	// +optional
+	CallerAppID string `json:"callerAppId,omitempty"`
+	// +optional
+	MethodPrefix string `json:"methodPrefix,omitempty"`
+	// +optional
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+	// +optional
+	Burst int `json:"burst,omitempty"`
+	// +optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// RecordingSpec configures the recording of service invocations and published events.
+type RecordingSpec struct {
+	Enabled bool `json:"enabled"`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// +optional
+	MaxSizeMB int `json:"maxSizeMB,omitempty"`
+	// +optional
+	MaxBackups int `json:"maxBackups,omitempty"`
+	// +optional
+	Redact RedactionSpec `json:"redact,omitempty"`
+}
+
+// RedactionSpec lists the values masked before they are written to a recording.
+type RedactionSpec struct {
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// ResiliencySpec defines the named resiliency policies and the targets they apply to.
+type ResiliencySpec struct {
+	// +optional
+	Policies PoliciesSpec `json:"policies"`
+	// +optional
+	Targets TargetsSpec `json:"targets"`
+}
+
+// PoliciesSpec holds the named timeout, retry and circuit breaker policies.
+type PoliciesSpec struct {
+	// +optional
+	Timeouts map[string]string `json:"timeouts,omitempty"`
+	// +optional
+	Retries map[string]RetrySpec `json:"retries,omitempty"`
+	// +optional
+	CircuitBreakers map[string]CircuitBreakerSpec `json:"circuitBreakers,omitempty"`
+}
+
+// RetrySpec defines a constant or exponential retry policy.
+type RetrySpec struct {
+	Policy string `json:"policy"`
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// +optional
+	MaxInterval string `json:"maxInterval,omitempty"`
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+}
+
+// CircuitBreakerSpec defines a circuit breaker policy.
+type CircuitBreakerSpec struct {
+	// +optional
+	MaxRequests int `json:"maxRequests,omitempty"`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// +optional
+	Trip string `json:"trip,omitempty"`
+}
+
+// TargetsSpec maps app IDs, actor types and components to named policies.
+type TargetsSpec struct {
+	// +optional
+	Apps map[string]EndpointPolicyNames `json:"apps,omitempty"`
+	// +optional
+	Actors map[string]EndpointPolicyNames `json:"actors,omitempty"`
+	// +optional
+	Components map[string]ComponentPolicyNames `json:"components,omitempty"`
+}
+
+// EndpointPolicyNames references the policies applied to an app or actor type.
+type EndpointPolicyNames struct {
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+	// +optional
+	Retry string `json:"retry,omitempty"`
+	// +optional
+	CircuitBreaker string `json:"circuitBreaker,omitempty"`
+}
+
+// ComponentPolicyNames references the policies applied to calls made to a component
+// and to deliveries from a component to the app.
+type ComponentPolicyNames struct {
+	// +optional
+	Outbound EndpointPolicyNames `json:"outbound,omitempty"`
+	// +optional
+	Inbound EndpointPolicyNames `json:"inbound,omitempty"`
 }
 
 // APISpec describes the configuration for Bhojpur Application APIs.
 type APISpec struct {
 	Allowed []APIAccessRule `json:"allowed,omitempty"`
+	// +optional
+	Tokens *APITokensSpec `json:"tokens,omitempty" yaml:",omitempty"`
+}
+
+// APITokensSpec describes where the named API tokens are loaded from.
+type APITokensSpec struct {
+	// +optional
+	File string `json:"file,omitempty"`
+	// +optional
+	SecretStore string `json:"secretStore,omitempty"`
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
 }
 
 // APIAccessRule describes an access rule for allowing a Bhojpur Application API to be enabled and accessible by an app.
@@ -133,6 +268,8 @@ type SelectorField struct {
 type TracingSpec struct {
 	SamplingRate string     `json:"samplingRate"`
 	Zipkin       ZipkinSpec `json:"zipkin"`
+	// +optional
+	Otel *OtelSpec `json:"otel,omitempty" yaml:",omitempty"`
 }
 
 // ZipkinSpec defines Zipkin trace configurations.
@@ -140,6 +277,17 @@ type ZipkinSpec struct {
 	EndpointAddresss string `json:"endpointAddress"`
 }
 
+// OtelSpec defines OpenTelemetry (OTLP) trace configurations.
+type OtelSpec struct {
+	EndpointAddress string `json:"endpointAddress"`
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
 // MetricSpec defines metrics configuration.
 type MetricSpec struct {
 	Enabled bool `json:"enabled"`
@@ -174,6 +322,44 @@ type AccessControlSpec struct {
 	TrustDomain string `json:"trustDomain" yaml:"trustDomain"`
 	// +optional
 	AppPolicies []AppPolicySpec `json:"policies" yaml:"policies"`
+	// +optional
+	CallerIdentity *CallerIdentitySpec `json:"callerIdentity,omitempty" yaml:"callerIdentity,omitempty"`
+}
+
+// CallerIdentitySpec selects how the callers of service invocations are identified for the access control policies.
+type CallerIdentitySpec struct {
+	// +optional
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// +optional
+	JWT *JWTIdentitySpec `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+	// +optional
+	Header *HeaderIdentitySpec `json:"header,omitempty" yaml:"header,omitempty"`
+}
+
+// JWTIdentitySpec identifies callers by a bearer JWT verified with the keys of a JWKS.
+type JWTIdentitySpec struct {
+	// +optional
+	JWKSFile string `json:"jwksFile,omitempty" yaml:"jwksFile,omitempty"`
+	// +optional
+	JWKSURL string `json:"jwksURL,omitempty" yaml:"jwksURL,omitempty"`
+	// +optional
+	Issuer string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// +optional
+	Audience string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	// +optional
+	AppIDClaim string `json:"appIdClaim,omitempty" yaml:"appIdClaim,omitempty"`
+	// +optional
+	NamespaceClaim string `json:"namespaceClaim,omitempty" yaml:"namespaceClaim,omitempty"`
+}
+
+// HeaderIdentitySpec identifies callers by a header trusted behind a shared secret.
+type HeaderIdentitySpec struct {
+	// +optional
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// +optional
+	SecretHeader string `json:"secretHeader,omitempty" yaml:"secretHeader,omitempty"`
+	// +optional
+	SecretEnvVar string `json:"secretEnvVar,omitempty" yaml:"secretEnvVar,omitempty"`
 }
 
 // FeatureSpec defines the features that are enabled/disabled.
@@ -52,6 +52,11 @@ func (in *APISpec) DeepCopyInto(out *APISpec) {
 		*out = make([]APIAccessRule, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tokens != nil {
+		in, out := &in.Tokens, &out.Tokens
+		*out = new(APITokensSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISpec.
@@ -64,6 +69,21 @@ func (in *APISpec) DeepCopy() *APISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APITokensSpec) DeepCopyInto(out *APITokensSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APITokensSpec.
+func (in *APITokensSpec) DeepCopy() *APITokensSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APITokensSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessControlSpec) DeepCopyInto(out *AccessControlSpec) {
 	*out = *in
@@ -74,6 +94,11 @@ func (in *AccessControlSpec) DeepCopyInto(out *AccessControlSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CallerIdentity != nil {
+		in, out := &in.CallerIdentity, &out.CallerIdentity
+		*out = new(CallerIdentitySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlSpec.
@@ -128,6 +153,63 @@ func (in *AppPolicySpec) DeepCopy() *AppPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CallerIdentitySpec) DeepCopyInto(out *CallerIdentitySpec) {
+	*out = *in
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTIdentitySpec)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(HeaderIdentitySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CallerIdentitySpec.
+func (in *CallerIdentitySpec) DeepCopy() *CallerIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CallerIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerSpec) DeepCopyInto(out *CircuitBreakerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerSpec.
+func (in *CircuitBreakerSpec) DeepCopy() *CircuitBreakerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentPolicyNames) DeepCopyInto(out *ComponentPolicyNames) {
+	*out = *in
+	out.Outbound = in.Outbound
+	out.Inbound = in.Inbound
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPolicyNames.
+func (in *ComponentPolicyNames) DeepCopy() *ComponentPolicyNames {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentPolicyNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -190,7 +272,7 @@ func (in *ConfigurationList) DeepCopyObject() runtime.Object {
 func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	*out = *in
 	in.HTTPPipelineSpec.DeepCopyInto(&out.HTTPPipelineSpec)
-	out.TracingSpec = in.TracingSpec
+	in.TracingSpec.DeepCopyInto(&out.TracingSpec)
 	out.MetricSpec = in.MetricSpec
 	out.MTLSSpec = in.MTLSSpec
 	in.Secrets.DeepCopyInto(&out.Secrets)
@@ -202,6 +284,21 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 		copy(*out, *in)
 	}
 	in.APISpec.DeepCopyInto(&out.APISpec)
+	if in.ResiliencySpec != nil {
+		in, out := &in.ResiliencySpec, &out.ResiliencySpec
+		*out = new(ResiliencySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecordingSpec != nil {
+		in, out := &in.RecordingSpec, &out.RecordingSpec
+		*out = new(RecordingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimitSpec != nil {
+		in, out := &in.RateLimitSpec, &out.RateLimitSpec
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationSpec.
@@ -230,6 +327,21 @@ func (in *DynamicValue) DeepCopy() *DynamicValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointPolicyNames) DeepCopyInto(out *EndpointPolicyNames) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointPolicyNames.
+func (in *EndpointPolicyNames) DeepCopy() *EndpointPolicyNames {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointPolicyNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
 	*out = *in
@@ -261,6 +373,36 @@ func (in *HandlerSpec) DeepCopy() *HandlerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderIdentitySpec) DeepCopyInto(out *HeaderIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderIdentitySpec.
+func (in *HeaderIdentitySpec) DeepCopy() *HeaderIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTIdentitySpec) DeepCopyInto(out *JWTIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTIdentitySpec.
+func (in *JWTIdentitySpec) DeepCopy() *JWTIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
 	*out = *in
@@ -329,6 +471,160 @@ func (in *PipelineSpec) DeepCopy() *PipelineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoliciesSpec) DeepCopyInto(out *PoliciesSpec) {
+	*out = *in
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = make(map[string]RetrySpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = make(map[string]CircuitBreakerSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoliciesSpec.
+func (in *PoliciesSpec) DeepCopy() *PoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitPolicySpec) DeepCopyInto(out *RateLimitPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicySpec.
+func (in *RateLimitPolicySpec) DeepCopy() *RateLimitPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]RateLimitPolicySpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingSpec) DeepCopyInto(out *RecordingSpec) {
+	*out = *in
+	in.Redact.DeepCopyInto(&out.Redact)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingSpec.
+func (in *RecordingSpec) DeepCopy() *RecordingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionSpec) DeepCopyInto(out *RedactionSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionSpec.
+func (in *RedactionSpec) DeepCopy() *RedactionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResiliencySpec) DeepCopyInto(out *ResiliencySpec) {
+	*out = *in
+	in.Policies.DeepCopyInto(&out.Policies)
+	in.Targets.DeepCopyInto(&out.Targets)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResiliencySpec.
+func (in *ResiliencySpec) DeepCopy() *ResiliencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResiliencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetrySpec) DeepCopyInto(out *RetrySpec) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetrySpec.
+func (in *RetrySpec) DeepCopy() *RetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretsScope) DeepCopyInto(out *SecretsScope) {
 	*out = *in
@@ -411,10 +707,73 @@ func (in *SelectorSpec) DeepCopy() *SelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetsSpec) DeepCopyInto(out *TargetsSpec) {
+	*out = *in
+	if in.Apps != nil {
+		in, out := &in.Apps, &out.Apps
+		*out = make(map[string]EndpointPolicyNames, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Actors != nil {
+		in, out := &in.Actors, &out.Actors
+		*out = make(map[string]EndpointPolicyNames, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]ComponentPolicyNames, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetsSpec.
+func (in *TargetsSpec) DeepCopy() *TargetsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelSpec) DeepCopyInto(out *OtelSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelSpec.
+func (in *OtelSpec) DeepCopy() *OtelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
 	*out = *in
 	out.Zipkin = in.Zipkin
+	if in.Otel != nil {
+		in, out := &in.Otel, &out.Otel
+		*out = new(OtelSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingSpec.
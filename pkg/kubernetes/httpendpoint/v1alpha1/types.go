@@ -0,0 +1,95 @@
+// +genclient
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+
+package v1alpha1
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+)
+
+// HTTPEndpoint describes an HTTP service without a sidecar that apps invoke by name.
+type HTTPEndpoint struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              HTTPEndpointSpec `json:"spec"`
+	// +optional
+	Auth `json:"auth,omitempty"`
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// HTTPEndpointSpec is the spec for an HTTP endpoint.
+type HTTPEndpointSpec struct {
+	BaseURL string `json:"baseUrl"`
+	// +optional
+	Headers []Header `json:"headers,omitempty"`
+	// +optional
+	ClientTLS *ClientTLS `json:"clientTLS,omitempty"`
+}
+
+// Header is a header sent with every request to the endpoint, with a value or a
+// reference to a secret holding the value.
+type Header struct {
+	Name string `json:"name"`
+	// +optional
+	Value string `json:"value,omitempty"`
+	// +optional
+	SecretKeyRef components_v1alpha1.SecretKeyRef `json:"secretKeyRef,omitempty"`
+}
+
+// ClientTLS describes the TLS settings used to connect to the endpoint.
+type ClientTLS struct {
+	// +optional
+	RootCA TLSDocument `json:"rootCA,omitempty"`
+	// +optional
+	Certificate TLSDocument `json:"certificate,omitempty"`
+	// +optional
+	PrivateKey TLSDocument `json:"privateKey,omitempty"`
+}
+
+// TLSDocument is a PEM document, with a value or a reference to a secret holding the value.
+type TLSDocument struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+	// +optional
+	SecretKeyRef components_v1alpha1.SecretKeyRef `json:"secretKeyRef,omitempty"`
+}
+
+// Auth represents authentication details for the endpoint.
+type Auth struct {
+	SecretStore string `json:"secretStore"`
+}
+
+// +kubebuilder:object:root=true
+
+// HTTPEndpointList is a list of HTTP endpoints.
+type HTTPEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HTTPEndpoint `json:"items"`
+}
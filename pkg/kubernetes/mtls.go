@@ -40,9 +40,12 @@ import (
 )
 
 const (
-	systemConfigName         = "appsystem"
-	trustBundleSecretName    = "app-trust-bundle" // nolint:gosec
-	warningDaysForCertExpiry = 30                  // in days
+	systemConfigName      = "appsystem"
+	trustBundleSecretName = "app-trust-bundle" // nolint:gosec
+
+	// WarningDaysForCertExpiry is the number of days before the root
+	// certificate expires from which the CLI warns about its expiry.
+	WarningDaysForCertExpiry = 30
 )
 
 func IsMTLSEnabled() (bool, error) {
@@ -116,7 +119,7 @@ func ExportTrustChain(outputDir string) error {
 	return nil
 }
 
-// Check and warn if cert expiry is less than `warningDaysForCertExpiry` days.
+// Check and warn if cert expiry is less than `WarningDaysForCertExpiry` days.
 func CheckForCertExpiry() {
 	expiry, err := Expiry()
 	// The intent is to warn for certificate expiry, only when it can be fetched.
@@ -126,7 +129,7 @@ func CheckForCertExpiry() {
 	}
 	daysRemaining := int(expiry.Sub(time.Now().UTC()).Hours() / 24)
 
-	if daysRemaining < warningDaysForCertExpiry {
+	if daysRemaining < WarningDaysForCertExpiry {
 		warningMessage := ""
 		switch {
 		case daysRemaining == 0:
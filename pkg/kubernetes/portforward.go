@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	core_v1 "k8s.io/api/core/v1"
@@ -82,6 +83,63 @@ func NewPortForward(
 		return nil, fmt.Errorf("no running pods found for %s", deployName)
 	}
 
+	return newPodPortForward(config, client, namespace, podName, host, localPort, remotePort, emitLogs), nil
+}
+
+// NewAppPortForward returns an instance of PortForward struct connected to the
+// HTTP port of the sidecar of a running pod of the given app ID.
+func NewAppPortForward(
+	config *rest.Config,
+	namespace, appID string,
+	host string, localPort int,
+	emitLogs bool,
+) (*PortForward, error) {
+	client, err := k8s.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := ListPods(client, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != core_v1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name != "appsvr" || containerArg(c.Args, "--app-id") != appID {
+				continue
+			}
+
+			remotePort, err := strconv.Atoi(containerArg(c.Args, "--app-http-port"))
+			if err != nil {
+				return nil, fmt.Errorf("could not read the HTTP port of the sidecar of %s: %w", appID, err)
+			}
+			return newPodPortForward(config, client, pod.Namespace, pod.Name, host, localPort, remotePort, emitLogs), nil
+		}
+	}
+
+	return nil, fmt.Errorf("no running pods found for app id %s", appID)
+}
+
+func containerArg(args []string, name string) string {
+	for i, a := range args {
+		if a == name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func newPodPortForward(
+	config *rest.Config,
+	client *k8s.Clientset,
+	namespace, podName string,
+	host string, localPort, remotePort int,
+	emitLogs bool,
+) *PortForward {
 	req := client.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Namespace(namespace).
@@ -98,7 +156,7 @@ func NewPortForward(
 		EmitLogs:   emitLogs,
 		StopCh:     make(chan struct{}, 1),
 		ReadyCh:    make(chan struct{}),
-	}, nil
+	}
 }
 
 // run creates port-forward connection and blocks
@@ -50,6 +50,24 @@ type SubscriptionSpec struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 	// The Routes configuration for this topic.
 	Routes Routes `json:"routes"`
+	// The optional topic messages are republished to once delivery is given up.
+	// +optional
+	DeadLetterTopic string `json:"deadLetterTopic,omitempty"`
+	// The optional configuration to deliver messages to the app in batches.
+	// +optional
+	BulkSubscribe BulkSubscribe `json:"bulkSubscribe,omitempty"`
+}
+
+// BulkSubscribe encapsulates the options to deliver messages to the app in batches.
+type BulkSubscribe struct {
+	// Whether messages are delivered to the app in batches.
+	Enabled bool `json:"enabled"`
+	// The maximum number of messages in a batch.
+	// +optional
+	MaxMessagesCount int32 `json:"maxMessagesCount,omitempty"`
+	// The maximum time in milliseconds to wait for a batch to fill up.
+	// +optional
+	MaxAwaitDurationMs int32 `json:"maxAwaitDurationMs,omitempty"`
 }
 
 // Routes encapsulates the rules and optional default path for a topic.
@@ -0,0 +1,367 @@
+package lint
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/bhojpur/application/pkg/expr"
+	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	subscriptions_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/subscriptions/v1alpha1"
+	subscriptions_v2alpha1 "github.com/bhojpur/application/pkg/kubernetes/subscriptions/v2alpha1"
+)
+
+const (
+	yamlSeparator    = "\n---"
+	componentKind    = "Component"
+	subscriptionKind = "Subscription"
+
+	subscriptionAPIVersionV2alpha1 = "bhojpur.net/v2alpha1"
+
+	bindingsCategory      = "bindings"
+	pubsubCategory        = "pubsub"
+	secretStoreCategory   = "secretstores"
+	stateCategory         = "state"
+	middlewareCategory    = "middleware"
+	configurationCategory = "configuration"
+	lockCategory          = "lock"
+	httpMiddlewarePrefix  = "middleware.http."
+)
+
+var (
+	componentCategories = []string{
+		bindingsCategory,
+		pubsubCategory,
+		secretStoreCategory,
+		stateCategory,
+		middlewareCategory,
+		configurationCategory,
+		lockCategory,
+	}
+	componentVersionExp = regexp.MustCompile(`^v\d+$`)
+)
+
+// Options selects what is validated.
+type Options struct {
+	// ComponentsPath is the directory holding the component and subscription
+	// files. It is not validated when empty.
+	ComponentsPath string
+	// ConfigFile is the configuration file. It is not validated when empty.
+	ConfigFile string
+	// Registries are the registries component types are checked against.
+	// Component types are not checked when nil.
+	Registries *Registries
+}
+
+// component is a component loaded from a file of the components directory.
+type component struct {
+	components_v1alpha1.Component
+	file string
+}
+
+// validator accumulates the findings of a validation run.
+type validator struct {
+	opts       Options
+	findings   []Finding
+	components []component
+}
+
+// Validate statically checks the components directory and configuration
+// file the way the runtime loads them.
+func Validate(opts Options) *Report {
+	v := &validator{opts: opts}
+	if opts.ComponentsPath != "" {
+		v.validateComponentsPath()
+	}
+	if opts.ConfigFile != "" {
+		v.validateConfigFile()
+	}
+	return NewReport(v.findings)
+}
+
+func (v *validator) addf(severity, file, resource, format string, args ...interface{}) {
+	v.findings = append(v.findings, Finding{
+		Severity: severity,
+		File:     file,
+		Resource: resource,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validateComponentsPath() {
+	path := v.opts.ComponentsPath
+	files, err := os.ReadDir(path)
+	if err != nil {
+		v.addf(SeverityError, path, "", "could not read components directory: %s", err)
+		return
+	}
+
+	subscriptions := []func(){}
+	for _, f := range files {
+		ext := strings.ToLower(filepath.Ext(f.Name()))
+		if f.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		file := filepath.Join(path, f.Name())
+		b, err := os.ReadFile(file)
+		if err != nil {
+			v.addf(SeverityError, file, "", "could not read file: %s", err)
+			continue
+		}
+
+		for _, doc := range strings.Split(string(b), yamlSeparator) {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+
+			var ti struct {
+				metav1.TypeMeta `json:",inline"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &ti); err != nil {
+				v.addf(SeverityError, file, "", "invalid YAML: %s", err)
+				continue
+			}
+
+			switch ti.Kind {
+			case componentKind:
+				var c components_v1alpha1.Component
+				if v.decode(file, componentKind, []byte(doc), &c) {
+					v.components = append(v.components, component{Component: c, file: file})
+				}
+			case subscriptionKind:
+				// Subscriptions reference components, check them once all
+				// components are loaded.
+				doc := doc
+				subscriptions = append(subscriptions, func() {
+					v.validateSubscription(file, ti.APIVersion, []byte(doc))
+				})
+			}
+		}
+	}
+
+	v.validateComponents()
+	for _, validateSubscription := range subscriptions {
+		validateSubscription()
+	}
+}
+
+// decode decodes a resource, reporting unknown fields as warnings. It
+// returns false when the resource cannot be decoded.
+func (v *validator) decode(file, kind string, doc []byte, out interface{}) bool {
+	strictErr := yaml.UnmarshalStrict(doc, out)
+	if strictErr == nil {
+		return true
+	}
+
+	if err := yaml.Unmarshal(doc, out); err != nil {
+		v.addf(SeverityError, file, kind, "could not decode %s: %s", kind, err)
+		return false
+	}
+	v.addf(SeverityWarning, file, kind, "%s", strictErr)
+	return true
+}
+
+func (v *validator) validateComponents() {
+	seen := map[string]component{}
+	secretStores := map[string]bool{}
+	for _, c := range v.components {
+		if componentCategory(c.Spec.Type) == secretStoreCategory {
+			secretStores[c.Name] = true
+		}
+	}
+
+	for _, c := range v.components {
+		resource := componentKind + "/" + c.Name
+		if c.Name == "" {
+			v.addf(SeverityError, c.file, componentKind, "metadata.name is not set")
+		}
+
+		category := componentCategory(c.Spec.Type)
+		if category != "" && c.Name != "" {
+			key := category + "/" + c.Name
+			if other, ok := seen[key]; ok {
+				v.addf(SeverityError, c.file, resource, "duplicate %s component name %s, also defined in %s", category, c.Name, other.file)
+			} else {
+				seen[key] = c
+			}
+		}
+
+		v.validateComponentType(c, resource, category)
+
+		for _, m := range c.Spec.Metadata {
+			if m.Name == "" {
+				v.addf(SeverityError, c.file, resource, "metadata item without a name")
+			}
+			if m.SecretKeyRef.Name == "" {
+				continue
+			}
+			switch {
+			case c.Auth.SecretStore == "":
+				v.addf(SeverityError, c.file, resource, "metadata %s references secret %s but auth.secretStore is not set", m.Name, m.SecretKeyRef.Name)
+			case !secretStores[c.Auth.SecretStore]:
+				v.addf(SeverityError, c.file, resource, "metadata %s references secret store %s which is not defined", m.Name, c.Auth.SecretStore)
+			}
+		}
+
+		v.validateScopes(c.file, resource, c.Scopes)
+	}
+}
+
+func (v *validator) validateComponentType(c component, resource, category string) {
+	componentType, version := c.Spec.Type, c.Spec.Version
+	switch {
+	case componentType == "":
+		v.addf(SeverityError, c.file, resource, "spec.type is not set")
+		return
+	case category == "":
+		v.addf(SeverityError, c.file, resource, "unknown component type %s. Types start with one of: %s", componentType, strings.Join(componentCategories, " "))
+		return
+	case category == middlewareCategory && !strings.HasPrefix(componentType, httpMiddlewarePrefix):
+		v.addf(SeverityError, c.file, resource, "unknown middleware type %s. Middleware types start with %s", componentType, httpMiddlewarePrefix)
+		return
+	}
+
+	if version != "" && !componentVersionExp.MatchString(version) {
+		v.addf(SeverityError, c.file, resource, "invalid version %s. Versions have the form v1", version)
+		return
+	}
+
+	if r := v.opts.Registries; r != nil && !r.has(category, componentType, version) {
+		v.addf(SeverityError, c.file, resource, "component type %s/%s is not included in the runtime", componentType, versionOrDefault(version))
+	}
+}
+
+// has checks if a component type exists in the registry of its category.
+func (r *Registries) has(category, componentType, version string) bool {
+	switch category {
+	case bindingsCategory:
+		return r.Bindings.HasInputBinding(componentType, version) || r.Bindings.HasOutputBinding(componentType, version)
+	case pubsubCategory:
+		return r.PubSubs.Has(componentType, version)
+	case secretStoreCategory:
+		return r.SecretStores.Has(componentType, version)
+	case stateCategory:
+		return r.States.Has(componentType, version)
+	case middlewareCategory:
+		return r.HTTPMiddleware.Has(componentType, version)
+	case configurationCategory:
+		return r.Configurations.Has(componentType, version)
+	case lockCategory:
+		return r.Locks.Has(componentType, version)
+	}
+	return false
+}
+
+func (v *validator) validateScopes(file, resource string, scopes []string) {
+	seen := map[string]bool{}
+	for _, scope := range scopes {
+		switch {
+		case strings.TrimSpace(scope) == "":
+			v.addf(SeverityError, file, resource, "empty scope")
+		case strings.TrimSpace(scope) != scope || strings.ContainsAny(scope, " \t,"):
+			v.addf(SeverityError, file, resource, "invalid scope %q. Scopes are app IDs", scope)
+		case seen[scope]:
+			v.addf(SeverityWarning, file, resource, "duplicate scope %s", scope)
+		}
+		seen[scope] = true
+	}
+}
+
+func (v *validator) validateSubscription(file, apiVersion string, doc []byte) {
+	var pubsubName string
+	var scopes []string
+	resource := subscriptionKind
+
+	if apiVersion == subscriptionAPIVersionV2alpha1 {
+		var sub subscriptions_v2alpha1.Subscription
+		if !v.decode(file, subscriptionKind, doc, &sub) {
+			return
+		}
+		resource += "/" + sub.Name
+		pubsubName, scopes = sub.Spec.Pubsubname, sub.Scopes
+
+		if len(sub.Spec.Routes.Rules) == 0 && sub.Spec.Routes.Default == "" {
+			v.addf(SeverityError, file, resource, "spec.routes has no rules and no default path")
+		}
+		for i, rule := range sub.Spec.Routes.Rules {
+			match := strings.TrimSpace(rule.Match)
+			if match == "" {
+				v.addf(SeverityWarning, file, resource, "routing rule %d has no match expression and always matches", i)
+				continue
+			}
+			var e expr.Expr
+			if err := e.DecodeString(match); err != nil {
+				v.addf(SeverityError, file, resource, "invalid match expression %q in routing rule %d: %s", rule.Match, i, err)
+			}
+		}
+	} else {
+		var sub subscriptions_v1alpha1.Subscription
+		if !v.decode(file, subscriptionKind, doc, &sub) {
+			return
+		}
+		resource += "/" + sub.Name
+		pubsubName, scopes = sub.Spec.Pubsubname, sub.Scopes
+
+		if sub.Spec.Route == "" {
+			v.addf(SeverityError, file, resource, "spec.route is not set")
+		}
+	}
+
+	if pubsubName == "" {
+		v.addf(SeverityError, file, resource, "spec.pubsubname is not set")
+	} else if !v.hasComponent(pubsubCategory, pubsubName) {
+		v.addf(SeverityWarning, file, resource, "pub/sub component %s is not defined in %s", pubsubName, v.opts.ComponentsPath)
+	}
+	v.validateScopes(file, resource, scopes)
+}
+
+func (v *validator) hasComponent(category, name string) bool {
+	for _, c := range v.components {
+		if c.Name == name && componentCategory(c.Spec.Type) == category {
+			return true
+		}
+	}
+	return false
+}
+
+func componentCategory(componentType string) string {
+	for _, category := range componentCategories {
+		if strings.HasPrefix(componentType, category+".") {
+			return category
+		}
+	}
+	return ""
+}
+
+func versionOrDefault(version string) string {
+	if version == "" {
+		return "v1"
+	}
+	return version
+}
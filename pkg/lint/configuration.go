@@ -0,0 +1,121 @@
+package lint
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bhojpur/application/pkg/acl"
+	"github.com/bhojpur/application/pkg/config"
+)
+
+const configurationKind = "Configuration"
+
+func (v *validator) validateConfigFile() {
+	file := v.opts.ConfigFile
+	conf, _, err := config.LoadStandaloneConfiguration(file)
+	if err != nil {
+		v.addf(SeverityError, file, configurationKind, "could not load configuration: %s", err)
+		return
+	}
+
+	resource := configurationKind
+	if conf.Name != "" {
+		resource += "/" + conf.Name
+	}
+	v.validateAccessControl(file, resource, conf.Spec.AccessControlSpec)
+	v.validateMTLS(file, resource, conf.Spec.MTLSSpec)
+	v.validateHTTPPipeline(file, resource, conf.Spec.HTTPPipelineSpec)
+
+	if nr := conf.Spec.NameResolutionSpec; nr.Component != "" && v.opts.Registries != nil {
+		if !v.opts.Registries.NameResolutions.Has(nr.Component, versionOrDefault(nr.Version)) {
+			v.addf(SeverityError, file, resource, "name resolution component %s/%s is not included in the runtime", nr.Component, versionOrDefault(nr.Version))
+		}
+	}
+}
+
+func (v *validator) validateAccessControl(file, resource string, spec config.AccessControlSpec) {
+	if _, err := acl.ParseAccessControlSpec(spec, config.HTTPProtocol); err != nil {
+		v.addf(SeverityError, file, resource, "%s", err)
+	}
+
+	// Any action other than allow denies access, which hides typos.
+	checkAction := func(field, action string) {
+		if action != "" && !strings.EqualFold(action, config.AllowAccess) && !strings.EqualFold(action, config.DenyAccess) {
+			v.addf(SeverityWarning, file, resource, "%s %q is neither %s nor %s and denies access", field, action, config.AllowAccess, config.DenyAccess)
+		}
+	}
+	checkAction("accessControl.defaultAction", spec.DefaultAction)
+	if spec.DefaultAction == "" && len(spec.AppPolicies) > 0 {
+		v.addf(SeverityWarning, file, resource, "accessControl.defaultAction is not set and defaults to %s", config.DenyAccess)
+	}
+	for _, policy := range spec.AppPolicies {
+		checkAction("defaultAction of app "+policy.AppName, policy.DefaultAction)
+		for _, operation := range policy.AppOperationActions {
+			checkAction("action of operation "+operation.Operation+" of app "+policy.AppName, operation.Action)
+		}
+	}
+}
+
+func (v *validator) validateMTLS(file, resource string, spec config.MTLSSpec) {
+	for _, d := range []struct {
+		field string
+		value string
+	}{
+		{"mtls.workloadCertTTL", spec.WorkloadCertTTL},
+		{"mtls.allowedClockSkew", spec.AllowedClockSkew},
+	} {
+		if d.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			v.addf(SeverityError, file, resource, "invalid duration %q for %s: %s", d.value, d.field, err)
+		} else if duration < 0 {
+			v.addf(SeverityError, file, resource, "negative duration %q for %s", d.value, d.field)
+		}
+	}
+}
+
+func (v *validator) validateHTTPPipeline(file, resource string, spec config.PipelineSpec) {
+	for _, handler := range spec.Handlers {
+		if !strings.HasPrefix(handler.Type, httpMiddlewarePrefix) {
+			v.addf(SeverityError, file, resource, "invalid HTTP pipeline handler type %s. Middleware types start with %s", handler.Type, httpMiddlewarePrefix)
+			continue
+		}
+		if r := v.opts.Registries; r != nil && !r.HTTPMiddleware.Has(handler.Type, handler.Version) {
+			v.addf(SeverityError, file, resource, "HTTP pipeline handler type %s/%s is not included in the runtime", handler.Type, versionOrDefault(handler.Version))
+		}
+		if v.opts.ComponentsPath != "" && !v.hasMiddleware(handler.Name, handler.Type) {
+			v.addf(SeverityError, file, resource, "HTTP pipeline handler %s of type %s is not defined in %s", handler.Name, handler.Type, v.opts.ComponentsPath)
+		}
+	}
+}
+
+func (v *validator) hasMiddleware(name, middlewareType string) bool {
+	for _, c := range v.components {
+		if c.Name == name && c.Spec.Type == middlewareType {
+			return true
+		}
+	}
+	return false
+}
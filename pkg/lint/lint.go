@@ -0,0 +1,145 @@
+package lint
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	bindings_loader "github.com/bhojpur/application/pkg/components/bindings"
+	configuration_loader "github.com/bhojpur/application/pkg/components/configuration"
+	lock_loader "github.com/bhojpur/application/pkg/components/lock"
+	http_middleware_loader "github.com/bhojpur/application/pkg/components/middleware/http"
+	nr_loader "github.com/bhojpur/application/pkg/components/nameresolution"
+	pubsub_loader "github.com/bhojpur/application/pkg/components/pubsub"
+	secretstores_loader "github.com/bhojpur/application/pkg/components/secretstores"
+	state_loader "github.com/bhojpur/application/pkg/components/state"
+)
+
+const (
+	// SeverityError marks a finding that prevents the runtime from loading
+	// a resource.
+	SeverityError = "error"
+	// SeverityWarning marks a finding that is likely a mistake but does not
+	// prevent the runtime from starting.
+	SeverityWarning = "warning"
+)
+
+// Finding is a problem found in a components or configuration file.
+type Finding struct {
+	Severity string `json:"severity" yaml:"severity"`
+	File     string `json:"file"     yaml:"file"`
+	Resource string `json:"resource" yaml:"resource"`
+	Message  string `json:"message"  yaml:"message"`
+}
+
+// Report holds the findings of a validation run.
+type Report struct {
+	Valid    bool      `json:"valid"    yaml:"valid"`
+	Errors   int       `json:"errors"   yaml:"errors"`
+	Warnings int       `json:"warnings" yaml:"warnings"`
+	Findings []Finding `json:"findings" yaml:"findings"`
+}
+
+// NewReport returns the report of the given findings. A report is valid when
+// none of its findings is an error.
+func NewReport(findings []Finding) *Report {
+	report := &Report{Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	report.Valid = report.Errors == 0
+	return report
+}
+
+// ComponentTypes lists the names of the components compiled into a runtime,
+// as they are registered in its component registries.
+type ComponentTypes struct {
+	SecretStores    []string `json:"secretStores"`
+	States          []string `json:"states"`
+	PubSubs         []string `json:"pubSubs"`
+	InputBindings   []string `json:"inputBindings"`
+	OutputBindings  []string `json:"outputBindings"`
+	HTTPMiddleware  []string `json:"httpMiddleware"`
+	Configurations  []string `json:"configurations"`
+	Locks           []string `json:"locks"`
+	NameResolutions []string `json:"nameResolutions"`
+}
+
+// Registries are the component registries that component types and versions
+// are checked against.
+type Registries struct {
+	SecretStores    secretstores_loader.Registry
+	States          state_loader.Registry
+	PubSubs         pubsub_loader.Registry
+	Bindings        bindings_loader.Registry
+	HTTPMiddleware  http_middleware_loader.Registry
+	Configurations  configuration_loader.Registry
+	Locks           lock_loader.Registry
+	NameResolutions nr_loader.Registry
+}
+
+// NewRegistries returns registries holding the given component types. The
+// registered components only support lookups and cannot be created.
+func NewRegistries(types ComponentTypes) *Registries {
+	r := &Registries{
+		SecretStores:    secretstores_loader.NewRegistry(),
+		States:          state_loader.NewRegistry(),
+		PubSubs:         pubsub_loader.NewRegistry(),
+		Bindings:        bindings_loader.NewRegistry(),
+		HTTPMiddleware:  http_middleware_loader.NewRegistry(),
+		Configurations:  configuration_loader.NewRegistry(),
+		Locks:           lock_loader.NewRegistry(),
+		NameResolutions: nr_loader.NewRegistry(),
+	}
+	for _, name := range types.SecretStores {
+		r.SecretStores.Register(secretstores_loader.New(name, nil))
+	}
+	for _, name := range types.States {
+		r.States.Register(state_loader.New(name, nil))
+	}
+	for _, name := range types.PubSubs {
+		r.PubSubs.Register(pubsub_loader.New(name, nil))
+	}
+	for _, name := range types.InputBindings {
+		r.Bindings.RegisterInputBindings(bindings_loader.NewInput(name, nil))
+	}
+	for _, name := range types.OutputBindings {
+		r.Bindings.RegisterOutputBindings(bindings_loader.NewOutput(name, nil))
+	}
+	for _, name := range types.HTTPMiddleware {
+		r.HTTPMiddleware.Register(http_middleware_loader.New(name, nil))
+	}
+	for _, name := range types.Configurations {
+		r.Configurations.Register(configuration_loader.New(name, nil))
+	}
+	for _, name := range types.Locks {
+		r.Locks.Register(lock_loader.New(name, nil))
+	}
+	for _, name := range types.NameResolutions {
+		r.NameResolutions.Register(nr_loader.New(name, nil))
+	}
+	return r
+}
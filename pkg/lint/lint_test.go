@@ -0,0 +1,282 @@
+package lint
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stateStoreYAML = `apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: state.redis
+  version: v1
+  metadata:
+  - name: redisPassword
+    secretKeyRef:
+      name: redis
+      key: password
+auth:
+  secretStore: secrets
+scopes:
+- orders
+`
+	secretStoreYAML = `apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+  name: secrets
+spec:
+  type: secretstores.local.env
+  version: v1
+`
+	pubsubYAML = `apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+  name: pubsub
+spec:
+  type: pubsub.redis
+  version: v1
+---
+apiVersion: bhojpur.net/v2alpha1
+kind: Subscription
+metadata:
+  name: orders
+spec:
+  pubsubname: pubsub
+  topic: orders
+  routes:
+    rules:
+    - match: event.type == "order.created"
+      path: /orders/created
+    default: /orders
+`
+	configYAML = `apiVersion: bhojpur.net/v1alpha1
+kind: Configuration
+metadata:
+  name: appconfig
+spec:
+  mtls:
+    workloadCertTTL: 24h
+    allowedClockSkew: 15m
+  accessControl:
+    defaultAction: deny
+    trustDomain: public
+    policies:
+    - appId: orders
+      defaultAction: allow
+      trustDomain: public
+      namespace: default
+  httpPipeline:
+    handlers:
+    - name: uppercase
+      type: middleware.http.uppercase
+`
+	middlewareYAML = `apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+  name: uppercase
+spec:
+  type: middleware.http.uppercase
+  version: v1
+`
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "lint")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func testRegistries() *Registries {
+	return NewRegistries(ComponentTypes{
+		SecretStores:    []string{"local.env"},
+		States:          []string{"redis"},
+		PubSubs:         []string{"redis"},
+		HTTPMiddleware:  []string{"uppercase"},
+		NameResolutions: []string{"mdns"},
+	})
+}
+
+func validFiles() map[string]string {
+	return map[string]string{
+		"statestore.yaml": stateStoreYAML,
+		"secrets.yaml":    secretStoreYAML,
+		"pubsub.yaml":     pubsubYAML,
+		"uppercase.yaml":  middlewareYAML,
+		"config.yaml":     configYAML,
+		"README.md":       "not a component",
+	}
+}
+
+func messages(report *Report) string {
+	msgs := []string{}
+	for _, f := range report.Findings {
+		msgs = append(msgs, f.Severity+": "+f.Message)
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid files", func(t *testing.T) {
+		dir := writeFiles(t, validFiles())
+		report := Validate(Options{
+			ComponentsPath: dir,
+			ConfigFile:     filepath.Join(dir, "config.yaml"),
+			Registries:     testRegistries(),
+		})
+		assert.True(t, report.Valid, messages(report))
+		assert.Empty(t, report.Findings, messages(report))
+	})
+
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		severity string
+		message  string
+	}{
+		{
+			name:     "unknown component type",
+			files:    map[string]string{"statestore.yaml": strings.Replace(stateStoreYAML, "state.redis", "state.mysql", 1)},
+			severity: SeverityError,
+			message:  "component type state.mysql/v1 is not included in the runtime",
+		},
+		{
+			name:     "unknown component version",
+			files:    map[string]string{"secrets.yaml": strings.Replace(secretStoreYAML, "version: v1", "version: v2", 1)},
+			severity: SeverityError,
+			message:  "component type secretstores.local.env/v2 is not included in the runtime",
+		},
+		{
+			name:     "unknown component category",
+			files:    map[string]string{"secrets.yaml": strings.Replace(secretStoreYAML, "secretstores.local.env", "secrets.local.env", 1)},
+			severity: SeverityError,
+			message:  "unknown component type secrets.local.env",
+		},
+		{
+			name:     "duplicate names",
+			files:    map[string]string{"other.yaml": stateStoreYAML},
+			severity: SeverityError,
+			message:  "duplicate state component name statestore",
+		},
+		{
+			name:     "missing secret store",
+			files:    map[string]string{"secrets.yaml": strings.Replace(secretStoreYAML, "name: secrets", "name: vault", 1)},
+			severity: SeverityError,
+			message:  "references secret store secrets which is not defined",
+		},
+		{
+			name:     "invalid scope",
+			files:    map[string]string{"statestore.yaml": strings.Replace(stateStoreYAML, "- orders", "- \"orders, payments\"", 1)},
+			severity: SeverityError,
+			message:  `invalid scope "orders, payments"`,
+		},
+		{
+			name:     "unknown field",
+			files:    map[string]string{"secrets.yaml": strings.Replace(secretStoreYAML, "  version: v1", "  version: v1\n  metdata: []", 1)},
+			severity: SeverityWarning,
+			message:  `unknown field "metdata"`,
+		},
+		{
+			name:     "invalid routing expression",
+			files:    map[string]string{"pubsub.yaml": strings.Replace(pubsubYAML, `event.type == "order.created"`, `event.type ==`, 1)},
+			severity: SeverityError,
+			message:  "invalid match expression",
+		},
+		{
+			name:     "invalid ACL",
+			files:    map[string]string{"config.yaml": strings.Replace(configYAML, "      namespace: default\n", "", 1)},
+			severity: SeverityError,
+			message:  "missing namespace for apps: [orders]",
+		},
+		{
+			name:     "invalid ACL action",
+			files:    map[string]string{"config.yaml": strings.Replace(configYAML, "defaultAction: allow", "defaultAction: alow", 1)},
+			severity: SeverityWarning,
+			message:  `defaultAction of app orders "alow" is neither allow nor deny`,
+		},
+		{
+			name:     "invalid mTLS duration",
+			files:    map[string]string{"config.yaml": strings.Replace(configYAML, "workloadCertTTL: 24h", "workloadCertTTL: 1 day", 1)},
+			severity: SeverityError,
+			message:  `invalid duration "1 day" for mtls.workloadCertTTL`,
+		},
+		{
+			name:     "undefined HTTP pipeline handler",
+			files:    map[string]string{"uppercase.yaml": strings.Replace(middlewareYAML, "name: uppercase", "name: lowercase", 1)},
+			severity: SeverityError,
+			message:  "HTTP pipeline handler uppercase of type middleware.http.uppercase is not defined",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			files := validFiles()
+			for name, content := range tc.files {
+				files[name] = content
+			}
+			dir := writeFiles(t, files)
+
+			report := Validate(Options{
+				ComponentsPath: dir,
+				ConfigFile:     filepath.Join(dir, "config.yaml"),
+				Registries:     testRegistries(),
+			})
+			assert.Equal(t, tc.severity != SeverityError, report.Valid)
+
+			found := false
+			for _, f := range report.Findings {
+				if f.Severity == tc.severity && strings.Contains(f.Message, tc.message) {
+					found = true
+				}
+			}
+			assert.True(t, found, "expected %s %q in:\n%s", tc.severity, tc.message, messages(report))
+		})
+	}
+
+	t.Run("component types are not checked without registries", func(t *testing.T) {
+		files := validFiles()
+		files["statestore.yaml"] = strings.Replace(stateStoreYAML, "state.redis", "state.mysql", 1)
+		dir := writeFiles(t, files)
+
+		report := Validate(Options{ComponentsPath: dir})
+		assert.True(t, report.Valid, messages(report))
+	})
+
+	t.Run("missing components directory", func(t *testing.T) {
+		report := Validate(Options{ComponentsPath: filepath.Join(os.TempDir(), "lint-missing-components")})
+		assert.False(t, report.Valid)
+		assert.Equal(t, 1, report.Errors)
+	})
+}
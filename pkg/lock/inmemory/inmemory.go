@@ -0,0 +1,118 @@
+package inmemory
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bhojpur/application/pkg/lock"
+)
+
+// sweepInterval is the minimum time between two removals of expired locks.
+const sweepInterval = time.Minute
+
+type lockEntry struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// InMemoryLock is a lock store that keeps locks in the memory of the runtime.
+// Locks are not shared between runtime instances, so it is meant for
+// standalone mode and tests.
+type InMemoryLock struct {
+	locks     map[string]lockEntry
+	lock      sync.Mutex
+	lastSweep time.Time
+	clock     func() time.Time
+}
+
+// NewInMemoryLock returns a new in-memory lock store.
+func NewInMemoryLock() lock.Store {
+	return newInMemoryLock(time.Now)
+}
+
+func newInMemoryLock(clock func() time.Time) *InMemoryLock {
+	return &InMemoryLock{
+		locks:     map[string]lockEntry{},
+		lastSweep: clock(),
+		clock:     clock,
+	}
+}
+
+// Init initializes the lock store.
+func (l *InMemoryLock) Init(metadata lock.Metadata) error {
+	return nil
+}
+
+// TryLock acquires the lock on a resource if it is not held by anyone.
+// Locks are not reentrant: a second request from the current owner fails
+// until the lock is released or expires.
+func (l *InMemoryLock) TryLock(ctx context.Context, req *lock.TryLockRequest) (*lock.TryLockResponse, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock()
+	l.sweep(now)
+
+	if entry, ok := l.locks[req.ResourceID]; ok && now.Before(entry.expiresAt) {
+		return &lock.TryLockResponse{Success: false}, nil
+	}
+
+	l.locks[req.ResourceID] = lockEntry{
+		owner:     req.LockOwner,
+		expiresAt: now.Add(time.Duration(req.ExpiryInSeconds) * time.Second),
+	}
+	return &lock.TryLockResponse{Success: true}, nil
+}
+
+// Unlock releases the lock on a resource if it is held by the requesting owner.
+func (l *InMemoryLock) Unlock(ctx context.Context, req *lock.UnlockRequest) (*lock.UnlockResponse, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entry, ok := l.locks[req.ResourceID]
+	if !ok || !l.clock().Before(entry.expiresAt) {
+		delete(l.locks, req.ResourceID)
+		return &lock.UnlockResponse{Status: lock.LockDoesNotExist}, nil
+	}
+	if entry.owner != req.LockOwner {
+		return &lock.UnlockResponse{Status: lock.LockBelongsToOthers}, nil
+	}
+
+	delete(l.locks, req.ResourceID)
+	return &lock.UnlockResponse{Status: lock.Success}, nil
+}
+
+// sweep removes expired locks so that resources that are never locked again
+// do not stay in memory. It must be called with the lock held.
+func (l *InMemoryLock) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	for resourceID, entry := range l.locks {
+		if !now.Before(entry.expiresAt) {
+			delete(l.locks, resourceID)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,85 @@
+package inmemory
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bhojpur/application/pkg/lock"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestInMemoryLock(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Now()}
+	store := newInMemoryLock(clock.Now)
+	assert.NoError(t, store.Init(lock.Metadata{}))
+
+	tryLock := func(owner string) bool {
+		resp, err := store.TryLock(ctx, &lock.TryLockRequest{ResourceID: "resource", LockOwner: owner, ExpiryInSeconds: 10})
+		assert.NoError(t, err)
+		return resp.Success
+	}
+	unlock := func(owner string) lock.Status {
+		resp, err := store.Unlock(ctx, &lock.UnlockRequest{ResourceID: "resource", LockOwner: owner})
+		assert.NoError(t, err)
+		return resp.Status
+	}
+
+	t.Run("lock is exclusive", func(t *testing.T) {
+		assert.True(t, tryLock("owner1"))
+		assert.False(t, tryLock("owner2"))
+		assert.False(t, tryLock("owner1"))
+	})
+
+	t.Run("only the owner can unlock", func(t *testing.T) {
+		assert.Equal(t, lock.LockBelongsToOthers, unlock("owner2"))
+		assert.Equal(t, lock.Success, unlock("owner1"))
+		assert.Equal(t, lock.LockDoesNotExist, unlock("owner1"))
+	})
+
+	t.Run("lock expires", func(t *testing.T) {
+		assert.True(t, tryLock("owner1"))
+		clock.now = clock.now.Add(10 * time.Second)
+		assert.Equal(t, lock.LockDoesNotExist, unlock("owner1"))
+		assert.True(t, tryLock("owner2"))
+		assert.Equal(t, lock.Success, unlock("owner2"))
+	})
+
+	t.Run("expired locks are swept", func(t *testing.T) {
+		_, err := store.TryLock(ctx, &lock.TryLockRequest{ResourceID: "other", LockOwner: "owner1", ExpiryInSeconds: 1})
+		assert.NoError(t, err)
+		clock.now = clock.now.Add(sweepInterval)
+		assert.True(t, tryLock("owner1"))
+		assert.NotContains(t, store.locks, "other")
+	})
+}
@@ -0,0 +1,37 @@
+package lock
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// TryLockRequest is the request to acquire a lock.
+type TryLockRequest struct {
+	// ResourceID is the identifier of the locked resource.
+	ResourceID string `json:"resourceId"`
+	// LockOwner identifies the holder of the lock. Only the owner can release it.
+	LockOwner string `json:"lockOwner"`
+	// ExpiryInSeconds is the time after which the lock is released if its owner did not unlock it.
+	ExpiryInSeconds int32 `json:"expiryInSeconds"`
+}
+
+// UnlockRequest is the request to release a lock.
+type UnlockRequest struct {
+	ResourceID string `json:"resourceId"`
+	LockOwner  string `json:"lockOwner"`
+}
@@ -0,0 +1,46 @@
+package lock
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Status is the result of an unlock operation.
+type Status int32
+
+const (
+	// Success means the lock was released.
+	Success Status = 0
+	// LockDoesNotExist means the resource is not locked.
+	LockDoesNotExist Status = 1
+	// LockBelongsToOthers means the lock is held by another owner.
+	LockBelongsToOthers Status = 2
+	// InternalError means the lock store failed to release the lock.
+	InternalError Status = 3
+)
+
+// TryLockResponse is the response of a lock request.
+type TryLockResponse struct {
+	// Success is true if the lock was acquired.
+	Success bool `json:"success"`
+}
+
+// UnlockResponse is the response of an unlock request.
+type UnlockResponse struct {
+	Status Status `json:"status"`
+}
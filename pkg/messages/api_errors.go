@@ -51,6 +51,10 @@ const (
 	ErrPubsubPublishMessage     = "error when publish to topic %s in pubsub %s: %s"
 	ErrPubsubForbidden          = "topic %s is not allowed for app id %s"
 	ErrPubsubCloudEventCreation = "cannot create cloudevent: %s"
+	ErrPubsubBulkEntriesEmpty   = "no entries in bulk publish request to topic %s in pubsub %s"
+	ErrPubsubBulkEntryIDEmpty   = "entryId is empty for an entry of bulk publish request to topic %s in pubsub %s"
+	ErrPubsubBulkEntryIDDup     = "entryId %s is not unique in bulk publish request to topic %s in pubsub %s"
+	ErrPubsubBulkPublishMessage = "error when bulk publish to topic %s in pubsub %s: %d of %d entries failed"
 
 	// AppChannel.
 	ErrChannelNotFound       = "app channel is not initialized"
@@ -64,11 +68,14 @@ const (
 	ErrActorReminderCreate       = "error creating actor reminder: %s"
 	ErrActorReminderRename       = "error rename actor reminder: %s"
 	ErrActorReminderGet          = "error getting actor reminder: %s"
+	ErrActorReminderList         = "error listing actor reminders: %s"
 	ErrActorReminderDelete       = "error deleting actor reminder: %s"
 	ErrActorTimerCreate          = "error creating actor timer: %s"
 	ErrActorTimerDelete          = "error deleting actor timer: %s"
 	ErrActorStateGet             = "error getting actor state: %s"
+	ErrActorStateQuery           = "error querying actor state: %s"
 	ErrActorStateTransactionSave = "error saving actor transaction state: %s"
+	ErrActorTypeReserved         = "actor type %s is reserved for internal use"
 
 	// Secret.
 	ErrSecretStoreNotConfigured = "secret store is not configured"
@@ -94,4 +101,21 @@ const (
 	ErrConfigurationStoreNotFound       = "error configuration stores %s not found"
 	ErrConfigurationGet                 = "fail to get %s from Configuration store %s: %s"
 	ErrConfigurationSubscribe           = "fail to subscribe %s from Configuration store %s: %s"
+
+	// Lock.
+	ErrLockStoresNotConfigured    = "lock store is not configured"
+	ErrLockStoreNotFound          = "lock store %s is not found"
+	ErrResourceIDEmpty            = "resourceId is empty in lock store %s"
+	ErrLockOwnerEmpty             = "lockOwner is empty in lock store %s"
+	ErrExpiryInSecondsNotPositive = "expiryInSeconds must be greater than zero in lock store %s"
+	ErrTryLockFailed              = "failed to try acquiring lock in lock store %s: %s"
+	ErrUnlockFailed               = "failed to release lock in lock store %s: %s"
+
+	// Workflows.
+	ErrWorkflowInstanceNotFound = "workflow instance %s not found"
+	ErrWorkflowInstanceExists   = "workflow instance %s already exists"
+	ErrWorkflowStart            = "error starting workflow instance %s: %s"
+	ErrWorkflowGet              = "error getting workflow instance %s: %s"
+	ErrWorkflowTerminate        = "error terminating workflow instance %s: %s"
+	ErrWorkflowRaiseEvent       = "error raising event %s on workflow instance %s: %s"
 )
@@ -30,6 +30,7 @@ import (
 	"github.com/valyala/fasthttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	nr "github.com/bhojpur/service/pkg/nameresolution"
@@ -42,11 +43,18 @@ import (
 	"github.com/bhojpur/application/pkg/utils"
 
 	internalv1pb "github.com/bhojpur/api/pkg/core/v1/internals"
+	internalv1streampb "github.com/bhojpur/application/pkg/api/v1/internals"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/resiliency"
+	auth "github.com/bhojpur/application/pkg/runtime/security"
 )
 
 var log = logger.NewLogger("app.runtime.direct_messaging")
 
+// maxBufferedInvokeDataSize is the size of the largest request data that is buffered
+// instead of streamed to the target app.
+const maxBufferedInvokeDataSize = 1 << 20
+
 // messageClientConnection is the function type to connect to the other
 // applications to send the message using service invocation.
 type messageClientConnection func(ctx context.Context, address, id string, namespace string, skipTLS, recreateIfExists, enableSSL bool, customOpts ...grpc.DialOption) (*grpc.ClientConn, error)
@@ -70,6 +78,9 @@ type directMessaging struct {
 	maxRequestBodySize  int
 	proxy               Proxy
 	readBufferSize      int
+	resiliency          *resiliency.Resiliency
+	callerToken         string
+	httpEndpoints       map[string]channel.AppChannel
 }
 
 type remoteApp struct {
@@ -85,7 +96,8 @@ func NewDirectMessaging(
 	appChannel channel.AppChannel,
 	clientConnFn messageClientConnection,
 	resolver nr.Resolver,
-	tracingSpec config.TracingSpec, maxRequestBodySize int, proxy Proxy, readBufferSize int, streamRequestBody bool) DirectMessaging {
+	tracingSpec config.TracingSpec, maxRequestBodySize int, proxy Proxy, readBufferSize int, streamRequestBody bool,
+	resiliency *resiliency.Resiliency, callerToken string, httpEndpoints map[string]channel.AppChannel) DirectMessaging {
 	hAddr, _ := utils.GetHostAddress()
 	hName, _ := os.Hostname()
 
@@ -103,6 +115,9 @@ func NewDirectMessaging(
 		maxRequestBodySize:  maxRequestBodySize,
 		proxy:               proxy,
 		readBufferSize:      readBufferSize,
+		resiliency:          resiliency,
+		callerToken:         callerToken,
+		httpEndpoints:       httpEndpoints,
 	}
 
 	if proxy != nil {
@@ -113,8 +128,19 @@ func NewDirectMessaging(
 	return dm
 }
 
-// Invoke takes a message requests and invokes an app, either local or remote.
+// Invoke takes a message requests and invokes an app, either local or remote,
+// or an HTTP endpoint without a sidecar.
 func (d *directMessaging) Invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	// Small streamed bodies are buffered, so the call can be retried, go through the
+	// resiliency policies and be recorded like any other.
+	if _, err := req.BufferSmallDataStream(maxBufferedInvokeDataSize); err != nil {
+		return nil, errors.Wrap(err, "failed to read request data")
+	}
+
+	if endpoint, ok := d.httpEndpoints[targetAppID]; ok {
+		return d.invokeHTTPEndpoint(ctx, targetAppID, endpoint, req)
+	}
+
 	app, err := d.getRemoteApp(targetAppID)
 	if err != nil {
 		return nil, err
@@ -123,6 +149,11 @@ func (d *directMessaging) Invoke(ctx context.Context, targetAppID string, req *i
 	if app.id == d.appID && app.namespace == d.namespace {
 		return d.invokeLocal(ctx, req)
 	}
+	if req.DataStream() != nil {
+		// A streamed request can only be read once, so it is neither retried
+		// nor sent through the resiliency policies.
+		return d.invokeRemoteStream(ctx, app.id, app.namespace, app.address, req)
+	}
 	return d.invokeWithRetry(ctx, utils.DefaultLinearRetryCount, utils.DefaultLinearBackoffInterval, app, d.invokeRemote, req)
 }
 
@@ -138,7 +169,8 @@ func (d *directMessaging) requestAppIDAndNamespace(targetAppID string) (string,
 	}
 }
 
-// invokeWithRetry will call a remote endpoint for the specified number of retries and will only retry in the case of transient failures
+// invokeWithRetry will call a remote endpoint for the specified number of retries and will only retry in the case of transient failures.
+// When a resiliency policy is configured for the target app, the policy replaces the built-in retries.
 // TODO: check why https://github.com/grpc-ecosystem/go-grpc-middleware/blob/master/retry/examples_test.go doesn't recover the connection when target
 // Server shuts down.
 func (d *directMessaging) invokeWithRetry(
@@ -148,6 +180,10 @@ func (d *directMessaging) invokeWithRetry(
 	app remoteApp,
 	fn func(ctx context.Context, appID, namespace, appAddress string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error),
 	req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	if d.resiliency.PolicyDefined(app.id, resiliency.Endpoint) {
+		return d.invokeWithPolicy(ctx, app, fn, req)
+	}
+
 	for i := 0; i < numRetries; i++ {
 		resp, err := fn(ctx, app.id, app.namespace, app.address, req)
 		if err == nil {
@@ -170,6 +206,49 @@ func (d *directMessaging) invokeWithRetry(
 	return nil, errors.Errorf("failed to invoke target %s after %v retries", app.id, numRetries)
 }
 
+// invokeWithPolicy calls a remote endpoint with the resiliency policy configured for the target app.
+func (d *directMessaging) invokeWithPolicy(
+	ctx context.Context,
+	app remoteApp,
+	fn func(ctx context.Context, appID, namespace, appAddress string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error),
+	req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	policy := d.resiliency.EndpointPolicy(ctx, app.id, app.id+":"+req.Message().Method)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		resp, rErr := fn(ctx, app.id, app.namespace, app.address, req)
+		if rErr == nil {
+			return resp, nil
+		}
+
+		code := status.Code(rErr)
+		if code == codes.Unavailable || code == codes.Unauthenticated {
+			_, connerr := d.connectionCreatorFn(context.TODO(), app.address, app.id, app.namespace, false, true, false)
+			if connerr != nil {
+				return nil, connerr
+			}
+		}
+		return resp, rErr
+	})
+	resp, _ := res.(*invokev1.InvokeMethodResponse)
+	return resp, err
+}
+
+// invokeHTTPEndpoint calls an HTTP endpoint with the resiliency policy configured for it.
+// Streamed requests can only be read once, so they are sent without the policy.
+func (d *directMessaging) invokeHTTPEndpoint(ctx context.Context, name string, endpoint channel.AppChannel, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	d.addForwardedHeadersToMetadata(req)
+
+	if req.DataStream() != nil || !d.resiliency.PolicyDefined(name, resiliency.Endpoint) {
+		return endpoint.InvokeMethod(ctx, req)
+	}
+
+	policy := d.resiliency.EndpointPolicy(ctx, name, name+":"+req.Message().Method)
+	res, err := policy(func(ctx context.Context) (interface{}, error) {
+		return endpoint.InvokeMethod(ctx, req)
+	})
+	resp, _ := res.(*invokev1.InvokeMethodResponse)
+	return resp, err
+}
+
 func (d *directMessaging) invokeLocal(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	if d.appChannel == nil {
 		return nil, errors.New("cannot invoke local endpoint: app channel not initialized")
@@ -192,9 +271,11 @@ func (d *directMessaging) invokeRemote(ctx context.Context, appID, namespace, ap
 	}
 
 	ctx = d.setContextSpan(ctx)
+	ctx = d.setCallerToken(ctx)
 
 	d.addForwardedHeadersToMetadata(req)
 	d.addDestinationAppIDHeaderToMetadata(appID, req)
+	d.addCallerAppIDHeaderToMetadata(req)
 
 	clientV1 := internalv1pb.NewServiceInvocationClient(conn)
 
@@ -209,12 +290,119 @@ func (d *directMessaging) invokeRemote(ctx context.Context, appID, namespace, ap
 	return invokev1.InternalInvokeResponse(resp)
 }
 
+// invokeRemoteStream sends a request to a remote app over a gRPC stream, with
+// its data and the response data in chunks. The response is returned as soon
+// as the callee starts answering, with a data stream which must be closed.
+func (d *directMessaging) invokeRemoteStream(ctx context.Context, appID, namespace, appAddress string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	conn, err := d.connectionCreatorFn(context.TODO(), appAddress, appID, namespace, false, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = d.setContextSpan(ctx)
+	ctx = d.setCallerToken(ctx)
+
+	d.addForwardedHeadersToMetadata(req)
+	d.addDestinationAppIDHeaderToMetadata(appID, req)
+	d.addCallerAppIDHeaderToMetadata(req)
+
+	// The stream outlives this call while the response data is read, so it is
+	// cancelled when the response data stream is closed.
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := internalv1streampb.NewServiceInvocationStreamClient(conn).CallLocalStream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		if readErr := sendRequestStream(stream, req); readErr != nil {
+			log.Debugf("error reading streamed request to app %s: %s", appID, readErr)
+			cancel()
+		}
+	}()
+	release := func() {
+		cancel()
+		// The request data may be read from the caller until the stream ends.
+		<-sent
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp, err := invokev1.InternalInvokeResponse(first.GetResponse())
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	data := invokev1.NewStreamPayloadReader(func() (*internalv1streampb.StreamPayload, error) {
+		msg, recvErr := stream.Recv()
+		if recvErr != nil {
+			return nil, recvErr
+		}
+		return msg.GetPayload(), nil
+	}, release)
+	return resp.WithDataStream(data, resp.Message().GetContentType()), nil
+}
+
+// sendRequestStream sends the request without its data, then its data in
+// chunks, and closes the sending side of the stream. Only the errors reading
+// the request data are returned: a failed send means the stream ended, and
+// the reason is received with the response.
+func sendRequestStream(stream internalv1streampb.ServiceInvocationStream_CallLocalStreamClient, req *invokev1.InvokeMethodRequest) error {
+	if err := stream.Send(&internalv1streampb.InternalInvokeRequestStream{Request: req.Proto()}); err != nil {
+		return nil
+	}
+
+	var sendErr error
+	err := invokev1.SendStreamPayloads(req.DataStream(), func(payload *internalv1streampb.StreamPayload) error {
+		sendErr = stream.Send(&internalv1streampb.InternalInvokeRequestStream{Payload: payload})
+		return sendErr
+	})
+	if err != nil && err != sendErr {
+		return err
+	}
+	if sendErr == nil {
+		stream.CloseSend()
+	}
+	return nil
+}
+
 func (d *directMessaging) addDestinationAppIDHeaderToMetadata(appID string, req *invokev1.InvokeMethodRequest) {
 	req.Metadata()[invokev1.DestinationIDHeader] = &internalv1pb.ListStringValue{
 		Values: []string{appID},
 	}
 }
 
+// setCallerToken presents the API token to the invoked app, whose access control
+// policies may identify its callers with the API token shared by the sidecars.
+func (d *directMessaging) setCallerToken(ctx context.Context) context.Context {
+	if d.callerToken == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, auth.APITokenHeader, d.callerToken)
+}
+
+// addCallerAppIDHeaderToMetadata sets the app id of the caller, replacing any
+// value sent by the app, for the rate limits of the invoked app.
+// addCallerAppIDHeaderToMetadata sets the caller app id, replacing the header in any
+// case the app may have set it in.
+func (d *directMessaging) addCallerAppIDHeaderToMetadata(req *invokev1.InvokeMethodRequest) {
+	for k := range req.Metadata() {
+		if strings.EqualFold(k, invokev1.CallerIDHeader) {
+			delete(req.Metadata(), k)
+		}
+	}
+	req.Metadata()[invokev1.CallerIDHeader] = &internalv1pb.ListStringValue{
+		Values: []string{d.appID},
+	}
+}
+
 func (d *directMessaging) addForwardedHeadersToMetadata(req *invokev1.InvokeMethodRequest) {
 	metadata := req.Metadata()
 
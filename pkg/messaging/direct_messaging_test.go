@@ -21,12 +21,23 @@ package messaging
 // THE SOFTWARE.
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/bhojpur/application/pkg/channel"
+	channelt "github.com/bhojpur/application/pkg/channel/testing"
+	"github.com/bhojpur/application/pkg/config"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/resiliency"
+	auth "github.com/bhojpur/application/pkg/runtime/security"
 )
 
 func newDirectMessaging() *directMessaging {
@@ -46,6 +57,115 @@ func TestDestinationHeaders(t *testing.T) {
 	})
 }
 
+func TestCallerHeaders(t *testing.T) {
+	t.Run("caller header replaces the app value", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("GET")
+		req.WithMetadata(map[string][]string{invokev1.CallerIDHeader: {"spoofed"}})
+
+		dm := newDirectMessaging()
+		dm.appID = "caller"
+		dm.addCallerAppIDHeaderToMetadata(req)
+		md := req.Metadata()[invokev1.CallerIDHeader]
+		assert.Equal(t, []string{"caller"}, md.Values)
+	})
+
+	t.Run("caller header in another case is removed", func(t *testing.T) {
+		req := invokev1.NewInvokeMethodRequest("GET")
+		req.WithMetadata(map[string][]string{"App-Caller-Id": {"spoofed"}})
+
+		dm := newDirectMessaging()
+		dm.appID = "caller"
+		dm.addCallerAppIDHeaderToMetadata(req)
+		assert.NotContains(t, req.Metadata(), "App-Caller-Id")
+		assert.Equal(t, []string{"caller"}, req.Metadata()[invokev1.CallerIDHeader].Values)
+	})
+}
+
+func TestCallerToken(t *testing.T) {
+	t.Run("token is not presented by default", func(t *testing.T) {
+		dm := newDirectMessaging()
+		ctx := dm.setCallerToken(context.Background())
+		_, ok := metadata.FromOutgoingContext(ctx)
+		assert.False(t, ok)
+	})
+
+	t.Run("token is presented", func(t *testing.T) {
+		dm := newDirectMessaging()
+		dm.callerToken = "token"
+		ctx := dm.setCallerToken(context.Background())
+		md, _ := metadata.FromOutgoingContext(ctx)
+		assert.Equal(t, []string{"token"}, md.Get(auth.APITokenHeader))
+	})
+}
+
+func TestInvokeHTTPEndpoint(t *testing.T) {
+	newRequest := func() *invokev1.InvokeMethodRequest {
+		return invokev1.NewInvokeMethodRequest("orders").
+			WithHTTPExtension("GET", "").
+			WithMetadata(map[string][]string{})
+	}
+
+	t.Run("endpoint is called without resolving an app", func(t *testing.T) {
+		endpoint := new(channelt.MockAppChannel)
+		endpoint.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil).Once()
+		d := &directMessaging{
+			hostAddress:   "1.2.3.4",
+			httpEndpoints: map[string]channel.AppChannel{"billing": endpoint},
+		}
+
+		req := newRequest()
+		resp, err := d.Invoke(context.Background(), "billing", req)
+		require.NoError(t, err)
+		assert.Equal(t, int32(200), resp.Status().Code)
+		assert.Equal(t, "1.2.3.4", req.Metadata()[fasthttp.HeaderXForwardedFor].Values[0])
+		endpoint.AssertNumberOfCalls(t, "InvokeMethod", 1)
+	})
+
+	t.Run("endpoint resiliency policy is applied", func(t *testing.T) {
+		maxRetries := 2
+		r, err := resiliency.New(config.ResiliencySpec{
+			Policies: config.PoliciesSpec{
+				Retries: map[string]config.RetrySpec{
+					"retry": {Policy: "constant", Duration: "1ms", MaxRetries: &maxRetries},
+				},
+			},
+			Targets: config.TargetsSpec{
+				Apps: map[string]config.EndpointPolicyNames{
+					"billing": {Retry: "retry"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		endpoint := new(channelt.MockAppChannel)
+		endpoint.On("InvokeMethod", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+		endpoint.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil).Once()
+		d := &directMessaging{
+			resiliency:    r,
+			httpEndpoints: map[string]channel.AppChannel{"billing": endpoint},
+		}
+
+		resp, err := d.Invoke(context.Background(), "billing", newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, int32(200), resp.Status().Code)
+		endpoint.AssertNumberOfCalls(t, "InvokeMethod", 2)
+
+		t.Run("small streamed request is buffered and retried", func(t *testing.T) {
+			endpoint.On("InvokeMethod", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+			endpoint.On("InvokeMethod", mock.Anything, mock.Anything).Return(invokev1.NewInvokeMethodResponse(200, "OK", nil), nil).Once()
+
+			req := newRequest().WithDataStream(strings.NewReader("order"), "text/plain")
+			resp, err := d.Invoke(context.Background(), "billing", req)
+			require.NoError(t, err)
+			assert.Equal(t, int32(200), resp.Status().Code)
+			assert.Nil(t, req.DataStream())
+			_, data := req.RawData()
+			assert.Equal(t, []byte("order"), data)
+			endpoint.AssertNumberOfCalls(t, "InvokeMethod", 4)
+		})
+	})
+}
+
 func TestForwardedHeaders(t *testing.T) {
 	t.Run("forwarded headers present", func(t *testing.T) {
 		req := invokev1.NewInvokeMethodRequest("GET")
@@ -21,7 +21,10 @@ package v1
 // THE SOFTWARE.
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"io/ioutil"
 	"strings"
 
 	"github.com/valyala/fasthttp"
@@ -39,7 +42,8 @@ const (
 // InvokeMethodRequest holds InternalInvokeRequest protobuf message
 // and provides the helpers to manage it.
 type InvokeMethodRequest struct {
-	r *internalv1pb.InternalInvokeRequest
+	r          *internalv1pb.InternalInvokeRequest
+	dataStream io.Reader
 }
 
 // NewInvokeMethodRequest creates InvokeMethodRequest object for method.
@@ -106,6 +110,18 @@ func (imr *InvokeMethodRequest) WithRawData(data []byte, contentType string) *In
 	return imr
 }
 
+// WithDataStream sets the reader the message data is streamed from, in place
+// of holding the data in the message.
+func (imr *InvokeMethodRequest) WithDataStream(stream io.Reader, contentType string) *InvokeMethodRequest {
+	if contentType == "" {
+		contentType = JSONContentType
+	}
+	imr.r.Message.ContentType = contentType
+	imr.r.Message.Data = nil
+	imr.dataStream = stream
+	return imr
+}
+
 // WithHTTPExtension sets new HTTP extension with verb and querystring.
 func (imr *InvokeMethodRequest) WithHTTPExtension(verb string, querystring string) *InvokeMethodRequest {
 	httpMethod, ok := commonv1pb.HTTPExtension_Verb_value[strings.ToUpper(verb)]
@@ -190,6 +206,50 @@ func (imr *InvokeMethodRequest) RawData() (string, []byte) {
 	return contentType, dataValue
 }
 
+// DataStream returns the reader the message data is streamed from, or nil
+// when the data is held in the message.
+func (imr *InvokeMethodRequest) DataStream() io.Reader {
+	return imr.dataStream
+}
+
+// BufferDataStream reads the streamed message data into the message, for the
+// receivers which cannot stream it.
+func (imr *InvokeMethodRequest) BufferDataStream() error {
+	if imr.dataStream == nil {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(imr.dataStream)
+	imr.dataStream = nil
+	if err != nil {
+		return err
+	}
+	imr.r.Message.Data = &anypb.Any{Value: data}
+	return nil
+}
+
+// BufferSmallDataStream reads the streamed message data into the message when it is
+// not longer than maxSize and returns true. Longer data keeps being streamed,
+// starting with the bytes already read.
+func (imr *InvokeMethodRequest) BufferSmallDataStream(maxSize int) (bool, error) {
+	if imr.dataStream == nil {
+		return true, nil
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(imr.dataStream, int64(maxSize)+1))
+	if err != nil {
+		imr.dataStream = nil
+		return false, err
+	}
+	if len(data) > maxSize {
+		imr.dataStream = io.MultiReader(bytes.NewReader(data), imr.dataStream)
+		return false, nil
+	}
+	imr.dataStream = nil
+	imr.r.Message.Data = &anypb.Any{Value: data}
+	return true, nil
+}
+
 // Adds a new header to the existing set.
 func (imr *InvokeMethodRequest) AddHeaders(header *fasthttp.RequestHeader) {
 	md := map[string][]string{}
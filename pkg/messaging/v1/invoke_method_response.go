@@ -21,6 +21,10 @@ package v1
 // THE SOFTWARE.
 
 import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
 	"github.com/valyala/fasthttp"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -32,7 +36,8 @@ import (
 // InvokeMethodResponse holds InternalInvokeResponse protobuf message
 // and provides the helpers to manage it.
 type InvokeMethodResponse struct {
-	r *internalv1pb.InternalInvokeResponse
+	r          *internalv1pb.InternalInvokeResponse
+	dataStream io.ReadCloser
 }
 
 // NewInvokeMethodResponse returns new InvokeMethodResponse object with status.
@@ -75,6 +80,18 @@ func (imr *InvokeMethodResponse) WithRawData(data []byte, contentType string) *I
 	return imr
 }
 
+// WithDataStream sets the reader the message data is streamed from, in place
+// of holding the data in the message. The reader is closed once the data is read.
+func (imr *InvokeMethodResponse) WithDataStream(stream io.ReadCloser, contentType string) *InvokeMethodResponse {
+	if contentType == "" {
+		contentType = JSONContentType
+	}
+	imr.r.Message.ContentType = contentType
+	imr.r.Message.Data = nil
+	imr.dataStream = stream
+	return imr
+}
+
 // WithHeaders sets gRPC response header metadata.
 func (imr *InvokeMethodResponse) WithHeaders(headers metadata.MD) *InvokeMethodResponse {
 	imr.r.Headers = MetadataToInternalMetadata(headers)
@@ -95,6 +112,18 @@ func (imr *InvokeMethodResponse) WithFastHTTPHeaders(header *fasthttp.ResponseHe
 	return imr
 }
 
+// WithHTTPHeaders populates net/http response header to gRPC header metadata.
+func (imr *InvokeMethodResponse) WithHTTPHeaders(header http.Header) *InvokeMethodResponse {
+	md := AppInternalMetadata{}
+	for k, v := range header {
+		md[k] = &internalv1pb.ListStringValue{Values: v}
+	}
+	if len(md) > 0 {
+		imr.r.Headers = md
+	}
+	return imr
+}
+
 // WithTrailers sets Trailer in internal InvokeMethodResponse.
 func (imr *InvokeMethodResponse) WithTrailers(trailer metadata.MD) *InvokeMethodResponse {
 	imr.r.Trailers = MetadataToInternalMetadata(trailer)
@@ -155,3 +184,26 @@ func (imr *InvokeMethodResponse) RawData() (string, []byte) {
 
 	return contentType, dataValue
 }
+
+// DataStream returns the reader the message data is streamed from, or nil
+// when the data is held in the message.
+func (imr *InvokeMethodResponse) DataStream() io.ReadCloser {
+	return imr.dataStream
+}
+
+// BufferDataStream reads the streamed message data into the message and
+// closes the stream, for the receivers which cannot stream it.
+func (imr *InvokeMethodResponse) BufferDataStream() error {
+	if imr.dataStream == nil {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(imr.dataStream)
+	imr.dataStream.Close()
+	imr.dataStream = nil
+	if err != nil {
+		return err
+	}
+	imr.r.Message.Data = &anypb.Any{Value: data}
+	return nil
+}
@@ -0,0 +1,102 @@
+package v1
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"io"
+
+	internalv1streampb "github.com/bhojpur/application/pkg/api/v1/internals"
+)
+
+// StreamChunkSize is the size of the chunks streamed message data is sent in.
+const StreamChunkSize = 64 << 10
+
+// SendStreamPayloads reads r to the end and sends its data with send, in
+// chunks of StreamChunkSize. A chunk is only read once the previous one was
+// sent, so a slow receiver throttles the reads.
+func SendStreamPayloads(r io.Reader, send func(*internalv1streampb.StreamPayload) error) error {
+	var seq uint64
+	for {
+		// gRPC may hold on to a sent message, so each chunk gets its own buffer.
+		buf := make([]byte, StreamChunkSize)
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if sendErr := send(&internalv1streampb.StreamPayload{Data: buf[:n], Seq: seq}); sendErr != nil {
+				return sendErr
+			}
+			seq++
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// NewStreamPayloadReader returns a reader of the data received in chunks with
+// recv, which returns io.EOF after the last chunk. closeFn is called when the
+// reader is closed, to release the stream the chunks are received from.
+func NewStreamPayloadReader(recv func() (*internalv1streampb.StreamPayload, error), closeFn func()) io.ReadCloser {
+	return &streamPayloadReader{recv: recv, closeFn: closeFn}
+}
+
+type streamPayloadReader struct {
+	recv    func() (*internalv1streampb.StreamPayload, error)
+	closeFn func()
+	buf     []byte
+	seq     uint64
+	err     error
+}
+
+func (r *streamPayloadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		payload, err := r.recv()
+		if err != nil {
+			r.err = err
+			continue
+		}
+		if payload.GetSeq() != r.seq {
+			r.err = fmt.Errorf("unexpected stream chunk %d, expected chunk %d", payload.GetSeq(), r.seq)
+			continue
+		}
+		r.seq++
+		r.buf = payload.GetData()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *streamPayloadReader) Close() error {
+	if r.closeFn != nil {
+		r.closeFn()
+		r.closeFn = nil
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package v1
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalv1streampb "github.com/bhojpur/application/pkg/api/v1/internals"
+)
+
+func TestStreamPayloads(t *testing.T) {
+	collect := func(data string) []*internalv1streampb.StreamPayload {
+		var payloads []*internalv1streampb.StreamPayload
+		err := SendStreamPayloads(strings.NewReader(data), func(p *internalv1streampb.StreamPayload) error {
+			payloads = append(payloads, p)
+			return nil
+		})
+		assert.NoError(t, err)
+		return payloads
+	}
+	receive := func(payloads []*internalv1streampb.StreamPayload, closeFn func()) io.ReadCloser {
+		return NewStreamPayloadReader(func() (*internalv1streampb.StreamPayload, error) {
+			if len(payloads) == 0 {
+				return nil, io.EOF
+			}
+			p := payloads[0]
+			payloads = payloads[1:]
+			return p, nil
+		}, closeFn)
+	}
+
+	t.Run("data is split into chunks", func(t *testing.T) {
+		data := strings.Repeat("x", 2*StreamChunkSize+1)
+		payloads := collect(data)
+
+		assert.Len(t, payloads, 3)
+		for i, p := range payloads {
+			assert.Equal(t, uint64(i), p.GetSeq())
+		}
+		assert.Len(t, payloads[0].GetData(), StreamChunkSize)
+		assert.Len(t, payloads[2].GetData(), 1)
+	})
+
+	t.Run("empty data sends nothing", func(t *testing.T) {
+		assert.Empty(t, collect(""))
+	})
+
+	t.Run("send error is returned", func(t *testing.T) {
+		err := SendStreamPayloads(strings.NewReader("data"), func(*internalv1streampb.StreamPayload) error {
+			return errors.New("send failed")
+		})
+		assert.EqualError(t, err, "send failed")
+	})
+
+	t.Run("chunks are read back", func(t *testing.T) {
+		data := strings.Repeat("abc", StreamChunkSize)
+		closed := 0
+		r := receive(collect(data), func() { closed++ })
+
+		b, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, data, string(b))
+
+		assert.NoError(t, r.Close())
+		assert.NoError(t, r.Close())
+		assert.Equal(t, 1, closed)
+	})
+
+	t.Run("out of order chunk fails the read", func(t *testing.T) {
+		payloads := collect(strings.Repeat("abc", StreamChunkSize))
+		payloads[1], payloads[2] = payloads[2], payloads[1]
+
+		_, err := ioutil.ReadAll(receive(payloads, nil))
+		assert.EqualError(t, err, "unexpected stream chunk 2, expected chunk 1")
+	})
+}
+
+func TestInvokeMethodDataStream(t *testing.T) {
+	t.Run("request", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test").WithRawData([]byte("raw"), "").
+			WithDataStream(bytes.NewReader([]byte("streamed")), "text/plain")
+		assert.NotNil(t, req.DataStream())
+		assert.Equal(t, "text/plain", req.Message().GetContentType())
+
+		assert.NoError(t, req.BufferDataStream())
+		assert.Nil(t, req.DataStream())
+		contentType, data := req.RawData()
+		assert.Equal(t, "text/plain", contentType)
+		assert.Equal(t, []byte("streamed"), data)
+	})
+
+	t.Run("small request is buffered", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test").WithDataStream(bytes.NewReader([]byte("streamed")), "text/plain")
+
+		buffered, err := req.BufferSmallDataStream(8)
+		assert.NoError(t, err)
+		assert.True(t, buffered)
+		assert.Nil(t, req.DataStream())
+		_, data := req.RawData()
+		assert.Equal(t, []byte("streamed"), data)
+	})
+
+	t.Run("large request keeps streaming", func(t *testing.T) {
+		req := NewInvokeMethodRequest("test").WithDataStream(bytes.NewReader([]byte("streamed")), "text/plain")
+
+		buffered, err := req.BufferSmallDataStream(4)
+		assert.NoError(t, err)
+		assert.False(t, buffered)
+		require.NotNil(t, req.DataStream())
+		data, err := ioutil.ReadAll(req.DataStream())
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("streamed"), data)
+	})
+
+	t.Run("response", func(t *testing.T) {
+		closed := false
+		stream := NewStreamPayloadReader(func() (*internalv1streampb.StreamPayload, error) {
+			return nil, io.EOF
+		}, func() { closed = true })
+		resp := NewInvokeMethodResponse(200, "OK", nil).WithDataStream(stream, "application/json")
+		assert.NotNil(t, resp.DataStream())
+
+		assert.NoError(t, resp.BufferDataStream())
+		assert.Nil(t, resp.DataStream())
+		assert.True(t, closed)
+		contentType, data := resp.RawData()
+		assert.Equal(t, "application/json", contentType)
+		assert.Empty(t, data)
+	})
+}
@@ -64,6 +64,8 @@ const (
 
 	// DestinationIDHeader is the header carrying the value of the invoked app id.
 	DestinationIDHeader = "destination-app-id"
+	// CallerIDHeader is the header carrying the app id of the caller.
+	CallerIDHeader = "app-caller-id"
 
 	// ErrorInfo metadata value is limited to 64 chars
 	// https://github.com/googleapis/googleapis/blob/master/google/rpc/error_details.proto#L126
@@ -0,0 +1,259 @@
+package ratelimit
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bhojpur/service/pkg/utils/logger"
+
+	"github.com/bhojpur/application/pkg/config"
+	diag "github.com/bhojpur/application/pkg/diagnostics"
+)
+
+var log = logger.NewLogger("app.runtime.ratelimit")
+
+const (
+	// AnyCaller is the caller app id of the policies applied to every caller.
+	AnyCaller = "*"
+
+	// ReasonRate is the reason of the calls rejected by a requests per second limit.
+	ReasonRate = "rate"
+	// ReasonConcurrency is the reason of the calls rejected by a concurrent requests limit.
+	ReasonConcurrency = "concurrency"
+
+	// concurrencyRetryAfter is the retry hint of the calls rejected by a concurrent
+	// requests limit, which can not tell when a request will complete.
+	concurrencyRetryAfter = time.Second
+
+	// callerIdleTimeout is how long the state of a caller without calls is kept.
+	// The callers are checked for eviction at the same interval.
+	callerIdleTimeout = time.Minute
+)
+
+// Error is returned for the calls over a rate limit.
+type Error struct {
+	CallerAppID string
+	Method      string
+	Reason      string
+	RetryAfter  time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s limit exceeded for caller %q on method %q, retry after %s", e.Reason, e.CallerAppID, e.Method, e.RetryAfter)
+}
+
+// RetryAfterSeconds returns a retry hint rounded up to whole seconds, as used by
+// the Retry-After HTTP header.
+func RetryAfterSeconds(retryAfter time.Duration) int {
+	return int((retryAfter + time.Second - 1) / time.Second)
+}
+
+// GRPCStatus returns a ResourceExhausted status carrying the retry hint.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	if withDetails, err := st.WithDetails(&epb.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)}); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// RetryAfterFromError returns the retry hint of a ResourceExhausted error
+// received from another app.
+func RetryAfterFromError(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*epb.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// Limiter enforces the rate limit policies of the service invocations, keeping
+// the state of each caller separately. A nil *Limiter is valid and allows all calls.
+type Limiter struct {
+	policies []config.RateLimitPolicySpec
+
+	lock      sync.Mutex
+	callers   map[callerKey]*callerState
+	lastSweep time.Time
+}
+
+type callerKey struct {
+	policy      int
+	callerAppID string
+}
+
+type callerState struct {
+	bucket   *rate.Limiter
+	inFlight int
+	lastCall time.Time
+}
+
+// New parses and validates the rate limit section of the configuration. It
+// returns a nil *Limiter when no policies are set.
+func New(spec config.RateLimitSpec) (*Limiter, error) {
+	if len(spec.Policies) == 0 {
+		return nil, nil
+	}
+
+	policies := make([]config.RateLimitPolicySpec, 0, len(spec.Policies))
+	for i, p := range spec.Policies {
+		if p.RequestsPerSecond < 0 || p.Burst < 0 || p.MaxConcurrency < 0 {
+			return nil, fmt.Errorf("rate limit policy %d: limits must not be negative", i)
+		}
+		if p.RequestsPerSecond == 0 && p.MaxConcurrency == 0 {
+			return nil, fmt.Errorf("rate limit policy %d: one of requestsPerSecond or maxConcurrency is required", i)
+		}
+		if p.CallerAppID == "" {
+			p.CallerAppID = AnyCaller
+		}
+		p.MethodPrefix = strings.TrimPrefix(p.MethodPrefix, "/")
+		if p.Burst == 0 {
+			p.Burst = p.RequestsPerSecond
+		}
+		policies = append(policies, p)
+	}
+
+	// The policies of a caller come before the ones of any caller, and longer
+	// method prefixes before shorter ones, so the first match is the most specific.
+	sort.SliceStable(policies, func(i, j int) bool {
+		if (policies[i].CallerAppID == AnyCaller) != (policies[j].CallerAppID == AnyCaller) {
+			return policies[j].CallerAppID == AnyCaller
+		}
+		return len(policies[i].MethodPrefix) > len(policies[j].MethodPrefix)
+	})
+
+	log.Infof("loaded %d rate limit policies", len(policies))
+	return &Limiter{
+		policies:  policies,
+		callers:   map[callerKey]*callerState{},
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// Allow checks the call of method by the caller app against the most specific
+// matching policy. An allowed call must be released with the returned function
+// once it completes. A call over a limit gets an *Error.
+func (l *Limiter) Allow(callerAppID, method string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	method = strings.TrimPrefix(method, "/")
+	policy := -1
+	for i, p := range l.policies {
+		if (p.CallerAppID == AnyCaller || p.CallerAppID == callerAppID) && strings.HasPrefix(method, p.MethodPrefix) {
+			policy = i
+			break
+		}
+	}
+	if policy < 0 {
+		return func() {}, nil
+	}
+	spec := l.policies[policy]
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) >= callerIdleTimeout {
+		l.sweep(now)
+	}
+
+	key := callerKey{policy: policy, callerAppID: callerAppID}
+	state, ok := l.callers[key]
+	if !ok {
+		state = &callerState{}
+		if spec.RequestsPerSecond > 0 {
+			state.bucket = rate.NewLimiter(rate.Limit(spec.RequestsPerSecond), spec.Burst)
+		}
+		l.callers[key] = state
+	}
+
+	state.lastCall = now
+
+	if spec.MaxConcurrency > 0 && state.inFlight >= spec.MaxConcurrency {
+		return nil, l.reject(callerAppID, method, ReasonConcurrency, concurrencyRetryAfter)
+	}
+	if state.bucket != nil {
+		reservation := state.bucket.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			return nil, l.reject(callerAppID, method, ReasonRate, delay)
+		}
+	}
+
+	state.inFlight++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.lock.Lock()
+			state.inFlight--
+			l.lock.Unlock()
+		})
+	}, nil
+}
+
+// sweep evicts the state of the callers that have no call in flight and made their
+// last call long enough ago for their bucket to be full again, so a new state is
+// equivalent. It must be called with the lock held.
+func (l *Limiter) sweep(now time.Time) {
+	l.lastSweep = now
+	for key, state := range l.callers {
+		if state.inFlight > 0 {
+			continue
+		}
+		idle := callerIdleTimeout
+		if spec := l.policies[key.policy]; spec.RequestsPerSecond > 0 {
+			if refill := time.Duration(float64(spec.Burst) / float64(spec.RequestsPerSecond) * float64(time.Second)); refill > idle {
+				idle = refill
+			}
+		}
+		if now.Sub(state.lastCall) >= idle {
+			delete(l.callers, key)
+		}
+	}
+}
+
+func (l *Limiter) reject(callerAppID, method, reason string, retryAfter time.Duration) error {
+	diag.DefaultMonitoring.ServiceInvocationRateLimited(callerAppID, method, reason)
+	return &Error{
+		CallerAppID: callerAppID,
+		Method:      method,
+		Reason:      reason,
+		RetryAfter:  retryAfter,
+	}
+}
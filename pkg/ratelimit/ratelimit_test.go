@@ -0,0 +1,170 @@
+package ratelimit
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bhojpur/application/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("no policies", func(t *testing.T) {
+		l, err := New(config.RateLimitSpec{})
+		assert.NoError(t, err)
+		assert.Nil(t, l)
+
+		release, err := l.Allow("app1", "method")
+		assert.NoError(t, err)
+		release()
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		_, err := New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{{RequestsPerSecond: -1}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		_, err := New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{{CallerAppID: "app1"}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("most specific policies first", func(t *testing.T) {
+		l, err := New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{
+			{MaxConcurrency: 1},
+			{CallerAppID: "*", MethodPrefix: "/orders", MaxConcurrency: 2},
+			{CallerAppID: "app1", MaxConcurrency: 3},
+			{CallerAppID: "app1", MethodPrefix: "orders/", RequestsPerSecond: 4},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, "app1", l.policies[0].CallerAppID)
+		assert.Equal(t, "orders/", l.policies[0].MethodPrefix)
+		assert.Equal(t, 4, l.policies[0].Burst)
+		assert.Equal(t, 3, l.policies[1].MaxConcurrency)
+		assert.Equal(t, "orders", l.policies[2].MethodPrefix)
+		assert.Equal(t, AnyCaller, l.policies[3].CallerAppID)
+	})
+}
+
+func TestAllowRate(t *testing.T) {
+	l, err := New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{
+		{CallerAppID: "app1", MethodPrefix: "orders", RequestsPerSecond: 1, Burst: 2},
+	}})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		release, err := l.Allow("app1", "/orders/1")
+		require.NoError(t, err)
+		release()
+	}
+
+	_, err = l.Allow("app1", "orders/2")
+	require.Error(t, err)
+	limitErr, ok := err.(*Error)
+	require.True(t, ok)
+	assert.Equal(t, ReasonRate, limitErr.Reason)
+	assert.Greater(t, limitErr.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, limitErr.RetryAfter, time.Second)
+
+	// Other callers and methods are not limited.
+	_, err = l.Allow("app2", "orders/2")
+	assert.NoError(t, err)
+	_, err = l.Allow("app1", "payments")
+	assert.NoError(t, err)
+}
+
+func TestAllowConcurrency(t *testing.T) {
+	l, err := New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{
+		{MaxConcurrency: 1},
+	}})
+	require.NoError(t, err)
+
+	release, err := l.Allow("app1", "method")
+	require.NoError(t, err)
+
+	_, err = l.Allow("app1", "method")
+	require.Error(t, err)
+	assert.Equal(t, ReasonConcurrency, err.(*Error).Reason)
+
+	// The policy applies to each caller separately.
+	releaseOther, err := l.Allow("app2", "method")
+	require.NoError(t, err)
+	releaseOther()
+
+	release()
+	release()
+	release, err = l.Allow("app1", "method")
+	require.NoError(t, err)
+	_, err = l.Allow("app1", "method")
+	assert.Error(t, err)
+	release()
+}
+
+func TestIdleCallersAreEvicted(t *testing.T) {
+	l, err := New(config.RateLimitSpec{Policies: []config.RateLimitPolicySpec{
+		{MethodPrefix: "fast", RequestsPerSecond: 10},
+		{MethodPrefix: "slow", RequestsPerSecond: 1, Burst: 300},
+	}})
+	require.NoError(t, err)
+
+	for _, call := range []struct{ caller, method string }{{"idle", "fast"}, {"busy", "fast"}, {"slow", "slow"}} {
+		release, err := l.Allow(call.caller, call.method)
+		require.NoError(t, err)
+		if call.caller != "busy" {
+			release()
+		}
+	}
+	require.Len(t, l.callers, 3)
+
+	l.lock.Lock()
+	l.sweep(time.Now().Add(2 * callerIdleTimeout))
+	l.lock.Unlock()
+
+	// The caller with a call in flight and the one whose bucket isn't full yet are kept.
+	callers := []string{}
+	for key := range l.callers {
+		callers = append(callers, key.callerAppID)
+	}
+	assert.ElementsMatch(t, []string{"busy", "slow"}, callers)
+}
+
+func TestErrorStatus(t *testing.T) {
+	err := &Error{CallerAppID: "app1", Method: "method", Reason: ReasonRate, RetryAfter: 1500 * time.Millisecond}
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The hint is read back from the status received over the wire.
+	received := status.FromProto(status.Convert(err).Proto()).Err()
+	retryAfter, ok := RetryAfterFromError(received)
+	assert.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, retryAfter)
+	assert.Equal(t, 2, RetryAfterSeconds(retryAfter))
+
+	_, ok = RetryAfterFromError(status.Error(codes.Internal, "failed"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,158 @@
+package recorder
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// KindInvoke is a service invocation sent by the sidecar to an app ID.
+	KindInvoke = "invoke"
+	// KindApp is a call sent by the sidecar to its app over the app channel.
+	KindApp = "app"
+	// KindPublish is an event published by the sidecar to a pub/sub.
+	KindPublish = "publish"
+
+	// ProtocolHTTP and ProtocolGRPC tell how a response status code is to be read.
+	ProtocolHTTP = "http"
+	ProtocolGRPC = "grpc"
+
+	base64Encoding = "base64"
+
+	// maxEntrySize is the largest recording line read back.
+	maxEntrySize = 16 << 20
+)
+
+// Entry is a recorded service invocation, app channel call or published event,
+// written as one JSON line of a recording.
+type Entry struct {
+	Kind        string    `json:"kind"`
+	Time        time.Time `json:"time"`
+	AppID       string    `json:"appId"`
+	TargetAppID string    `json:"targetAppId,omitempty"`
+	DurationMs  float64   `json:"durationMs,omitempty"`
+	Request     *Request  `json:"request,omitempty"`
+	Response    *Response `json:"response,omitempty"`
+	Event       *Event    `json:"event,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Request is a recorded InvokeMethodRequest. The body of a streamed request
+// isn't recorded, and Streamed is set instead.
+type Request struct {
+	Method      string              `json:"method"`
+	Verb        string              `json:"verb,omitempty"`
+	QueryString string              `json:"queryString,omitempty"`
+	ContentType string              `json:"contentType,omitempty"`
+	Headers     map[string][]string `json:"headers,omitempty"`
+	Data        string              `json:"data,omitempty"`
+	Encoding    string              `json:"encoding,omitempty"`
+	Streamed    bool                `json:"streamed,omitempty"`
+}
+
+// Response is a recorded InvokeMethodResponse. The body of a streamed response
+// isn't recorded, and Streamed is set instead.
+type Response struct {
+	Status      int32               `json:"status"`
+	Protocol    string              `json:"protocol"`
+	ContentType string              `json:"contentType,omitempty"`
+	Headers     map[string][]string `json:"headers,omitempty"`
+	Data        string              `json:"data,omitempty"`
+	Encoding    string              `json:"encoding,omitempty"`
+	Streamed    bool                `json:"streamed,omitempty"`
+}
+
+// Event is a recorded pub/sub publish request.
+type Event struct {
+	PubsubName string            `json:"pubsubName"`
+	Topic      string            `json:"topic"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Data       string            `json:"data,omitempty"`
+	Encoding   string            `json:"encoding,omitempty"`
+}
+
+// Body returns the request body.
+func (r *Request) Body() ([]byte, error) {
+	return decodeData(r.Data, r.Encoding)
+}
+
+// Body returns the response body.
+func (r *Response) Body() ([]byte, error) {
+	return decodeData(r.Data, r.Encoding)
+}
+
+// Streamed returns true if the request or the response body of a recorded
+// invocation was streamed, so the entry can't be replayed.
+func (e *Entry) Streamed() bool {
+	return (e.Request != nil && e.Request.Streamed) || (e.Response != nil && e.Response.Streamed)
+}
+
+// Body returns the event data.
+func (e *Event) Body() ([]byte, error) {
+	return decodeData(e.Data, e.Encoding)
+}
+
+// encodeData keeps text bodies readable in the recording and falls back to
+// base64 for binary ones.
+func encodeData(b []byte) (string, string) {
+	if utf8.Valid(b) {
+		return string(b), ""
+	}
+	return base64.StdEncoding.EncodeToString(b), base64Encoding
+}
+
+func decodeData(data, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(data), nil
+	case base64Encoding:
+		return base64.StdEncoding.DecodeString(data)
+	default:
+		return nil, fmt.Errorf("unknown data encoding %s", encoding)
+	}
+}
+
+// ReadEntries reads the entries of a recording.
+func ReadEntries(r io.Reader) ([]Entry, error) {
+	entries := []Entry{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEntrySize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("error parsing recording line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,268 @@
+package recorder
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/bhojpur/service/pkg/pubsub"
+	"github.com/bhojpur/service/pkg/utils/logger"
+
+	"github.com/bhojpur/application/pkg/channel"
+	"github.com/bhojpur/application/pkg/config"
+	"github.com/bhojpur/application/pkg/messaging"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+)
+
+const (
+	defaultMaxSizeMB  = 100
+	defaultMaxBackups = 3
+)
+
+var log = logger.NewLogger("app.runtime.recorder")
+
+// Recorder writes the service invocations, app channel calls and published
+// events of a sidecar to a local file, masking the values matched by the
+// redaction rules.
+type Recorder struct {
+	appID    string
+	lock     sync.Mutex
+	file     *lumberjack.Logger
+	closed   bool
+	redactor *redactor
+}
+
+// DefaultPath returns the recording file used when the spec sets no path.
+func DefaultPath(appID string) string {
+	return fmt.Sprintf("%s.recording.jsonl", appID)
+}
+
+// New opens the recording file of an app, appending to an existing recording.
+// The recording file is rotated when it grows over the size cap of the spec.
+func New(appID string, spec config.RecordingSpec) (*Recorder, error) {
+	r, err := newRedactor(spec.Redact)
+	if err != nil {
+		return nil, err
+	}
+
+	path := spec.Path
+	if path == "" {
+		path = DefaultPath(appID)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err = os.MkdirAll(dir, 0o755); err != nil {
+			return nil, errors.Wrap(err, "error creating recording directory")
+		}
+	}
+	maxSizeMB := spec.MaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	maxBackups := spec.MaxBackups
+	if maxBackups <= 0 {
+		maxBackups = defaultMaxBackups
+	}
+	f := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+	}
+	// Writing nothing opens the file, so that errors are reported now.
+	if _, err = f.Write(nil); err != nil {
+		return nil, errors.Wrap(err, "error opening recording file")
+	}
+
+	log.Infof("recording service invocations and published events to %s", path)
+	return &Recorder{
+		appID:    appID,
+		file:     f,
+		redactor: r,
+	}, nil
+}
+
+// Close closes the recording file. Entries recorded afterwards are dropped.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.closed = true
+	return r.file.Close()
+}
+
+func (r *Recorder) write(e *Entry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Warnf("error encoding recording entry: %s", err)
+		return
+	}
+	b = append(b, '\n')
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return
+	}
+	if _, err = r.file.Write(b); err != nil {
+		log.Warnf("error writing recording entry: %s", err)
+	}
+}
+
+// RecordInvocation records a request sent to an app ID, or to the app when
+// kind is KindApp, with its response or error.
+func (r *Recorder) RecordInvocation(kind, targetAppID string, start time.Time, req *invokev1.InvokeMethodRequest, resp *invokev1.InvokeMethodResponse, err error) {
+	e := &Entry{
+		Kind:        kind,
+		Time:        start.UTC(),
+		AppID:       r.appID,
+		TargetAppID: targetAppID,
+		DurationMs:  float64(time.Since(start)) / float64(time.Millisecond),
+		Request:     r.request(req),
+		Response:    r.response(resp),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	r.write(e)
+}
+
+// RecordPublish records an event published to a pub/sub, with the publish error.
+func (r *Recorder) RecordPublish(req *pubsub.PublishRequest, err error) {
+	data, encoding := encodeData(r.redactor.redactData(req.Data))
+	e := &Entry{
+		Kind:  KindPublish,
+		Time:  time.Now().UTC(),
+		AppID: r.appID,
+		Event: &Event{
+			PubsubName: req.PubsubName,
+			Topic:      req.Topic,
+			Metadata:   r.redactor.redactMetadata(req.Metadata),
+			Data:       data,
+			Encoding:   encoding,
+		},
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	r.write(e)
+}
+
+func (r *Recorder) request(req *invokev1.InvokeMethodRequest) *Request {
+	if req == nil || req.Message() == nil {
+		return nil
+	}
+
+	m := req.Message()
+	recorded := &Request{
+		Method:  m.GetMethod(),
+		Headers: r.redactor.redactHeaders(internalMetadataToMap(req.Metadata())),
+	}
+	if req.DataStream() != nil {
+		// The stream is read by the callee, so the body can't be recorded.
+		recorded.ContentType = m.GetContentType()
+		recorded.Streamed = true
+	} else {
+		var body []byte
+		recorded.ContentType, body = req.RawData()
+		recorded.Data, recorded.Encoding = encodeData(r.redactor.redactData(body))
+	}
+	if ext := m.GetHttpExtension(); ext != nil {
+		recorded.Verb = ext.GetVerb().String()
+		recorded.QueryString = ext.GetQuerystring()
+	}
+	return recorded
+}
+
+func (r *Recorder) response(resp *invokev1.InvokeMethodResponse) *Response {
+	if resp == nil {
+		return nil
+	}
+
+	protocol := ProtocolGRPC
+	if resp.IsHTTPResponse() {
+		protocol = ProtocolHTTP
+	}
+	recorded := &Response{
+		Status:   resp.Status().GetCode(),
+		Protocol: protocol,
+		Headers:  r.redactor.redactHeaders(internalMetadataToMap(resp.Headers())),
+	}
+	if resp.DataStream() != nil {
+		// The stream is read by the caller, so the body can't be recorded.
+		recorded.ContentType = resp.Message().GetContentType()
+		recorded.Streamed = true
+	} else {
+		var body []byte
+		recorded.ContentType, body = resp.RawData()
+		recorded.Data, recorded.Encoding = encodeData(r.redactor.redactData(body))
+	}
+	return recorded
+}
+
+func internalMetadataToMap(md invokev1.AppInternalMetadata) map[string][]string {
+	m := make(map[string][]string, len(md))
+	for k, v := range md {
+		m[k] = v.GetValues()
+	}
+	return m
+}
+
+// DirectMessaging returns a DirectMessaging recording the invocations sent
+// through dm.
+func (r *Recorder) DirectMessaging(dm messaging.DirectMessaging) messaging.DirectMessaging {
+	return &recordedDirectMessaging{DirectMessaging: dm, recorder: r}
+}
+
+type recordedDirectMessaging struct {
+	messaging.DirectMessaging
+	recorder *Recorder
+}
+
+func (d *recordedDirectMessaging) Invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	start := time.Now()
+	resp, err := d.DirectMessaging.Invoke(ctx, targetAppID, req)
+	d.recorder.RecordInvocation(KindInvoke, targetAppID, start, req, resp, err)
+	return resp, err
+}
+
+// AppChannel returns an AppChannel recording the calls sent to the app through ch.
+func (r *Recorder) AppChannel(ch channel.AppChannel) channel.AppChannel {
+	return &recordedAppChannel{AppChannel: ch, recorder: r}
+}
+
+type recordedAppChannel struct {
+	channel.AppChannel
+	recorder *Recorder
+}
+
+func (c *recordedAppChannel) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	start := time.Now()
+	resp, err := c.AppChannel.InvokeMethod(ctx, req)
+	c.recorder.RecordInvocation(KindApp, c.recorder.appID, start, req, resp, err)
+	return resp, err
+}
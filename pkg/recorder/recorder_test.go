@@ -0,0 +1,234 @@
+package recorder
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/service/pkg/pubsub"
+
+	channelt "github.com/bhojpur/application/pkg/channel/testing"
+	"github.com/bhojpur/application/pkg/config"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	apptesting "github.com/bhojpur/application/pkg/testing"
+)
+
+func newTestRecorder(t *testing.T, redact config.RedactionSpec) (*Recorder, string) {
+	path := filepath.Join(t.TempDir(), "recordings", "app1.jsonl")
+	r, err := New("app1", config.RecordingSpec{Enabled: true, Path: path, Redact: redact})
+	require.NoError(t, err)
+	return r, path
+}
+
+func readTestEntries(t *testing.T, path string) []Entry {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	entries, err := ReadEntries(f)
+	require.NoError(t, err)
+	return entries
+}
+
+func TestNew(t *testing.T) {
+	t.Run("invalid redaction pattern", func(t *testing.T) {
+		_, err := New("app1", config.RecordingSpec{
+			Path:   filepath.Join(t.TempDir(), "app1.jsonl"),
+			Redact: config.RedactionSpec{Patterns: []string{"("}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("appends to an existing recording", func(t *testing.T) {
+		r, path := newTestRecorder(t, config.RedactionSpec{})
+		r.RecordPublish(&pubsub.PublishRequest{PubsubName: "pubsub", Topic: "a"}, nil)
+		require.NoError(t, r.Close())
+
+		r, err := New("app1", config.RecordingSpec{Path: path})
+		require.NoError(t, err)
+		r.RecordPublish(&pubsub.PublishRequest{PubsubName: "pubsub", Topic: "b"}, nil)
+		require.NoError(t, r.Close())
+
+		entries := readTestEntries(t, path)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "a", entries[0].Event.Topic)
+		assert.Equal(t, "b", entries[1].Event.Topic)
+	})
+
+	t.Run("rotates the recording over the size cap", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app1.jsonl")
+		r, err := New("app1", config.RecordingSpec{Path: path, MaxSizeMB: 1, MaxBackups: 1})
+		require.NoError(t, err)
+		data := []byte(strings.Repeat("a", 256*1024))
+		for i := 0; i < 12; i++ {
+			r.RecordPublish(&pubsub.PublishRequest{PubsubName: "pubsub", Topic: "a", Data: data}, nil)
+		}
+		require.NoError(t, r.Close())
+
+		files, err := filepath.Glob(filepath.Join(filepath.Dir(path), "app1*.jsonl"))
+		require.NoError(t, err)
+		// Backups over MaxBackups are removed in the background.
+		assert.Greater(t, len(files), 1)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(1024*1024))
+	})
+
+	t.Run("entries recorded after close are dropped", func(t *testing.T) {
+		r, path := newTestRecorder(t, config.RedactionSpec{})
+		r.RecordPublish(&pubsub.PublishRequest{PubsubName: "pubsub", Topic: "a"}, nil)
+		require.NoError(t, r.Close())
+		r.RecordPublish(&pubsub.PublishRequest{PubsubName: "pubsub", Topic: "b"}, nil)
+
+		assert.Len(t, readTestEntries(t, path), 1)
+	})
+}
+
+func TestDirectMessaging(t *testing.T) {
+	r, path := newTestRecorder(t, config.RedactionSpec{Fields: []string{"password"}})
+
+	req := invokev1.NewInvokeMethodRequest("login").
+		WithHTTPExtension("POST", "user=alice").
+		WithRawData([]byte(`{"user":"alice","password":"secret"}`), "application/json").
+		WithMetadata(map[string][]string{"Authorization": {"Bearer token"}, "X-Request-Id": {"1"}})
+	resp := invokev1.NewInvokeMethodResponse(201, "", nil).
+		WithRawData([]byte(`{"id":1}`), "application/json")
+
+	dm := new(apptesting.MockDirectMessaging)
+	dm.On("Invoke", mock.Anything, "app2", req).Return(resp, nil).Once()
+
+	got, err := r.DirectMessaging(dm).Invoke(context.Background(), "app2", req)
+	require.NoError(t, err)
+	assert.Equal(t, resp, got)
+	require.NoError(t, r.Close())
+
+	entries := readTestEntries(t, path)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, KindInvoke, e.Kind)
+	assert.Equal(t, "app1", e.AppID)
+	assert.Equal(t, "app2", e.TargetAppID)
+	assert.Equal(t, "login", e.Request.Method)
+	assert.Equal(t, "POST", e.Request.Verb)
+	assert.Equal(t, "user=alice", e.Request.QueryString)
+	assert.Equal(t, []string{RedactedValue}, e.Request.Headers["Authorization"])
+	assert.Equal(t, []string{"1"}, e.Request.Headers["X-Request-Id"])
+	assert.JSONEq(t, `{"user":"alice","password":"[REDACTED]"}`, e.Request.Data)
+	assert.Equal(t, int32(201), e.Response.Status)
+	assert.Equal(t, ProtocolHTTP, e.Response.Protocol)
+
+	body, err := e.Response.Body()
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(body))
+}
+
+func TestStreamedInvocation(t *testing.T) {
+	r, path := newTestRecorder(t, config.RedactionSpec{})
+
+	req := invokev1.NewInvokeMethodRequest("upload").
+		WithDataStream(strings.NewReader("chunk"), "application/octet-stream")
+	resp := invokev1.NewInvokeMethodResponse(200, "", nil).
+		WithDataStream(ioutil.NopCloser(strings.NewReader("chunk")), "text/plain")
+
+	dm := new(apptesting.MockDirectMessaging)
+	dm.On("Invoke", mock.Anything, "app2", req).Return(resp, nil).Once()
+
+	_, err := r.DirectMessaging(dm).Invoke(context.Background(), "app2", req)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	entries := readTestEntries(t, path)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.True(t, e.Streamed())
+	assert.True(t, e.Request.Streamed)
+	assert.Equal(t, "application/octet-stream", e.Request.ContentType)
+	assert.Empty(t, e.Request.Data)
+	assert.True(t, e.Response.Streamed)
+	assert.Equal(t, "text/plain", e.Response.ContentType)
+	assert.Empty(t, e.Response.Data)
+}
+
+func TestAppChannel(t *testing.T) {
+	r, path := newTestRecorder(t, config.RedactionSpec{Patterns: []string{`\d{4}-\d{4}`}})
+
+	req := invokev1.NewInvokeMethodRequest("cards").
+		WithRawData([]byte("card 1234-5678"), "text/plain")
+	binary := []byte{0xff, 0x00, 0x01}
+	resp := invokev1.NewInvokeMethodResponse(0, "", nil).WithRawData(binary, "application/octet-stream")
+
+	ch := new(channelt.MockAppChannel)
+	ch.On("InvokeMethod", mock.Anything, req).Return(resp, nil).Once()
+	ch.On("InvokeMethod", mock.Anything, req).Return(nil, errors.New("app down")).Once()
+	ch.On("GetBaseAddress").Return("http://127.0.0.1:3000")
+
+	appChannel := r.AppChannel(ch)
+	assert.Equal(t, "http://127.0.0.1:3000", appChannel.GetBaseAddress())
+	_, err := appChannel.InvokeMethod(context.Background(), req)
+	require.NoError(t, err)
+	_, err = appChannel.InvokeMethod(context.Background(), req)
+	require.Error(t, err)
+	require.NoError(t, r.Close())
+
+	entries := readTestEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, KindApp, entries[0].Kind)
+	assert.Equal(t, "app1", entries[0].TargetAppID)
+	assert.Equal(t, "card [REDACTED]", entries[0].Request.Data)
+	assert.Equal(t, base64Encoding, entries[0].Response.Encoding)
+	body, err := entries[0].Response.Body()
+	require.NoError(t, err)
+	assert.Equal(t, binary, body)
+
+	assert.Nil(t, entries[1].Response)
+	assert.Equal(t, "app down", entries[1].Error)
+}
+
+func TestRecordPublish(t *testing.T) {
+	r, path := newTestRecorder(t, config.RedactionSpec{Fields: []string{"ssn"}})
+	r.RecordPublish(&pubsub.PublishRequest{
+		PubsubName: "pubsub",
+		Topic:      "orders",
+		Data:       []byte(`{"data":{"ssn":"123"},"id":"1"}`),
+		Metadata:   map[string]string{"ttlInSeconds": "10", "ssn": "123"},
+	}, errors.New("broker unavailable"))
+	require.NoError(t, r.Close())
+
+	entries := readTestEntries(t, path)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, KindPublish, e.Kind)
+	assert.Equal(t, "pubsub", e.Event.PubsubName)
+	assert.Equal(t, "orders", e.Event.Topic)
+	assert.Equal(t, map[string]string{"ttlInSeconds": "10", "ssn": RedactedValue}, e.Event.Metadata)
+	assert.JSONEq(t, `{"data":{"ssn":"[REDACTED]"},"id":"1"}`, e.Event.Data)
+	assert.Equal(t, "broker unavailable", e.Error)
+}
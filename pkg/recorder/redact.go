@@ -0,0 +1,134 @@
+package recorder
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/application/pkg/config"
+)
+
+// RedactedValue replaces the redacted values in a recording.
+const RedactedValue = "[REDACTED]"
+
+// defaultRedactedHeaders are always masked, since they carry credentials.
+var defaultRedactedHeaders = []string{"authorization", "proxy-authorization", "cookie", "set-cookie", "app-api-token"}
+
+// redactor masks the headers, JSON fields and body patterns configured in a
+// RedactionSpec.
+type redactor struct {
+	headers  map[string]bool
+	fields   map[string]bool
+	patterns []*regexp.Regexp
+}
+
+func newRedactor(spec config.RedactionSpec) (*redactor, error) {
+	r := &redactor{
+		headers: map[string]bool{},
+		fields:  map[string]bool{},
+	}
+	for _, h := range append(defaultRedactedHeaders, spec.Headers...) {
+		r.headers[strings.ToLower(h)] = true
+	}
+	for _, f := range spec.Fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	for _, p := range spec.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid redaction pattern %s", p)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+func (r *redactor) redactHeaders(headers map[string][]string) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := make(map[string][]string, len(headers))
+	for k, v := range headers {
+		if r.headers[strings.ToLower(k)] {
+			v = []string{RedactedValue}
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+func (r *redactor) redactMetadata(md map[string]string) map[string]string {
+	if len(md) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(md))
+	for k, v := range md {
+		if r.headers[strings.ToLower(k)] || r.fields[strings.ToLower(k)] {
+			v = RedactedValue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// redactData masks the configured fields of a JSON body at any depth, then
+// the configured patterns.
+func (r *redactor) redactData(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+
+	if len(r.fields) > 0 {
+		var v interface{}
+		if json.Unmarshal(data, &v) == nil {
+			if b, err := json.Marshal(r.redactValue(v)); err == nil {
+				data = b
+			}
+		}
+	}
+
+	for _, re := range r.patterns {
+		data = re.ReplaceAll(data, []byte(RedactedValue))
+	}
+	return data
+}
+
+func (r *redactor) redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, field := range t {
+			if r.fields[strings.ToLower(k)] {
+				t[k] = RedactedValue
+			} else {
+				t[k] = r.redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = r.redactValue(t[i])
+		}
+	}
+	return v
+}
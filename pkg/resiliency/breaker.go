@@ -0,0 +1,95 @@
+package resiliency
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sony/gobreaker"
+
+	"github.com/bhojpur/application/pkg/config"
+	diag "github.com/bhojpur/application/pkg/diagnostics"
+	"github.com/bhojpur/application/pkg/expr"
+)
+
+const defaultTripExpression = "consecutiveFailures > 5"
+
+// newCircuitBreaker creates a circuit breaker for the given target from the named policy.
+// The trip expression is evaluated against the breaker counters: requests, totalSuccesses,
+// totalFailures, consecutiveSuccesses and consecutiveFailures.
+func newCircuitBreaker(policyName, target string, spec config.CircuitBreakerSpec) (*gobreaker.CircuitBreaker, error) {
+	interval, err := parseDuration(spec.Interval)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid interval in circuit breaker %s", policyName)
+	}
+	timeout, err := parseDuration(spec.Timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timeout in circuit breaker %s", policyName)
+	}
+
+	trip := spec.Trip
+	if trip == "" {
+		trip = defaultTripExpression
+	}
+	var tripExpr expr.Expr
+	if err = tripExpr.DecodeString(trip); err != nil {
+		return nil, errors.Wrapf(err, "invalid trip expression in circuit breaker %s", policyName)
+	}
+
+	maxRequests := spec.MaxRequests
+	if maxRequests < 0 {
+		maxRequests = 0
+	}
+
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        target,
+		MaxRequests: uint32(maxRequests),
+		Interval:    interval,
+		Timeout:     timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			result, err := tripExpr.Eval(map[string]interface{}{
+				"requests":             int64(counts.Requests),
+				"totalSuccesses":       int64(counts.TotalSuccesses),
+				"totalFailures":        int64(counts.TotalFailures),
+				"consecutiveSuccesses": int64(counts.ConsecutiveSuccesses),
+				"consecutiveFailures":  int64(counts.ConsecutiveFailures),
+			})
+			if err != nil {
+				log.Warnf("failed to evaluate trip expression of circuit breaker %s: %s", policyName, err)
+				return false
+			}
+			tripped, ok := result.(bool)
+			return ok && tripped
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			log.Infof("circuit breaker %s for %s changed state from %s to %s", policyName, name, from, to)
+			diag.DefaultMonitoring.ReportCircuitBreakerState(policyName, name, int64(to))
+		},
+	}), nil
+}
+
+func parseDuration(val string) (time.Duration, error) {
+	if val == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(val)
+}
@@ -0,0 +1,413 @@
+package resiliency
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/pkg/errors"
+	"github.com/sony/gobreaker"
+
+	"github.com/bhojpur/service/pkg/utils/logger"
+	"github.com/bhojpur/service/pkg/utils/retry"
+
+	"github.com/bhojpur/application/pkg/config"
+)
+
+var log = logger.NewLogger("app.runtime.resiliency")
+
+// PolicyType is the kind of target a resiliency policy is applied to.
+type PolicyType string
+
+const (
+	// Endpoint is the policy type for service invocation targets keyed by app ID.
+	Endpoint PolicyType = "apps"
+	// Actor is the policy type for actor invocation targets keyed by actor type.
+	Actor PolicyType = "actors"
+	// Component is the policy type for calls to and deliveries from components keyed by component name.
+	Component PolicyType = "components"
+)
+
+// Operation represents a function to invoke with resiliency policies applied.
+// An attempt that times out keeps running in the background, so an Operation must hand
+// its result back through the return value instead of writing to captured variables.
+type Operation func(ctx context.Context) (interface{}, error)
+
+// Runner represents a function to invoke an Operation with resiliency policies applied.
+// It returns the result of the last attempt.
+type Runner func(oper Operation) (interface{}, error)
+
+// Resiliency holds the named timeout, retry and circuit breaker policies and their targets.
+// A nil *Resiliency is valid and applies no policies.
+type Resiliency struct {
+	lock sync.RWMutex
+
+	timeouts        map[string]time.Duration
+	retries         map[string]retry.Config
+	circuitBreakers map[string]config.CircuitBreakerSpec
+
+	apps       map[string]config.EndpointPolicyNames
+	actors     map[string]config.EndpointPolicyNames
+	components map[string]config.ComponentPolicyNames
+
+	breakers     map[string]*gobreaker.CircuitBreaker
+	breakersLock sync.Mutex
+}
+
+// New parses and validates the resiliency section of the configuration.
+func New(spec config.ResiliencySpec) (*Resiliency, error) {
+	r := &Resiliency{
+		timeouts:        map[string]time.Duration{},
+		retries:         map[string]retry.Config{},
+		circuitBreakers: map[string]config.CircuitBreakerSpec{},
+		apps:            spec.Targets.Apps,
+		actors:          spec.Targets.Actors,
+		components:      spec.Targets.Components,
+		breakers:        map[string]*gobreaker.CircuitBreaker{},
+	}
+
+	for name, val := range spec.Policies.Timeouts {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid timeout %s", name)
+		}
+		r.timeouts[name] = d
+	}
+
+	for name, val := range spec.Policies.Retries {
+		rc, err := parseRetry(val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid retry %s", name)
+		}
+		r.retries[name] = rc
+	}
+
+	for name, val := range spec.Policies.CircuitBreakers {
+		// Build a throwaway breaker to surface invalid durations or trip expressions early.
+		if _, err := newCircuitBreaker(name, "", val); err != nil {
+			return nil, err
+		}
+		r.circuitBreakers[name] = val
+	}
+
+	for target, names := range r.apps {
+		if err := r.validatePolicyNames(string(Endpoint), target, names); err != nil {
+			return nil, err
+		}
+	}
+	for target, names := range r.actors {
+		if err := r.validatePolicyNames(string(Actor), target, names); err != nil {
+			return nil, err
+		}
+	}
+	for target, names := range r.components {
+		if err := r.validatePolicyNames(string(Component), target, names.Outbound); err != nil {
+			return nil, err
+		}
+		if err := r.validatePolicyNames(string(Component), target, names.Inbound); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Update replaces the policies and targets with the ones of the given spec and resets
+// the circuit breakers. The current policies are kept if the spec is invalid.
+func (r *Resiliency) Update(spec config.ResiliencySpec) error {
+	updated, err := New(spec)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	r.timeouts = updated.timeouts
+	r.retries = updated.retries
+	r.circuitBreakers = updated.circuitBreakers
+	r.apps = updated.apps
+	r.actors = updated.actors
+	r.components = updated.components
+	r.lock.Unlock()
+
+	r.breakersLock.Lock()
+	r.breakers = map[string]*gobreaker.CircuitBreaker{}
+	r.breakersLock.Unlock()
+	return nil
+}
+
+func parseRetry(spec config.RetrySpec) (retry.Config, error) {
+	rc := retry.DefaultConfig()
+	if err := rc.Policy.DecodeString(spec.Policy); err != nil {
+		return rc, err
+	}
+
+	d, err := parseDuration(spec.Duration)
+	if err != nil {
+		return rc, err
+	}
+	if d > 0 {
+		rc.Duration = d
+		rc.InitialInterval = d
+	}
+
+	maxInterval, err := parseDuration(spec.MaxInterval)
+	if err != nil {
+		return rc, err
+	}
+	if maxInterval > 0 {
+		rc.MaxInterval = maxInterval
+	}
+
+	if spec.MaxRetries != nil {
+		rc.MaxRetries = int64(*spec.MaxRetries)
+	}
+	// A retry policy is bounded by its max retries rather than by elapsed time.
+	rc.MaxElapsedTime = 0
+
+	return rc, nil
+}
+
+func (r *Resiliency) validatePolicyNames(kind, target string, names config.EndpointPolicyNames) error {
+	if _, ok := r.timeouts[names.Timeout]; names.Timeout != "" && !ok {
+		return errors.Errorf("%s target %s references unknown timeout %s", kind, target, names.Timeout)
+	}
+	if _, ok := r.retries[names.Retry]; names.Retry != "" && !ok {
+		return errors.Errorf("%s target %s references unknown retry %s", kind, target, names.Retry)
+	}
+	if _, ok := r.circuitBreakers[names.CircuitBreaker]; names.CircuitBreaker != "" && !ok {
+		return errors.Errorf("%s target %s references unknown circuit breaker %s", kind, target, names.CircuitBreaker)
+	}
+	return nil
+}
+
+// PolicyDefined returns true if a policy is configured for the given target.
+func (r *Resiliency) PolicyDefined(target string, policyType PolicyType) bool {
+	if r == nil {
+		return false
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var exists bool
+	switch policyType {
+	case Endpoint:
+		_, exists = r.apps[target]
+	case Actor:
+		_, exists = r.actors[target]
+	case Component:
+		_, exists = r.components[target]
+	}
+	return exists
+}
+
+// EndpointPolicy returns the policy for service invocation calls to the given app.
+func (r *Resiliency) EndpointPolicy(ctx context.Context, appID string, endpoint string) Runner {
+	if r == nil {
+		return noOp(ctx)
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names, ok := r.apps[appID]
+	if !ok {
+		return noOp(ctx)
+	}
+	return r.policy(ctx, endpoint, string(Endpoint)+"/"+appID, names, nil)
+}
+
+// ActorPolicy returns the policy for invocation calls to the given actor.
+// Circuit breakers are shared by all actors of the same type.
+func (r *Resiliency) ActorPolicy(ctx context.Context, actorType string, actorID string) Runner {
+	if r == nil {
+		return noOp(ctx)
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names, ok := r.actors[actorType]
+	if !ok {
+		return noOp(ctx)
+	}
+	return r.policy(ctx, actorType+"/"+actorID, string(Actor)+"/"+actorType, names, nil)
+}
+
+// ComponentOutboundPolicy returns the policy for calls made to the given component.
+func (r *Resiliency) ComponentOutboundPolicy(ctx context.Context, name string) Runner {
+	if r == nil {
+		return noOp(ctx)
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names, ok := r.components[name]
+	if !ok {
+		return noOp(ctx)
+	}
+	return r.policy(ctx, name, string(Component)+"/"+name+"/outbound", names.Outbound, nil)
+}
+
+// ComponentInboundPolicy returns the policy for deliveries from the given component to the app,
+// such as pub/sub messages.
+func (r *Resiliency) ComponentInboundPolicy(ctx context.Context, name string) Runner {
+	if r == nil {
+		return noOp(ctx)
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names, ok := r.components[name]
+	if !ok {
+		return noOp(ctx)
+	}
+	return r.policy(ctx, name, string(Component)+"/"+name+"/inbound", names.Inbound, nil)
+}
+
+// ComponentInboundPolicyWithRetry returns the inbound policy of the given component like
+// ComponentInboundPolicy, but retries with defaultRetry when the component has no inbound
+// retry policy. It is used for deliveries that are given up for good once they fail,
+// such as pub/sub messages with a dead-letter topic.
+func (r *Resiliency) ComponentInboundPolicyWithRetry(ctx context.Context, name string, defaultRetry retry.Config) Runner {
+	if r == nil {
+		return Policy(ctx, name, 0, &defaultRetry, nil)
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.policy(ctx, name, string(Component)+"/"+name+"/inbound", r.components[name].Inbound, &defaultRetry)
+}
+
+func (r *Resiliency) policy(ctx context.Context, operationName, target string, names config.EndpointPolicyNames, defaultRetry *retry.Config) Runner {
+	rc := defaultRetry
+	if val, ok := r.retries[names.Retry]; ok {
+		rc = &val
+	}
+
+	var cb *gobreaker.CircuitBreaker
+	if names.CircuitBreaker != "" {
+		cb = r.circuitBreaker(names.CircuitBreaker, target)
+	}
+
+	return Policy(ctx, operationName, r.timeouts[names.Timeout], rc, cb)
+}
+
+// circuitBreaker returns the breaker of the named policy for the target, creating it on first use.
+func (r *Resiliency) circuitBreaker(policyName, target string) *gobreaker.CircuitBreaker {
+	key := policyName + "||" + target
+
+	r.breakersLock.Lock()
+	defer r.breakersLock.Unlock()
+
+	if cb, ok := r.breakers[key]; ok {
+		return cb
+	}
+
+	// The spec has been validated in New.
+	cb, _ := newCircuitBreaker(policyName, target, r.circuitBreakers[policyName])
+	r.breakers[key] = cb
+	return cb
+}
+
+// Policy returns a Runner that applies the timeout to every attempt, records the outcome in the
+// circuit breaker and retries failed attempts according to the retry config. Any of the policies
+// may be omitted.
+func Policy(ctx context.Context, operationName string, t time.Duration, rc *retry.Config, cb *gobreaker.CircuitBreaker) Runner {
+	return func(oper Operation) (interface{}, error) {
+		operation := oper
+		if t > 0 {
+			operation = withTimeout(oper, t)
+		}
+
+		if cb != nil {
+			operCB := operation
+			operation = func(ctx context.Context) (interface{}, error) {
+				res, err := cb.Execute(func() (interface{}, error) {
+					return operCB(ctx)
+				})
+				if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+					// Retrying against an open breaker only burns the retry budget.
+					return nil, backoff.Permanent(err)
+				}
+				return res, err
+			}
+		}
+
+		if rc == nil {
+			res, err := operation(ctx)
+			return res, unwrapPermanent(err)
+		}
+
+		var res interface{}
+		b := rc.NewBackOffWithContext(ctx)
+		err := backoff.RetryNotify(func() (err error) {
+			res, err = operation(ctx)
+			return err
+		}, b, func(err error, d time.Duration) {
+			log.Infof("error processing operation %s: %s. retrying in %s", operationName, err, d)
+		})
+		return res, err
+	}
+}
+
+type operationResult struct {
+	res interface{}
+	err error
+}
+
+// withTimeout bounds a single attempt of the operation. The operation keeps running in the
+// background until it observes the cancelled context; its late result is discarded.
+func withTimeout(oper Operation, t time.Duration) Operation {
+	return func(ctx context.Context) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, t)
+		defer cancel()
+
+		done := make(chan operationResult, 1)
+		go func() {
+			res, err := oper(ctx)
+			done <- operationResult{res: res, err: err}
+		}()
+
+		select {
+		case r := <-done:
+			return r.res, r.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func unwrapPermanent(err error) error {
+	var permanent *backoff.PermanentError
+	if errors.As(err, &permanent) {
+		return permanent.Err
+	}
+	return err
+}
+
+func noOp(ctx context.Context) Runner {
+	return func(oper Operation) (interface{}, error) {
+		res, err := oper(ctx)
+		return res, unwrapPermanent(err)
+	}
+}
@@ -0,0 +1,349 @@
+package resiliency
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/service/pkg/utils/retry"
+
+	"github.com/bhojpur/application/pkg/config"
+)
+
+func getTestSpec() config.ResiliencySpec {
+	maxRetries := 2
+	return config.ResiliencySpec{
+		Policies: config.PoliciesSpec{
+			Timeouts: map[string]string{
+				"fast": "10ms",
+			},
+			Retries: map[string]config.RetrySpec{
+				"twice": {
+					Policy:     "constant",
+					Duration:   "1ms",
+					MaxRetries: &maxRetries,
+				},
+			},
+			CircuitBreakers: map[string]config.CircuitBreakerSpec{
+				"simpleCB": {
+					MaxRequests: 1,
+					Timeout:     "1m",
+					Trip:        "consecutiveFailures > 2",
+				},
+			},
+		},
+		Targets: config.TargetsSpec{
+			Apps: map[string]config.EndpointPolicyNames{
+				"appB": {
+					Timeout: "fast",
+					Retry:   "twice",
+				},
+			},
+			Actors: map[string]config.EndpointPolicyNames{
+				"myActor": {
+					CircuitBreaker: "simpleCB",
+				},
+			},
+			Components: map[string]config.ComponentPolicyNames{
+				"statestore": {
+					Outbound: config.EndpointPolicyNames{
+						Retry: "twice",
+					},
+				},
+				"pubsub": {
+					Inbound: config.EndpointPolicyNames{
+						Retry: "twice",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("valid spec", func(t *testing.T) {
+		r, err := New(getTestSpec())
+		require.NoError(t, err)
+		assert.True(t, r.PolicyDefined("appB", Endpoint))
+		assert.True(t, r.PolicyDefined("myActor", Actor))
+		assert.True(t, r.PolicyDefined("statestore", Component))
+		assert.False(t, r.PolicyDefined("appC", Endpoint))
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		spec := getTestSpec()
+		spec.Policies.Timeouts["fast"] = "soon"
+		_, err := New(spec)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid retry policy", func(t *testing.T) {
+		spec := getTestSpec()
+		spec.Policies.Retries["twice"] = config.RetrySpec{Policy: "sometimes"}
+		_, err := New(spec)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid trip expression", func(t *testing.T) {
+		spec := getTestSpec()
+		spec.Policies.CircuitBreakers["simpleCB"] = config.CircuitBreakerSpec{Trip: "consecutiveFailures >"}
+		_, err := New(spec)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown policy reference", func(t *testing.T) {
+		spec := getTestSpec()
+		spec.Targets.Apps["appB"] = config.EndpointPolicyNames{Retry: "forever"}
+		_, err := New(spec)
+		assert.Error(t, err)
+	})
+}
+
+func TestNilResiliency(t *testing.T) {
+	var r *Resiliency
+	called := 0
+	_, err := r.EndpointPolicy(context.Background(), "appB", "method")(func(ctx context.Context) (interface{}, error) {
+		called++
+		return nil, errors.New("failed")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, called)
+	assert.False(t, r.PolicyDefined("appB", Endpoint))
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("replaces policies and targets", func(t *testing.T) {
+		r, err := New(getTestSpec())
+		require.NoError(t, err)
+
+		spec := getTestSpec()
+		delete(spec.Targets.Apps, "appB")
+		spec.Targets.Apps["appC"] = config.EndpointPolicyNames{Retry: "twice"}
+		require.NoError(t, r.Update(spec))
+
+		assert.False(t, r.PolicyDefined("appB", Endpoint))
+		assert.True(t, r.PolicyDefined("appC", Endpoint))
+		assert.True(t, r.PolicyDefined("myActor", Actor))
+	})
+
+	t.Run("invalid spec keeps current policies", func(t *testing.T) {
+		r, err := New(getTestSpec())
+		require.NoError(t, err)
+
+		spec := getTestSpec()
+		spec.Policies.Timeouts["fast"] = "soon"
+		assert.Error(t, r.Update(spec))
+		assert.True(t, r.PolicyDefined("appB", Endpoint))
+	})
+
+	t.Run("resets circuit breakers", func(t *testing.T) {
+		r, err := New(getTestSpec())
+		require.NoError(t, err)
+
+		policy := r.ActorPolicy(context.Background(), "myActor", "id")
+		for i := 0; i < 3; i++ {
+			_, _ = policy(func(ctx context.Context) (interface{}, error) {
+				return nil, errors.New("failed")
+			})
+		}
+		_, err = r.ActorPolicy(context.Background(), "myActor", "id")(func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+
+		require.NoError(t, r.Update(getTestSpec()))
+		_, err = r.ActorPolicy(context.Background(), "myActor", "id")(func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	r, err := New(getTestSpec())
+	require.NoError(t, err)
+
+	t.Run("retries until max retries", func(t *testing.T) {
+		called := 0
+		_, err := r.ComponentOutboundPolicy(context.Background(), "statestore")(func(ctx context.Context) (interface{}, error) {
+			called++
+			return nil, errors.New("failed")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 3, called)
+	})
+
+	t.Run("stops on success", func(t *testing.T) {
+		called := 0
+		_, err := r.ComponentOutboundPolicy(context.Background(), "statestore")(func(ctx context.Context) (interface{}, error) {
+			called++
+			if called < 2 {
+				return nil, errors.New("failed")
+			}
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, called)
+	})
+
+	t.Run("inbound and outbound policies are separate", func(t *testing.T) {
+		called := 0
+		_, err := r.ComponentOutboundPolicy(context.Background(), "pubsub")(func(ctx context.Context) (interface{}, error) {
+			called++
+			return nil, errors.New("failed")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, called)
+
+		called = 0
+		_, err = r.ComponentInboundPolicy(context.Background(), "pubsub")(func(ctx context.Context) (interface{}, error) {
+			called++
+			return nil, errors.New("failed")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 3, called)
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		called := 0
+		permanent := errors.New("dropped")
+		_, err := r.ComponentInboundPolicy(context.Background(), "pubsub")(func(ctx context.Context) (interface{}, error) {
+			called++
+			return nil, backoff.Permanent(permanent)
+		})
+		assert.ErrorIs(t, err, permanent)
+		assert.Equal(t, 1, called)
+	})
+}
+
+func TestComponentInboundPolicyWithRetry(t *testing.T) {
+	r, err := New(getTestSpec())
+	require.NoError(t, err)
+
+	defaultRetry := retry.Config{Policy: retry.PolicyConstant, Duration: time.Millisecond, MaxRetries: 1}
+	failing := func(called *int) Operation {
+		return func(ctx context.Context) (interface{}, error) {
+			*called++
+			return nil, errors.New("failed")
+		}
+	}
+
+	t.Run("configured retry policy is used", func(t *testing.T) {
+		called := 0
+		_, err := r.ComponentInboundPolicyWithRetry(context.Background(), "pubsub", defaultRetry)(failing(&called))
+		assert.Error(t, err)
+		assert.Equal(t, 3, called)
+	})
+
+	t.Run("default retry without an inbound retry policy", func(t *testing.T) {
+		called := 0
+		_, err := r.ComponentInboundPolicyWithRetry(context.Background(), "statestore", defaultRetry)(failing(&called))
+		assert.Error(t, err)
+		assert.Equal(t, 2, called)
+	})
+
+	t.Run("default retry without resiliency", func(t *testing.T) {
+		var none *Resiliency
+		called := 0
+		_, err := none.ComponentInboundPolicyWithRetry(context.Background(), "pubsub", defaultRetry)(failing(&called))
+		assert.Error(t, err)
+		assert.Equal(t, 2, called)
+	})
+}
+
+func TestTimeoutPolicy(t *testing.T) {
+	r, err := New(getTestSpec())
+	require.NoError(t, err)
+
+	var called int32
+	_, err = r.EndpointPolicy(context.Background(), "appB", "method")(func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&called, 1)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&called))
+}
+
+func TestCircuitBreakerPolicy(t *testing.T) {
+	r, err := New(getTestSpec())
+	require.NoError(t, err)
+
+	called := 0
+	oper := func(ctx context.Context) (interface{}, error) {
+		called++
+		return nil, errors.New("failed")
+	}
+	for i := 0; i < 5; i++ {
+		_, err = r.ActorPolicy(context.Background(), "myActor", "1")(oper)
+		assert.Error(t, err)
+	}
+
+	// The breaker trips after the third consecutive failure and rejects the following calls.
+	assert.Equal(t, 3, called)
+	assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+
+	// Breakers are shared by all actors of the same type.
+	_, err = r.ActorPolicy(context.Background(), "myActor", "2")(oper)
+	assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+	assert.Equal(t, 3, called)
+}
+
+func TestPolicyWithoutRetryTimesOut(t *testing.T) {
+	_, err := Policy(context.Background(), "test", time.Millisecond, nil, nil)(func(ctx context.Context) (interface{}, error) {
+		time.Sleep(50 * time.Millisecond)
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPolicyReturnsResult(t *testing.T) {
+	r, err := New(getTestSpec())
+	require.NoError(t, err)
+
+	attempt := 0
+	res, err := r.ComponentOutboundPolicy(context.Background(), "statestore")(func(ctx context.Context) (interface{}, error) {
+		attempt++
+		if attempt < 2 {
+			return "stale", errors.New("failed")
+		}
+		return "value", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", res)
+
+	// A late result from an attempt that timed out is discarded.
+	res, err = Policy(context.Background(), "test", time.Millisecond, nil, nil)(func(ctx context.Context) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return "late", nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, res)
+}
@@ -65,6 +65,7 @@ func FromFlags() (*AppRuntime, error) {
 	enableProfiling := flag.Bool("enable-profiling", false, "Enable profiling")
 	runtimeVersion := flag.Bool("version", false, "Prints the runtime version")
 	buildInfo := flag.Bool("build-info", false, "Prints the build info")
+	listComponents := flag.Bool("list-components", false, "Prints the components included in the runtime as JSON")
 	waitCommand := flag.Bool("wait", false, "wait for Bhojpur Application outbound ready")
 	appMaxConcurrency := flag.Int("app-max-concurrency", -1, "Controls the concurrency level when forwarding requests to user code")
 	enableMTLS := flag.Bool("enable-mtls", false, "Enables automatic mTLS for Bhojpur Application runtime-to-runtime communication channels")
@@ -94,6 +95,11 @@ func FromFlags() (*AppRuntime, error) {
 		os.Exit(0)
 	}
 
+	if *listComponents {
+		// The included components are only known once they are passed to Run.
+		return &AppRuntime{listComponentsOnly: true}, nil
+	}
+
 	if *waitCommand {
 		waitUntilAppOutboundReady(*appHTTPPort)
 		os.Exit(0)
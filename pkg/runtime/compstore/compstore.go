@@ -0,0 +1,423 @@
+package compstore
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"sync"
+
+	"github.com/bhojpur/service/pkg/bindings"
+	"github.com/bhojpur/service/pkg/configuration"
+	"github.com/bhojpur/service/pkg/pubsub"
+	"github.com/bhojpur/service/pkg/secretstores"
+	"github.com/bhojpur/service/pkg/state"
+
+	"github.com/bhojpur/application/pkg/config"
+	"github.com/bhojpur/application/pkg/lock"
+)
+
+// TopicScopes are the topics of a pubsub the app is allowed to subscribe and publish to.
+// Empty lists allow every topic.
+type TopicScopes struct {
+	ScopedSubscriptions []string
+	ScopedPublishings   []string
+	AllowedTopics       []string
+}
+
+// ComponentStore holds the initialized component instances of the runtime by name,
+// along with the settings read by the APIs for them. Components are created, replaced
+// and deleted while the APIs serve requests, so every access goes through the store's lock.
+type ComponentStore struct {
+	storesLock sync.RWMutex
+
+	stateStores         map[string]state.Store
+	lockStores          map[string]lock.Store
+	pubSubs             map[string]pubsub.PubSub
+	secretStores        map[string]secretstores.SecretStore
+	configurationStores map[string]configuration.Store
+	inputBindings       map[string]bindings.InputBinding
+	outputBindings      map[string]bindings.OutputBinding
+
+	inputBindingRoutes   map[string]string
+	topicScopes          map[string]TopicScopes
+	secretsConfiguration map[string]config.SecretsScope
+}
+
+// New returns an empty ComponentStore.
+func New() *ComponentStore {
+	return &ComponentStore{
+		stateStores:         map[string]state.Store{},
+		lockStores:          map[string]lock.Store{},
+		pubSubs:             map[string]pubsub.PubSub{},
+		secretStores:        map[string]secretstores.SecretStore{},
+		configurationStores: map[string]configuration.Store{},
+		inputBindings:       map[string]bindings.InputBinding{},
+		outputBindings:      map[string]bindings.OutputBinding{},
+
+		inputBindingRoutes:   map[string]string{},
+		topicScopes:          map[string]TopicScopes{},
+		secretsConfiguration: map[string]config.SecretsScope{},
+	}
+}
+
+// AddStateStore adds or replaces the state store with the given name.
+func (c *ComponentStore) AddStateStore(name string, store state.Store) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.stateStores[name] = store
+}
+
+// GetStateStore returns the state store with the given name.
+func (c *ComponentStore) GetStateStore(name string) (state.Store, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	store, ok := c.stateStores[name]
+	return store, ok
+}
+
+// DeleteStateStore removes the state store with the given name.
+func (c *ComponentStore) DeleteStateStore(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.stateStores, name)
+}
+
+// ListStateStores returns a copy of the state stores by name.
+func (c *ComponentStore) ListStateStores() map[string]state.Store {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]state.Store, len(c.stateStores))
+	for name, store := range c.stateStores {
+		m[name] = store
+	}
+	return m
+}
+
+// StateStoresLen returns the number of state stores.
+func (c *ComponentStore) StateStoresLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.stateStores)
+}
+
+// AddLockStore adds or replaces the lock store with the given name.
+func (c *ComponentStore) AddLockStore(name string, store lock.Store) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.lockStores[name] = store
+}
+
+// GetLockStore returns the lock store with the given name.
+func (c *ComponentStore) GetLockStore(name string) (lock.Store, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	store, ok := c.lockStores[name]
+	return store, ok
+}
+
+// DeleteLockStore removes the lock store with the given name.
+func (c *ComponentStore) DeleteLockStore(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.lockStores, name)
+}
+
+// ListLockStores returns a copy of the lock stores by name.
+func (c *ComponentStore) ListLockStores() map[string]lock.Store {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]lock.Store, len(c.lockStores))
+	for name, store := range c.lockStores {
+		m[name] = store
+	}
+	return m
+}
+
+// LockStoresLen returns the number of lock stores.
+func (c *ComponentStore) LockStoresLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.lockStores)
+}
+
+// AddPubSub adds or replaces the pubsub with the given name.
+func (c *ComponentStore) AddPubSub(name string, ps pubsub.PubSub) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.pubSubs[name] = ps
+}
+
+// GetPubSub returns the pubsub with the given name.
+func (c *ComponentStore) GetPubSub(name string) (pubsub.PubSub, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	ps, ok := c.pubSubs[name]
+	return ps, ok
+}
+
+// DeletePubSub removes the pubsub with the given name.
+func (c *ComponentStore) DeletePubSub(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.pubSubs, name)
+}
+
+// ListPubSubs returns a copy of the pubsubs by name.
+func (c *ComponentStore) ListPubSubs() map[string]pubsub.PubSub {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]pubsub.PubSub, len(c.pubSubs))
+	for name, ps := range c.pubSubs {
+		m[name] = ps
+	}
+	return m
+}
+
+// PubSubsLen returns the number of pubsubs.
+func (c *ComponentStore) PubSubsLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.pubSubs)
+}
+
+// AddSecretStore adds or replaces the secret store with the given name.
+func (c *ComponentStore) AddSecretStore(name string, store secretstores.SecretStore) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.secretStores[name] = store
+}
+
+// GetSecretStore returns the secret store with the given name.
+func (c *ComponentStore) GetSecretStore(name string) (secretstores.SecretStore, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	store, ok := c.secretStores[name]
+	return store, ok
+}
+
+// DeleteSecretStore removes the secret store with the given name.
+func (c *ComponentStore) DeleteSecretStore(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.secretStores, name)
+}
+
+// ListSecretStores returns a copy of the secret stores by name.
+func (c *ComponentStore) ListSecretStores() map[string]secretstores.SecretStore {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]secretstores.SecretStore, len(c.secretStores))
+	for name, store := range c.secretStores {
+		m[name] = store
+	}
+	return m
+}
+
+// SecretStoresLen returns the number of secret stores.
+func (c *ComponentStore) SecretStoresLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.secretStores)
+}
+
+// AddConfigurationStore adds or replaces the configuration store with the given name.
+func (c *ComponentStore) AddConfigurationStore(name string, store configuration.Store) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.configurationStores[name] = store
+}
+
+// GetConfigurationStore returns the configuration store with the given name.
+func (c *ComponentStore) GetConfigurationStore(name string) (configuration.Store, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	store, ok := c.configurationStores[name]
+	return store, ok
+}
+
+// DeleteConfigurationStore removes the configuration store with the given name.
+func (c *ComponentStore) DeleteConfigurationStore(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.configurationStores, name)
+}
+
+// ListConfigurationStores returns a copy of the configuration stores by name.
+func (c *ComponentStore) ListConfigurationStores() map[string]configuration.Store {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]configuration.Store, len(c.configurationStores))
+	for name, store := range c.configurationStores {
+		m[name] = store
+	}
+	return m
+}
+
+// ConfigurationStoresLen returns the number of configuration stores.
+func (c *ComponentStore) ConfigurationStoresLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.configurationStores)
+}
+
+// AddInputBinding adds or replaces the input binding with the given name.
+func (c *ComponentStore) AddInputBinding(name string, binding bindings.InputBinding) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.inputBindings[name] = binding
+}
+
+// GetInputBinding returns the input binding with the given name.
+func (c *ComponentStore) GetInputBinding(name string) (bindings.InputBinding, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	binding, ok := c.inputBindings[name]
+	return binding, ok
+}
+
+// DeleteInputBinding removes the input binding with the given name.
+func (c *ComponentStore) DeleteInputBinding(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.inputBindings, name)
+}
+
+// ListInputBindings returns a copy of the input bindings by name.
+func (c *ComponentStore) ListInputBindings() map[string]bindings.InputBinding {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]bindings.InputBinding, len(c.inputBindings))
+	for name, binding := range c.inputBindings {
+		m[name] = binding
+	}
+	return m
+}
+
+// InputBindingsLen returns the number of input bindings.
+func (c *ComponentStore) InputBindingsLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.inputBindings)
+}
+
+// AddOutputBinding adds or replaces the output binding with the given name.
+func (c *ComponentStore) AddOutputBinding(name string, binding bindings.OutputBinding) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.outputBindings[name] = binding
+}
+
+// GetOutputBinding returns the output binding with the given name.
+func (c *ComponentStore) GetOutputBinding(name string) (bindings.OutputBinding, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	binding, ok := c.outputBindings[name]
+	return binding, ok
+}
+
+// DeleteOutputBinding removes the output binding with the given name.
+func (c *ComponentStore) DeleteOutputBinding(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.outputBindings, name)
+}
+
+// ListOutputBindings returns a copy of the output bindings by name.
+func (c *ComponentStore) ListOutputBindings() map[string]bindings.OutputBinding {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	m := make(map[string]bindings.OutputBinding, len(c.outputBindings))
+	for name, binding := range c.outputBindings {
+		m[name] = binding
+	}
+	return m
+}
+
+// OutputBindingsLen returns the number of output bindings.
+func (c *ComponentStore) OutputBindingsLen() int {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return len(c.outputBindings)
+}
+
+// AddInputBindingRoute adds or replaces the app route the input binding with the given name
+// delivers its events to.
+func (c *ComponentStore) AddInputBindingRoute(name, route string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.inputBindingRoutes[name] = route
+}
+
+// GetInputBindingRoute returns the app route of the input binding with the given name.
+func (c *ComponentStore) GetInputBindingRoute(name string) (string, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	route, ok := c.inputBindingRoutes[name]
+	return route, ok
+}
+
+// DeleteInputBindingRoute removes the app route of the input binding with the given name.
+func (c *ComponentStore) DeleteInputBindingRoute(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.inputBindingRoutes, name)
+}
+
+// SetTopicScopes adds or replaces the topic scopes of the pubsub with the given name.
+func (c *ComponentStore) SetTopicScopes(name string, scopes TopicScopes) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.topicScopes[name] = scopes
+}
+
+// GetTopicScopes returns the topic scopes of the pubsub with the given name, allowing
+// every topic when the pubsub has none.
+func (c *ComponentStore) GetTopicScopes(name string) TopicScopes {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	return c.topicScopes[name]
+}
+
+// DeleteTopicScopes removes the topic scopes of the pubsub with the given name.
+func (c *ComponentStore) DeleteTopicScopes(name string) {
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	delete(c.topicScopes, name)
+}
+
+// SetSecretsConfigurations replaces the secret scopes of all the secret stores.
+func (c *ComponentStore) SetSecretsConfigurations(scopes []config.SecretsScope) {
+	m := make(map[string]config.SecretsScope, len(scopes))
+	for _, scope := range scopes {
+		m[scope.StoreName] = scope
+	}
+
+	c.storesLock.Lock()
+	defer c.storesLock.Unlock()
+	c.secretsConfiguration = m
+}
+
+// GetSecretsConfiguration returns the secret scope of the secret store with the given name.
+func (c *ComponentStore) GetSecretsConfiguration(name string) (config.SecretsScope, bool) {
+	c.storesLock.RLock()
+	defer c.storesLock.RUnlock()
+	scope, ok := c.secretsConfiguration[name]
+	return scope, ok
+}
@@ -0,0 +1,126 @@
+package compstore
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bhojpur/application/pkg/config"
+	appt "github.com/bhojpur/application/pkg/testing"
+)
+
+func TestStateStores(t *testing.T) {
+	c := New()
+	store := &appt.MockStateStore{}
+
+	c.AddStateStore("store", store)
+	got, ok := c.GetStateStore("store")
+	assert.True(t, ok)
+	assert.Same(t, store, got)
+	assert.Equal(t, 1, c.StateStoresLen())
+
+	// The list is a copy that is not affected by later changes.
+	list := c.ListStateStores()
+	c.DeleteStateStore("store")
+	_, ok = c.GetStateStore("store")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.StateStoresLen())
+	assert.Len(t, list, 1)
+}
+
+func TestSecretStores(t *testing.T) {
+	c := New()
+	store := appt.FakeSecretStore{}
+
+	c.AddSecretStore("store", store)
+	got, ok := c.GetSecretStore("store")
+	assert.True(t, ok)
+	assert.Equal(t, store, got)
+
+	c.DeleteSecretStore("store")
+	assert.Empty(t, c.ListSecretStores())
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	c := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("store%d", i)
+			c.AddStateStore(name, &appt.MockStateStore{})
+			c.DeleteStateStore(name)
+			c.SetTopicScopes(name, TopicScopes{AllowedTopics: []string{"topic"}})
+			c.DeleteTopicScopes(name)
+			c.SetSecretsConfigurations([]config.SecretsScope{{StoreName: name}})
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for name := range c.ListStateStores() {
+				c.GetStateStore(name)
+			}
+			name := fmt.Sprintf("store%d", i)
+			c.GetTopicScopes(name)
+			c.GetSecretsConfiguration(name)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 0, c.StateStoresLen())
+}
+
+func TestSettings(t *testing.T) {
+	c := New()
+
+	t.Run("input binding routes", func(t *testing.T) {
+		c.AddInputBindingRoute("binding", "/route")
+		route, ok := c.GetInputBindingRoute("binding")
+		assert.True(t, ok)
+		assert.Equal(t, "/route", route)
+
+		c.DeleteInputBindingRoute("binding")
+		_, ok = c.GetInputBindingRoute("binding")
+		assert.False(t, ok)
+	})
+
+	t.Run("topic scopes", func(t *testing.T) {
+		c.SetTopicScopes("pubsub", TopicScopes{ScopedPublishings: []string{"topic"}})
+		assert.Equal(t, []string{"topic"}, c.GetTopicScopes("pubsub").ScopedPublishings)
+
+		c.DeleteTopicScopes("pubsub")
+		assert.Empty(t, c.GetTopicScopes("pubsub"))
+	})
+
+	t.Run("secrets configurations are replaced", func(t *testing.T) {
+		c.SetSecretsConfigurations([]config.SecretsScope{{StoreName: "store1"}, {StoreName: "store2"}})
+		c.SetSecretsConfigurations([]config.SecretsScope{{StoreName: "store2", DefaultAccess: config.DenyAccess}})
+
+		_, ok := c.GetSecretsConfiguration("store1")
+		assert.False(t, ok)
+		scope, ok := c.GetSecretsConfiguration("store2")
+		assert.True(t, ok)
+		assert.Equal(t, config.DenyAccess, scope.DefaultAccess)
+	})
+}
@@ -0,0 +1,419 @@
+package runtime
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bhojpur/application/pkg/components"
+	"github.com/bhojpur/application/pkg/config"
+	"github.com/bhojpur/application/pkg/fswatcher"
+	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+// maxComponentUpdates is the number of component and configuration updates kept for the metadata API.
+const maxComponentUpdates = 50
+
+// beginStandaloneUpdates watches the components directory and the configuration file
+// and applies their changes to the running sidecar. Kubernetes mode receives the
+// equivalent updates from the operator in beginComponentsUpdates.
+func (a *AppRuntime) beginStandaloneUpdates() error {
+	if a.runtimeConfig.Mode != utils.StandaloneMode {
+		return nil
+	}
+
+	componentsCh := make(chan struct{})
+	if a.runtimeConfig.Standalone.ComponentsPath != "" {
+		dir, err := filepath.Abs(a.runtimeConfig.Standalone.ComponentsPath)
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := fswatcher.WatchAll(a.ctx, dir, componentsCh); err != nil {
+				log.Errorf("error watching components directory %s: %s", dir, err)
+			}
+		}()
+		log.Infof("watching components directory %s for updates", dir)
+	}
+
+	configCh := make(chan struct{})
+	var configFile string
+	if a.runtimeConfig.GlobalConfig != "" {
+		file, err := filepath.Abs(a.runtimeConfig.GlobalConfig)
+		if err != nil {
+			return err
+		}
+		configFile = file
+		go func() {
+			// Editors often replace a file instead of writing it, so the directory is watched.
+			if err := fswatcher.WatchAll(a.ctx, filepath.Dir(file), configCh); err != nil {
+				log.Errorf("error watching configuration file %s: %s", file, err)
+			}
+		}()
+		log.Infof("watching configuration file %s for updates", file)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-componentsCh:
+				a.reloadStandaloneComponents()
+			case <-configCh:
+				a.reloadStandaloneConfiguration(configFile)
+			case <-a.ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// reloadStandaloneComponents loads the components directory again and creates, updates or
+// deletes the components that changed since the last load. The current components are kept
+// when a file cannot be read or parsed, e.g. while it is being written.
+func (a *AppRuntime) reloadStandaloneComponents() {
+	comps, err := components.NewStandaloneComponents(a.runtimeConfig.Standalone).LoadComponentsStrict()
+	if err != nil {
+		log.Errorf("failed to reload components, keeping the current components: %s", err)
+		a.recordComponentUpdate(components.Update{
+			Kind:      components.UpdateKindComponent,
+			Name:      filepath.Base(a.runtimeConfig.Standalone.ComponentsPath),
+			Operation: components.UpdateOperationUpdate,
+			Time:      time.Now().UTC(),
+			Error:     err.Error(),
+		})
+		return
+	}
+	a.applyComponents(a.getAuthorizedComponents(comps))
+}
+
+// applyComponents brings the loaded components in line with desired. Failures are logged
+// and recorded but never stop the sidecar.
+func (a *AppRuntime) applyComponents(desired []components_v1alpha1.Component) {
+	a.componentsProcessingLock.Lock()
+	defer a.componentsProcessingLock.Unlock()
+
+	current := a.getComponents()
+	for _, comp := range desired {
+		old, exists := findComponent(current, comp.Spec.Type, comp.Name)
+		if !exists {
+			a.createComponent(comp)
+			continue
+		}
+
+		newComp, _ := a.processComponentSecrets(comp)
+		if reflect.DeepEqual(old.Spec, newComp.Spec) {
+			continue
+		}
+		a.updateComponent(comp)
+	}
+
+	for _, comp := range current {
+		if _, ok := findComponent(desired, comp.Spec.Type, comp.Name); !ok {
+			a.deleteComponent(comp)
+		}
+	}
+}
+
+func (a *AppRuntime) createComponent(comp components_v1alpha1.Component) {
+	update := newComponentUpdate(comp, components.UpdateOperationCreate)
+	if a.extractComponentCategory(comp) == middlewareComponent {
+		// The HTTP pipeline is built once at startup.
+		a.appendOrReplaceComponents(comp)
+		update.RestartRequired = true
+	} else if err := a.processComponentAndDependents(comp); err != nil {
+		update.Error = err.Error()
+	} else {
+		a.startComponent(comp)
+	}
+	a.recordComponentUpdate(update)
+}
+
+func (a *AppRuntime) updateComponent(comp components_v1alpha1.Component) {
+	update := newComponentUpdate(comp, components.UpdateOperationUpdate)
+	category := a.extractComponentCategory(comp)
+	switch {
+	case category == middlewareComponent:
+		a.appendOrReplaceComponents(comp)
+		update.RestartRequired = true
+	case a.isActorStateStore(category, comp.Name):
+		// The actor runtime keeps a reference to the store it was started with.
+		update.RestartRequired = true
+	default:
+		old := a.componentInstances(category, comp.Name)
+		if err := a.processComponentAndDependents(comp); err != nil {
+			update.Error = err.Error()
+			break
+		}
+		if category == pubsubComponent {
+			a.closeBulkSubscribers(comp.Name)
+		}
+		if err := closeComponentInstances(old); err != nil {
+			log.Warnf("error closing previous instance of component %s: %s", comp.Name, err)
+		}
+		a.startComponent(comp)
+	}
+	a.recordComponentUpdate(update)
+}
+
+func (a *AppRuntime) deleteComponent(comp components_v1alpha1.Component) {
+	update := newComponentUpdate(comp, components.UpdateOperationDelete)
+	category := a.extractComponentCategory(comp)
+	switch {
+	case category == middlewareComponent:
+		a.removeComponent(comp)
+		update.RestartRequired = true
+	case a.isActorStateStore(category, comp.Name):
+		update.RestartRequired = true
+	default:
+		if err := a.shutdownComponent(category, comp.Name); err != nil {
+			update.Error = err.Error()
+		}
+		a.removeComponent(comp)
+	}
+	a.recordComponentUpdate(update)
+}
+
+// startComponent starts delivering messages and events to the app from a component that
+// was initialized after the runtime started.
+func (a *AppRuntime) startComponent(comp components_v1alpha1.Component) {
+	switch a.extractComponentCategory(comp) {
+	case pubsubComponent:
+		if ps, ok := a.compStore.GetPubSub(comp.Name); ok {
+			if err := a.beginPubSub(comp.Name, ps); err != nil {
+				log.Errorf("error occurred while beginning pubsub %s: %s", comp.Name, err)
+			}
+		}
+	case bindingsComponent:
+		if binding, ok := a.compStore.GetInputBinding(comp.Name); ok && a.appChannel != nil {
+			go a.startReadingFromBinding(comp.Name, binding)
+		}
+	}
+}
+
+// componentInstances returns the initialized instances of a component.
+// A bindings component can have both an input and an output instance.
+func (a *AppRuntime) componentInstances(category ComponentCategory, name string) []interface{} {
+	var instances []interface{}
+	add := func(instance interface{}, ok bool) {
+		if ok {
+			instances = append(instances, instance)
+		}
+	}
+
+	switch category {
+	case bindingsComponent:
+		input, ok := a.compStore.GetInputBinding(name)
+		add(input, ok)
+		output, ok := a.compStore.GetOutputBinding(name)
+		add(output, ok)
+	case pubsubComponent:
+		ps, ok := a.compStore.GetPubSub(name)
+		add(ps, ok)
+	case secretStoreComponent:
+		store, ok := a.compStore.GetSecretStore(name)
+		add(store, ok)
+	case stateComponent:
+		store, ok := a.compStore.GetStateStore(name)
+		add(store, ok)
+	case configurationComponent:
+		store, ok := a.compStore.GetConfigurationStore(name)
+		add(store, ok)
+	case lockComponent:
+		store, ok := a.compStore.GetLockStore(name)
+		add(store, ok)
+	}
+	return instances
+}
+
+// shutdownComponent closes a component and removes it from the runtime.
+func (a *AppRuntime) shutdownComponent(category ComponentCategory, name string) error {
+	if category == pubsubComponent {
+		a.closeBulkSubscribers(name)
+	}
+	err := closeComponentInstances(a.componentInstances(category, name))
+
+	switch category {
+	case bindingsComponent:
+		a.compStore.DeleteInputBinding(name)
+		a.compStore.DeleteInputBindingRoute(name)
+		a.compStore.DeleteOutputBinding(name)
+	case pubsubComponent:
+		a.compStore.DeletePubSub(name)
+		a.compStore.DeleteTopicScopes(name)
+	case secretStoreComponent:
+		a.compStore.DeleteSecretStore(name)
+	case stateComponent:
+		a.compStore.DeleteStateStore(name)
+	case configurationComponent:
+		a.compStore.DeleteConfigurationStore(name)
+	case lockComponent:
+		a.compStore.DeleteLockStore(name)
+	}
+	return err
+}
+
+func closeComponentInstances(instances []interface{}) error {
+	var merr error
+	for _, instance := range instances {
+		if closer, ok := instance.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				merr = multierror.Append(merr, err)
+			}
+		}
+	}
+	return merr
+}
+
+func (a *AppRuntime) isActorStateStore(category ComponentCategory, name string) bool {
+	return category == stateComponent && a.actorStateStoreName != "" && a.actorStateStoreName == name
+}
+
+func (a *AppRuntime) removeComponent(component components_v1alpha1.Component) {
+	a.componentsLock.Lock()
+	defer a.componentsLock.Unlock()
+
+	for i, c := range a.components {
+		if c.Spec.Type == component.Spec.Type && c.ObjectMeta.Name == component.Name {
+			a.components = append(a.components[:i], a.components[i+1:]...)
+			return
+		}
+	}
+}
+
+func findComponent(comps []components_v1alpha1.Component, componentType string, name string) (components_v1alpha1.Component, bool) {
+	for _, c := range comps {
+		if c.Spec.Type == componentType && c.ObjectMeta.Name == name {
+			return c, true
+		}
+	}
+	return components_v1alpha1.Component{}, false
+}
+
+// reloadStandaloneConfiguration loads the configuration file again. Resiliency policies and
+// secret scopes are applied live, changes to any other section require a restart.
+func (a *AppRuntime) reloadStandaloneConfiguration(file string) {
+	conf, _, err := config.LoadStandaloneConfiguration(file)
+	if err != nil {
+		log.Errorf("failed to reload configuration %s: %s", file, err)
+		a.recordComponentUpdate(components.Update{
+			Kind:      components.UpdateKindConfiguration,
+			Name:      filepath.Base(file),
+			Operation: components.UpdateOperationUpdate,
+			Time:      time.Now().UTC(),
+			Error:     err.Error(),
+		})
+		return
+	}
+	a.applyConfiguration(conf.Spec)
+}
+
+func (a *AppRuntime) applyConfiguration(spec config.ConfigurationSpec) {
+	a.componentsProcessingLock.Lock()
+	defer a.componentsProcessingLock.Unlock()
+
+	for _, section := range changedConfigurationSections(a.globalConfig.Spec, spec) {
+		update := components.Update{
+			Kind:      components.UpdateKindConfiguration,
+			Name:      section,
+			Operation: components.UpdateOperationUpdate,
+			Time:      time.Now().UTC(),
+		}
+
+		switch section {
+		case "resiliency":
+			if a.resiliency == nil {
+				update.RestartRequired = true
+			} else if err := a.resiliency.Update(spec.ResiliencySpec); err != nil {
+				update.Error = err.Error()
+			} else {
+				a.globalConfig.Spec.ResiliencySpec = spec.ResiliencySpec
+			}
+		case "secrets":
+			a.globalConfig.Spec.Secrets = spec.Secrets
+			a.populateSecretsConfiguration()
+		default:
+			update.RestartRequired = true
+		}
+		a.recordComponentUpdate(update)
+	}
+}
+
+// changedConfigurationSections returns the JSON names of the configuration spec sections
+// that differ between old and updated.
+func changedConfigurationSections(old, updated config.ConfigurationSpec) []string {
+	var sections []string
+	oldValue := reflect.ValueOf(old)
+	updatedValue := reflect.ValueOf(updated)
+	for i := 0; i < oldValue.NumField(); i++ {
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), updatedValue.Field(i).Interface()) {
+			continue
+		}
+		name := strings.Split(oldValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		sections = append(sections, name)
+	}
+	return sections
+}
+
+func newComponentUpdate(comp components_v1alpha1.Component, operation components.UpdateOperation) components.Update {
+	return components.Update{
+		Kind:      components.UpdateKindComponent,
+		Name:      comp.Name,
+		Type:      comp.Spec.Type,
+		Operation: operation,
+		Time:      time.Now().UTC(),
+	}
+}
+
+func (a *AppRuntime) recordComponentUpdate(update components.Update) {
+	switch {
+	case update.Error != "":
+		log.Errorf("failed to %s %s %s: %s", update.Operation, strings.ToLower(update.Kind), update.Name, update.Error)
+	case update.RestartRequired:
+		log.Warnf("%s of %s %s requires a restart to take effect", update.Operation, strings.ToLower(update.Kind), update.Name)
+	default:
+		log.Infof("%s of %s %s applied", update.Operation, strings.ToLower(update.Kind), update.Name)
+	}
+
+	a.componentUpdatesLock.Lock()
+	defer a.componentUpdatesLock.Unlock()
+
+	a.componentUpdates = append(a.componentUpdates, update)
+	if len(a.componentUpdates) > maxComponentUpdates {
+		a.componentUpdates = a.componentUpdates[len(a.componentUpdates)-maxComponentUpdates:]
+	}
+}
+
+func (a *AppRuntime) getComponentUpdates() []components.Update {
+	a.componentUpdatesLock.RLock()
+	defer a.componentUpdatesLock.RUnlock()
+
+	updates := make([]components.Update, len(a.componentUpdates))
+	copy(updates, a.componentUpdates)
+	return updates
+}
@@ -0,0 +1,321 @@
+package runtime
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/bhojpur/service/pkg/secretstores"
+
+	"github.com/bhojpur/application/pkg/components"
+	secretstores_loader "github.com/bhojpur/application/pkg/components/secretstores"
+	"github.com/bhojpur/application/pkg/config"
+	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	"github.com/bhojpur/application/pkg/resiliency"
+	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+type closeTrackingSecretStore struct {
+	mockSecretStore
+	closed bool
+}
+
+func (s *closeTrackingSecretStore) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newHotReloadTestRuntime() (*AppRuntime, *[]*closeTrackingSecretStore) {
+	rt := NewTestAppRuntime(utils.StandaloneMode)
+	created := []*closeTrackingSecretStore{}
+	rt.secretStoresRegistry.Register(
+		secretstores_loader.New("mock", func() secretstores.SecretStore {
+			store := &closeTrackingSecretStore{}
+			created = append(created, store)
+			return store
+		}))
+	return rt, &created
+}
+
+func testSecretStoreComponent(name, value string) components_v1alpha1.Component {
+	comp := components_v1alpha1.Component{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: components_v1alpha1.ComponentSpec{
+			Type:    "secretstores.mock",
+			Version: "v1",
+		},
+	}
+	if value != "" {
+		comp.Spec.Metadata = []components_v1alpha1.MetadataItem{
+			{
+				Name: "key",
+				Value: components_v1alpha1.DynamicValue{
+					JSON: v1.JSON{Raw: []byte(value)},
+				},
+			},
+		}
+	}
+	return comp
+}
+
+func TestApplyComponents(t *testing.T) {
+	t.Run("component is created", func(t *testing.T) {
+		rt, _ := newHotReloadTestRuntime()
+		defer stopRuntime(t, rt)
+
+		rt.applyComponents([]components_v1alpha1.Component{testSecretStoreComponent("store", "")})
+
+		assert.Contains(t, rt.compStore.ListSecretStores(), "store")
+		assert.Len(t, rt.getComponents(), 1)
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 1)
+		assert.Equal(t, components.UpdateOperationCreate, updates[0].Operation)
+		assert.Equal(t, components.UpdateKindComponent, updates[0].Kind)
+		assert.Equal(t, "store", updates[0].Name)
+		assert.Empty(t, updates[0].Error)
+	})
+
+	t.Run("unchanged component is skipped", func(t *testing.T) {
+		rt, created := newHotReloadTestRuntime()
+		defer stopRuntime(t, rt)
+
+		rt.applyComponents([]components_v1alpha1.Component{testSecretStoreComponent("store", "a")})
+		rt.applyComponents([]components_v1alpha1.Component{testSecretStoreComponent("store", "a")})
+
+		assert.Len(t, *created, 1)
+		assert.Len(t, rt.getComponentUpdates(), 1)
+	})
+
+	t.Run("changed component is re-initialized and the previous instance closed", func(t *testing.T) {
+		rt, created := newHotReloadTestRuntime()
+		defer stopRuntime(t, rt)
+
+		rt.applyComponents([]components_v1alpha1.Component{testSecretStoreComponent("store", "a")})
+		rt.applyComponents([]components_v1alpha1.Component{testSecretStoreComponent("store", "b")})
+
+		require.Len(t, *created, 2)
+		assert.True(t, (*created)[0].closed)
+		assert.False(t, (*created)[1].closed)
+		store, _ := rt.compStore.GetSecretStore("store")
+		assert.Same(t, (*created)[1], store)
+
+		comp, ok := rt.getComponent("secretstores.mock", "store")
+		require.True(t, ok)
+		assert.Equal(t, "b", comp.Spec.Metadata[0].Value.String())
+
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 2)
+		assert.Equal(t, components.UpdateOperationUpdate, updates[1].Operation)
+	})
+
+	t.Run("removed component is shut down", func(t *testing.T) {
+		rt, created := newHotReloadTestRuntime()
+		defer stopRuntime(t, rt)
+
+		rt.applyComponents([]components_v1alpha1.Component{testSecretStoreComponent("store", "")})
+		rt.applyComponents([]components_v1alpha1.Component{})
+
+		require.Len(t, *created, 1)
+		assert.True(t, (*created)[0].closed)
+		assert.NotContains(t, rt.compStore.ListSecretStores(), "store")
+		assert.Empty(t, rt.getComponents())
+
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 2)
+		assert.Equal(t, components.UpdateOperationDelete, updates[1].Operation)
+	})
+
+	t.Run("failed component is recorded without stopping the runtime", func(t *testing.T) {
+		rt, _ := newHotReloadTestRuntime()
+		defer stopRuntime(t, rt)
+
+		comp := testSecretStoreComponent("store", "")
+		comp.Spec.Type = "secretstores.unknown"
+		rt.applyComponents([]components_v1alpha1.Component{comp})
+
+		assert.Empty(t, rt.getComponents())
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 1)
+		assert.NotEmpty(t, updates[0].Error)
+	})
+
+	t.Run("middleware change requires a restart", func(t *testing.T) {
+		rt, _ := newHotReloadTestRuntime()
+		defer stopRuntime(t, rt)
+
+		comp := testSecretStoreComponent("uppercase", "")
+		comp.Spec.Type = "middleware.http.uppercase"
+		rt.applyComponents([]components_v1alpha1.Component{comp})
+
+		assert.Len(t, rt.getComponents(), 1)
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 1)
+		assert.True(t, updates[0].RestartRequired)
+	})
+}
+
+func TestReloadStandaloneComponents(t *testing.T) {
+	dir := t.TempDir()
+	rt, created := newHotReloadTestRuntime()
+	defer stopRuntime(t, rt)
+	rt.runtimeConfig.Standalone.ComponentsPath = dir
+
+	manifest := `apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+  name: store
+spec:
+  type: secretstores.mock
+  version: v1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store.yaml"), []byte(manifest), 0o600))
+	rt.reloadStandaloneComponents()
+	assert.Contains(t, rt.compStore.ListSecretStores(), "store")
+
+	// A file that is being written keeps the current components.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store.yaml"), nil, 0o600))
+	rt.reloadStandaloneComponents()
+	assert.Contains(t, rt.compStore.ListSecretStores(), "store")
+	assert.False(t, (*created)[0].closed)
+	updates := rt.getComponentUpdates()
+	assert.NotEmpty(t, updates[len(updates)-1].Error)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "store.yaml")))
+	rt.reloadStandaloneComponents()
+	assert.NotContains(t, rt.compStore.ListSecretStores(), "store")
+	require.Len(t, *created, 1)
+	assert.True(t, (*created)[0].closed)
+}
+
+func TestShutdownPubSubClosesBulkSubscribers(t *testing.T) {
+	rt, _ := newHotReloadTestRuntime()
+	defer stopRuntime(t, rt)
+
+	batcher := runtime_pubsub.NewBatcher(runtime_pubsub.BulkSubscribe{Enabled: true, MaxAwaitDurationMs: 60000}, func(ctx context.Context, path string, entries []*runtime_pubsub.BulkMessageEntry) []error {
+		return make([]error, len(entries))
+	})
+	rt.addBulkSubscriber("pubsub", batcher)
+
+	assert.NoError(t, rt.shutdownComponent(pubsubComponent, "pubsub"))
+	err := batcher.Submit(context.Background(), "/orders", &runtime_pubsub.BulkMessageEntry{EntryID: "1"})
+	assert.ErrorIs(t, err, runtime_pubsub.ErrBatcherClosed)
+	assert.Empty(t, rt.bulkSubscribers)
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	t.Run("resiliency is applied live", func(t *testing.T) {
+		rt := NewTestAppRuntime(utils.StandaloneMode)
+		defer stopRuntime(t, rt)
+		var err error
+		rt.resiliency, err = resiliency.New(config.ResiliencySpec{})
+		require.NoError(t, err)
+
+		spec := rt.globalConfig.Spec
+		spec.ResiliencySpec = config.ResiliencySpec{
+			Policies: config.PoliciesSpec{
+				Timeouts: map[string]string{"fast": "10ms"},
+			},
+			Targets: config.TargetsSpec{
+				Apps: map[string]config.EndpointPolicyNames{
+					"appB": {Timeout: "fast"},
+				},
+			},
+		}
+		rt.applyConfiguration(spec)
+
+		assert.True(t, rt.resiliency.PolicyDefined("appB", resiliency.Endpoint))
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 1)
+		assert.Equal(t, components.UpdateKindConfiguration, updates[0].Kind)
+		assert.Equal(t, "resiliency", updates[0].Name)
+		assert.False(t, updates[0].RestartRequired)
+	})
+
+	t.Run("invalid resiliency keeps current policies", func(t *testing.T) {
+		rt := NewTestAppRuntime(utils.StandaloneMode)
+		defer stopRuntime(t, rt)
+		var err error
+		rt.resiliency, err = resiliency.New(config.ResiliencySpec{})
+		require.NoError(t, err)
+
+		spec := rt.globalConfig.Spec
+		spec.ResiliencySpec = config.ResiliencySpec{
+			Policies: config.PoliciesSpec{
+				Timeouts: map[string]string{"fast": "soon"},
+			},
+		}
+		rt.applyConfiguration(spec)
+
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 1)
+		assert.NotEmpty(t, updates[0].Error)
+		assert.Empty(t, rt.globalConfig.Spec.ResiliencySpec.Policies.Timeouts)
+	})
+
+	t.Run("secret scopes are applied live", func(t *testing.T) {
+		rt := NewTestAppRuntime(utils.StandaloneMode)
+		defer stopRuntime(t, rt)
+
+		spec := rt.globalConfig.Spec
+		spec.Secrets = config.SecretsSpec{
+			Scopes: []config.SecretsScope{{StoreName: "store", DefaultAccess: config.DenyAccess}},
+		}
+		rt.applyConfiguration(spec)
+
+		scope, _ := rt.compStore.GetSecretsConfiguration("store")
+		assert.Equal(t, config.DenyAccess, scope.DefaultAccess)
+	})
+
+	t.Run("other sections require a restart", func(t *testing.T) {
+		rt := NewTestAppRuntime(utils.StandaloneMode)
+		defer stopRuntime(t, rt)
+
+		spec := rt.globalConfig.Spec
+		spec.TracingSpec = config.TracingSpec{SamplingRate: "0.5"}
+		rt.applyConfiguration(spec)
+
+		updates := rt.getComponentUpdates()
+		require.Len(t, updates, 1)
+		assert.Equal(t, "tracing", updates[0].Name)
+		assert.True(t, updates[0].RestartRequired)
+	})
+}
+
+func TestComponentUpdatesAreBounded(t *testing.T) {
+	rt := NewTestAppRuntime(utils.StandaloneMode)
+	defer stopRuntime(t, rt)
+
+	for i := 0; i < maxComponentUpdates+10; i++ {
+		rt.recordComponentUpdate(components.Update{Name: "store"})
+	}
+	assert.Len(t, rt.getComponentUpdates(), maxComponentUpdates)
+}
@@ -0,0 +1,186 @@
+package runtime
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	nr "github.com/bhojpur/service/pkg/nameresolution"
+
+	"github.com/bhojpur/application/pkg/channel"
+	http_channel "github.com/bhojpur/application/pkg/channel/http"
+	"github.com/bhojpur/application/pkg/components"
+	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	httpendpoint_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/httpendpoint/v1alpha1"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+// initHTTPEndpoints loads the HTTP endpoints defined next to the components and creates
+// the channels used to invoke them by name. Only standalone mode defines HTTP endpoints.
+func (a *AppRuntime) initHTTPEndpoints() {
+	if a.runtimeConfig.Mode != utils.StandaloneMode || a.runtimeConfig.Standalone.ComponentsPath == "" {
+		return
+	}
+
+	endpoints, err := components.NewStandaloneComponents(a.runtimeConfig.Standalone).LoadHTTPEndpoints()
+	if err != nil {
+		log.Warnf("failed to load HTTP endpoints: %s", err)
+		return
+	}
+
+	a.httpEndpoints = map[string]channel.AppChannel{}
+	for _, endpoint := range endpoints {
+		if !a.isHTTPEndpointAuthorized(endpoint) {
+			continue
+		}
+
+		// Endpoints are invoked by name like apps, and take precedence over them.
+		if endpoint.Name == a.runtimeConfig.ID {
+			log.Errorf("failed to init HTTP endpoint %s: the name is the app id of this runtime", endpoint.Name)
+			continue
+		}
+		if _, ok := a.httpEndpoints[endpoint.Name]; ok {
+			log.Errorf("failed to init HTTP endpoint %s: an HTTP endpoint with the same name is already loaded", endpoint.Name)
+			continue
+		}
+
+		ch, err := a.createHTTPEndpointChannel(endpoint)
+		if err != nil {
+			log.Errorf("failed to init HTTP endpoint %s: %s", endpoint.Name, err)
+			continue
+		}
+		a.httpEndpoints[endpoint.Name] = ch
+		log.Infof("HTTP endpoint loaded: %s (%s)", endpoint.Name, endpoint.Spec.BaseURL)
+	}
+
+	if a.nameResolver != nil && len(a.httpEndpoints) > 0 {
+		// Resolving can browse the network, so it doesn't hold up the initialization.
+		go a.warnHTTPEndpointsShadowingApps()
+	}
+}
+
+// warnHTTPEndpointsShadowingApps warns about the HTTP endpoints named after an app id
+// known to the name resolver, as the app can no longer be invoked from this runtime.
+func (a *AppRuntime) warnHTTPEndpointsShadowingApps() {
+	for name := range a.httpEndpoints {
+		_, err := a.nameResolver.ResolveID(nr.ResolveRequest{ID: name, Namespace: a.namespace})
+		if err == nil {
+			log.Warnf("HTTP endpoint %s has the same name as an app id, the app can't be invoked from %s", name, a.runtimeConfig.ID)
+		}
+	}
+}
+
+func (a *AppRuntime) isHTTPEndpointAuthorized(endpoint httpendpoint_v1alpha1.HTTPEndpoint) bool {
+	if a.namespace != "" && endpoint.ObjectMeta.Namespace != a.namespace {
+		if endpoint.ObjectMeta.Namespace == "" {
+			log.Warnf("HTTP endpoint %s has no namespace and is ignored in namespace %s", endpoint.Name, a.namespace)
+		}
+		return false
+	}
+	if len(endpoint.Scopes) == 0 {
+		return true
+	}
+	for _, s := range endpoint.Scopes {
+		if s == a.runtimeConfig.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AppRuntime) createHTTPEndpointChannel(endpoint httpendpoint_v1alpha1.HTTPEndpoint) (channel.AppChannel, error) {
+	u, err := url.Parse(endpoint.Spec.BaseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base URL")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Errorf("base URL %q is not an absolute http or https URL", endpoint.Spec.BaseURL)
+	}
+
+	headers := make(map[string]string, len(endpoint.Spec.Headers))
+	for _, h := range endpoint.Spec.Headers {
+		value, err := a.httpEndpointValue(endpoint, h.Value, h.SecretKeyRef)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get header %s", h.Name)
+		}
+		headers[h.Name] = value
+	}
+
+	tlsConfig, err := a.httpEndpointTLSConfig(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return http_channel.CreateExternalChannel(endpoint.Spec.BaseURL, headers, tlsConfig, a.globalConfig.Spec.TracingSpec,
+		a.runtimeConfig.MaxRequestBodySize, a.runtimeConfig.ReadBufferSize), nil
+}
+
+// httpEndpointTLSConfig returns the TLS settings of an endpoint, or nil when it sets none.
+func (a *AppRuntime) httpEndpointTLSConfig(endpoint httpendpoint_v1alpha1.HTTPEndpoint) (*tls.Config, error) {
+	clientTLS := endpoint.Spec.ClientTLS
+	if clientTLS == nil {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	rootCA, err := a.httpEndpointValue(endpoint, clientTLS.RootCA.Value, clientTLS.RootCA.SecretKeyRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get root CA")
+	}
+	if rootCA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(rootCA)) {
+			return nil, errors.New("root CA holds no PEM certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	cert, err := a.httpEndpointValue(endpoint, clientTLS.Certificate.Value, clientTLS.Certificate.SecretKeyRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get client certificate")
+	}
+	key, err := a.httpEndpointValue(endpoint, clientTLS.PrivateKey.Value, clientTLS.PrivateKey.SecretKeyRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get client private key")
+	}
+	if cert != "" || key != "" {
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	return tlsConfig, nil
+}
+
+// httpEndpointValue returns value, or the secret ref points to in the secret store of the endpoint.
+func (a *AppRuntime) httpEndpointValue(endpoint httpendpoint_v1alpha1.HTTPEndpoint, value string, ref components_v1alpha1.SecretKeyRef) (string, error) {
+	if ref.Name == "" {
+		return value, nil
+	}
+	return a.getSecretValue(endpoint.Auth.SecretStore, ref.Name, ref.Key)
+}
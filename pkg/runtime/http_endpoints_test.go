@@ -0,0 +1,188 @@
+package runtime
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/service/pkg/nameresolution"
+
+	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
+	httpendpoint_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/httpendpoint/v1alpha1"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	appt "github.com/bhojpur/application/pkg/testing"
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+func TestInitHTTPEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-Path", r.URL.Path)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	resources := fmt.Sprintf(`
+apiVersion: bhojpur.net/v1alpha1
+kind: HTTPEndpoint
+metadata:
+  name: billing
+spec:
+  baseUrl: %s/api
+  headers:
+  - name: Authorization
+    secretKeyRef:
+      name: key1
+auth:
+  secretStore: mockSecretStore
+---
+apiVersion: bhojpur.net/v1alpha1
+kind: HTTPEndpoint
+metadata:
+  name: scoped
+spec:
+  baseUrl: %s
+scopes:
+- other-app
+---
+apiVersion: bhojpur.net/v1alpha1
+kind: HTTPEndpoint
+metadata:
+  name: relative
+spec:
+  baseUrl: /api
+---
+apiVersion: bhojpur.net/v1alpha1
+kind: HTTPEndpoint
+metadata:
+  name: billing
+spec:
+  baseUrl: %s/duplicate
+---
+apiVersion: bhojpur.net/v1alpha1
+kind: HTTPEndpoint
+metadata:
+  name: %s
+spec:
+  baseUrl: %s
+`, server.URL, server.URL, server.URL, TestRuntimeConfigID, server.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "endpoints.yaml"), []byte(resources), 0o600))
+
+	rt := NewTestAppRuntime(utils.StandaloneMode)
+	rt.runtimeConfig.Standalone.ComponentsPath = dir
+	rt.compStore.AddSecretStore("mockSecretStore", &mockSecretStore{})
+	resolved := make(chan struct{})
+	mockResolver := new(appt.MockResolver)
+	mockResolver.On("ResolveID", nameresolution.ResolveRequest{ID: "billing"}).
+		Run(func(mock.Arguments) { close(resolved) }).
+		Return("10.0.0.1:50002", nil)
+	rt.nameResolver = mockResolver
+
+	rt.initHTTPEndpoints()
+	require.Len(t, rt.httpEndpoints, 1)
+	require.Contains(t, rt.httpEndpoints, "billing")
+
+	select {
+	case <-resolved:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "HTTP endpoint names were not checked against app ids")
+	}
+
+	req := invokev1.NewInvokeMethodRequest("orders").
+		WithHTTPExtension(http.MethodGet, "").
+		WithMetadata(map[string][]string{})
+	resp, err := rt.httpEndpoints["billing"].InvokeMethod(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int32(http.StatusOK), resp.Status().Code)
+	assert.Equal(t, []string{"value1"}, resp.Headers()["X-Authorization"].GetValues())
+	assert.Equal(t, []string{"/api/orders"}, resp.Headers()["X-Path"].GetValues())
+}
+
+func TestHTTPEndpointTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	rootCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	rt := NewTestAppRuntime(utils.StandaloneMode)
+	rt.compStore.AddSecretStore("mockSecretStore", &mockSecretStore{})
+	newEndpoint := func(clientTLS *httpendpoint_v1alpha1.ClientTLS) httpendpoint_v1alpha1.HTTPEndpoint {
+		endpoint := httpendpoint_v1alpha1.HTTPEndpoint{
+			Spec: httpendpoint_v1alpha1.HTTPEndpointSpec{BaseURL: server.URL, ClientTLS: clientTLS},
+		}
+		endpoint.Auth.SecretStore = "mockSecretStore"
+		return endpoint
+	}
+
+	t.Run("no TLS settings", func(t *testing.T) {
+		tlsConfig, err := rt.httpEndpointTLSConfig(newEndpoint(nil))
+		assert.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("root CA trusts the endpoint", func(t *testing.T) {
+		ch, err := rt.createHTTPEndpointChannel(newEndpoint(&httpendpoint_v1alpha1.ClientTLS{
+			RootCA: httpendpoint_v1alpha1.TLSDocument{Value: rootCA},
+		}))
+		require.NoError(t, err)
+
+		req := invokev1.NewInvokeMethodRequest("orders").
+			WithHTTPExtension(http.MethodGet, "").
+			WithMetadata(map[string][]string{})
+		resp, err := ch.InvokeMethod(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, int32(http.StatusOK), resp.Status().Code)
+	})
+
+	t.Run("invalid root CA", func(t *testing.T) {
+		_, err := rt.httpEndpointTLSConfig(newEndpoint(&httpendpoint_v1alpha1.ClientTLS{
+			RootCA: httpendpoint_v1alpha1.TLSDocument{SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "key1"}},
+		}))
+		assert.Error(t, err)
+	})
+
+	t.Run("certificate without private key", func(t *testing.T) {
+		_, err := rt.httpEndpointTLSConfig(newEndpoint(&httpendpoint_v1alpha1.ClientTLS{
+			Certificate: httpendpoint_v1alpha1.TLSDocument{Value: rootCA},
+		}))
+		assert.Error(t, err)
+	})
+
+	t.Run("secret store not found", func(t *testing.T) {
+		endpoint := newEndpoint(&httpendpoint_v1alpha1.ClientTLS{
+			RootCA: httpendpoint_v1alpha1.TLSDocument{SecretKeyRef: components_v1alpha1.SecretKeyRef{Name: "key1"}},
+		})
+		endpoint.Auth.SecretStore = "unknown"
+		_, err := rt.httpEndpointTLSConfig(endpoint)
+		assert.Error(t, err)
+	})
+}
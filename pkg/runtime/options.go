@@ -23,11 +23,13 @@ package runtime
 import (
 	"github.com/bhojpur/application/pkg/components/bindings"
 	"github.com/bhojpur/application/pkg/components/configuration"
+	"github.com/bhojpur/application/pkg/components/lock"
 	"github.com/bhojpur/application/pkg/components/middleware/http"
 	"github.com/bhojpur/application/pkg/components/nameresolution"
 	"github.com/bhojpur/application/pkg/components/pubsub"
 	"github.com/bhojpur/application/pkg/components/secretstores"
 	"github.com/bhojpur/application/pkg/components/state"
+	"github.com/bhojpur/application/pkg/lint"
 )
 
 type (
@@ -36,6 +38,7 @@ type (
 		secretStores    []secretstores.SecretStore
 		states          []state.State
 		configurations  []configuration.Configuration
+		locks           []lock.Lock
 		pubsubs         []pubsub.PubSub
 		nameResolutions []nameresolution.NameResolution
 		inputBindings   []bindings.InputBinding
@@ -70,6 +73,13 @@ func WithConfigurations(configurations ...configuration.Configuration) Option {
 	}
 }
 
+// WithLocks adds lock store components to the runtime.
+func WithLocks(locks ...lock.Lock) Option {
+	return func(o *runtimeOpts) {
+		o.locks = append(o.locks, locks...)
+	}
+}
+
 // WithPubSubs adds pubsub store components to the runtime.
 func WithPubSubs(pubsubs ...pubsub.PubSub) Option {
 	return func(o *runtimeOpts) {
@@ -112,3 +122,36 @@ func WithComponentsCallback(componentsCallback ComponentsCallback) Option {
 		o.componentsCallback = componentsCallback
 	}
 }
+
+// componentTypes returns the names of the components included in the runtime.
+func (o *runtimeOpts) componentTypes() lint.ComponentTypes {
+	var types lint.ComponentTypes
+	for _, c := range o.secretStores {
+		types.SecretStores = append(types.SecretStores, c.Name)
+	}
+	for _, c := range o.states {
+		types.States = append(types.States, c.Name)
+	}
+	for _, c := range o.pubsubs {
+		types.PubSubs = append(types.PubSubs, c.Name)
+	}
+	for _, c := range o.inputBindings {
+		types.InputBindings = append(types.InputBindings, c.Name)
+	}
+	for _, c := range o.outputBindings {
+		types.OutputBindings = append(types.OutputBindings, c.Name)
+	}
+	for _, c := range o.httpMiddleware {
+		types.HTTPMiddleware = append(types.HTTPMiddleware, c.Name)
+	}
+	for _, c := range o.configurations {
+		types.Configurations = append(types.Configurations, c.Name)
+	}
+	for _, c := range o.locks {
+		types.Locks = append(types.Locks, c.Name)
+	}
+	for _, c := range o.nameResolutions {
+		types.NameResolutions = append(types.NameResolutions, c.Name)
+	}
+	return types
+}
@@ -28,4 +28,5 @@ import (
 type Adapter interface {
 	GetPubSub(pubsubName string) svc_pubsub.PubSub
 	Publish(req *svc_pubsub.PublishRequest) error
+	BulkPublish(req *BulkPublishRequest) (BulkPublishResponse, error)
 }
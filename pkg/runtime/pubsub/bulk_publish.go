@@ -0,0 +1,117 @@
+package pubsub
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	svc_pubsub "github.com/bhojpur/service/pkg/pubsub"
+
+	"github.com/bhojpur/application/pkg/concurrency"
+)
+
+// BulkPublishStatus is the outcome of publishing a single entry of a bulk publish request.
+type BulkPublishStatus string
+
+const (
+	// PublishSucceeded means the entry was accepted by the pubsub component.
+	PublishSucceeded BulkPublishStatus = "SUCCESS"
+	// PublishFailed means the entry was rejected by the pubsub component.
+	PublishFailed BulkPublishStatus = "FAILED"
+)
+
+// BulkPublishRequest is a request to publish several events to the same topic.
+type BulkPublishRequest struct {
+	PubsubName string
+	Topic      string
+	Entries    []BulkPublishEntry
+	Metadata   map[string]string
+}
+
+// BulkPublishEntry is a single serialized event of a bulk publish request.
+// Its metadata takes precedence over the metadata of the request.
+type BulkPublishEntry struct {
+	EntryID  string
+	Data     []byte
+	Metadata map[string]string
+}
+
+// BulkPublishResponse holds the status of every entry of a bulk publish request,
+// in the order of the request entries.
+type BulkPublishResponse struct {
+	Statuses []BulkPublishResponseEntry
+}
+
+// BulkPublishResponseEntry is the status of a single entry of a bulk publish request.
+type BulkPublishResponseEntry struct {
+	EntryID string
+	Status  BulkPublishStatus
+	Error   error
+}
+
+// PublishEntries publishes the entries of the request one at a time using the given publish func,
+// running up to parallelism publish calls concurrently.
+func PublishEntries(publish func(req *svc_pubsub.PublishRequest) error, req *BulkPublishRequest, parallelism int) BulkPublishResponse {
+	res := BulkPublishResponse{
+		Statuses: make([]BulkPublishResponseEntry, len(req.Entries)),
+	}
+
+	limiter := concurrency.NewLimiter(parallelism)
+	for i := range req.Entries {
+		limiter.Execute(func(param interface{}) {
+			idx := param.(int)
+			entry := req.Entries[idx]
+
+			err := publish(&svc_pubsub.PublishRequest{
+				PubsubName: req.PubsubName,
+				Topic:      req.Topic,
+				Data:       entry.Data,
+				Metadata:   mergeMetadata(req.Metadata, entry.Metadata),
+			})
+
+			status := PublishSucceeded
+			if err != nil {
+				status = PublishFailed
+			}
+			res.Statuses[idx] = BulkPublishResponseEntry{
+				EntryID: entry.EntryID,
+				Status:  status,
+				Error:   err,
+			}
+		}, i)
+	}
+	limiter.Wait()
+
+	return res
+}
+
+func mergeMetadata(base, override map[string]string) map[string]string {
+	if len(override) == 0 {
+		return base
+	}
+
+	md := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		md[k] = v
+	}
+	for k, v := range override {
+		md[k] = v
+	}
+	return md
+}
@@ -0,0 +1,73 @@
+package pubsub
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	svc_pubsub "github.com/bhojpur/service/pkg/pubsub"
+)
+
+func TestPublishEntries(t *testing.T) {
+	req := &BulkPublishRequest{
+		PubsubName: "pubsub",
+		Topic:      "topic",
+		Metadata:   map[string]string{"ttlInSeconds": "10", "key": "request"},
+		Entries: []BulkPublishEntry{
+			{EntryID: "1", Data: []byte("one")},
+			{EntryID: "2", Data: []byte("two"), Metadata: map[string]string{"key": "entry"}},
+			{EntryID: "3", Data: []byte("fail")},
+		},
+	}
+
+	var lock sync.Mutex
+	published := map[string]*svc_pubsub.PublishRequest{}
+	res := PublishEntries(func(r *svc_pubsub.PublishRequest) error {
+		lock.Lock()
+		defer lock.Unlock()
+		published[string(r.Data)] = r
+		if string(r.Data) == "fail" {
+			return errors.New("publish failed")
+		}
+		return nil
+	}, req, 2)
+
+	if assert.Len(t, res.Statuses, 3) {
+		assert.Equal(t, BulkPublishResponseEntry{EntryID: "1", Status: PublishSucceeded}, res.Statuses[0])
+		assert.Equal(t, BulkPublishResponseEntry{EntryID: "2", Status: PublishSucceeded}, res.Statuses[1])
+		assert.Equal(t, "3", res.Statuses[2].EntryID)
+		assert.Equal(t, PublishFailed, res.Statuses[2].Status)
+		assert.EqualError(t, res.Statuses[2].Error, "publish failed")
+	}
+
+	assert.Len(t, published, 3)
+	assert.Equal(t, "pubsub", published["one"].PubsubName)
+	assert.Equal(t, "topic", published["one"].Topic)
+	assert.Equal(t, "request", published["one"].Metadata["key"])
+	// Entry metadata overrides the request metadata.
+	assert.Equal(t, "entry", published["two"].Metadata["key"])
+	assert.Equal(t, "10", published["two"].Metadata["ttlInSeconds"])
+	assert.Equal(t, "request", req.Metadata["key"])
+}
@@ -0,0 +1,236 @@
+package pubsub
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	svc_pubsub "github.com/bhojpur/service/pkg/pubsub"
+)
+
+const (
+	// DefaultBulkSubscribeMaxMessagesCount is the default number of messages delivered to the app in one batch.
+	DefaultBulkSubscribeMaxMessagesCount = 100
+	// DefaultBulkSubscribeMaxAwaitDurationMs is the default time to wait for a batch to fill up before delivering it.
+	DefaultBulkSubscribeMaxAwaitDurationMs = 1000
+
+	// BulkSubscribeEventType is the type of the envelope of a batch of messages delivered to the app.
+	BulkSubscribeEventType = "net.bhojpur.event.pubsub.bulk"
+)
+
+// BulkSubscribe configures the delivery of a subscription's messages to the app in batches.
+type BulkSubscribe struct {
+	Enabled            bool  `json:"enabled"`
+	MaxMessagesCount   int32 `json:"maxMessagesCount,omitempty"`
+	MaxAwaitDurationMs int32 `json:"maxAwaitDurationMs,omitempty"`
+}
+
+// AppBulkRequest is the envelope of a batch of messages delivered to an HTTP app.
+type AppBulkRequest struct {
+	ID         string                `json:"id"`
+	Entries    []AppBulkRequestEntry `json:"entries"`
+	Metadata   map[string]string     `json:"metadata,omitempty"`
+	Topic      string                `json:"topic"`
+	PubsubName string                `json:"pubsubname"`
+	Type       string                `json:"type"`
+}
+
+// AppBulkRequestEntry is a single message of an AppBulkRequest. Cloudevents are sent as JSON objects.
+type AppBulkRequestEntry struct {
+	EntryID     string            `json:"entryId"`
+	Event       interface{}       `json:"event"`
+	ContentType string            `json:"contentType"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// AppBulkResponse is the response of an HTTP app to an AppBulkRequest.
+type AppBulkResponse struct {
+	Statuses []AppBulkResponseEntry `json:"statuses"`
+}
+
+// AppBulkResponseEntry is the processing status of a single message of an AppBulkRequest.
+type AppBulkResponseEntry struct {
+	EntryID string                       `json:"entryId"`
+	Status  svc_pubsub.AppResponseStatus `json:"status"`
+}
+
+// BulkMessageEntry is a single message waiting to be delivered to the app as part of a batch.
+type BulkMessageEntry struct {
+	EntryID    string
+	CloudEvent map[string]interface{}
+	Data       []byte
+	Metadata   map[string]string
+}
+
+// BulkDeliverFunc delivers a batch of messages sharing the same route path to the app.
+// It returns the outcome of every entry, in the order of the entries.
+type BulkDeliverFunc func(ctx context.Context, path string, entries []*BulkMessageEntry) []error
+
+type pendingEntry struct {
+	path  string
+	entry *BulkMessageEntry
+	done  chan error
+}
+
+// Batcher collects the messages of a subscription and delivers them to the app in batches,
+// once a batch is full or the oldest message has waited for the max await duration.
+type Batcher struct {
+	maxCount int
+	maxAwait time.Duration
+	deliver  BulkDeliverFunc
+	pending  chan *pendingEntry
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// NewBatcher returns a Batcher for the given bulk subscribe configuration.
+func NewBatcher(cfg BulkSubscribe, deliver BulkDeliverFunc) *Batcher {
+	maxCount := int(cfg.MaxMessagesCount)
+	if maxCount <= 0 {
+		maxCount = DefaultBulkSubscribeMaxMessagesCount
+	}
+	maxAwaitMs := cfg.MaxAwaitDurationMs
+	if maxAwaitMs <= 0 {
+		maxAwaitMs = DefaultBulkSubscribeMaxAwaitDurationMs
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &Batcher{
+		maxCount: maxCount,
+		maxAwait: time.Duration(maxAwaitMs) * time.Millisecond,
+		deliver:  deliver,
+		pending:  make(chan *pendingEntry, maxCount),
+		ctx:      ctx,
+		cancel:   cancel,
+		stopped:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Close stops the Batcher and cancels the batches being delivered to the app.
+// Messages that were not delivered yet fail with ErrBatcherClosed.
+func (b *Batcher) Close() {
+	b.cancel()
+	<-b.stopped
+}
+
+// Submit adds the message to the current batch and blocks until the app has processed it,
+// returning the outcome for this message.
+func (b *Batcher) Submit(ctx context.Context, path string, entry *BulkMessageEntry) error {
+	p := &pendingEntry{
+		path:  path,
+		entry: entry,
+		done:  make(chan error, 1),
+	}
+
+	select {
+	case b.pending <- p:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.ctx.Done():
+		return ErrBatcherClosed
+	}
+
+	select {
+	case err := <-p.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.ctx.Done():
+		return ErrBatcherClosed
+	}
+}
+
+func (b *Batcher) run() {
+	defer close(b.stopped)
+
+	var (
+		batch []*pendingEntry
+		timer *time.Timer
+		flush <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case p := <-b.pending:
+			batch = append(batch, p)
+			if len(batch) == 1 {
+				timer = time.NewTimer(b.maxAwait)
+				flush = timer.C
+			}
+			if len(batch) < b.maxCount {
+				continue
+			}
+			timer.Stop()
+		case <-flush:
+		}
+
+		// The next batch fills up while the app processes this one.
+		go b.deliverBatch(batch)
+		batch = nil
+		flush = nil
+	}
+}
+
+func (b *Batcher) deliverBatch(batch []*pendingEntry) {
+	// Group the entries by route path, keeping the order they were received in.
+	var paths []string
+	byPath := map[string][]*pendingEntry{}
+	for _, p := range batch {
+		if _, ok := byPath[p.path]; !ok {
+			paths = append(paths, p.path)
+		}
+		byPath[p.path] = append(byPath[p.path], p)
+	}
+
+	for _, path := range paths {
+		pending := byPath[path]
+		entries := make([]*BulkMessageEntry, len(pending))
+		for i, p := range pending {
+			entries[i] = p.entry
+		}
+
+		errs := b.deliver(b.ctx, path, entries)
+		for i, p := range pending {
+			if i >= len(errs) {
+				// A missing status is retried, like an unknown one.
+				p.done <- fmt.Errorf("no status returned for bulk message entry %s", p.entry.EntryID)
+				continue
+			}
+			// Failures caused by Close are reported as such, the pubsub redelivers them.
+			if errs[i] != nil && b.ctx.Err() != nil {
+				p.done <- ErrBatcherClosed
+				continue
+			}
+			p.done <- errs[i]
+		}
+	}
+}
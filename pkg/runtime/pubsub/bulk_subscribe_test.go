@@ -0,0 +1,149 @@
+package pubsub
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingDeliverer struct {
+	lock    sync.Mutex
+	batches map[string][][]string
+}
+
+func (r *recordingDeliverer) deliver(ctx context.Context, path string, entries []*BulkMessageEntry) []error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ids := make([]string, len(entries))
+	errs := make([]error, len(entries))
+	for i, e := range entries {
+		ids[i] = e.EntryID
+		if e.EntryID == "retry" {
+			errs[i] = errors.New("retry")
+		}
+	}
+	r.batches[path] = append(r.batches[path], ids)
+	return errs
+}
+
+func TestBatcher(t *testing.T) {
+	t.Run("delivers full batches without waiting", func(t *testing.T) {
+		r := &recordingDeliverer{batches: map[string][][]string{}}
+		b := NewBatcher(BulkSubscribe{Enabled: true, MaxMessagesCount: 3, MaxAwaitDurationMs: 60000}, r.deliver)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 3)
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id := fmt.Sprintf("%d", i)
+				if i == 2 {
+					id = "retry"
+				}
+				errs[i] = b.Submit(context.Background(), "/orders", &BulkMessageEntry{EntryID: id})
+			}(i)
+		}
+		wg.Wait()
+
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+		assert.EqualError(t, errs[2], "retry")
+		if assert.Len(t, r.batches["/orders"], 1) {
+			assert.Len(t, r.batches["/orders"][0], 3)
+		}
+	})
+
+	t.Run("delivers partial batches after max await duration", func(t *testing.T) {
+		r := &recordingDeliverer{batches: map[string][][]string{}}
+		b := NewBatcher(BulkSubscribe{Enabled: true, MaxMessagesCount: 100, MaxAwaitDurationMs: 10}, r.deliver)
+
+		start := time.Now()
+		err := b.Submit(context.Background(), "/orders", &BulkMessageEntry{EntryID: "1"})
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+		assert.Equal(t, [][]string{{"1"}}, r.batches["/orders"])
+	})
+
+	t.Run("groups entries by path", func(t *testing.T) {
+		r := &recordingDeliverer{batches: map[string][][]string{}}
+		b := NewBatcher(BulkSubscribe{Enabled: true, MaxMessagesCount: 2, MaxAwaitDurationMs: 60000}, r.deliver)
+
+		var wg sync.WaitGroup
+		for _, path := range []string{"/a", "/b"} {
+			wg.Add(1)
+			go func(path string) {
+				defer wg.Done()
+				assert.NoError(t, b.Submit(context.Background(), path, &BulkMessageEntry{EntryID: path}))
+			}(path)
+		}
+		wg.Wait()
+
+		assert.Equal(t, [][]string{{"/a"}}, r.batches["/a"])
+		assert.Equal(t, [][]string{{"/b"}}, r.batches["/b"])
+	})
+
+	t.Run("submit returns when context is cancelled", func(t *testing.T) {
+		r := &recordingDeliverer{batches: map[string][][]string{}}
+		b := NewBatcher(BulkSubscribe{Enabled: true, MaxMessagesCount: 100, MaxAwaitDurationMs: 60000}, r.deliver)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := b.Submit(ctx, "/orders", &BulkMessageEntry{EntryID: "1"})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("close fails pending messages and cancels delivery", func(t *testing.T) {
+		delivering := make(chan struct{})
+		b := NewBatcher(BulkSubscribe{Enabled: true, MaxMessagesCount: 1, MaxAwaitDurationMs: 60000}, func(ctx context.Context, path string, entries []*BulkMessageEntry) []error {
+			close(delivering)
+			<-ctx.Done()
+			return []error{ctx.Err()}
+		})
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- b.Submit(context.Background(), "/orders", &BulkMessageEntry{EntryID: "1"})
+		}()
+		<-delivering
+		b.Close()
+
+		assert.ErrorIs(t, <-errCh, ErrBatcherClosed)
+		assert.ErrorIs(t, b.Submit(context.Background(), "/orders", &BulkMessageEntry{EntryID: "2"}), ErrBatcherClosed)
+	})
+
+	t.Run("missing statuses are returned as errors", func(t *testing.T) {
+		b := NewBatcher(BulkSubscribe{Enabled: true, MaxMessagesCount: 1, MaxAwaitDurationMs: 60000}, func(ctx context.Context, path string, entries []*BulkMessageEntry) []error {
+			return nil
+		})
+		defer b.Close()
+
+		err := b.Submit(context.Background(), "/orders", &BulkMessageEntry{EntryID: "1"})
+		assert.EqualError(t, err, "no status returned for bulk message entry 1")
+	})
+}
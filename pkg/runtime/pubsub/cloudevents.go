@@ -21,6 +21,8 @@ package pubsub
 // THE SOFTWARE.
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	svc_contenttype "github.com/bhojpur/service/pkg/contenttype"
@@ -47,3 +49,28 @@ func NewCloudEvent(req *CloudEvent) (map[string]interface{}, error) {
 	return svc_pubsub.NewCloudEventsEnvelope(uuid.New().String(), req.ID, svc_pubsub.DefaultCloudEventType,
 		"", req.Topic, req.Pubsub, req.DataContentType, req.Data, req.TraceID, req.TraceState), nil
 }
+
+const (
+	// OriginalTopicField is the cloudevent extension holding the topic a dead-lettered message was first delivered on.
+	OriginalTopicField = "originaltopic"
+	// OriginalPubsubField is the cloudevent extension holding the pubsub a dead-lettered message was first delivered on.
+	OriginalPubsubField = "originalpubsubname"
+	// DeadLetterReasonField is the cloudevent extension holding why delivery of a dead-lettered message was given up.
+	DeadLetterReasonField = "deadletterreason"
+	// DeadLetterTimeField is the cloudevent extension holding when a message was dead-lettered.
+	DeadLetterTimeField = "deadlettertime"
+)
+
+// NewDeadLetterCloudEvent returns a copy of the given cloudevent with the failure
+// metadata of a message that could not be delivered on topic.
+func NewDeadLetterCloudEvent(cloudEvent map[string]interface{}, pubsubName, topic string, reason error, now time.Time) map[string]interface{} {
+	ce := make(map[string]interface{}, len(cloudEvent)+4)
+	for k, v := range cloudEvent {
+		ce[k] = v
+	}
+	ce[OriginalTopicField] = topic
+	ce[OriginalPubsubField] = pubsubName
+	ce[DeadLetterReasonField] = reason.Error()
+	ce[DeadLetterTimeField] = now.UTC().Format(time.RFC3339)
+	return ce
+}
@@ -22,7 +22,9 @@ package pubsub
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -85,3 +87,23 @@ func TestNewCloudEvent(t *testing.T) {
 		assert.Equal(t, "pubsub", ce["pubsubname"].(string))
 	})
 }
+
+func TestNewDeadLetterCloudEvent(t *testing.T) {
+	original := map[string]interface{}{
+		"id":    "a",
+		"topic": "orders",
+		"data":  "hello",
+	}
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ce := NewDeadLetterCloudEvent(original, "pubsub", "orders", errors.New("app dropped"), now)
+
+	assert.Equal(t, "a", ce["id"])
+	assert.Equal(t, "hello", ce["data"])
+	assert.Equal(t, "orders", ce[OriginalTopicField])
+	assert.Equal(t, "pubsub", ce[OriginalPubsubField])
+	assert.Equal(t, "app dropped", ce[DeadLetterReasonField])
+	assert.Equal(t, "2022-01-02T03:04:05Z", ce[DeadLetterTimeField])
+	// The original event is left untouched.
+	assert.NotContains(t, original, DeadLetterReasonField)
+}
@@ -21,6 +21,7 @@ package pubsub
 // THE SOFTWARE.
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/bhojpur/application/pkg/messages"
@@ -44,3 +45,12 @@ type NotAllowedError struct {
 func (e NotAllowedError) Error() string {
 	return fmt.Sprintf(messages.ErrPubsubForbidden, e.Topic, e.ID)
 }
+
+// ErrMessageDropped is returned when the app drops a message or rejects it with a
+// non-retriable error. The message is not retried: it is sent to the dead-letter topic
+// of the subscription when there is one, and acknowledged otherwise.
+var ErrMessageDropped = errors.New("pubsub message dropped")
+
+// ErrBatcherClosed is returned for the messages of a bulk subscription that is closed
+// before they are delivered to the app. The message is redelivered by the pubsub.
+var ErrBatcherClosed = errors.New("bulk subscription closed")
@@ -21,11 +21,13 @@ package pubsub
 // THE SOFTWARE.
 
 type Subscription struct {
-	PubsubName string            `json:"pubsubname"`
-	Topic      string            `json:"topic"`
-	Metadata   map[string]string `json:"metadata"`
-	Rules      []*Rule           `json:"rules,omitempty"`
-	Scopes     []string          `json:"scopes"`
+	PubsubName      string            `json:"pubsubname"`
+	Topic           string            `json:"topic"`
+	DeadLetterTopic string            `json:"deadLetterTopic"`
+	BulkSubscribe   BulkSubscribe     `json:"bulkSubscribe"`
+	Metadata        map[string]string `json:"metadata"`
+	Rules           []*Rule           `json:"rules,omitempty"`
+	Scopes          []string          `json:"scopes"`
 }
 
 type Rule struct {
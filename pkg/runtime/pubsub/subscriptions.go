@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,17 +56,29 @@ const (
 	noSubscriptionsError   = "user app did not subscribe to any topic"
 	subscriptionKind       = "Subscription"
 
+	// DeadLetterTopicMetadataKey is the subscription metadata key gRPC apps use to
+	// set the dead-letter topic, since the callback protocol has no field for it.
+	DeadLetterTopicMetadataKey = "deadLetterTopic"
+	// BulkSubscribeEnabledMetadataKey is the subscription metadata key gRPC apps use to opt into bulk subscribe.
+	BulkSubscribeEnabledMetadataKey = "bulkSubscribe.enabled"
+	// BulkSubscribeMaxMessagesCountMetadataKey is the subscription metadata key gRPC apps use to set the batch size.
+	BulkSubscribeMaxMessagesCountMetadataKey = "bulkSubscribe.maxMessagesCount"
+	// BulkSubscribeMaxAwaitDurationMsMetadataKey is the subscription metadata key gRPC apps use to set the batch wait time.
+	BulkSubscribeMaxAwaitDurationMsMetadataKey = "bulkSubscribe.maxAwaitDurationMs"
+
 	APIVersionV1alpha1 = "bhojpur.net/v1alpha1"
 	APIVersionV2alpha1 = "bhojpur.net/v2alpha1"
 )
 
 type (
 	SubscriptionJSON struct {
-		PubsubName string            `json:"pubsubname"`
-		Topic      string            `json:"topic"`
-		Metadata   map[string]string `json:"metadata,omitempty"`
-		Route      string            `json:"route"`  // Single route from v1alpha1
-		Routes     RoutesJSON        `json:"routes"` // Multiple routes from v2alpha1
+		PubsubName      string            `json:"pubsubname"`
+		Topic           string            `json:"topic"`
+		DeadLetterTopic string            `json:"deadLetterTopic,omitempty"`
+		BulkSubscribe   BulkSubscribe     `json:"bulkSubscribe,omitempty"`
+		Metadata        map[string]string `json:"metadata,omitempty"`
+		Route           string            `json:"route"`  // Single route from v1alpha1
+		Routes          RoutesJSON        `json:"routes"` // Multiple routes from v2alpha1
 	}
 
 	RoutesJSON struct {
@@ -142,10 +155,12 @@ func GetSubscriptionsHTTP(channel channel.AppChannel, log logger.Logger) ([]Subs
 			}
 
 			subscriptions[i] = Subscription{
-				PubsubName: si.PubsubName,
-				Topic:      si.Topic,
-				Metadata:   si.Metadata,
-				Rules:      rules,
+				PubsubName:      si.PubsubName,
+				Topic:           si.Topic,
+				DeadLetterTopic: si.DeadLetterTopic,
+				BulkSubscribe:   si.BulkSubscribe,
+				Metadata:        si.Metadata,
+				Rules:           rules,
 			}
 		}
 
@@ -219,18 +234,74 @@ func GetSubscriptionsGRPC(channel runtimev1pb.AppCallbackClient, log logger.Logg
 			if err != nil {
 				return nil, err
 			}
-			subscriptions = append(subscriptions, Subscription{
+			sub := Subscription{
 				PubsubName: s.PubsubName,
 				Topic:      s.GetTopic(),
 				Metadata:   s.GetMetadata(),
 				Rules:      rules,
-			})
+			}
+			if err := extractSubscriptionOptions(&sub); err != nil {
+				return nil, err
+			}
+			subscriptions = append(subscriptions, sub)
 		}
 	}
 
 	return subscriptions, nil
 }
 
+// extractSubscriptionOptions moves the dead-letter topic and bulk subscribe options from
+// the metadata of a gRPC subscription to their fields, so they are not passed on to the
+// pubsub component.
+func extractSubscriptionOptions(sub *Subscription) error {
+	keys := []string{
+		DeadLetterTopicMetadataKey,
+		BulkSubscribeEnabledMetadataKey,
+		BulkSubscribeMaxMessagesCountMetadataKey,
+		BulkSubscribeMaxAwaitDurationMsMetadataKey,
+	}
+
+	options := map[string]string{}
+	md := make(map[string]string, len(sub.Metadata))
+	for k, v := range sub.Metadata {
+		md[k] = v
+	}
+	for _, key := range keys {
+		if v, ok := md[key]; ok {
+			options[key] = v
+			delete(md, key)
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	sub.Metadata = md
+
+	sub.DeadLetterTopic = options[DeadLetterTopicMetadataKey]
+	if v, ok := options[BulkSubscribeEnabledMetadataKey]; ok {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s for topic %s", BulkSubscribeEnabledMetadataKey, sub.Topic)
+		}
+		sub.BulkSubscribe.Enabled = enabled
+	}
+	if v, ok := options[BulkSubscribeMaxMessagesCountMetadataKey]; ok {
+		count, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s for topic %s", BulkSubscribeMaxMessagesCountMetadataKey, sub.Topic)
+		}
+		sub.BulkSubscribe.MaxMessagesCount = int32(count)
+	}
+	if v, ok := options[BulkSubscribeMaxAwaitDurationMsMetadataKey]; ok {
+		ms, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s for topic %s", BulkSubscribeMaxAwaitDurationMsMetadataKey, sub.Topic)
+		}
+		sub.BulkSubscribe.MaxAwaitDurationMs = int32(ms)
+	}
+	return nil
+}
+
 // DeclarativeSelfHosted loads subscriptions from the given components path.
 func DeclarativeSelfHosted(componentsPath string, log logger.Logger) []Subscription {
 	var subs []Subscription
@@ -295,11 +366,17 @@ func marshalSubscription(b []byte) (*Subscription, error) {
 		}
 
 		return &Subscription{
-			Topic:      sub.Spec.Topic,
-			PubsubName: sub.Spec.Pubsubname,
-			Rules:      rules,
-			Metadata:   sub.Spec.Metadata,
-			Scopes:     sub.Scopes,
+			Topic:           sub.Spec.Topic,
+			PubsubName:      sub.Spec.Pubsubname,
+			DeadLetterTopic: sub.Spec.DeadLetterTopic,
+			BulkSubscribe: BulkSubscribe{
+				Enabled:            sub.Spec.BulkSubscribe.Enabled,
+				MaxMessagesCount:   sub.Spec.BulkSubscribe.MaxMessagesCount,
+				MaxAwaitDurationMs: sub.Spec.BulkSubscribe.MaxAwaitDurationMs,
+			},
+			Rules:    rules,
+			Metadata: sub.Spec.Metadata,
+			Scopes:   sub.Scopes,
 		}, nil
 
 	default:
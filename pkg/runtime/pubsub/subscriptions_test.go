@@ -106,8 +106,13 @@ func testDeclarativeSubscriptionV2() subscriptionsapi_v2alpha1.Subscription {
 			APIVersion: APIVersionV2alpha1,
 		},
 		Spec: subscriptionsapi_v2alpha1.SubscriptionSpec{
-			Pubsubname: "pubsub",
-			Topic:      "topic1",
+			Pubsubname:      "pubsub",
+			Topic:           "topic1",
+			DeadLetterTopic: "topic1-dlq",
+			BulkSubscribe: subscriptionsapi_v2alpha1.BulkSubscribe{
+				Enabled:            true,
+				MaxAwaitDurationMs: 500,
+			},
 			Metadata: map[string]string{
 				"testName": "testValue",
 			},
@@ -221,6 +226,8 @@ func TestDeclarativeSubscriptionsV2(t *testing.T) {
 			assert.Equal(t, "pubsub", subs[0].PubsubName)
 			assert.Equal(t, "scope1", subs[0].Scopes[0])
 			assert.Equal(t, "testValue", subs[0].Metadata["testName"])
+			assert.Equal(t, "topic1-dlq", subs[0].DeadLetterTopic)
+			assert.Equal(t, BulkSubscribe{Enabled: true, MaxAwaitDurationMs: 500}, subs[0].BulkSubscribe)
 		}
 	})
 
@@ -325,8 +332,13 @@ type mockHTTPSubscriptions struct {
 func (m *mockHTTPSubscriptions) InvokeMethod(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
 	subs := []SubscriptionJSON{
 		{
-			PubsubName: "pubsub",
-			Topic:      "topic1",
+			PubsubName:      "pubsub",
+			Topic:           "topic1",
+			DeadLetterTopic: "topic1-dlq",
+			BulkSubscribe: BulkSubscribe{
+				Enabled:          true,
+				MaxMessagesCount: 10,
+			},
 			Metadata: map[string]string{
 				"testName": "testValue",
 			},
@@ -367,6 +379,8 @@ func TestHTTPSubscriptions(t *testing.T) {
 			}
 			assert.Equal(t, "pubsub", subs[0].PubsubName)
 			assert.Equal(t, "testValue", subs[0].Metadata["testName"])
+			assert.Equal(t, "topic1-dlq", subs[0].DeadLetterTopic)
+			assert.Equal(t, BulkSubscribe{Enabled: true, MaxMessagesCount: 10}, subs[0].BulkSubscribe)
 		}
 	})
 
@@ -455,7 +469,8 @@ func (m *mockGRPCSubscriptions) ListTopicSubscriptions(ctx context.Context, in *
 				PubsubName: "pubsub",
 				Topic:      "topic1",
 				Metadata: map[string]string{
-					"testName": "testValue",
+					"testName":                 "testValue",
+					DeadLetterTopicMetadataKey: "topic1-dlq",
 				},
 				Routes: &runtimev1pb.TopicRoutes{
 					Rules: []*runtimev1pb.TopicRule{
@@ -489,6 +504,8 @@ func TestGRPCSubscriptions(t *testing.T) {
 			}
 			assert.Equal(t, "pubsub", subs[0].PubsubName)
 			assert.Equal(t, "testValue", subs[0].Metadata["testName"])
+			assert.Equal(t, "topic1-dlq", subs[0].DeadLetterTopic)
+			assert.NotContains(t, subs[0].Metadata, DeadLetterTopicMetadataKey)
 		}
 	})
 
@@ -553,3 +570,40 @@ func TestK8sSubscriptions(t *testing.T) {
 		assert.Equal(t, "testValue", subs[0].Metadata["testName"])
 	}
 }
+
+func TestExtractSubscriptionOptions(t *testing.T) {
+	t.Run("options are moved out of the metadata", func(t *testing.T) {
+		sub := Subscription{
+			Topic: "topic1",
+			Metadata: map[string]string{
+				"testName":                                 "testValue",
+				DeadLetterTopicMetadataKey:                 "topic1-dlq",
+				BulkSubscribeEnabledMetadataKey:            "true",
+				BulkSubscribeMaxMessagesCountMetadataKey:   "50",
+				BulkSubscribeMaxAwaitDurationMsMetadataKey: "200",
+			},
+		}
+		require.NoError(t, extractSubscriptionOptions(&sub))
+		assert.Equal(t, map[string]string{"testName": "testValue"}, sub.Metadata)
+		assert.Equal(t, "topic1-dlq", sub.DeadLetterTopic)
+		assert.Equal(t, BulkSubscribe{Enabled: true, MaxMessagesCount: 50, MaxAwaitDurationMs: 200}, sub.BulkSubscribe)
+	})
+
+	t.Run("metadata without options is kept", func(t *testing.T) {
+		md := map[string]string{"testName": "testValue"}
+		sub := Subscription{Topic: "topic1", Metadata: md}
+		require.NoError(t, extractSubscriptionOptions(&sub))
+		assert.Equal(t, md, sub.Metadata)
+		assert.False(t, sub.BulkSubscribe.Enabled)
+	})
+
+	t.Run("invalid bulk subscribe option", func(t *testing.T) {
+		sub := Subscription{
+			Topic: "topic1",
+			Metadata: map[string]string{
+				BulkSubscribeMaxMessagesCountMetadataKey: "many",
+			},
+		}
+		assert.Error(t, extractSubscriptionOptions(&sub))
+	})
+}
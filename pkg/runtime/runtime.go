@@ -35,9 +35,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cenkalti/backoff"
+	"github.com/cenkalti/backoff/v4"
 
 	configuration_loader "github.com/bhojpur/application/pkg/components/configuration"
+	lock_loader "github.com/bhojpur/application/pkg/components/lock"
+	"github.com/bhojpur/application/pkg/lock"
 	"github.com/bhojpur/service/pkg/configuration"
 
 	"contrib.go.opencensus.io/exporter/zipkin"
@@ -63,10 +65,13 @@ import (
 	"github.com/bhojpur/service/pkg/secretstores"
 	"github.com/bhojpur/service/pkg/state"
 	"github.com/bhojpur/service/pkg/utils/logger"
+	"github.com/bhojpur/service/pkg/utils/retry"
 
 	"github.com/bhojpur/application/pkg/actors"
+	"github.com/bhojpur/application/pkg/apitoken"
 	operatorv1pb "github.com/bhojpur/api/pkg/core/v1/operator"
 	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
+	runtimev1alphapb "github.com/bhojpur/application/pkg/api/v1/runtime"
 	"github.com/bhojpur/application/pkg/channel"
 	http_channel "github.com/bhojpur/application/pkg/channel/http"
 	"github.com/bhojpur/application/pkg/components"
@@ -87,10 +92,15 @@ import (
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
 	http_middleware "github.com/bhojpur/application/pkg/middleware/http"
 	"github.com/bhojpur/application/pkg/operator/client"
+	"github.com/bhojpur/application/pkg/ratelimit"
+	"github.com/bhojpur/application/pkg/recorder"
+	"github.com/bhojpur/application/pkg/resiliency"
+	"github.com/bhojpur/application/pkg/runtime/compstore"
 	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
 	"github.com/bhojpur/application/pkg/runtime/security"
 	"github.com/bhojpur/application/pkg/scopes"
 	"github.com/bhojpur/application/pkg/utils"
+	"github.com/bhojpur/application/pkg/workflows"
 )
 
 const (
@@ -100,6 +110,9 @@ const (
 	bindingsConcurrencyParallel   = "parallel"
 	bindingsConcurrencySequential = "sequential"
 	pubsubName                    = "pubsubName"
+
+	// bulkPublishParallelism is the number of bulk publish entries sent to a pubsub component concurrently.
+	bulkPublishParallelism = 10
 )
 
 type ComponentCategory string
@@ -111,11 +124,20 @@ const (
 	stateComponent                  ComponentCategory = "state"
 	middlewareComponent             ComponentCategory = "middleware"
 	configurationComponent          ComponentCategory = "configuration"
+	lockComponent                   ComponentCategory = "lock"
 	defaultComponentInitTimeout                       = time.Second * 5
 	defaultGracefulShutdownDuration                   = time.Second * 5
 	kubernetesSecretStore                             = "kubernetes"
 )
 
+// deadLetterRetry retries the messages of subscriptions with a dead-letter topic before they
+// are dead-lettered, when their pubsub has no inbound retry policy.
+var deadLetterRetry = retry.Config{
+	Policy:     retry.PolicyConstant,
+	Duration:   time.Second,
+	MaxRetries: 3,
+}
+
 var componentCategoriesNeedProcess = []ComponentCategory{
 	bindingsComponent,
 	pubsubComponent,
@@ -123,6 +145,7 @@ var componentCategoriesNeedProcess = []ComponentCategory{
 	stateComponent,
 	middlewareComponent,
 	configurationComponent,
+	lockComponent,
 }
 
 var log = logger.NewLogger("app.runtime")
@@ -132,8 +155,10 @@ var log = logger.NewLogger("app.runtime")
 var ErrUnexpectedEnvelopeData = errors.New("unexpected data type encountered in envelope")
 
 type Route struct {
-	metadata map[string]string
-	rules    []*runtime_pubsub.Rule
+	metadata        map[string]string
+	rules           []*runtime_pubsub.Rule
+	deadLetterTopic string
+	bulkSubscribe   runtime_pubsub.BulkSubscribe
 }
 
 type TopicRoute struct {
@@ -142,6 +167,7 @@ type TopicRoute struct {
 
 // AppRuntime holds all the core components of the runtime.
 type AppRuntime struct {
+	listComponentsOnly     bool
 	runtimeConfig          *Config
 	globalConfig           *config.Configuration
 	accessControlList      *config.AccessControlList
@@ -154,15 +180,11 @@ type AppRuntime struct {
 	stateStoreRegistry     state_loader.Registry
 	secretStoresRegistry   secretstores_loader.Registry
 	nameResolutionRegistry nr_loader.Registry
-	stateStores            map[string]state.Store
+	compStore              *compstore.ComponentStore
 	actor                  actors.Actors
 	bindingsRegistry       bindings_loader.Registry
 	subscribeBindingList   []string
-	inputBindings          map[string]bindings.InputBinding
-	outputBindings         map[string]bindings.OutputBinding
-	secretStores           map[string]secretstores.SecretStore
 	pubSubRegistry         pubsub_loader.Registry
-	pubSubs                map[string]pubsub.PubSub
 	nameResolver           nr.Resolver
 	json                   jsoniter.API
 	httpMiddlewareRegistry http_middleware_loader.Registry
@@ -172,26 +194,40 @@ type AppRuntime struct {
 	authenticator          security.Authenticator
 	namespace              string
 	podName                string
-	scopedSubscriptions    map[string][]string
-	scopedPublishings      map[string][]string
-	allowedTopics          map[string][]string
 	appHTTPAPI             http.API
 	operatorClient         operatorv1pb.OperatorClient
 	topicRoutes            map[string]TopicRoute
-	inputBindingRoutes     map[string]string
 	shutdownC              chan error
 	apiClosers             []io.Closer
+	traceExporterClosers   []io.Closer
+	recorder               *recorder.Recorder
 
-	secretsConfiguration map[string]config.SecretsScope
 
 	configurationStoreRegistry configuration_loader.Registry
-	configurationStores        map[string]configuration.Store
+
+	lockStoreRegistry lock_loader.Registry
 
 	pendingComponents          chan components_v1alpha1.Component
 	pendingComponentDependents map[string][]components_v1alpha1.Component
+	componentsProcessingLock   *sync.Mutex
+
+	componentUpdatesLock *sync.RWMutex
+	componentUpdates     []components.Update
+
+	bulkSubscribersLock *sync.Mutex
+	bulkSubscribers     map[string][]*runtime_pubsub.Batcher
+
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	proxy messaging.Proxy
 
+	resiliency  *resiliency.Resiliency
+	rateLimiter *ratelimit.Limiter
+	apiTokens   *apitoken.Store
+
+	httpEndpoints map[string]channel.AppChannel
+
 	// TODO: Remove feature flag once feature is ratified
 	featureRoutingEnabled bool
 }
@@ -222,6 +258,7 @@ type pubsubSubscribedMessage struct {
 
 // NewAppRuntime returns a new runtime with the given runtime config and global config.
 func NewAppRuntime(runtimeConfig *Config, globalConfig *config.Configuration, accessControlList *config.AccessControlList) *AppRuntime {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &AppRuntime{
 		runtimeConfig:          runtimeConfig,
 		globalConfig:           globalConfig,
@@ -231,11 +268,7 @@ func NewAppRuntime(runtimeConfig *Config, globalConfig *config.Configuration, ac
 		actorStateStoreLock:    &sync.RWMutex{},
 		grpc:                   grpc.NewGRPCManager(runtimeConfig.Mode),
 		json:                   jsoniter.ConfigFastest,
-		inputBindings:          map[string]bindings.InputBinding{},
-		outputBindings:         map[string]bindings.OutputBinding{},
-		secretStores:           map[string]secretstores.SecretStore{},
-		stateStores:            map[string]state.Store{},
-		pubSubs:                map[string]pubsub.PubSub{},
+		compStore:              compstore.New(),
 		stateStoreRegistry:     state_loader.NewRegistry(),
 		bindingsRegistry:       bindings_loader.NewRegistry(),
 		pubSubRegistry:         pubsub_loader.NewRegistry(),
@@ -243,32 +276,43 @@ func NewAppRuntime(runtimeConfig *Config, globalConfig *config.Configuration, ac
 		nameResolutionRegistry: nr_loader.NewRegistry(),
 		httpMiddlewareRegistry: http_middleware_loader.NewRegistry(),
 
-		scopedSubscriptions: map[string][]string{},
-		scopedPublishings:   map[string][]string{},
-		allowedTopics:       map[string][]string{},
-		inputBindingRoutes:  map[string]string{},
 
-		secretsConfiguration:       map[string]config.SecretsScope{},
 		configurationStoreRegistry: configuration_loader.NewRegistry(),
-		configurationStores:        map[string]configuration.Store{},
+		lockStoreRegistry:          lock_loader.NewRegistry(),
 
 		pendingComponents:          make(chan components_v1alpha1.Component),
 		pendingComponentDependents: map[string][]components_v1alpha1.Component{},
+		componentsProcessingLock:   &sync.Mutex{},
+		componentUpdatesLock:       &sync.RWMutex{},
+		bulkSubscribersLock:        &sync.Mutex{},
+		bulkSubscribers:            map[string][]*runtime_pubsub.Batcher{},
 		shutdownC:                  make(chan error, 1),
+
+		ctx:    ctx,
+		cancel: cancel,
 	}
 }
 
 // Run performs initialization of the runtime with the runtime and global configurations.
 func (a *AppRuntime) Run(opts ...Option) error {
-	start := time.Now().UTC()
-	log.Infof("Bhojpur Application %s mode configured", a.runtimeConfig.Mode)
-	log.Infof("app id: %s", a.runtimeConfig.ID)
-
 	var o runtimeOpts
 	for _, opt := range opts {
 		opt(&o)
 	}
 
+	if a.listComponentsOnly {
+		b, err := json.Marshal(o.componentTypes())
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		os.Exit(0)
+	}
+
+	start := time.Now().UTC()
+	log.Infof("Bhojpur Application %s mode configured", a.runtimeConfig.Mode)
+	log.Infof("app id: %s", a.runtimeConfig.ID)
+
 	err := a.initRuntime(&o)
 	if err != nil {
 		return err
@@ -323,6 +367,16 @@ func (a *AppRuntime) setupTracing(hostAddress string, exporters traceExporterSto
 		exporter := zipkin.NewExporter(reporter, localEndpoint)
 		exporters.RegisterExporter(exporter)
 	}
+
+	// Register otel trace exporter if OtelSpec is specified
+	if a.globalConfig.Spec.TracingSpec.Otel.EndpointAddress != "" {
+		exporter, err := diag.NewOtelExporter(a.runtimeConfig.ID, a.globalConfig.Spec.TracingSpec.Otel)
+		if err != nil {
+			return err
+		}
+		exporters.RegisterExporter(exporter)
+		a.traceExporterClosers = append(a.traceExporterClosers, exporter)
+	}
 	return nil
 }
 
@@ -351,6 +405,17 @@ func (a *AppRuntime) initRuntime(opts *runtimeOpts) error {
 	if err = a.setupTracing(a.hostAddress, openCensusExporterStore{}); err != nil {
 		return errors.Wrap(err, "failed to setup tracing")
 	}
+	if a.resiliency, err = resiliency.New(a.globalConfig.Spec.ResiliencySpec); err != nil {
+		return errors.Wrap(err, "failed to load resiliency policies")
+	}
+	if a.rateLimiter, err = ratelimit.New(a.globalConfig.Spec.RateLimitSpec); err != nil {
+		log.Warnf("failed to load rate limit policies, no limits will be applied: %s", err)
+	}
+	if a.globalConfig.Spec.RecordingSpec.Enabled {
+		if a.recorder, err = recorder.New(a.runtimeConfig.ID, a.globalConfig.Spec.RecordingSpec); err != nil {
+			log.Warnf("failed to start request recording, requests will not be recorded: %s", err)
+		}
+	}
 	// Register and initialize name resolution for service discovery.
 	a.nameResolutionRegistry.Register(opts.nameResolutions...)
 	err = a.initNameResolution()
@@ -362,6 +427,7 @@ func (a *AppRuntime) initRuntime(opts *runtimeOpts) error {
 	a.secretStoresRegistry.Register(opts.secretStores...)
 	a.stateStoreRegistry.Register(opts.states...)
 	a.configurationStoreRegistry.Register(opts.configurations...)
+	a.lockStoreRegistry.Register(opts.locks...)
 	a.bindingsRegistry.RegisterInputBindings(opts.inputBindings...)
 	a.bindingsRegistry.RegisterOutputBindings(opts.outputBindings...)
 	a.httpMiddlewareRegistry.Register(opts.httpMiddleware...)
@@ -387,6 +453,11 @@ func (a *AppRuntime) initRuntime(opts *runtimeOpts) error {
 	// Setup allow/deny list for secrets
 	a.populateSecretsConfiguration()
 
+	// Load the API tokens, which may come from a secret store
+	if err = a.initAPITokens(); err != nil {
+		log.Errorf("failed to load API tokens, no named API token is accepted until they load: %s", err)
+	}
+
 	// Start proxy
 	a.initProxy()
 
@@ -436,6 +507,7 @@ func (a *AppRuntime) initRuntime(opts *runtimeOpts) error {
 
 	a.loadAppConfiguration()
 
+	a.initHTTPEndpoints()
 	a.initDirectMessaging(a.nameResolver)
 
 	a.appHTTPAPI.SetDirectMessaging(a.directMessaging)
@@ -456,11 +528,11 @@ func (a *AppRuntime) initRuntime(opts *runtimeOpts) error {
 		if err = opts.componentsCallback(ComponentRegistry{
 			Actors:          a.actor,
 			DirectMessaging: a.directMessaging,
-			StateStores:     a.stateStores,
-			InputBindings:   a.inputBindings,
-			OutputBindings:  a.outputBindings,
-			SecretStores:    a.secretStores,
-			PubSubs:         a.pubSubs,
+			StateStores:     a.compStore.ListStateStores(),
+			InputBindings:   a.compStore.ListInputBindings(),
+			OutputBindings:  a.compStore.ListOutputBindings(),
+			SecretStores:    a.compStore.ListSecretStores(),
+			PubSubs:         a.compStore.ListPubSubs(),
 		}); err != nil {
 			log.Fatalf("failed to register Bhojpur Application runtime components with callback: %s", err)
 		}
@@ -471,14 +543,74 @@ func (a *AppRuntime) initRuntime(opts *runtimeOpts) error {
 	if err != nil {
 		log.Warnf("failed to read from Bhojpur Application runtime bindings: %s ", err)
 	}
+
+	err = a.beginStandaloneUpdates()
+	if err != nil {
+		log.Warnf("failed to watch standalone component and configuration updates: %s", err)
+	}
 	return nil
 }
 
 func (a *AppRuntime) populateSecretsConfiguration() {
-	// Populate in a map for easy lookup by store name.
-	for _, scope := range a.globalConfig.Spec.Secrets.Scopes {
-		a.secretsConfiguration[scope.StoreName] = scope
+	a.compStore.SetSecretsConfigurations(a.globalConfig.Spec.Secrets.Scopes)
+}
+
+// defaultAPITokensRefreshInterval is how often API tokens kept in a secret store are
+// fetched again when the configuration sets no interval.
+const defaultAPITokensRefreshInterval = time.Minute
+
+// initAPITokens creates the store of the tokens accepted by the API servers: the token
+// of the environment and the named tokens listed in a file or a secret, which are
+// reloaded when they change.
+func (a *AppRuntime) initAPITokens() error {
+	a.apiTokens = apitoken.NewStore(security.GetAPIToken())
+
+	spec := a.globalConfig.Spec.APISpec.Tokens
+	switch {
+	case spec.File != "":
+		return a.apiTokens.WatchFile(a.ctx, spec.File)
+	case spec.SecretStore != "":
+		interval := defaultAPITokensRefreshInterval
+		if spec.RefreshInterval != "" {
+			d, err := time.ParseDuration(spec.RefreshInterval)
+			if err != nil {
+				a.apiTokens.Update(nil)
+				return errors.Wrapf(err, "invalid API tokens refresh interval %q", spec.RefreshInterval)
+			}
+			interval = d
+		}
+		return a.apiTokens.Poll(a.ctx, interval, func(context.Context) ([]byte, error) {
+			value, err := a.getSecretValue(spec.SecretStore, spec.SecretName, spec.SecretKey)
+			return []byte(value), err
+		})
 	}
+	return nil
+}
+
+// getSecretValue returns the value of key in the secret name of a secret store. The key
+// defaults to the secret name.
+func (a *AppRuntime) getSecretValue(storeName, name, key string) (string, error) {
+	store := a.getSecretStore(storeName)
+	if store == nil {
+		return "", errors.Errorf("secret store %s not found", storeName)
+	}
+	resp, err := store.GetSecret(secretstores.GetSecretRequest{
+		Name: name,
+		Metadata: map[string]string{
+			"namespace": a.namespace,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		key = name
+	}
+	value, ok := resp.Data[key]
+	if !ok {
+		return "", errors.Errorf("secret %s has no key %s", name, key)
+	}
+	return value, nil
 }
 
 func (a *AppRuntime) buildHTTPPipeline() (http_middleware.Pipeline, error) {
@@ -525,11 +657,14 @@ func (a *AppRuntime) initBinding(c components_v1alpha1.Component) error {
 
 func (a *AppRuntime) beginPubSub(name string, ps pubsub.PubSub) error {
 	var publishFunc func(ctx context.Context, msg *pubsubSubscribedMessage) error
+	var publishBulkFunc func(ctx context.Context, pubsubName, topic, path string, entries []*runtime_pubsub.BulkMessageEntry) []error
 	switch a.runtimeConfig.ApplicationProtocol {
 	case HTTPProtocol:
 		publishFunc = a.publishMessageHTTP
+		publishBulkFunc = a.publishBulkMessageHTTP
 	case GRPCProtocol:
 		publishFunc = a.publishMessageGRPC
+		publishBulkFunc = a.publishBulkMessageGRPC
 	}
 	topicRoutes, err := a.getTopicRoutes()
 	if err != nil {
@@ -540,7 +675,7 @@ func (a *AppRuntime) beginPubSub(name string, ps pubsub.PubSub) error {
 		return nil
 	}
 	for topic, route := range v.routes {
-		allowed := a.isPubSubOperationAllowed(name, topic, a.scopedSubscriptions[name])
+		allowed := a.isPubSubOperationAllowed(name, topic, a.compStore.GetTopicScopes(name).ScopedSubscriptions)
 		if !allowed {
 			log.Warnf("subscription to topic %s on pubsub %s is not allowed", topic, name)
 			continue
@@ -550,6 +685,16 @@ func (a *AppRuntime) beginPubSub(name string, ps pubsub.PubSub) error {
 
 		routeMetadata := route.metadata
 		routeRules := route.rules
+		deadLetterTopic := route.deadLetterTopic
+
+		var batcher *runtime_pubsub.Batcher
+		if route.bulkSubscribe.Enabled {
+			subscribedTopic := topic
+			batcher = runtime_pubsub.NewBatcher(route.bulkSubscribe, func(ctx context.Context, path string, entries []*runtime_pubsub.BulkMessageEntry) []error {
+				return publishBulkFunc(ctx, name, subscribedTopic, path, entries)
+			})
+			a.addBulkSubscriber(name, batcher)
+		}
 		if err := ps.Subscribe(pubsub.SubscribeRequest{
 			Topic:    topic,
 			Metadata: route.metadata,
@@ -599,13 +744,59 @@ func (a *AppRuntime) beginPubSub(name string, ps pubsub.PubSub) error {
 				return nil
 			}
 
-			return publishFunc(ctx, &pubsubSubscribedMessage{
+			psm := &pubsubSubscribedMessage{
 				cloudEvent: cloudEvent,
 				data:       data,
 				topic:      msg.Topic,
 				metadata:   msg.Metadata,
 				path:       routePath,
+			}
+			deliver := func(ctx context.Context) error {
+				return publishFunc(ctx, psm)
+			}
+			if batcher != nil {
+				entry := &runtime_pubsub.BulkMessageEntry{
+					EntryID:    uuid.New().String(),
+					CloudEvent: cloudEvent,
+					Data:       data,
+					Metadata:   msg.Metadata,
+				}
+				deliver = func(ctx context.Context) error {
+					return batcher.Submit(ctx, routePath, entry)
+				}
+			}
+			policy := a.resiliency.ComponentInboundPolicy(ctx, name)
+			if deadLetterTopic != "" {
+				// A dead-lettered message is not redelivered, so it is retried first even
+				// when the pubsub has no inbound retry policy.
+				policy = a.resiliency.ComponentInboundPolicyWithRetry(ctx, name, deadLetterRetry)
+			}
+			_, err = policy(func(ctx context.Context) (interface{}, error) {
+				if perr := deliver(ctx); perr != nil {
+					if errors.Is(perr, runtime_pubsub.ErrMessageDropped) || errors.Is(perr, runtime_pubsub.ErrBatcherClosed) {
+						return nil, backoff.Permanent(perr)
+					}
+					return nil, perr
+				}
+				return nil, nil
 			})
+			if err == nil || errors.Is(err, runtime_pubsub.ErrBatcherClosed) {
+				return err
+			}
+
+			if deadLetterTopic == "" {
+				// Dropped messages are acknowledged, they are not redelivered.
+				if errors.Is(err, runtime_pubsub.ErrMessageDropped) {
+					return nil
+				}
+				return err
+			}
+
+			if dlErr := a.sendToDeadLetter(name, psm, deadLetterTopic, err); dlErr != nil {
+				log.Errorf("failed to send pub/sub event %v to dead-letter topic %s: %s", cloudEvent[pubsub.IDField], deadLetterTopic, dlErr)
+				return err
+			}
+			return nil
 		}); err != nil {
 			log.Errorf("failed to subscribe to Bhojpur Application runtime topic %s: %s", topic, err)
 		}
@@ -614,6 +805,43 @@ func (a *AppRuntime) beginPubSub(name string, ps pubsub.PubSub) error {
 	return nil
 }
 
+func (a *AppRuntime) addBulkSubscriber(pubsubName string, batcher *runtime_pubsub.Batcher) {
+	a.bulkSubscribersLock.Lock()
+	defer a.bulkSubscribersLock.Unlock()
+
+	a.bulkSubscribers[pubsubName] = append(a.bulkSubscribers[pubsubName], batcher)
+}
+
+// closeBulkSubscribers stops batching the messages of the subscriptions of a pubsub.
+// Messages waiting for a batch fail and are redelivered by the pubsub.
+func (a *AppRuntime) closeBulkSubscribers(pubsubName string) {
+	a.bulkSubscribersLock.Lock()
+	batchers := a.bulkSubscribers[pubsubName]
+	delete(a.bulkSubscribers, pubsubName)
+	a.bulkSubscribersLock.Unlock()
+
+	for _, batcher := range batchers {
+		batcher.Close()
+	}
+}
+
+// sendToDeadLetter republishes the original cloudevent of a message whose delivery
+// was given up to the dead-letter topic of the same pubsub.
+func (a *AppRuntime) sendToDeadLetter(name string, msg *pubsubSubscribedMessage, deadLetterTopic string, reason error) error {
+	cloudEvent := runtime_pubsub.NewDeadLetterCloudEvent(msg.cloudEvent, name, msg.topic, reason, time.Now())
+	data, err := a.json.Marshal(cloudEvent)
+	if err != nil {
+		return err
+	}
+
+	log.Warnf("sending pub/sub event %v from topic %s to dead-letter topic %s", cloudEvent[pubsub.IDField], msg.topic, deadLetterTopic)
+	return a.Publish(&pubsub.PublishRequest{
+		PubsubName: name,
+		Topic:      deadLetterTopic,
+		Data:       data,
+	})
+}
+
 // findMatchingRoute selects the path based on routing rules. If there are
 // no matching rules, the route-level path is used.
 func findMatchingRoute(rules []*runtime_pubsub.Rule, cloudEvent interface{}, routingEnabled bool) (path string, shouldProcess bool, err error) {
@@ -660,6 +888,15 @@ func matchRoutingRule(rules []*runtime_pubsub.Rule, data map[string]interface{},
 	return nil, nil
 }
 
+// callerToken returns the API token presented to the invoked apps when the access
+// control policies identify callers by the API token shared by the sidecars.
+func (a *AppRuntime) callerToken() string {
+	if a.globalConfig.Spec.AccessControlSpec.CallerIdentity.Source != config.CallerIdentityAPIToken {
+		return ""
+	}
+	return security.GetAPIToken()
+}
+
 func (a *AppRuntime) initDirectMessaging(resolver nr.Resolver) {
 	a.directMessaging = messaging.NewDirectMessaging(
 		a.runtimeConfig.ID,
@@ -674,7 +911,13 @@ func (a *AppRuntime) initDirectMessaging(resolver nr.Resolver) {
 		a.proxy,
 		a.runtimeConfig.ReadBufferSize,
 		a.runtimeConfig.StreamRequestBody,
+		a.resiliency,
+		a.callerToken(),
+		a.httpEndpoints,
 	)
+	if a.recorder != nil {
+		a.directMessaging = a.recorder.DirectMessaging(a.directMessaging)
+	}
 }
 
 func (a *AppRuntime) initProxy() {
@@ -810,11 +1053,16 @@ func (a *AppRuntime) sendToOutputBinding(name string, req *bindings.InvokeReques
 		return nil, errors.New("operation field is missing from request")
 	}
 
-	if binding, ok := a.outputBindings[name]; ok {
+	if binding, ok := a.compStore.GetOutputBinding(name); ok {
 		ops := binding.Operations()
 		for _, o := range ops {
 			if o == req.Operation {
-				return binding.Invoke(req)
+				policy := a.resiliency.ComponentOutboundPolicy(context.Background(), name)
+				res, err := policy(func(ctx context.Context) (interface{}, error) {
+					return binding.Invoke(req)
+				})
+				resp, _ := res.(*bindings.InvokeResponse)
+				return resp, err
 			}
 		}
 		supported := make([]string, 0, len(ops))
@@ -829,8 +1077,8 @@ func (a *AppRuntime) sendToOutputBinding(name string, req *bindings.InvokeReques
 func (a *AppRuntime) onAppResponse(response *bindings.AppResponse) error {
 	if len(response.State) > 0 {
 		go func(reqs []state.SetRequest) {
-			if a.stateStores != nil {
-				err := a.stateStores[response.StoreName].BulkSet(reqs)
+			if store, ok := a.compStore.GetStateStore(response.StoreName); ok {
+				err := store.BulkSet(reqs)
 				if err != nil {
 					log.Errorf("error saving Bhojpur Application runtime state from application response: %s", err)
 				}
@@ -913,7 +1161,7 @@ func (a *AppRuntime) sendBindingEventToApp(bindingName string, data []byte, meta
 			}
 		}
 	} else if a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
-		path := a.inputBindingRoutes[bindingName]
+		path, _ := a.compStore.GetInputBindingRoute(bindingName)
 		req := invokev1.NewInvokeMethodRequest(path)
 		req.WithHTTPExtension(nethttp.MethodPost, "")
 		req.WithRawData(data, invokev1.JSONContentType)
@@ -973,11 +1221,11 @@ func (a *AppRuntime) readFromBinding(name string, binding bindings.InputBinding)
 }
 
 func (a *AppRuntime) startHTTPServer(port int, publicPort *int, profilePort int, allowedOrigins string, pipeline http_middleware.Pipeline) error {
-	a.appHTTPAPI = http.NewAPI(a.runtimeConfig.ID, a.appChannel, a.directMessaging, a.getComponents, a.stateStores, a.secretStores,
-		a.secretsConfiguration, a.getPublishAdapter(), a.actor, a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec, a.ShutdownWithWait)
+	a.appHTTPAPI = http.NewAPI(a.runtimeConfig.ID, a.appChannel, a.directMessaging, a.getComponents, a.getComponentUpdates, a.compStore,
+		a.getPublishAdapter(), a.actor, a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec, a.ShutdownWithWait, a.resiliency, a.rateLimiter)
 	serverConf := http.NewServerConfig(a.runtimeConfig.ID, a.hostAddress, port, a.runtimeConfig.APIListenAddresses, publicPort, profilePort, allowedOrigins, a.runtimeConfig.EnableProfiling, a.runtimeConfig.MaxRequestBodySize, a.runtimeConfig.UnixDomainSocket, a.runtimeConfig.ReadBufferSize, a.runtimeConfig.StreamRequestBody)
 
-	server := http.NewServer(a.appHTTPAPI, serverConf, a.globalConfig.Spec.TracingSpec, a.globalConfig.Spec.MetricSpec, pipeline, a.globalConfig.Spec.APISpec)
+	server := http.NewServer(a.appHTTPAPI, serverConf, a.globalConfig.Spec.TracingSpec, a.globalConfig.Spec.MetricSpec, pipeline, a.globalConfig.Spec.APISpec, a.apiTokens)
 	if err := server.StartNonBlocking(); err != nil {
 		return err
 	}
@@ -1000,7 +1248,7 @@ func (a *AppRuntime) startGRPCInternalServer(api grpc.API, port int) error {
 
 func (a *AppRuntime) startGRPCAPIServer(api grpc.API, port int) error {
 	serverConf := a.getNewServerConfig(a.runtimeConfig.APIListenAddresses, port)
-	server := grpc.NewAPIServer(api, serverConf, a.globalConfig.Spec.TracingSpec, a.globalConfig.Spec.MetricSpec, a.globalConfig.Spec.APISpec, a.proxy)
+	server := grpc.NewAPIServer(api, serverConf, a.globalConfig.Spec.TracingSpec, a.globalConfig.Spec.MetricSpec, a.globalConfig.Spec.APISpec, a.apiTokens, a.proxy)
 	if err := server.StartNonBlocking(); err != nil {
 		return err
 	}
@@ -1020,13 +1268,13 @@ func (a *AppRuntime) getNewServerConfig(apiListenAddresses []string, port int) g
 }
 
 func (a *AppRuntime) getGRPCAPI() grpc.API {
-	return grpc.NewAPI(a.runtimeConfig.ID, a.appChannel, a.stateStores, a.secretStores, a.secretsConfiguration, a.configurationStores,
+	return grpc.NewAPI(a.runtimeConfig.ID, a.appChannel, a.compStore,
 		a.getPublishAdapter(), a.directMessaging, a.actor,
-		a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec, a.accessControlList, string(a.runtimeConfig.ApplicationProtocol), a.getComponents, a.ShutdownWithWait)
+		a.sendToOutputBinding, a.globalConfig.Spec.TracingSpec, a.accessControlList, string(a.runtimeConfig.ApplicationProtocol), a.getComponents, a.ShutdownWithWait, a.resiliency, a.rateLimiter)
 }
 
 func (a *AppRuntime) getPublishAdapter() runtime_pubsub.Adapter {
-	if a.pubSubs == nil || len(a.pubSubs) == 0 {
+	if a.compStore.PubSubsLen() == 0 {
 		return nil
 	}
 
@@ -1057,7 +1305,7 @@ func (a *AppRuntime) isAppSubscribedToBinding(binding string) bool {
 		}
 	} else if a.runtimeConfig.ApplicationProtocol == HTTPProtocol {
 		// if HTTP, check if there's an endpoint listening for that binding
-		path := a.inputBindingRoutes[binding]
+		path, _ := a.compStore.GetInputBindingRoute(binding)
 		req := invokev1.NewInvokeMethodRequest(path)
 		req.WithHTTPExtension(nethttp.MethodOptions, "")
 		req.WithRawData(nil, invokev1.JSONContentType)
@@ -1093,13 +1341,14 @@ func (a *AppRuntime) initInputBinding(c components_v1alpha1.Component) error {
 	}
 
 	log.Infof("successful init for Bhojpur Application runtime input binding %s (%s/%s)", c.ObjectMeta.Name, c.Spec.Type, c.Spec.Version)
-	a.inputBindingRoutes[c.Name] = c.Name
+	route := c.Name
 	for _, item := range c.Spec.Metadata {
 		if item.Name == "route" {
-			a.inputBindingRoutes[c.ObjectMeta.Name] = item.Value.String()
+			route = item.Value.String()
 		}
 	}
-	a.inputBindings[c.Name] = binding
+	a.compStore.AddInputBindingRoute(c.Name, route)
+	a.compStore.AddInputBinding(c.Name, binding)
 	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 	return nil
 }
@@ -1123,7 +1372,7 @@ func (a *AppRuntime) initOutputBinding(c components_v1alpha1.Component) error {
 			return err
 		}
 		log.Infof("successful init for Bhojpur Application runtime output binding %s (%s/%s)", c.ObjectMeta.Name, c.Spec.Type, c.Spec.Version)
-		a.outputBindings[c.ObjectMeta.Name] = binding
+		a.compStore.AddOutputBinding(c.ObjectMeta.Name, binding)
 		diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 	}
 	return nil
@@ -1147,7 +1396,39 @@ func (a *AppRuntime) initConfiguration(s components_v1alpha1.Component) error {
 			return err
 		}
 
-		a.configurationStores[s.ObjectMeta.Name] = store
+		a.compStore.AddConfigurationStore(s.ObjectMeta.Name, store)
+		diag.DefaultMonitoring.ComponentInitialized(s.Spec.Type)
+	}
+
+	return nil
+}
+
+func (a *AppRuntime) initLock(s components_v1alpha1.Component) error {
+	store, err := a.lockStoreRegistry.Create(s.Spec.Type, s.Spec.Version)
+	if err != nil {
+		log.Warnf("error creating Bhojpur Application runtime lock store %s (%s/%s): %s", s.ObjectMeta.Name, s.Spec.Type, s.Spec.Version, err)
+		diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "creation")
+		return err
+	}
+	if store != nil {
+		props := a.convertMetadataItemsToProperties(s.Spec.Metadata)
+		err := store.Init(lock.Metadata{
+			Properties: props,
+		})
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
+			log.Warnf("error initializing Bhojpur Application runtime lock store %s (%s/%s): %s", s.ObjectMeta.Name, s.Spec.Type, s.Spec.Version, err)
+			return err
+		}
+
+		err = lock_loader.SaveLockConfiguration(s.ObjectMeta.Name, props)
+		if err != nil {
+			diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
+			log.Warnf("error saving Bhojpur Application runtime lock store configuration %s (%s/%s): %s", s.ObjectMeta.Name, s.Spec.Type, s.Spec.Version, err)
+			return err
+		}
+
+		a.compStore.AddLockStore(s.ObjectMeta.Name, store)
 		diag.DefaultMonitoring.ComponentInitialized(s.Spec.Type)
 	}
 
@@ -1192,7 +1473,7 @@ func (a *AppRuntime) initState(s components_v1alpha1.Component) error {
 			return err
 		}
 
-		a.stateStores[s.ObjectMeta.Name] = store
+		a.compStore.AddStateStore(s.ObjectMeta.Name, store)
 		err = state_loader.SaveStateConfiguration(s.ObjectMeta.Name, props)
 		if err != nil {
 			diag.DefaultMonitoring.ComponentInitFailed(s.Spec.Type, "init")
@@ -1301,7 +1582,7 @@ func (a *AppRuntime) getTopicRoutes() (map[string]TopicRoute, error) {
 			topicRoutes[s.PubsubName] = TopicRoute{routes: make(map[string]Route)}
 		}
 
-		topicRoutes[s.PubsubName].routes[s.Topic] = Route{metadata: s.Metadata, rules: s.Rules}
+		topicRoutes[s.PubsubName].routes[s.Topic] = Route{metadata: s.Metadata, rules: s.Rules, deadLetterTopic: s.DeadLetterTopic, bulkSubscribe: s.BulkSubscribe}
 	}
 
 	if len(topicRoutes) > 0 {
@@ -1343,10 +1624,12 @@ func (a *AppRuntime) initPubSub(c components_v1alpha1.Component) error {
 
 	pubsubName := c.ObjectMeta.Name
 
-	a.scopedSubscriptions[pubsubName] = scopes.GetScopedTopics(scopes.SubscriptionScopes, a.runtimeConfig.ID, properties)
-	a.scopedPublishings[pubsubName] = scopes.GetScopedTopics(scopes.PublishingScopes, a.runtimeConfig.ID, properties)
-	a.allowedTopics[pubsubName] = scopes.GetAllowedTopics(properties)
-	a.pubSubs[pubsubName] = pubSub
+	a.compStore.SetTopicScopes(pubsubName, compstore.TopicScopes{
+		ScopedSubscriptions: scopes.GetScopedTopics(scopes.SubscriptionScopes, a.runtimeConfig.ID, properties),
+		ScopedPublishings:   scopes.GetScopedTopics(scopes.PublishingScopes, a.runtimeConfig.ID, properties),
+		AllowedTopics:       scopes.GetAllowedTopics(properties),
+	})
+	a.compStore.AddPubSub(pubsubName, pubSub)
 	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 
 	return nil
@@ -1361,24 +1644,54 @@ func (a *AppRuntime) Publish(req *pubsub.PublishRequest) error {
 		return runtime_pubsub.NotFoundError{PubsubName: req.PubsubName}
 	}
 
-	if allowed := a.isPubSubOperationAllowed(req.PubsubName, req.Topic, a.scopedPublishings[req.PubsubName]); !allowed {
+	if allowed := a.isPubSubOperationAllowed(req.PubsubName, req.Topic, a.compStore.GetTopicScopes(req.PubsubName).ScopedPublishings); !allowed {
 		return runtime_pubsub.NotAllowedError{Topic: req.Topic, ID: a.runtimeConfig.ID}
 	}
 
-	return a.pubSubs[req.PubsubName].Publish(req)
+	err := thepubsub.Publish(req)
+	if a.recorder != nil {
+		a.recorder.RecordPublish(req, err)
+	}
+	return err
+}
+
+// BulkPublish is an adapter method for the runtime to pre-validate bulk publish requests
+// and then forward their entries to the Pub/Sub component one at a time, since components
+// only publish single messages.
+func (a *AppRuntime) BulkPublish(req *runtime_pubsub.BulkPublishRequest) (runtime_pubsub.BulkPublishResponse, error) {
+	thepubsub := a.GetPubSub(req.PubsubName)
+	if thepubsub == nil {
+		return runtime_pubsub.BulkPublishResponse{}, runtime_pubsub.NotFoundError{PubsubName: req.PubsubName}
+	}
+
+	if allowed := a.isPubSubOperationAllowed(req.PubsubName, req.Topic, a.compStore.GetTopicScopes(req.PubsubName).ScopedPublishings); !allowed {
+		return runtime_pubsub.BulkPublishResponse{}, runtime_pubsub.NotAllowedError{Topic: req.Topic, ID: a.runtimeConfig.ID}
+	}
+
+	publish := thepubsub.Publish
+	if a.recorder != nil {
+		publish = func(req *pubsub.PublishRequest) error {
+			err := thepubsub.Publish(req)
+			a.recorder.RecordPublish(req, err)
+			return err
+		}
+	}
+	return runtime_pubsub.PublishEntries(publish, req, bulkPublishParallelism), nil
 }
 
 // GetPubSub is an adapter method to find a pubsub by name.
 func (a *AppRuntime) GetPubSub(pubsubName string) pubsub.PubSub {
-	return a.pubSubs[pubsubName]
+	ps, _ := a.compStore.GetPubSub(pubsubName)
+	return ps
 }
 
 func (a *AppRuntime) isPubSubOperationAllowed(pubsubName string, topic string, scopedTopics []string) bool {
 	inAllowedTopics := false
 
 	// first check if allowedTopics contain it
-	if len(a.allowedTopics[pubsubName]) > 0 {
-		for _, t := range a.allowedTopics[pubsubName] {
+	allowedTopics := a.compStore.GetTopicScopes(pubsubName).AllowedTopics
+	if len(allowedTopics) > 0 {
+		for _, t := range allowedTopics {
 			if t == topic {
 				inAllowedTopics = true
 				break
@@ -1509,7 +1822,7 @@ func (a *AppRuntime) publishMessageHTTP(ctx context.Context, msg *pubsubSubscrib
 			return errors.Errorf("RETRY status returned from application while processing pub/sub event %v", cloudEvent[pubsub.IDField])
 		case pubsub.Drop:
 			log.Warnf("DROP status returned from application while processing pub/sub event %v", cloudEvent[pubsub.IDField])
-			return nil
+			return runtime_pubsub.ErrMessageDropped
 		}
 		// Consider unknown status field as error and retry
 		return errors.Errorf("unknown status returned from application while processing pub/sub event %v: %v", cloudEvent[pubsub.IDField], appResponse.Status)
@@ -1520,7 +1833,7 @@ func (a *AppRuntime) publishMessageHTTP(ctx context.Context, msg *pubsubSubscrib
 		// When adding/removing an error here, check if that is also applicable to GRPC since there is a mapping between HTTP and GRPC errors:
 		// https://cloud.google.com/apis/design/errors#handling_errors
 		log.Errorf("non-retriable error returned from application while processing pub/sub event %v: %s. status code returned: %v", cloudEvent[pubsub.IDField], body, statusCode)
-		return nil
+		return runtime_pubsub.ErrMessageDropped
 	}
 
 	// Every error from now on is a retriable error.
@@ -1605,7 +1918,7 @@ func (a *AppRuntime) publishMessageGRPC(ctx context.Context, msg *pubsubSubscrib
 			// DROP
 			log.Warnf("non-retriable error returned from application while processing pub/sub event %v: %s", cloudEvent[pubsub.IDField], err)
 
-			return nil
+			return runtime_pubsub.ErrMessageDropped
 		}
 
 		err = errors.Errorf("error returned from application while processing pub/sub event %v: %s", cloudEvent[pubsub.IDField], err)
@@ -1625,13 +1938,162 @@ func (a *AppRuntime) publishMessageGRPC(ctx context.Context, msg *pubsubSubscrib
 	case runtimev1pb.TopicEventResponse_DROP:
 		log.Warnf("DROP status returned from application while processing pub/sub event %v", cloudEvent[pubsub.IDField])
 
-		return nil
+		return runtime_pubsub.ErrMessageDropped
 	}
 
 	// Consider unknown status field as error and retry
 	return errors.Errorf("unknown status returned from application while processing pub/sub event %v: %v", cloudEvent[pubsub.IDField], res.GetStatus())
 }
 
+// publishBulkMessageHTTP delivers a batch of messages to the app in a single request
+// and returns the outcome of every entry.
+func (a *AppRuntime) publishBulkMessageHTTP(ctx context.Context, pubsubName, topic, path string, entries []*runtime_pubsub.BulkMessageEntry) []error {
+	bulkReq := runtime_pubsub.AppBulkRequest{
+		ID:         uuid.New().String(),
+		Entries:    make([]runtime_pubsub.AppBulkRequestEntry, len(entries)),
+		Topic:      topic,
+		PubsubName: pubsubName,
+		Type:       runtime_pubsub.BulkSubscribeEventType,
+	}
+	for i, entry := range entries {
+		bulkReq.Entries[i] = runtime_pubsub.AppBulkRequestEntry{
+			EntryID:     entry.EntryID,
+			Event:       entry.CloudEvent,
+			ContentType: contenttype.CloudEventContentType,
+			Metadata:    entry.Metadata,
+		}
+	}
+
+	data, err := a.json.Marshal(bulkReq)
+	if err != nil {
+		return bulkErrors(entries, err)
+	}
+
+	req := invokev1.NewInvokeMethodRequest(path)
+	req.WithHTTPExtension(nethttp.MethodPost, "")
+	req.WithRawData(data, invokev1.JSONContentType)
+
+	resp, err := a.appChannel.InvokeMethod(ctx, req)
+	if err != nil {
+		return bulkErrors(entries, errors.Wrap(err, "error from application channel while sending bulk pub/sub event to application"))
+	}
+
+	statusCode := int(resp.Status().Code)
+	_, body := resp.RawData()
+
+	if (statusCode >= 200) && (statusCode <= 299) {
+		var appResponse runtime_pubsub.AppBulkResponse
+		if err := a.json.Unmarshal(body, &appResponse); err != nil {
+			log.Debugf("skipping status check due to error parsing result from bulk pub/sub event %s", bulkReq.ID)
+			// Return no error so messages do not get reprocessed.
+			return make([]error, len(entries))
+		}
+
+		statuses := make(map[string]pubsub.AppResponseStatus, len(appResponse.Statuses))
+		for _, s := range appResponse.Statuses {
+			statuses[s.EntryID] = s.Status
+		}
+
+		errs := make([]error, len(entries))
+		for i, entry := range entries {
+			status, ok := statuses[entry.EntryID]
+			switch {
+			case !ok:
+				errs[i] = errors.Errorf("no status returned from application for entry %s of bulk pub/sub event %s", entry.EntryID, bulkReq.ID)
+			case status == "" || status == pubsub.Success:
+				errs[i] = nil
+			case status == pubsub.Retry:
+				errs[i] = errors.Errorf("RETRY status returned from application for entry %s of bulk pub/sub event %s", entry.EntryID, bulkReq.ID)
+			case status == pubsub.Drop:
+				log.Warnf("DROP status returned from application for entry %s of bulk pub/sub event %s", entry.EntryID, bulkReq.ID)
+				errs[i] = runtime_pubsub.ErrMessageDropped
+			default:
+				errs[i] = errors.Errorf("unknown status returned from application for entry %s of bulk pub/sub event %s: %v", entry.EntryID, bulkReq.ID, status)
+			}
+		}
+		return errs
+	}
+
+	if statusCode == nethttp.StatusNotFound {
+		log.Errorf("non-retriable error returned from application while processing bulk pub/sub event %s: %s. status code returned: %v", bulkReq.ID, body, statusCode)
+		return bulkErrors(entries, runtime_pubsub.ErrMessageDropped)
+	}
+
+	log.Warnf("retriable error returned from application while processing bulk pub/sub event %s, topic: %v, body: %s. status code returned: %v", bulkReq.ID, topic, body, statusCode)
+	return bulkErrors(entries, errors.Errorf("retriable error returned from application while processing bulk pub/sub event %s, topic: %v, body: %s. status code returned: %v", bulkReq.ID, topic, body, statusCode))
+}
+
+// publishBulkMessageGRPC delivers a batch of messages to the app in a single call
+// and returns the outcome of every entry.
+func (a *AppRuntime) publishBulkMessageGRPC(ctx context.Context, pubsubName, topic, path string, entries []*runtime_pubsub.BulkMessageEntry) []error {
+	envelope := &runtimev1alphapb.TopicEventBulkRequest{
+		Id:         uuid.New().String(),
+		Entries:    make([]*runtimev1alphapb.TopicEventBulkRequestEntry, len(entries)),
+		Topic:      topic,
+		PubsubName: pubsubName,
+		Path:       path,
+	}
+	for i, entry := range entries {
+		envelope.Entries[i] = &runtimev1alphapb.TopicEventBulkRequestEntry{
+			EntryId:     entry.EntryID,
+			Event:       entry.Data,
+			ContentType: contenttype.CloudEventContentType,
+			Metadata:    entry.Metadata,
+		}
+	}
+
+	clientV1alpha := runtimev1alphapb.NewAppCallbackAlphaClient(a.grpc.AppClient)
+	res, err := clientV1alpha.OnBulkTopicEventAlpha1(ctx, envelope)
+	if err != nil {
+		errStatus, hasErrStatus := status.FromError(err)
+		if hasErrStatus && (errStatus.Code() == codes.Unimplemented) {
+			// DROP
+			log.Warnf("non-retriable error returned from application while processing bulk pub/sub event %s: %s", envelope.Id, err)
+
+			return bulkErrors(entries, runtime_pubsub.ErrMessageDropped)
+		}
+
+		err = errors.Errorf("error returned from application while processing bulk pub/sub event %s: %s", envelope.Id, err)
+		log.Debug(err)
+
+		// on error from application, return error for redelivery of events
+		return bulkErrors(entries, err)
+	}
+
+	statuses := make(map[string]runtimev1alphapb.TopicEventBulkResponseEntry_Status, len(res.GetStatuses()))
+	for _, s := range res.GetStatuses() {
+		statuses[s.EntryId] = s.Status
+	}
+
+	errs := make([]error, len(entries))
+	for i, entry := range entries {
+		status, ok := statuses[entry.EntryID]
+		switch {
+		case !ok:
+			errs[i] = errors.Errorf("no status returned from application for entry %s of bulk pub/sub event %s", entry.EntryID, envelope.Id)
+		case status == runtimev1alphapb.TopicEventBulkResponseEntry_SUCCESS:
+			errs[i] = nil
+		case status == runtimev1alphapb.TopicEventBulkResponseEntry_RETRY:
+			errs[i] = errors.Errorf("RETRY status returned from application for entry %s of bulk pub/sub event %s", entry.EntryID, envelope.Id)
+		case status == runtimev1alphapb.TopicEventBulkResponseEntry_DROP:
+			log.Warnf("DROP status returned from application for entry %s of bulk pub/sub event %s", entry.EntryID, envelope.Id)
+			errs[i] = runtime_pubsub.ErrMessageDropped
+		default:
+			errs[i] = errors.Errorf("unknown status returned from application for entry %s of bulk pub/sub event %s: %v", entry.EntryID, envelope.Id, status)
+		}
+	}
+	return errs
+}
+
+// bulkErrors returns the same error for every entry of a batch.
+func bulkErrors(entries []*runtime_pubsub.BulkMessageEntry, err error) []error {
+	errs := make([]error, len(entries))
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
+
 func extractCloudEventProperty(cloudEvent map[string]interface{}, property string) string {
 	if cloudEvent == nil {
 		return ""
@@ -1657,7 +2119,19 @@ func (a *AppRuntime) initActors() error {
 		return errors.New("no Bhojpur Application runtime actor state store defined")
 	}
 	actorConfig := actors.NewConfig(a.hostAddress, a.runtimeConfig.ID, a.runtimeConfig.PlacementAddresses, a.runtimeConfig.InternalGRPCPort, a.namespace, a.appConfig)
-	act := actors.NewActors(a.stateStores[a.actorStateStoreName], a.appChannel, a.grpc.GetGRPCConnection, actorConfig, a.runtimeConfig.CertChain, a.globalConfig.Spec.TracingSpec, a.globalConfig.Spec.Features)
+	actorStateStore, _ := a.compStore.GetStateStore(a.actorStateStoreName)
+	for _, comp := range a.getComponents() {
+		if comp.Name == a.actorStateStoreName && a.extractComponentCategory(comp) == stateComponent {
+			props := a.convertMetadataItemsToProperties(comp.Spec.Metadata)
+			actorConfig.NativeStateTTL = state_loader.SupportsTTL(comp.Spec.Type, actorStateStore, props)
+		}
+	}
+	act := actors.NewActors(actorStateStore, a.appChannel, a.grpc.GetGRPCConnection, actorConfig, a.runtimeConfig.CertChain, a.globalConfig.Spec.TracingSpec, a.globalConfig.Spec.Features, a.resiliency)
+	workflowEngine := workflows.NewEngine(a.runtimeConfig.ID, a.directMessaging, a.sendToOutputBinding)
+	err = act.RegisterInternalActor(context.Background(), workflows.ActorType(a.runtimeConfig.ID), workflowEngine)
+	if err != nil {
+		return err
+	}
 	err = act.Init()
 	a.actor = act
 	return err
@@ -1759,7 +2233,9 @@ func (a *AppRuntime) processComponents() {
 			continue
 		}
 
+		a.componentsProcessingLock.Lock()
 		err := a.processComponentAndDependents(comp)
+		a.componentsProcessingLock.Unlock()
 		if err != nil {
 			e := fmt.Sprintf("process Bhojpur Application runtime component %s error: %s", comp.Name, err.Error())
 			if !comp.Spec.IgnoreErrors {
@@ -1843,6 +2319,8 @@ func (a *AppRuntime) doProcessOneComponent(category ComponentCategory, comp comp
 		return a.initState(comp)
 	case configurationComponent:
 		return a.initConfiguration(comp)
+	case lockComponent:
+		return a.initLock(comp)
 	}
 	return nil
 }
@@ -1871,7 +2349,7 @@ func (a *AppRuntime) shutdownComponents() error {
 	var merr error
 
 	// Close components if they implement `io.Closer`
-	for name, binding := range a.inputBindings {
+	for name, binding := range a.compStore.ListInputBindings() {
 		if closer, ok := binding.(io.Closer); ok {
 			if err := closer.Close(); err != nil {
 				err = fmt.Errorf("error closing Bhojpur Application runtime input binding %s: %w", name, err)
@@ -1880,7 +2358,7 @@ func (a *AppRuntime) shutdownComponents() error {
 			}
 		}
 	}
-	for name, binding := range a.outputBindings {
+	for name, binding := range a.compStore.ListOutputBindings() {
 		if closer, ok := binding.(io.Closer); ok {
 			if err := closer.Close(); err != nil {
 				err = fmt.Errorf("error closing Bhojpur Application runtime output binding %s: %w", name, err)
@@ -1889,7 +2367,7 @@ func (a *AppRuntime) shutdownComponents() error {
 			}
 		}
 	}
-	for name, secretstore := range a.secretStores {
+	for name, secretstore := range a.compStore.ListSecretStores() {
 		if closer, ok := secretstore.(io.Closer); ok {
 			if err := closer.Close(); err != nil {
 				err = fmt.Errorf("error closing Bhojpur Application runtime secret store %s: %w", name, err)
@@ -1898,14 +2376,15 @@ func (a *AppRuntime) shutdownComponents() error {
 			}
 		}
 	}
-	for name, pubSub := range a.pubSubs {
+	for name, pubSub := range a.compStore.ListPubSubs() {
+		a.closeBulkSubscribers(name)
 		if err := pubSub.Close(); err != nil {
 			err = fmt.Errorf("error closing Bhojpur Application runtime pubsub %s: %w", name, err)
 			merr = multierror.Append(merr, err)
 			log.Warn(err)
 		}
 	}
-	for name, stateStore := range a.stateStores {
+	for name, stateStore := range a.compStore.ListStateStores() {
 		if closer, ok := stateStore.(io.Closer); ok {
 			if err := closer.Close(); err != nil {
 				err = fmt.Errorf("error closing Bhojpur Application runtime state store %s: %w", name, err)
@@ -1943,6 +2422,7 @@ func (a *AppRuntime) Shutdown(duration time.Duration) {
 	// Ensure the Unix socket file is removed if a panic occurs.
 	defer a.cleanSocket()
 
+	a.cancel()
 	a.stopActor()
 	log.Infof("Bhojpur Application runtime engine shutting down.")
 	log.Info("Stopping the Bhojpur Application runtime APIs")
@@ -1954,6 +2434,17 @@ func (a *AppRuntime) Shutdown(duration time.Duration) {
 	log.Infof("Waiting %s to finish outstanding operations", duration)
 	<-time.After(duration)
 	a.shutdownComponents()
+	// The recorder is closed last, as the APIs and the subscriptions record until they stop.
+	if a.recorder != nil {
+		if err := a.recorder.Close(); err != nil {
+			log.Warnf("error closing Bhojpur Application runtime request recorder: %v", err)
+		}
+	}
+	for _, closer := range a.traceExporterClosers {
+		if err := closer.Close(); err != nil {
+			log.Warnf("error closing Bhojpur Application runtime trace exporter: %v", err)
+		}
+	}
 	a.shutdownC <- nil
 }
 
@@ -2053,7 +2544,8 @@ func (a *AppRuntime) getSecretStore(storeName string) secretstores.SecretStore {
 	if storeName == "" {
 		return nil
 	}
-	return a.secretStores[storeName]
+	store, _ := a.compStore.GetSecretStore(storeName)
+	return store
 }
 
 func (a *AppRuntime) blockUntilAppIsReady() {
@@ -2110,6 +2602,9 @@ func (a *AppRuntime) createAppChannel() error {
 			log.Infof("application max concurrency set to %v", a.runtimeConfig.MaxConcurrency)
 		}
 		a.appChannel = ch
+		if a.recorder != nil {
+			a.appChannel = a.recorder.AppChannel(ch)
+		}
 	} else {
 		log.Warn("application channel is not initialized. did you make sure to configure an app-port?")
 	}
@@ -2157,7 +2652,7 @@ func (a *AppRuntime) initSecretStore(c components_v1alpha1.Component) error {
 		return err
 	}
 
-	a.secretStores[c.ObjectMeta.Name] = secretStore
+	a.compStore.AddSecretStore(c.ObjectMeta.Name, secretStore)
 	diag.DefaultMonitoring.ComponentInitialized(c.Spec.Type)
 	return nil
 }
@@ -2223,7 +2718,7 @@ func componentDependency(compCategory ComponentCategory, name string) string {
 }
 
 func (a *AppRuntime) startSubscribing() {
-	for name, pubsub := range a.pubSubs {
+	for name, pubsub := range a.compStore.ListPubSubs() {
 		if err := a.beginPubSub(name, pubsub); err != nil {
 			log.Errorf("error occurred while beginning pubsub %s: %s", name, err)
 		}
@@ -2234,18 +2729,20 @@ func (a *AppRuntime) startReadingFromBindings() error {
 	if a.appChannel == nil {
 		return errors.New("application channel not initialized")
 	}
-	for name, binding := range a.inputBindings {
-		go func(name string, binding bindings.InputBinding) {
-			if !a.isAppSubscribedToBinding(name) {
-				log.Infof("application has not subscribed to binding %s.", name)
-				return
-			}
-
-			err := a.readFromBinding(name, binding)
-			if err != nil {
-				log.Errorf("error reading from input binding %s: %s", name, err)
-			}
-		}(name, binding)
+	for name, binding := range a.compStore.ListInputBindings() {
+		go a.startReadingFromBinding(name, binding)
 	}
 	return nil
 }
+
+func (a *AppRuntime) startReadingFromBinding(name string, binding bindings.InputBinding) {
+	if !a.isAppSubscribedToBinding(name) {
+		log.Infof("application has not subscribed to binding %s.", name)
+		return
+	}
+
+	err := a.readFromBinding(name, binding)
+	if err != nil {
+		log.Errorf("error reading from input binding %s: %s", name, err)
+	}
+}
@@ -66,18 +66,23 @@ import (
 	runtimev1pb "github.com/bhojpur/api/pkg/core/v1/runtime"
 	channelt "github.com/bhojpur/application/pkg/channel/testing"
 	bindings_loader "github.com/bhojpur/application/pkg/components/bindings"
+	lock_loader "github.com/bhojpur/application/pkg/components/lock"
 	nr_loader "github.com/bhojpur/application/pkg/components/nameresolution"
 	pubsub_loader "github.com/bhojpur/application/pkg/components/pubsub"
 	secretstores_loader "github.com/bhojpur/application/pkg/components/secretstores"
 	state_loader "github.com/bhojpur/application/pkg/components/state"
 	"github.com/bhojpur/application/pkg/config"
 	"github.com/bhojpur/application/pkg/cors"
+	diag "github.com/bhojpur/application/pkg/diagnostics"
 	diag_utils "github.com/bhojpur/application/pkg/diagnostics/utils"
 	"github.com/bhojpur/application/pkg/encryption"
 	"github.com/bhojpur/application/pkg/expr"
 	components_v1alpha1 "github.com/bhojpur/application/pkg/kubernetes/components/v1alpha1"
 	subscriptionsapi "github.com/bhojpur/application/pkg/kubernetes/subscriptions/v1alpha1"
+	"github.com/bhojpur/application/pkg/lock"
+	lock_inmemory "github.com/bhojpur/application/pkg/lock/inmemory"
 	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/runtime/compstore"
 	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
 	"github.com/bhojpur/application/pkg/runtime/security"
 	"github.com/bhojpur/application/pkg/scopes"
@@ -625,7 +630,7 @@ func TestInitState(t *testing.T) {
 			Enabled: true,
 		})
 
-		rt.secretStores["mockSecretStore"] = &mockSecretStore{}
+		rt.compStore.AddSecretStore("mockSecretStore", &mockSecretStore{})
 
 		err := rt.initState(mockStateComponent)
 		ok := encryption.EncryptedStateStore("testpubsub")
@@ -636,6 +641,67 @@ func TestInitState(t *testing.T) {
 	})
 }
 
+func TestInitLock(t *testing.T) {
+	rt := NewTestAppRuntime(utils.StandaloneMode)
+	defer stopRuntime(t, rt)
+
+	rt.lockStoreRegistry.Register(
+		lock_loader.New("in-memory", func() lock.Store {
+			return lock_inmemory.NewInMemoryLock()
+		}),
+	)
+
+	t.Run("test init lock store", func(t *testing.T) {
+		err := rt.initLock(components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "lockstore",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:    "lock.in-memory",
+				Version: "v1",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, rt.compStore.ListLockStores(), "lockstore")
+	})
+
+	t.Run("test init unknown lock store", func(t *testing.T) {
+		err := rt.initLock(components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "otherstore",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:    "lock.unknown",
+				Version: "v1",
+			},
+		})
+		assert.Error(t, err)
+		assert.NotContains(t, rt.compStore.ListLockStores(), "otherstore")
+	})
+
+	t.Run("test init lock store with illegal key prefix", func(t *testing.T) {
+		err := rt.initLock(components_v1alpha1.Component{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: "prefixstore",
+			},
+			Spec: components_v1alpha1.ComponentSpec{
+				Type:    "lock.in-memory",
+				Version: "v1",
+				Metadata: []components_v1alpha1.MetadataItem{
+					{
+						Name: "keyPrefix",
+						Value: components_v1alpha1.DynamicValue{
+							JSON: v1.JSON{Raw: []byte("a||b")},
+						},
+					},
+				},
+			},
+		})
+		assert.Error(t, err)
+		assert.NotContains(t, rt.compStore.ListLockStores(), "prefixstore")
+	})
+}
+
 func TestInitNameResolution(t *testing.T) {
 	initMockResolverForRuntime := func(rt *AppRuntime, resolverName string, e error) *appt.MockResolver {
 		mockResolver := new(appt.MockResolver)
@@ -775,6 +841,24 @@ func TestSetupTracing(t *testing.T) {
 			Stdout: true,
 		},
 		expectedExporters: []trace.Exporter{&diag_utils.StdoutExporter{}, &zipkin.Exporter{}},
+	}, {
+		name: "otel trace exporter",
+		tracingConfig: config.TracingSpec{
+			Otel: config.OtelSpec{
+				EndpointAddress: "localhost:4317",
+				Insecure:        true,
+			},
+		},
+		expectedExporters: []trace.Exporter{&diag.OtelExporter{}},
+	}, {
+		name: "otel trace exporter with unsupported protocol",
+		tracingConfig: config.TracingSpec{
+			Otel: config.OtelSpec{
+				EndpointAddress: "localhost:4317",
+				Protocol:        "thrift",
+			},
+		},
+		expectedErr: "unsupported otel protocol",
 	}}
 
 	for _, tc := range testcases {
@@ -1078,7 +1162,7 @@ func TestInitPubSub(t *testing.T) {
 		mockAppChannel := new(channelt.MockAppChannel)
 		rt.appChannel = mockAppChannel
 		rt.topicRoutes = nil
-		rt.pubSubs = make(map[string]pubsub.PubSub)
+		rt.compStore = compstore.New()
 
 		return mockPubSub, mockPubSub2
 	}
@@ -1189,7 +1273,8 @@ func TestInitPubSub(t *testing.T) {
 	t.Run("publish adapter not nil, with pub sub component", func(t *testing.T) {
 		rts := NewTestAppRuntime(utils.StandaloneMode)
 		defer stopRuntime(t, rts)
-		rts.pubSubs[TestPubsubName], _ = initMockPubSubForRuntime(rts)
+		ps, _ := initMockPubSubForRuntime(rts)
+		rts.compStore.AddPubSub(TestPubsubName, ps)
 		a := rts.getPublishAdapter()
 		assert.NotNil(t, a)
 	})
@@ -1424,7 +1509,7 @@ func TestInitPubSub(t *testing.T) {
 			assert.Nil(t, err)
 		}
 
-		rt.pubSubs[TestPubsubName] = &mockPublishPubSub{}
+		rt.compStore.AddPubSub(TestPubsubName, &mockPublishPubSub{})
 		md := make(map[string]string, 2)
 		md["key"] = "v3"
 		err := rt.Publish(&pubsub.PublishRequest{
@@ -1435,7 +1520,7 @@ func TestInitPubSub(t *testing.T) {
 
 		assert.Nil(t, err)
 
-		rt.pubSubs[TestSecondPubsubName] = &mockPublishPubSub{}
+		rt.compStore.AddPubSub(TestSecondPubsubName, &mockPublishPubSub{})
 		err = rt.Publish(&pubsub.PublishRequest{
 			PubsubName: TestSecondPubsubName,
 			Topic:      "topic1",
@@ -1466,14 +1551,14 @@ func TestInitPubSub(t *testing.T) {
 			assert.Nil(t, err)
 		}
 
-		rt.pubSubs[TestPubsubName] = &mockPublishPubSub{}
+		rt.compStore.AddPubSub(TestPubsubName, &mockPublishPubSub{})
 		err := rt.Publish(&pubsub.PublishRequest{
 			PubsubName: TestPubsubName,
 			Topic:      "topic5",
 		})
 		assert.NotNil(t, err)
 
-		rt.pubSubs[TestPubsubName] = &mockPublishPubSub{}
+		rt.compStore.AddPubSub(TestPubsubName, &mockPublishPubSub{})
 		err = rt.Publish(&pubsub.PublishRequest{
 			PubsubName: TestSecondPubsubName,
 			Topic:      "topic5",
@@ -1482,46 +1567,42 @@ func TestInitPubSub(t *testing.T) {
 	})
 
 	t.Run("test allowed topics, no scopes, operation allowed", func(t *testing.T) {
-		rt.allowedTopics = map[string][]string{TestPubsubName: {"topic1"}}
-		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.scopedPublishings[TestPubsubName])
+		rt.compStore.SetTopicScopes(TestPubsubName, compstore.TopicScopes{AllowedTopics: []string{"topic1"}})
+		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.True(t, a)
 	})
 
 	t.Run("test allowed topics, no scopes, operation not allowed", func(t *testing.T) {
-		rt.allowedTopics = map[string][]string{TestPubsubName: {"topic1"}}
-		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic2", rt.scopedPublishings[TestPubsubName])
+		rt.compStore.SetTopicScopes(TestPubsubName, compstore.TopicScopes{AllowedTopics: []string{"topic1"}})
+		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic2", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.False(t, a)
 	})
 
 	t.Run("test allowed topics, with scopes, operation allowed", func(t *testing.T) {
-		rt.allowedTopics = map[string][]string{TestPubsubName: {"topic1"}}
-		rt.scopedPublishings = map[string][]string{TestPubsubName: {"topic1"}}
-		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.scopedPublishings[TestPubsubName])
+		rt.compStore.SetTopicScopes(TestPubsubName, compstore.TopicScopes{AllowedTopics: []string{"topic1"}, ScopedPublishings: []string{"topic1"}})
+		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.True(t, a)
 	})
 
 	t.Run("topic in allowed topics, not in existing publishing scopes, operation not allowed", func(t *testing.T) {
-		rt.allowedTopics = map[string][]string{TestPubsubName: {"topic1"}}
-		rt.scopedPublishings = map[string][]string{TestPubsubName: {"topic2"}}
-		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.scopedPublishings[TestPubsubName])
+		rt.compStore.SetTopicScopes(TestPubsubName, compstore.TopicScopes{AllowedTopics: []string{"topic1"}, ScopedPublishings: []string{"topic2"}})
+		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.False(t, a)
 	})
 
 	t.Run("topic in allowed topics, not in publishing scopes, operation allowed", func(t *testing.T) {
-		rt.allowedTopics = map[string][]string{TestPubsubName: {"topic1"}}
-		rt.scopedPublishings = map[string][]string{}
-		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.scopedPublishings[TestPubsubName])
+		rt.compStore.SetTopicScopes(TestPubsubName, compstore.TopicScopes{AllowedTopics: []string{"topic1"}})
+		a := rt.isPubSubOperationAllowed(TestPubsubName, "topic1", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.True(t, a)
 	})
 
 	t.Run("topics A and B in allowed topics, A in publishing scopes, operation allowed for A only", func(t *testing.T) {
-		rt.allowedTopics = map[string][]string{TestPubsubName: {"A", "B"}}
-		rt.scopedPublishings = map[string][]string{TestPubsubName: {"A"}}
+		rt.compStore.SetTopicScopes(TestPubsubName, compstore.TopicScopes{AllowedTopics: []string{"A", "B"}, ScopedPublishings: []string{"A"}})
 
-		a := rt.isPubSubOperationAllowed(TestPubsubName, "A", rt.scopedPublishings[TestPubsubName])
+		a := rt.isPubSubOperationAllowed(TestPubsubName, "A", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.True(t, a)
 
-		b := rt.isPubSubOperationAllowed(TestPubsubName, "B", rt.scopedPublishings[TestPubsubName])
+		b := rt.isPubSubOperationAllowed(TestPubsubName, "B", rt.compStore.GetTopicScopes(TestPubsubName).ScopedPublishings)
 		assert.False(t, b)
 	})
 }
@@ -1567,7 +1648,8 @@ func TestInitSecretStores(t *testing.T) {
 			},
 		})
 		assert.NoError(t, err)
-		assert.NotNil(t, rt.secretStores["kubernetesMock"])
+		store, _ := rt.compStore.GetSecretStore("kubernetesMock")
+		assert.NotNil(t, store)
 	})
 
 	t.Run("get secret store", func(t *testing.T) {
@@ -1693,10 +1775,11 @@ func TestPopulateSecretsConfiguration(t *testing.T) {
 		rt.populateSecretsConfiguration()
 
 		// verify
-		assert.Contains(t, rt.secretsConfiguration, "testMock", "Expected testMock secret store configuration to be populated")
-		assert.Equal(t, config.AllowAccess, rt.secretsConfiguration["testMock"].DefaultAccess, "Expected default access as allow")
-		assert.Empty(t, rt.secretsConfiguration["testMock"].DeniedSecrets, "Expected testMock deniedSecrets to not be populated")
-		assert.NotContains(t, rt.secretsConfiguration["testMock"].AllowedSecrets, "Expected testMock allowedSecrets to not be populated")
+		scope, ok := rt.compStore.GetSecretsConfiguration("testMock")
+		assert.True(t, ok, "Expected testMock secret store configuration to be populated")
+		assert.Equal(t, config.AllowAccess, scope.DefaultAccess, "Expected default access as allow")
+		assert.Empty(t, scope.DeniedSecrets, "Expected testMock deniedSecrets to not be populated")
+		assert.NotContains(t, scope.AllowedSecrets, "Expected testMock allowedSecrets to not be populated")
 	})
 }
 
@@ -2086,7 +2169,7 @@ func TestErrorPublishedNonCloudEventHTTP(t *testing.T) {
 		err := rt.publishMessageHTTP(context.Background(), testPubSubMessage)
 
 		// assert
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, runtime_pubsub.ErrMessageDropped)
 	})
 
 	t.Run("ok with unknown", func(t *testing.T) {
@@ -2121,7 +2204,7 @@ func TestErrorPublishedNonCloudEventHTTP(t *testing.T) {
 		err := rt.publishMessageHTTP(context.Background(), testPubSubMessage)
 
 		// assert
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, runtime_pubsub.ErrMessageDropped)
 	})
 }
 
@@ -2147,10 +2230,11 @@ func TestErrorPublishedNonCloudEventGRPC(t *testing.T) {
 	rt.topicRoutes[TestPubsubName].routes["topic1"] = Route{rules: []*runtime_pubsub.Rule{{Path: "topic1"}}}
 
 	testcases := []struct {
-		Name        string
-		Status      runtimev1pb.TopicEventResponse_TopicEventResponseStatus
-		Error       error
-		ExpectError bool
+		Name          string
+		Status        runtimev1pb.TopicEventResponse_TopicEventResponseStatus
+		Error         error
+		ExpectError   bool
+		ExpectedError error
 	}{
 		{
 			Name:   "ok without success",
@@ -2162,8 +2246,10 @@ func TestErrorPublishedNonCloudEventGRPC(t *testing.T) {
 			ExpectError: true,
 		},
 		{
-			Name:   "ok with drop",
-			Status: runtimev1pb.TopicEventResponse_DROP,
+			Name:          "ok with drop",
+			Status:        runtimev1pb.TopicEventResponse_DROP,
+			ExpectError:   true,
+			ExpectedError: runtime_pubsub.ErrMessageDropped,
 		},
 		{
 			Name:        "ok with unknown",
@@ -2198,7 +2284,9 @@ func TestErrorPublishedNonCloudEventGRPC(t *testing.T) {
 			rt.grpc.AppClient = &mockClientConn
 
 			err := rt.publishMessageGRPC(context.Background(), testPubSubMessage)
-			if tc.ExpectError {
+			if tc.ExpectedError != nil {
+				assert.ErrorIs(t, err, tc.ExpectedError)
+			} else if tc.ExpectError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
@@ -2360,7 +2448,7 @@ func TestOnNewPublishedMessage(t *testing.T) {
 		err := rt.publishMessageHTTP(context.Background(), testPubSubMessage)
 
 		// assert
-		assert.Nil(t, err)
+		assert.ErrorIs(t, err, runtime_pubsub.ErrMessageDropped)
 		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
 	})
 
@@ -2448,7 +2536,7 @@ func TestOnNewPublishedMessage(t *testing.T) {
 		err := rt.publishMessageHTTP(context.Background(), testPubSubMessage)
 
 		// assert
-		assert.Nil(t, err, "expected error to be nil")
+		assert.ErrorIs(t, err, runtime_pubsub.ErrMessageDropped)
 		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
 	})
 
@@ -2511,13 +2599,14 @@ func TestOnNewPublishedMessageGRPC(t *testing.T) {
 		errorExpected    bool
 		noResponseStatus bool
 		responseError    error
+		expectedError    error
 	}{
 		{
 			name:             "failed to publish message to user app with unimplemented error",
 			message:          testPubSubMessage,
 			noResponseStatus: true,
 			responseError:    status.Errorf(codes.Unimplemented, "unimplemented method"),
-			errorExpected:    false, // should be dropped with no error
+			expectedError:    runtime_pubsub.ErrMessageDropped, // should be dropped without redelivery
 		},
 		{
 			name:             "failed to publish message to user app with response error",
@@ -2551,6 +2640,7 @@ func TestOnNewPublishedMessageGRPC(t *testing.T) {
 			name:           "succeeded to publish message to user app with drop",
 			message:        testPubSubMessage,
 			responseStatus: runtimev1pb.TopicEventResponse_DROP,
+			expectedError:  runtime_pubsub.ErrMessageDropped,
 		},
 		{
 			name:           "succeeded to publish message to user app with invalid response",
@@ -2599,7 +2689,9 @@ func TestOnNewPublishedMessageGRPC(t *testing.T) {
 			err = rt.publishMessageGRPC(context.Background(), tc.message)
 
 			// assert
-			if tc.errorExpected {
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else if tc.errorExpected {
 				assert.Error(t, err, "expected an error")
 			} else {
 				assert.Nil(t, err, "expected no error")
@@ -2608,6 +2700,158 @@ func TestOnNewPublishedMessageGRPC(t *testing.T) {
 	}
 }
 
+type deadLetterTestPubSub struct {
+	mockPublishPubSub
+	handler   pubsub.Handler
+	published []*pubsub.PublishRequest
+}
+
+func (p *deadLetterTestPubSub) Subscribe(req pubsub.SubscribeRequest, handler pubsub.Handler) error {
+	p.handler = handler
+	return nil
+}
+
+func (p *deadLetterTestPubSub) Publish(req *pubsub.PublishRequest) error {
+	p.published = append(p.published, req)
+	return nil
+}
+
+func TestDeadLetterTopic(t *testing.T) {
+	defaultRetry := deadLetterRetry
+	deadLetterRetry.Duration = time.Millisecond
+	defer func() { deadLetterRetry = defaultRetry }()
+
+	subscribe := func(t *testing.T, deadLetterTopic string, status int, body string) (*AppRuntime, *deadLetterTestPubSub, *channelt.MockAppChannel) {
+		rt := NewTestAppRuntime(utils.StandaloneMode)
+		ps := &deadLetterTestPubSub{}
+		rt.compStore.AddPubSub(TestPubsubName, ps)
+		rt.topicRoutes = map[string]TopicRoute{
+			TestPubsubName: {routes: map[string]Route{
+				"topic1": {rules: []*runtime_pubsub.Rule{{Path: "topic1"}}, deadLetterTopic: deadLetterTopic},
+			}},
+		}
+
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+		fakeResp := invokev1.NewInvokeMethodResponse(int32(status), "", nil)
+		fakeResp.WithRawData([]byte(body), "application/json")
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(fakeResp, nil)
+
+		require.NoError(t, rt.beginPubSub(TestPubsubName, ps))
+		require.NotNil(t, ps.handler)
+		return rt, ps, mockAppChannel
+	}
+	msg := func() *pubsub.NewMessage {
+		return &pubsub.NewMessage{
+			Topic: "topic1",
+			Data:  []byte(`{"id":"1","specversion":"1.0","type":"test","source":"test","data":"hello"}`),
+		}
+	}
+
+	t.Run("failed message is retried before it is dead-lettered", func(t *testing.T) {
+		rt, ps, mockAppChannel := subscribe(t, "poison", 500, "error")
+		defer stopRuntime(t, rt)
+
+		assert.NoError(t, ps.handler(context.Background(), msg()))
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", int(deadLetterRetry.MaxRetries)+1)
+		require.Len(t, ps.published, 1)
+		assert.Equal(t, "poison", ps.published[0].Topic)
+	})
+
+	t.Run("dropped message is dead-lettered without retries", func(t *testing.T) {
+		rt, ps, mockAppChannel := subscribe(t, "poison", 200, `{"status": "DROP"}`)
+		defer stopRuntime(t, rt)
+
+		assert.NoError(t, ps.handler(context.Background(), msg()))
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+		require.Len(t, ps.published, 1)
+		assert.Equal(t, "poison", ps.published[0].Topic)
+	})
+
+	t.Run("non-retriable message is dead-lettered without retries", func(t *testing.T) {
+		rt, ps, mockAppChannel := subscribe(t, "poison", 404, "not found")
+		defer stopRuntime(t, rt)
+
+		assert.NoError(t, ps.handler(context.Background(), msg()))
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+		require.Len(t, ps.published, 1)
+	})
+
+	t.Run("dropped message is acknowledged without a dead-letter topic", func(t *testing.T) {
+		rt, ps, mockAppChannel := subscribe(t, "", 200, `{"status": "DROP"}`)
+		defer stopRuntime(t, rt)
+
+		assert.NoError(t, ps.handler(context.Background(), msg()))
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+		assert.Empty(t, ps.published)
+	})
+}
+
+func TestPublishBulkMessageHTTP(t *testing.T) {
+	entries := []*runtime_pubsub.BulkMessageEntry{
+		{EntryID: "1", CloudEvent: map[string]interface{}{"id": "1"}},
+		{EntryID: "2", CloudEvent: map[string]interface{}{"id": "2"}},
+		{EntryID: "3", CloudEvent: map[string]interface{}{"id": "3"}},
+	}
+
+	invoke := func(t *testing.T, status int, body string) []error {
+		rt := NewTestAppRuntime(utils.StandaloneMode)
+		defer stopRuntime(t, rt)
+
+		mockAppChannel := new(channelt.MockAppChannel)
+		rt.appChannel = mockAppChannel
+
+		fakeResp := invokev1.NewInvokeMethodResponse(int32(status), "", nil)
+		fakeResp.WithRawData([]byte(body), "application/json")
+		mockAppChannel.On("InvokeMethod", mock.Anything, mock.Anything).Return(fakeResp, nil)
+
+		errs := rt.publishBulkMessageHTTP(context.Background(), TestPubsubName, "topic", "orders", entries)
+		mockAppChannel.AssertNumberOfCalls(t, "InvokeMethod", 1)
+		return errs
+	}
+
+	t.Run("statuses are mapped per entry", func(t *testing.T) {
+		errs := invoke(t, 200, `{"statuses": [{"entryId": "1", "status": "SUCCESS"}, {"entryId": "2", "status": "RETRY"}, {"entryId": "3", "status": "DROP"}]}`)
+		require.Len(t, errs, 3)
+		assert.NoError(t, errs[0])
+		assert.Error(t, errs[1])
+		assert.ErrorIs(t, errs[2], runtime_pubsub.ErrMessageDropped)
+	})
+
+	t.Run("entries without status are retried", func(t *testing.T) {
+		errs := invoke(t, 200, `{"statuses": [{"entryId": "1", "status": "SUCCESS"}]}`)
+		require.Len(t, errs, 3)
+		assert.NoError(t, errs[0])
+		assert.Error(t, errs[1])
+		assert.Error(t, errs[2])
+	})
+
+	t.Run("unparsable response acknowledges all entries", func(t *testing.T) {
+		errs := invoke(t, 200, "OK")
+		require.Len(t, errs, 3)
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("404 drops all entries", func(t *testing.T) {
+		errs := invoke(t, 404, "Not found")
+		require.Len(t, errs, 3)
+		for _, err := range errs {
+			assert.ErrorIs(t, err, runtime_pubsub.ErrMessageDropped)
+		}
+	})
+
+	t.Run("other status codes retry all entries", func(t *testing.T) {
+		errs := invoke(t, 500, "Internal error")
+		require.Len(t, errs, 3)
+		for _, err := range errs {
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, runtime_pubsub.ErrMessageDropped)
+		}
+	})
+}
+
 func TestGetSubscribedBindingsGRPC(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -2858,7 +3102,7 @@ func TestInvokeOutputBindings(t *testing.T) {
 	t.Run("output binding valid operation", func(t *testing.T) {
 		rt := NewTestAppRuntime(utils.StandaloneMode)
 		defer stopRuntime(t, rt)
-		rt.outputBindings["mockBinding"] = &mockBinding{}
+		rt.compStore.AddOutputBinding("mockBinding", &mockBinding{})
 
 		_, err := rt.sendToOutputBinding("mockBinding", &bindings.InvokeRequest{
 			Data:      []byte(""),
@@ -2870,7 +3114,7 @@ func TestInvokeOutputBindings(t *testing.T) {
 	t.Run("output binding invalid operation", func(t *testing.T) {
 		rt := NewTestAppRuntime(utils.StandaloneMode)
 		defer stopRuntime(t, rt)
-		rt.outputBindings["mockBinding"] = &mockBinding{}
+		rt.compStore.AddOutputBinding("mockBinding", &mockBinding{})
 
 		_, err := rt.sendToOutputBinding("mockBinding", &bindings.InvokeRequest{
 			Data:      []byte(""),
@@ -2911,7 +3155,7 @@ func TestReadInputBindings(t *testing.T) {
 
 		rt.appChannel = mockAppChannel
 
-		rt.inputBindingRoutes[testInputBindingName] = testInputBindingName
+		rt.compStore.AddInputBindingRoute(testInputBindingName, testInputBindingName)
 
 		b := mockBinding{}
 		rt.readFromBinding(testInputBindingName, &b)
@@ -2944,7 +3188,7 @@ func TestReadInputBindings(t *testing.T) {
 		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.valueCtx"), fakeReq).Return(fakeResp, nil)
 
 		rt.appChannel = mockAppChannel
-		rt.inputBindingRoutes[testInputBindingName] = testInputBindingName
+		rt.compStore.AddInputBindingRoute(testInputBindingName, testInputBindingName)
 
 		b := mockBinding{}
 		rt.readFromBinding(testInputBindingName, &b)
@@ -2977,7 +3221,7 @@ func TestReadInputBindings(t *testing.T) {
 		mockAppChannel.On("InvokeMethod", mock.AnythingOfType("*context.valueCtx"), fakeReq).Return(fakeResp, nil)
 
 		rt.appChannel = mockAppChannel
-		rt.inputBindingRoutes[testInputBindingName] = testInputBindingName
+		rt.compStore.AddInputBindingRoute(testInputBindingName, testInputBindingName)
 
 		b := mockBinding{metadata: map[string]string{"bindings": "input"}}
 		rt.readFromBinding(testInputBindingName, &b)
@@ -3581,3 +3825,24 @@ func TestComponentsCallback(t *testing.T) {
 
 	assert.True(t, callbackInvoked, "component callback was not invoked")
 }
+
+func TestComponentTypes(t *testing.T) {
+	var o runtimeOpts
+	for _, opt := range []Option{
+		WithStates(state_loader.New("redis", nil), state_loader.New("mongodb/v2", nil)),
+		WithPubSubs(pubsub_loader.New("kafka", nil)),
+		WithInputBindings(bindings_loader.NewInput("cron", nil)),
+		WithOutputBindings(bindings_loader.NewOutput("http", nil)),
+		WithNameResolutions(nr_loader.New("mdns", nil)),
+	} {
+		opt(&o)
+	}
+
+	types := o.componentTypes()
+	assert.Equal(t, []string{"redis", "mongodb/v2"}, types.States)
+	assert.Equal(t, []string{"kafka"}, types.PubSubs)
+	assert.Equal(t, []string{"cron"}, types.InputBindings)
+	assert.Equal(t, []string{"http"}, types.OutputBindings)
+	assert.Equal(t, []string{"mdns"}, types.NameResolutions)
+	assert.Empty(t, types.SecretStores)
+}
@@ -240,11 +240,33 @@ func (c *defaultCA) validateAndBuildTrustBundle() (*trustRootBundle, error) {
 }
 
 func (c *defaultCA) generateRootAndIssuerCerts() (*certs.Credentials, []byte, []byte, error) {
+	rootCertPem, issuerCertPem, issuerKeyPem, err := GenerateRootAndIssuerCerts(c.config.AllowedClockSkew)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	issuerCreds, err := certs.PEMCredentialsFromFiles(issuerCertPem, issuerKeyPem)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// store credentials so that next time sentry restarts it'll load normally
+	err = certs.StoreCredentials(c.config, rootCertPem, issuerCertPem, issuerKeyPem)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return issuerCreds, rootCertPem, issuerCertPem, nil
+}
+
+// GenerateRootAndIssuerCerts creates a self signed root certificate and an issuer certificate
+// signed by it, returning the PEM encoded root cert, issuer cert and issuer key.
+func GenerateRootAndIssuerCerts(allowedClockSkew time.Duration) ([]byte, []byte, []byte, error) {
 	rootKey, err := certs.GenerateECPrivateKey()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	rootCsr, err := csr.GenerateRootCertCSR(caOrg, caCommonName, &rootKey.PublicKey, selfSignedRootCertLifetime, c.config.AllowedClockSkew)
+	rootCsr, err := csr.GenerateRootCertCSR(caOrg, caCommonName, &rootKey.PublicKey, selfSignedRootCertLifetime, allowedClockSkew)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -266,7 +288,7 @@ func (c *defaultCA) generateRootAndIssuerCerts() (*certs.Credentials, []byte, []
 		return nil, nil, nil, err
 	}
 
-	issuerCsr, err := csr.GenerateIssuerCertCSR(caCommonName, &issuerKey.PublicKey, selfSignedRootCertLifetime, c.config.AllowedClockSkew)
+	issuerCsr, err := csr.GenerateIssuerCertCSR(caCommonName, &issuerKey.PublicKey, selfSignedRootCertLifetime, allowedClockSkew)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -284,22 +306,5 @@ func (c *defaultCA) generateRootAndIssuerCerts() (*certs.Credentials, []byte, []
 	}
 	issuerKeyPem := pem.EncodeToMemory(&pem.Block{Type: certs.ECPrivateKey, Bytes: encodedKey})
 
-	issuerCert, err := x509.ParseCertificate(issuerCertBytes)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
-	// store credentials so that next time sentry restarts it'll load normally
-	err = certs.StoreCredentials(c.config, rootCertPem, issuerCertPem, issuerKeyPem)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
-	return &certs.Credentials{
-		PrivateKey: &certs.PrivateKey{
-			Type: certs.ECPrivateKey,
-			Key:  issuerKey,
-		},
-		Certificate: issuerCert,
-	}, rootCertPem, issuerCertPem, nil
+	return rootCertPem, issuerCertPem, issuerKeyPem, nil
 }
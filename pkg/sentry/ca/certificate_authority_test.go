@@ -243,6 +243,21 @@ func TestCACertsGeneration(t *testing.T) {
 	assert.True(t, len(ca.GetCACertBundle().GetIssuerCertPem()) > 0)
 }
 
+func TestGenerateRootAndIssuerCerts(t *testing.T) {
+	rootCertPem, issuerCertPem, issuerKeyPem, err := GenerateRootAndIssuerCerts(time.Minute)
+	assert.NoError(t, err)
+
+	roots, err := certs.CertPoolFromPEM(rootCertPem)
+	assert.NoError(t, err)
+
+	creds, err := certs.PEMCredentialsFromFiles(issuerCertPem, issuerKeyPem)
+	assert.NoError(t, err)
+	assert.True(t, creds.Certificate.IsCA)
+
+	_, err = creds.Certificate.Verify(x509.VerifyOptions{Roots: roots})
+	assert.NoError(t, err)
+}
+
 func TestShouldCreateCerts(t *testing.T) {
 	t.Run("certs exist, should not create", func(t *testing.T) {
 		writeTestCredentialsToDisk()
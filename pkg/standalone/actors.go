@@ -0,0 +1,185 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	apisvr "github.com/bhojpur/api/pkg/core"
+)
+
+// ActiveActors is the number of active actors of a type hosted by a sidecar.
+type ActiveActors struct {
+	Type  string `csv:"TYPE"  json:"type"  yaml:"type"`
+	Count int    `csv:"COUNT" json:"count" yaml:"count"`
+}
+
+// ActorReminder is a reminder registered for an actor.
+type ActorReminder struct {
+	ActorType      string      `csv:"ACTOR TYPE"      json:"actorType"                yaml:"actorType"`
+	ActorID        string      `csv:"ACTOR ID"        json:"actorID"                  yaml:"actorID"`
+	Name           string      `csv:"NAME"            json:"name"                     yaml:"name"`
+	DueTime        string      `csv:"DUE TIME"        json:"dueTime"                  yaml:"dueTime"`
+	Period         string      `csv:"PERIOD"          json:"period"                   yaml:"period"`
+	ExpirationTime string      `csv:"EXPIRATION TIME" json:"expirationTime,omitempty" yaml:"expirationTime,omitempty"`
+	Data           interface{} `csv:"-"               json:"data,omitempty"           yaml:"data,omitempty"`
+}
+
+// ActorsClient calls the actor APIs of a sidecar.
+type ActorsClient struct {
+	sidecar *sidecarClient
+}
+
+// NewActorsClient returns a client for the actor APIs of a running app ID.
+func NewActorsClient(appID, socket string) (*ActorsClient, error) {
+	return newActorsClient(&appProcess{}, appID, socket)
+}
+
+// NewActorsClientForAddress returns a client for the actor APIs of the sidecar
+// listening on the given host:port address, such as a port-forwarded pod.
+func NewActorsClientForAddress(address string) *ActorsClient {
+	return &ActorsClient{sidecar: newSidecarClientForAddress(address)}
+}
+
+func newActorsClient(process AppProcess, appID, socket string) (*ActorsClient, error) {
+	sidecar, err := newSidecarClient(process, appID, socket)
+	if err != nil {
+		return nil, err
+	}
+	return &ActorsClient{sidecar: sidecar}, nil
+}
+
+func (c *ActorsClient) call(version, method, path string, body []byte) ([]byte, int, error) {
+	r, err := c.sidecar.do(version, method, path, nil, nil, body)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer r.Body.Close()
+
+	b, err := readSidecarResponse(r)
+	return b, r.StatusCode, err
+}
+
+func actorPath(actorType, actorID string, elems ...string) string {
+	path := fmt.Sprintf("actors/%s/%s", url.PathEscape(actorType), url.PathEscape(actorID))
+	for _, e := range elems {
+		path += "/" + url.PathEscape(e)
+	}
+	return path
+}
+
+// ListActors returns the number of active actors per type.
+func (c *ActorsClient) ListActors() ([]ActiveActors, error) {
+	b, _, err := c.call(apisvr.RuntimeAPIVersion, http.MethodGet, "metadata", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata apisvr.Metadata
+	err = json.Unmarshal(b, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metadata response: %w", err)
+	}
+
+	actors := make([]ActiveActors, 0, len(metadata.ActiveActorsCount))
+	for _, a := range metadata.ActiveActorsCount {
+		actors = append(actors, ActiveActors{Type: a.Type, Count: a.Count})
+	}
+	return actors, nil
+}
+
+// InvokeActor invokes a method of an actor and returns the response body.
+func (c *ActorsClient) InvokeActor(actorType, actorID, method, verb string, data []byte) ([]byte, error) {
+	b, _, err := c.call(apisvr.RuntimeAPIVersion, verb, actorPath(actorType, actorID, "method", method), data)
+	return b, err
+}
+
+// GetActorState reads a key of an actor state. Nil is returned when the key
+// does not exist.
+func (c *ActorsClient) GetActorState(actorType, actorID, key string) ([]byte, error) {
+	b, status, err := c.call(apisvr.RuntimeAPIVersion, http.MethodGet, actorPath(actorType, actorID, "state", key), nil)
+	if err != nil || status == http.StatusNoContent {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListActorReminders lists the reminders of an actor, or of all the actors of
+// the type when actorID is empty.
+func (c *ActorsClient) ListActorReminders(actorType, actorID string) ([]ActorReminder, error) {
+	path := fmt.Sprintf("actors/%s/reminders", url.PathEscape(actorType))
+	if actorID != "" {
+		path = actorPath(actorType, actorID, "reminders")
+	}
+
+	b, _, err := c.call(alphaAPIVersion, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := []ActorReminder{}
+	err = json.Unmarshal(b, &reminders)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing reminders response: %w", err)
+	}
+	return reminders, nil
+}
+
+// GetActorReminder reads a reminder of an actor. Nil is returned when the
+// reminder does not exist.
+func (c *ActorsClient) GetActorReminder(actorType, actorID, name string) (*ActorReminder, error) {
+	b, _, err := c.call(apisvr.RuntimeAPIVersion, http.MethodGet, actorPath(actorType, actorID, "reminders", name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reminder *ActorReminder
+	err = json.Unmarshal(b, &reminder)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing reminder response: %w", err)
+	}
+	if reminder != nil {
+		reminder.ActorType = actorType
+		reminder.ActorID = actorID
+		reminder.Name = name
+	}
+	return reminder, nil
+}
+
+// DeleteActorReminder deletes a reminder of an actor.
+func (c *ActorsClient) DeleteActorReminder(actorType, actorID, name string) error {
+	_, _, err := c.call(apisvr.RuntimeAPIVersion, http.MethodDelete, actorPath(actorType, actorID, "reminders", name), nil)
+	return err
+}
+
+// RenameActorReminder renames a reminder of an actor.
+func (c *ActorsClient) RenameActorReminder(actorType, actorID, oldName, newName string) error {
+	body, err := json.Marshal(map[string]string{"newName": newName})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.call(apisvr.RuntimeAPIVersion, http.MethodPatch, actorPath(actorType, actorID, "reminders", oldName), body)
+	return err
+}
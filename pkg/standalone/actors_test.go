@@ -0,0 +1,168 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newActorsTestClient(t *testing.T, handler http.HandlerFunc) *ActorsClient {
+	ts, port := getTestServerFunc(handler)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	client, err := newActorsClient(&mockAppProcess{
+		Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+	}, "testapp", "")
+	require.NoError(t, err)
+	return client
+}
+
+func TestListActors(t *testing.T) {
+	client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0/metadata", r.URL.Path)
+		w.Write([]byte(`{"id":"testapp","actors":[{"type":"Order","count":2}]}`))
+	})
+
+	actors, err := client.ListActors()
+	require.NoError(t, err)
+	assert.Equal(t, []ActiveActors{{Type: "Order", Count: 2}}, actors)
+}
+
+func TestInvokeActor(t *testing.T) {
+	client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1.0/actors/Order/1/method/ship", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"carrier":"ups"}`, string(b))
+		w.Write([]byte(`"shipped"`))
+	})
+
+	resp, err := client.InvokeActor("Order", "1", "ship", http.MethodPost, []byte(`{"carrier":"ups"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `"shipped"`, string(resp))
+}
+
+func TestGetActorState(t *testing.T) {
+	t.Run("existing key", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1.0/actors/Order/1/state/status", r.URL.Path)
+			w.Write([]byte(`"new"`))
+		})
+
+		value, err := client.GetActorState("Order", "1", "status")
+		require.NoError(t, err)
+		assert.Equal(t, `"new"`, string(value))
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		value, err := client.GetActorState("Order", "1", "status")
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestActorReminders(t *testing.T) {
+	t.Run("list reminders of an actor type", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1.0-alpha1/actors/Order/reminders", r.URL.Path)
+			w.Write([]byte(`[{"actorType":"Order","actorID":"1","name":"r1","period":"1m","dueTime":"1s","data":"a"}]`))
+		})
+
+		reminders, err := client.ListActorReminders("Order", "")
+		require.NoError(t, err)
+		assert.Equal(t, []ActorReminder{
+			{ActorType: "Order", ActorID: "1", Name: "r1", Period: "1m", DueTime: "1s", Data: "a"},
+		}, reminders)
+	})
+
+	t.Run("list reminders of an actor", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1.0-alpha1/actors/Order/1/reminders", r.URL.Path)
+			w.Write([]byte(`[]`))
+		})
+
+		reminders, err := client.ListActorReminders("Order", "1")
+		require.NoError(t, err)
+		assert.Empty(t, reminders)
+	})
+
+	t.Run("get reminder", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1.0/actors/Order/1/reminders/r1", r.URL.Path)
+			w.Write([]byte(`{"period":"1m","dueTime":"1s","data":"a"}`))
+		})
+
+		reminder, err := client.GetActorReminder("Order", "1", "r1")
+		require.NoError(t, err)
+		assert.Equal(t, &ActorReminder{ActorType: "Order", ActorID: "1", Name: "r1", Period: "1m", DueTime: "1s", Data: "a"}, reminder)
+	})
+
+	t.Run("get missing reminder", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`null`))
+		})
+
+		reminder, err := client.GetActorReminder("Order", "1", "r1")
+		require.NoError(t, err)
+		assert.Nil(t, reminder)
+	})
+
+	t.Run("delete reminder", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/v1.0/actors/Order/1/reminders/r1", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		assert.NoError(t, client.DeleteActorReminder("Order", "1", "r1"))
+	})
+
+	t.Run("rename reminder", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/v1.0/actors/Order/1/reminders/r1", r.URL.Path)
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"newName":"r2"}`, string(b))
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		assert.NoError(t, client.RenameActorReminder("Order", "1", "r1", "r2"))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client := newActorsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errorCode":"ERR_ACTOR_REMINDER_DELETE","message":"boom"}`))
+		})
+
+		assert.EqualError(t, client.DeleteActorReminder("Order", "1", "r1"), "ERR_ACTOR_REMINDER_DELETE: boom")
+	})
+}
@@ -20,6 +20,10 @@ package standalone
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+import (
+	"github.com/bhojpur/application/pkg/recorder"
+)
+
 type AppProcess interface {
 	List() ([]ListOutput, error)
 }
@@ -33,6 +37,18 @@ type Client interface {
 	Invoke(appID, method string, data []byte, verb string, socket string) (string, error)
 	// Publish is used to publish event to a topic in a pubsub for an app ID.
 	Publish(publishAppID, pubsubName, topic string, payload []byte, socket string) error
+	// GetState reads a key from a state store of an app ID.
+	GetState(appID, storeName, key string, opts StateOptions, socket string) (*StateItem, error)
+	// SaveState writes a key to a state store of an app ID.
+	SaveState(appID, storeName, key string, value []byte, opts StateOptions, socket string) error
+	// DeleteState deletes a key from a state store of an app ID.
+	DeleteState(appID, storeName, key string, opts StateOptions, socket string) error
+	// BulkGetState reads several keys from a state store of an app ID.
+	BulkGetState(appID, storeName string, keys []string, opts StateOptions, socket string) ([]StateItem, error)
+	// QueryState runs a query against a state store of an app ID.
+	QueryState(appID, storeName string, query []byte, opts StateOptions, socket string) (*StateQueryResult, error)
+	// Replay sends a recording through the sidecar of an app ID and compares the responses.
+	Replay(entries []recorder.Entry, opts ReplayOptions) ([]ReplayResult, error)
 }
 
 type Standalone struct {
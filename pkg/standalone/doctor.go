@@ -0,0 +1,241 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/bhojpur/application/pkg/components"
+	modes "github.com/bhojpur/application/pkg/config/modes"
+)
+
+const (
+	// DoctorPass is the status of a check that found no problem.
+	DoctorPass = "pass"
+	// DoctorWarn is the status of a check that found a problem which does not
+	// prevent the runtime from starting.
+	DoctorWarn = "warn"
+	// DoctorFail is the status of a check that found a problem which prevents
+	// the runtime from starting.
+	DoctorFail = "fail"
+
+	placementDialTimeout = 2 * time.Second
+)
+
+// DoctorCheck is the result of a single diagnostic check. Messages do not
+// contain commas as they are printed in a table through a CSV encoding.
+type DoctorCheck struct {
+	Name    string `csv:"CHECK"   json:"name"    yaml:"name"`
+	Status  string `csv:"STATUS"  json:"status"  yaml:"status"`
+	Message string `csv:"MESSAGE" json:"message" yaml:"message"`
+}
+
+// DoctorReport is the outcome of all the diagnostic checks. Its status is the
+// worst status of its checks.
+type DoctorReport struct {
+	Status string        `json:"status" yaml:"status"`
+	Checks []DoctorCheck `json:"checks" yaml:"checks"`
+}
+
+// DoctorConfig holds the settings diagnosed in self-hosted mode. Ports are
+// checked the same way `appctl run` validates them.
+type DoctorConfig struct {
+	CLIVersion        string
+	ComponentsPath    string
+	PlacementHostAddr string
+	HTTPPort          int
+	GRPCPort          int
+	MetricsPort       int
+	ProfilePort       int
+}
+
+// NewDoctorReport returns a report of the given checks.
+func NewDoctorReport(checks []DoctorCheck) *DoctorReport {
+	report := &DoctorReport{
+		Status: DoctorPass,
+		Checks: checks,
+	}
+	for _, c := range checks {
+		if c.Status == DoctorFail || (c.Status == DoctorWarn && report.Status == DoctorPass) {
+			report.Status = c.Status
+		}
+	}
+	return report
+}
+
+// Doctor diagnoses the self-hosted environment.
+func Doctor(config DoctorConfig) []DoctorCheck {
+	checks := []DoctorCheck{
+		checkAppDir(),
+		checkRuntimeVersion(config.CLIVersion),
+		checkPlacement(config.PlacementHostAddr),
+		checkComponents(config.ComponentsPath),
+	}
+	return append(checks, checkPorts(config)...)
+}
+
+func checkAppDir() DoctorCheck {
+	check := DoctorCheck{Name: "bhojpur-dir"}
+	dir := defaultAppDirPath()
+	if _, err := os.Stat(dir); err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%s not found. Run `appctl init` to set up the Bhojpur Application runtime", dir)
+		return check
+	}
+
+	missing := []string{}
+	for _, path := range []string{
+		binaryFilePath(defaultAppBinPath(), appRuntimeFilePrefix),
+		DefaultComponentsDirPath(),
+		DefaultConfigFilePath(),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("missing %s. Run `appctl uninstall` and `appctl init` to repair the installation", strings.Join(missing, " "))
+		return check
+	}
+
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("%s is set up", dir)
+	return check
+}
+
+func checkRuntimeVersion(cliVersion string) DoctorCheck {
+	check := DoctorCheck{Name: "runtime-version"}
+	out, err := exec.Command(binaryFilePath(defaultAppBinPath(), appRuntimeFilePrefix), "--version").Output()
+	if err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("could not get the runtime version: %s", err)
+		return check
+	}
+
+	runtimeVersion := strings.TrimSpace(string(out))
+	check.Status, check.Message = compareVersions(cliVersion, runtimeVersion)
+	return check
+}
+
+// compareVersions warns when the runtime and CLI releases differ in their
+// major or minor version. Development builds cannot be compared.
+func compareVersions(cliVersion, runtimeVersion string) (string, string) {
+	cli, cliErr := version.NewVersion(cliVersion)
+	runtime, runtimeErr := version.NewVersion(runtimeVersion)
+	if cliErr != nil || runtimeErr != nil {
+		return DoctorPass, fmt.Sprintf("runtime version %s and CLI version %s", runtimeVersion, cliVersion)
+	}
+
+	c, r := cli.Segments(), runtime.Segments()
+	if c[0] != r[0] || c[1] != r[1] {
+		return DoctorWarn, fmt.Sprintf("runtime version %s does not match CLI version %s. Run `appctl init --runtime-version %s` to install a matching runtime", runtimeVersion, cliVersion, cliVersion)
+	}
+	return DoctorPass, fmt.Sprintf("runtime version %s matches CLI version %s", runtimeVersion, cliVersion)
+}
+
+func checkPlacement(placementHostAddr string) DoctorCheck {
+	check := DoctorCheck{Name: "placement"}
+	config := &RunConfig{PlacementHostAddr: placementHostAddr}
+	config.validatePlacementHostAddr()
+
+	conn, err := net.DialTimeout("tcp", config.PlacementHostAddr, placementDialTimeout)
+	if err != nil {
+		check.Status = DoctorWarn
+		check.Message = fmt.Sprintf("placement service at %s is not reachable so actors will not work: %s", config.PlacementHostAddr, err)
+		return check
+	}
+	conn.Close()
+
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("placement service at %s is reachable", config.PlacementHostAddr)
+	return check
+}
+
+func checkComponents(componentsPath string) DoctorCheck {
+	check := DoctorCheck{Name: "components"}
+	loader := components.NewStandaloneComponents(modes.StandaloneConfig{ComponentsPath: componentsPath})
+	list, err := loader.LoadComponents()
+	if err != nil {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("could not load components from %s: %s", componentsPath, err)
+		return check
+	}
+	if len(list) == 0 {
+		check.Status = DoctorWarn
+		check.Message = fmt.Sprintf("no components found in %s", componentsPath)
+		return check
+	}
+
+	names := make([]string, 0, len(list))
+	for _, c := range list {
+		names = append(names, fmt.Sprintf("%s (%s)", c.Name, c.Spec.Type))
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("loaded %d components from %s: %s", len(list), componentsPath, strings.Join(names, " "))
+	return check
+}
+
+func checkPorts(config DoctorConfig) []DoctorCheck {
+	meta, err := newAppMeta()
+	if err != nil {
+		return []DoctorCheck{{
+			Name:    "ports",
+			Status:  DoctorFail,
+			Message: fmt.Sprintf("could not read running instances: %s", err),
+		}}
+	}
+
+	run := &RunConfig{}
+	checks := []DoctorCheck{}
+	for _, p := range []struct {
+		name    string
+		port    int
+		portPtr *int
+	}{
+		{"HTTPPort", config.HTTPPort, &run.HTTPPort},
+		{"GRPCPort", config.GRPCPort, &run.GRPCPort},
+		{"MetricsPort", config.MetricsPort, &run.MetricsPort},
+		{"ProfilePort", config.ProfilePort, &run.ProfilePort},
+	} {
+		check := DoctorCheck{Name: "port " + p.name}
+		*p.portPtr = p.port
+		if err := run.validatePort(p.name, p.portPtr, meta); err != nil {
+			check.Status = DoctorFail
+			check.Message = err.Error()
+		} else if p.port <= 0 {
+			check.Status = DoctorPass
+			check.Message = fmt.Sprintf("a free port will be picked (currently %d)", *p.portPtr)
+		} else {
+			check.Status = DoctorPass
+			check.Message = fmt.Sprintf("port %d is available", p.port)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
@@ -0,0 +1,123 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDoctorReport(t *testing.T) {
+	assert.Equal(t, DoctorPass, NewDoctorReport(nil).Status)
+	assert.Equal(t, DoctorWarn, NewDoctorReport([]DoctorCheck{
+		{Status: DoctorPass}, {Status: DoctorWarn}, {Status: DoctorPass},
+	}).Status)
+	assert.Equal(t, DoctorFail, NewDoctorReport([]DoctorCheck{
+		{Status: DoctorFail}, {Status: DoctorWarn},
+	}).Status)
+}
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		cli      string
+		runtime  string
+		expected string
+	}{
+		{"1.2.0", "1.2.3", DoctorPass},
+		{"v1.2.0", "1.2.0", DoctorPass},
+		{"1.2.0", "1.3.0", DoctorWarn},
+		{"2.0.0", "1.0.0", DoctorWarn},
+		{"edge", "1.0.0", DoctorPass},
+		{"1.0.0", "edge", DoctorPass},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.cli+" "+tc.runtime, func(t *testing.T) {
+			status, _ := compareVersions(tc.cli, tc.runtime)
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func TestCheckAppDir(t *testing.T) {
+	setupRegistry(t)
+
+	assert.Equal(t, DoctorFail, checkAppDir().Status)
+
+	require.NoError(t, os.MkdirAll(defaultAppBinPath(), 0o755))
+	require.NoError(t, os.MkdirAll(DefaultComponentsDirPath(), 0o755))
+	require.NoError(t, ioutil.WriteFile(DefaultConfigFilePath(), []byte{}, 0o644))
+	check := checkAppDir()
+	assert.Equal(t, DoctorFail, check.Status)
+	assert.Contains(t, check.Message, appRuntimeFilePrefix)
+
+	require.NoError(t, ioutil.WriteFile(binaryFilePath(defaultAppBinPath(), appRuntimeFilePrefix), []byte{}, 0o755))
+	assert.Equal(t, DoctorPass, checkAppDir().Status)
+}
+
+func TestCheckComponents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "components")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Equal(t, DoctorFail, checkComponents(filepath.Join(dir, "missing")).Status)
+	assert.Equal(t, DoctorWarn, checkComponents(dir).Status)
+
+	component := `apiVersion: bhojpur.net/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: state.redis
+  version: v1
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "statestore.yaml"), []byte(component), 0o644))
+	check := checkComponents(dir)
+	assert.Equal(t, DoctorPass, check.Status)
+	assert.Contains(t, check.Message, "statestore (state.redis)")
+}
+
+func TestCheckPorts(t *testing.T) {
+	setupRegistry(t)
+
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer listener.Close()
+	usedPort := listener.Addr().(*net.TCPAddr).Port
+
+	checks := checkPorts(DoctorConfig{
+		HTTPPort:    usedPort,
+		GRPCPort:    -1,
+		MetricsPort: -1,
+		ProfilePort: -1,
+	})
+	require.Len(t, checks, 4)
+	assert.Equal(t, DoctorFail, checks[0].Status)
+	assert.NotContains(t, checks[0].Message, ",")
+	for _, c := range checks[1:] {
+		assert.Equal(t, DoctorPass, c.Status)
+	}
+}
@@ -41,6 +41,10 @@ func (s *Standalone) Invoke(appID, method string, data []byte, verb string, path
 
 	for _, lo := range list {
 		if lo.AppID == appID {
+			if path == "" {
+				path = lo.UnixDomainSocket
+			}
+
 			url := makeEndpoint(lo, method)
 			req, err := http.NewRequest(verb, url, bytes.NewBuffer(data))
 			if err != nil {
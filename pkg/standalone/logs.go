@@ -0,0 +1,336 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	defaultLogsDirName = "logs"
+
+	// LogComponentSidecar is the log of the Bhojpur Application runtime sidecar.
+	LogComponentSidecar = "sidecar"
+	// LogComponentApp is the log of the application started next to the sidecar.
+	LogComponentApp = "app"
+
+	logFileMaxSizeMB  = 10
+	logFileMaxBackups = 5
+	logTimeFormat     = time.RFC3339Nano
+)
+
+// LogComponents lists the components whose logs are captured in standalone mode.
+var LogComponents = []string{LogComponentSidecar, LogComponentApp}
+
+var (
+	logLevels      = map[string]int{"debug": 0, "info": 1, "warn": 2, "warning": 2, "error": 3, "fatal": 4, "panic": 5}
+	logfmtLevelExp = regexp.MustCompile(`(?:^|\s)level=("?)(\w+)`)
+	logfmtMsgExp   = regexp.MustCompile(`(?:^|\s)msg="((?:[^"\\]|\\.)*)"`)
+)
+
+// LogLine is a line captured from the output of a sidecar or application.
+type LogLine struct {
+	Time      time.Time
+	Component string
+	Level     string
+	Scope     string
+	Message   string
+	Raw       string
+	JSON      bool
+}
+
+// logWriter writes every line it receives to a rotated log file, prefixed
+// with the time it was received.
+type logWriter struct {
+	lock sync.Mutex
+	file *lumberjack.Logger
+	buf  []byte
+}
+
+func defaultLogsDirPath() string {
+	return filepath.Join(defaultAppDirPath(), defaultLogsDirName)
+}
+
+func logFilePath(appID, component string) string {
+	return filepath.Join(defaultLogsDirPath(), appID, component+".log")
+}
+
+func newLogWriter(appID, component string) *logWriter {
+	return &logWriter{
+		file: &lumberjack.Logger{
+			Filename:   logFilePath(appID, component),
+			MaxSize:    logFileMaxSizeMB,
+			MaxBackups: logFileMaxBackups,
+		},
+	}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		_, err := fmt.Fprintf(w.file, "%s %s\n", time.Now().UTC().Format(logTimeFormat), bytes.TrimRight(w.buf[:i], "\r"))
+		w.buf = w.buf[i+1:]
+		if err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Close writes any pending partial line and closes the log file.
+func (w *logWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.buf) > 0 {
+		fmt.Fprintf(w.file, "%s %s\n", time.Now().UTC().Format(logTimeFormat), w.buf)
+		w.buf = nil
+	}
+	return w.file.Close()
+}
+
+// parseLogLine parses a line of a log file. The level, scope and message are
+// extracted from JSON output (--log-as-json) and from the logfmt text output
+// of the runtime.
+func parseLogLine(component, line string) (LogLine, bool) {
+	i := strings.IndexByte(line, ' ')
+	if i < 0 {
+		return LogLine{}, false
+	}
+	t, err := time.Parse(logTimeFormat, line[:i])
+	if err != nil {
+		return LogLine{}, false
+	}
+
+	raw := line[i+1:]
+	l := LogLine{
+		Time:      t,
+		Component: component,
+		Message:   raw,
+		Raw:       raw,
+	}
+
+	var fields map[string]interface{}
+	if strings.HasPrefix(raw, "{") && json.Unmarshal([]byte(raw), &fields) == nil {
+		l.JSON = true
+		l.Level, _ = fields["level"].(string)
+		l.Scope, _ = fields["scope"].(string)
+		if msg, ok := fields["msg"].(string); ok {
+			l.Message = msg
+		}
+		if ts, ok := fields["time"].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+				l.Time = t
+			}
+		}
+		return l, true
+	}
+
+	if m := logfmtLevelExp.FindStringSubmatch(raw); m != nil {
+		l.Level = m[2]
+	}
+	if m := logfmtMsgExp.FindStringSubmatch(raw); m != nil {
+		l.Message = strings.ReplaceAll(m[1], `\"`, `"`)
+	}
+	return l, true
+}
+
+// LogFilter selects the lines returned by the log readers.
+type LogFilter struct {
+	Since    time.Time
+	MinLevel string
+}
+
+func (f LogFilter) match(l LogLine) bool {
+	if !f.Since.IsZero() && l.Time.Before(f.Since) {
+		return false
+	}
+	if f.MinLevel != "" {
+		level, ok := logLevels[strings.ToLower(l.Level)]
+		if !ok || level < logLevels[strings.ToLower(f.MinLevel)] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateLogLevel checks that level is a known log level.
+func ValidateLogLevel(level string) error {
+	if _, ok := logLevels[strings.ToLower(level)]; !ok {
+		return fmt.Errorf("invalid log level %s. Valid values are: debug, info, warn, error, fatal, or panic", level)
+	}
+	return nil
+}
+
+// logFiles returns the rotated backups of a log file, oldest first, followed
+// by the current file.
+func logFiles(appID, component string) ([]string, error) {
+	path := logFilePath(appID, component)
+	ext := filepath.Ext(path)
+	backups, err := filepath.Glob(strings.TrimSuffix(path, ext) + "-*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(backups)
+	return append(backups, path), nil
+}
+
+// ReadLogs returns the captured log lines of the given components of an app
+// ID, merged in time order.
+func ReadLogs(appID string, components []string, filter LogFilter) ([]LogLine, error) {
+	lines := []LogLine{}
+	found := false
+	for _, component := range components {
+		files, err := logFiles(appID, component)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range files {
+			f, err := os.Open(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			found = true
+			lines = append(lines, readLogLines(f, component, filter)...)
+			f.Close()
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no logs found for app id %s", appID)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+	return lines, nil
+}
+
+func readLogLines(r io.Reader, component string, filter LogFilter) []LogLine {
+	lines := []LogLine{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l, ok := parseLogLine(component, scanner.Text())
+		if ok && filter.match(l) {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// FollowLogs sends the lines appended to the log files of the given
+// components to fn until stopCh is closed. Rotated files are reopened.
+func FollowLogs(appID string, components []string, filter LogFilter, stopCh <-chan struct{}, fn func(LogLine)) error {
+	var wg sync.WaitGroup
+	var fnLock sync.Mutex
+	for _, component := range components {
+		wg.Add(1)
+		go func(component string) {
+			defer wg.Done()
+			followLogFile(logFilePath(appID, component), component, filter, stopCh, func(l LogLine) {
+				fnLock.Lock()
+				defer fnLock.Unlock()
+				fn(l)
+			})
+		}(component)
+	}
+	wg.Wait()
+	return nil
+}
+
+func followLogFile(path, component string, filter LogFilter, stopCh <-chan struct{}, fn func(LogLine)) {
+	var f *os.File
+	var reader *bufio.Reader
+	var pending string
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+
+	// Only the lines written from now on are followed.
+	if file, err := os.Open(path); err == nil {
+		file.Seek(0, io.SeekEnd)
+		f, reader = file, bufio.NewReader(file)
+	}
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if f != nil {
+			for {
+				s, err := reader.ReadString('\n')
+				pending += s
+				if err != nil {
+					break
+				}
+				if l, ok := parseLogLine(component, strings.TrimRight(pending, "\n")); ok && filter.match(l) {
+					fn(l)
+				}
+				pending = ""
+			}
+
+			// Reopen the file once it has been rotated.
+			if info, err := os.Stat(path); err == nil {
+				if current, err := f.Stat(); err == nil && !os.SameFile(info, current) {
+					f.Close()
+					f = nil
+				}
+			}
+		}
+
+		if f == nil {
+			if file, err := os.Open(path); err == nil {
+				f, reader, pending = file, bufio.NewReader(file), ""
+				continue
+			}
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLogFile(t *testing.T, path string, lines ...string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	content := ""
+	for _, l := range lines {
+		content += l + "\n"
+	}
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLogWriter(t *testing.T) {
+	setupRegistry(t)
+
+	w := newLogWriter("orders", LogComponentSidecar)
+	_, err := w.Write([]byte("first line\nsecond "))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("line\r\npartial"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	lines, err := ReadLogs("orders", []string{LogComponentSidecar}, LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "first line", lines[0].Raw)
+	assert.Equal(t, "second line", lines[1].Raw)
+	assert.Equal(t, "partial", lines[2].Raw)
+	assert.Equal(t, LogComponentSidecar, lines[0].Component)
+	assert.WithinDuration(t, time.Now(), lines[0].Time, time.Minute)
+}
+
+func TestParseLogLine(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		l, ok := parseLogLine(LogComponentSidecar, `2021-06-01T10:00:00Z {"level":"warning","msg":"component not ready","scope":"appsvr.runtime","time":"2021-06-01T10:00:01.5Z"}`)
+		require.True(t, ok)
+		assert.True(t, l.JSON)
+		assert.Equal(t, "warning", l.Level)
+		assert.Equal(t, "appsvr.runtime", l.Scope)
+		assert.Equal(t, "component not ready", l.Message)
+		assert.Equal(t, time.Date(2021, 6, 1, 10, 0, 1, 500000000, time.UTC), l.Time.UTC())
+	})
+
+	t.Run("logfmt", func(t *testing.T) {
+		l, ok := parseLogLine(LogComponentSidecar, `2021-06-01T10:00:00Z time="2021-06-01T10:00:00Z" level=error msg="failed to \"load\" component" app_id=orders`)
+		require.True(t, ok)
+		assert.False(t, l.JSON)
+		assert.Equal(t, "error", l.Level)
+		assert.Equal(t, `failed to "load" component`, l.Message)
+		assert.Equal(t, time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC), l.Time.UTC())
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		l, ok := parseLogLine(LogComponentApp, `2021-06-01T10:00:00Z listening on :3000`)
+		require.True(t, ok)
+		assert.Equal(t, "", l.Level)
+		assert.Equal(t, "listening on :3000", l.Message)
+	})
+
+	t.Run("missing timestamp", func(t *testing.T) {
+		_, ok := parseLogLine(LogComponentApp, `listening on :3000`)
+		assert.False(t, ok)
+	})
+}
+
+func TestLogFilter(t *testing.T) {
+	now := time.Now()
+	l := LogLine{Time: now, Level: "warning"}
+
+	assert.True(t, LogFilter{}.match(l))
+	assert.True(t, LogFilter{Since: now.Add(-time.Minute)}.match(l))
+	assert.False(t, LogFilter{Since: now.Add(time.Minute)}.match(l))
+	assert.True(t, LogFilter{MinLevel: "info"}.match(l))
+	assert.True(t, LogFilter{MinLevel: "warn"}.match(l))
+	assert.False(t, LogFilter{MinLevel: "error"}.match(l))
+	assert.False(t, LogFilter{MinLevel: "info"}.match(LogLine{Time: now}))
+
+	assert.NoError(t, ValidateLogLevel("WARN"))
+	assert.Error(t, ValidateLogLevel("verbose"))
+}
+
+func TestReadLogs(t *testing.T) {
+	t.Run("merges components and rotated files in time order", func(t *testing.T) {
+		setupRegistry(t)
+
+		sidecar := logFilePath("orders", LogComponentSidecar)
+		writeLogFile(t, filepath.Join(filepath.Dir(sidecar), "sidecar-2021-06-01T09-00-00.000.log"),
+			`2021-06-01T09:00:00Z level=info msg="starting"`)
+		writeLogFile(t, sidecar,
+			`2021-06-01T10:00:00Z level=info msg="ready"`,
+			`2021-06-01T10:00:02Z level=error msg="failed"`)
+		writeLogFile(t, logFilePath("orders", LogComponentApp),
+			`2021-06-01T10:00:01Z listening on :3000`)
+
+		lines, err := ReadLogs("orders", LogComponents, LogFilter{})
+		require.NoError(t, err)
+		require.Len(t, lines, 4)
+		assert.Equal(t, "starting", lines[0].Message)
+		assert.Equal(t, "ready", lines[1].Message)
+		assert.Equal(t, LogComponentApp, lines[2].Component)
+		assert.Equal(t, "failed", lines[3].Message)
+
+		lines, err = ReadLogs("orders", LogComponents, LogFilter{
+			Since:    time.Date(2021, 6, 1, 9, 30, 0, 0, time.UTC),
+			MinLevel: "info",
+		})
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "ready", lines[0].Message)
+		assert.Equal(t, "failed", lines[1].Message)
+	})
+
+	t.Run("no logs", func(t *testing.T) {
+		setupRegistry(t)
+
+		_, err := ReadLogs("orders", LogComponents, LogFilter{})
+		assert.Error(t, err)
+	})
+}
+
+func TestFollowLogs(t *testing.T) {
+	setupRegistry(t)
+
+	path := logFilePath("orders", LogComponentSidecar)
+	writeLogFile(t, path, `2021-06-01T10:00:00Z level=info msg="old"`)
+
+	stopCh := make(chan struct{})
+	linesCh := make(chan LogLine, 10)
+	done := make(chan struct{})
+	go func() {
+		FollowLogs("orders", []string{LogComponentSidecar}, LogFilter{}, stopCh, func(l LogLine) {
+			linesCh <- l
+		})
+		close(done)
+	}()
+
+	// Give the follower time to seek to the end of the file.
+	time.Sleep(300 * time.Millisecond)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString("2021-06-01T10:00:01Z level=info msg=\"new\"\n")
+	require.NoError(t, err)
+	f.Close()
+
+	select {
+	case l := <-linesCh:
+		assert.Equal(t, "new", l.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for followed log line")
+	}
+
+	close(stopCh)
+	<-done
+}
@@ -0,0 +1,246 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	process "github.com/shirou/gopsutil/process"
+	"gopkg.in/yaml.v2"
+
+	"github.com/bhojpur/application/pkg/credentials"
+	"github.com/bhojpur/application/pkg/sentry/ca"
+	"github.com/bhojpur/application/pkg/sentry/certs"
+	sentry_config "github.com/bhojpur/application/pkg/sentry/config"
+)
+
+const (
+	defaultCertsDirName     = "certs"
+	sentryServiceFilePrefix = "sentry"
+	sentryPIDFileName       = "sentry.pid"
+	sentryLogFileName       = "sentry.log"
+	sentryHealthzPort       = 50002
+	sentryAllowedClockSkew  = time.Minute * 15
+
+	sentryLocalIdentityEnvVar = "SENTRY_LOCAL_IDENTITY"
+)
+
+// DefaultCertsDirPath returns the directory holding the local mTLS trust bundle.
+func DefaultCertsDirPath() string {
+	return filepath.Join(defaultAppDirPath(), defaultCertsDirName)
+}
+
+func sentryPIDFilePath() string {
+	return filepath.Join(defaultAppDirPath(), sentryPIDFileName)
+}
+
+func trustBundleConfig(dir string) sentry_config.SentryConfig {
+	return sentry_config.SentryConfig{
+		RootCertPath:   filepath.Join(dir, credentials.RootCertFilename),
+		IssuerCertPath: filepath.Join(dir, credentials.IssuerCertFilename),
+		IssuerKeyPath:  filepath.Join(dir, credentials.IssuerKeyFilename),
+	}
+}
+
+// createTrustBundle generates a self signed root certificate and the issuer
+// credentials sentry signs workload certificates with. An existing bundle is kept.
+func createTrustBundle(dir string) error {
+	conf := trustBundleConfig(dir)
+	if _, err := os.Stat(conf.RootCertPath); err == nil {
+		return nil
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("error creating certs folder: %s", err)
+	}
+
+	rootCertPem, issuerCertPem, issuerKeyPem, err := ca.GenerateRootAndIssuerCerts(sentryAllowedClockSkew)
+	if err != nil {
+		return fmt.Errorf("error generating trust bundle: %s", err)
+	}
+	return certs.StoreCredentials(conf, rootCertPem, issuerCertPem, issuerKeyPem)
+}
+
+// localMTLSEnv returns the environment handing the local trust bundle and the
+// sentry identity to a sidecar. It returns nil when `appctl init --mtls` has
+// not generated a bundle.
+func localMTLSEnv(dir, appID string) ([]string, error) {
+	conf := trustBundleConfig(dir)
+	if _, err := os.Stat(conf.RootCertPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	chain, err := credentials.LoadFromDisk(conf.RootCertPath, conf.IssuerCertPath, conf.IssuerKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading local trust bundle: %s", err)
+	}
+
+	return []string{
+		fmt.Sprintf("%s=%s", certs.TrustAnchorsEnvVar, chain.RootCA),
+		fmt.Sprintf("%s=%s", certs.CertChainEnvVar, chain.Cert),
+		fmt.Sprintf("%s=%s", certs.CertKeyEnvVar, chain.Key),
+		fmt.Sprintf("%s=%s", sentryLocalIdentityEnvVar, appID),
+	}, nil
+}
+
+// enableConfigMTLS turns on mTLS in a configuration file, keeping the rest of
+// its content.
+func enableConfigMTLS(filePath string) error {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.MapSlice
+	err = yaml.Unmarshal(b, &doc)
+	if err != nil {
+		return err
+	}
+
+	spec, _ := mapSliceValue(doc, "spec").(yaml.MapSlice)
+	mtls, _ := mapSliceValue(spec, "mtls").(yaml.MapSlice)
+	mtls = setMapSliceValue(mtls, "enabled", true)
+	spec = setMapSliceValue(spec, "mtls", mtls)
+	doc = setMapSliceValue(doc, "spec", spec)
+
+	b, err = yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	// #nosec G306
+	return ioutil.WriteFile(filePath, b, 0644)
+}
+
+func mapSliceValue(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+func setMapSliceValue(m yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i, item := range m {
+		if item.Key == key {
+			m[i].Value = value
+			return m
+		}
+	}
+	return append(m, yaml.MapItem{Key: key, Value: value})
+}
+
+// sentryPID returns the PID of the local sentry process started by init, if it
+// is still running.
+func sentryPID() (int, bool) {
+	b, err := ioutil.ReadFile(sentryPIDFilePath())
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, false
+	}
+	exists, err := process.PidExists(int32(pid))
+	if err != nil || !exists {
+		return 0, false
+	}
+	return pid, true
+}
+
+// startSentry runs the sentry binary in the background, issuing workload
+// certificates from the trust bundle in certsDir.
+func startSentry(binDir, certsDir string) error {
+	if _, ok := sentryPID(); ok {
+		return nil
+	}
+
+	err := os.MkdirAll(defaultLogsDirPath(), 0o755)
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(filepath.Join(defaultLogsDirPath(), sentryLogFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	// #nosec G204
+	cmd := exec.Command(binaryFilePath(binDir, sentryServiceFilePrefix),
+		"--issuer-credentials", certsDir,
+		"--config", DefaultConfigFilePath(),
+		"--healthz-port", strconv.Itoa(sentryHealthzPort),
+		"--enable-metrics=false")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("error starting sentry: %s", err)
+	}
+
+	// #nosec G306
+	err = ioutil.WriteFile(sentryPIDFilePath(), []byte(strconv.Itoa(cmd.Process.Pid)), 0644)
+	if err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// stopSentry terminates the local sentry process started by init.
+func stopSentry() error {
+	defer os.Remove(sentryPIDFilePath())
+
+	pid, ok := sentryPID()
+	if !ok {
+		return nil
+	}
+	proc, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
+
+// setupLocalMTLS generates the local trust bundle, enables mTLS in the
+// default configuration and starts sentry, so that sidecars started by
+// `appctl run` get workload certificates.
+func setupLocalMTLS(binDir string) error {
+	certsDir := DefaultCertsDirPath()
+	err := createTrustBundle(certsDir)
+	if err != nil {
+		return err
+	}
+
+	err = enableConfigMTLS(DefaultConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("error enabling mTLS in default configuration file: %s", err)
+	}
+
+	return startSentry(binDir, certsDir)
+}
@@ -57,6 +57,10 @@ func (s *Standalone) Publish(publishAppID, pubsubName, topic string, payload []b
 		return err
 	}
 
+	if socket == "" {
+		socket = instance.UnixDomainSocket
+	}
+
 	url := fmt.Sprintf("http://unix/v%s/publish/%s/%s", apisvr.RuntimeAPIVersion, pubsubName, topic)
 
 	var httpc http.Client
@@ -0,0 +1,217 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nightlyone/lockfile"
+	process "github.com/shirou/gopsutil/process"
+)
+
+const (
+	defaultInstancesDirName = "instances"
+	instancesLockFileName   = "instances.lock"
+	instanceFileExtension   = ".json"
+)
+
+// instance is the registry entry of a sidecar started by `appctl run`. One
+// file per instance is kept in the instances directory, so that other appctl
+// commands can find running sidecars without inspecting processes.
+type instance struct {
+	AppID              string    `json:"appId"`
+	CLIPID             int       `json:"cliPid"`
+	SidecarPID         int       `json:"sidecarPid,omitempty"`
+	AppPID             int       `json:"appPid,omitempty"`
+	HTTPPort           int       `json:"httpPort"`
+	GRPCPort           int       `json:"grpcPort"`
+	AppPort            int       `json:"appPort"`
+	MetricsPort        int       `json:"metricsPort"`
+	ProfilePort        int       `json:"profilePort,omitempty"`
+	UnixDomainSocket   string    `json:"unixDomainSocket,omitempty"`
+	HTTPSocket         string    `json:"httpSocket,omitempty"`
+	GRPCSocket         string    `json:"grpcSocket,omitempty"`
+	ConfigFile         string    `json:"configFile,omitempty"`
+	ComponentsPath     string    `json:"componentsPath,omitempty"`
+	AppCommand         string    `json:"appCommand,omitempty"`
+	MaxRequestBodySize int       `json:"maxRequestBodySize"`
+	Created            time.Time `json:"created"`
+}
+
+func defaultInstancesDirPath() string {
+	return filepath.Join(defaultAppDirPath(), defaultInstancesDirName)
+}
+
+func (i *instance) filePath() string {
+	return filepath.Join(defaultInstancesDirPath(), fmt.Sprintf("%s_%d%s", i.AppID, i.CLIPID, instanceFileExtension))
+}
+
+// alive tells whether the appctl process owning the instance is still running.
+// A process created after the instance was registered reuses a stale PID.
+func (i *instance) alive() bool {
+	proc, err := process.NewProcess(int32(i.CLIPID))
+	if err != nil {
+		return false
+	}
+	createTime, err := proc.CreateTime()
+	if err != nil {
+		return false
+	}
+	return !time.Unix(0, createTime*int64(time.Millisecond)).After(i.Created)
+}
+
+func lockInstances() (*lockfile.Lockfile, error) {
+	dir := defaultInstancesDirPath()
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	lockFile, err := lockfile.New(filepath.Join(dir, instancesLockFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < 40; i++ {
+		err = lockFile.TryLock()
+
+		// Error handling is essential, as we only try to get the lock.
+		if err == nil {
+			return &lockFile, nil
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return nil, fmt.Errorf("error locking the instances registry: %w", err)
+}
+
+// writeInstance adds or updates the registry entry of an instance.
+func writeInstance(i *instance) error {
+	lockFile, err := lockInstances()
+	if err != nil {
+		return err
+	}
+	defer lockFile.Unlock()
+
+	b, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial entry.
+	path := i.filePath()
+	err = ioutil.WriteFile(path+".tmp", b, 0o600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// removeInstance deletes the registry entry of an instance.
+func removeInstance(i *instance) error {
+	lockFile, err := lockInstances()
+	if err != nil {
+		return err
+	}
+	defer lockFile.Unlock()
+
+	err = os.Remove(i.filePath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// stopInstance terminates the application and the sidecar of an instance,
+// leaving the other instances owned by the same appctl process running.
+func stopInstance(i *instance) error {
+	if i.SidecarPID == 0 && i.AppPID == 0 {
+		// The processes of the instance are still being started.
+		return stopCLI(i.CLIPID)
+	}
+
+	if i.AppPID != 0 {
+		err := stopProcess(i.AppPID)
+		if err != nil {
+			return fmt.Errorf("error stopping app %s: %w", i.AppID, err)
+		}
+	}
+	if i.SidecarPID != 0 {
+		err := stopProcess(i.SidecarPID)
+		if err != nil {
+			return fmt.Errorf("error stopping sidecar of app %s: %w", i.AppID, err)
+		}
+	}
+	return nil
+}
+
+// readInstances returns the registered instances sorted by creation time.
+// Entries left behind by appctl processes which are no longer running are
+// garbage collected.
+func readInstances() ([]*instance, error) {
+	lockFile, err := lockInstances()
+	if err != nil {
+		return nil, err
+	}
+	defer lockFile.Unlock()
+
+	dir := defaultInstancesDirPath()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []*instance{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), instanceFileExtension) {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var i instance
+		if json.Unmarshal(b, &i) != nil || !i.alive() {
+			os.Remove(path)
+			continue
+		}
+		instances = append(instances, &i)
+	}
+
+	sort.Slice(instances, func(a, b int) bool {
+		if instances[a].Created.Equal(instances[b].Created) {
+			return instances[a].AppID < instances[b].AppID
+		}
+		return instances[a].Created.Before(instances[b].Created)
+	})
+	return instances, nil
+}
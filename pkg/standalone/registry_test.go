@@ -0,0 +1,105 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRegistry(t *testing.T) {
+	home, err := ioutil.TempDir("", "registry")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(home) })
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+}
+
+func TestInstancesRegistry(t *testing.T) {
+	t.Run("running instances are listed", func(t *testing.T) {
+		setupRegistry(t)
+
+		i := &instance{
+			AppID:      "orders",
+			CLIPID:     os.Getpid(),
+			HTTPPort:   3500,
+			GRPCPort:   50001,
+			AppPort:    3000,
+			AppCommand: "node app.js",
+			Created:    time.Now(),
+		}
+		require.NoError(t, writeInstance(i))
+
+		list, err := List()
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "orders", list[0].AppID)
+		assert.Equal(t, 3500, list[0].HTTPPort)
+		assert.Equal(t, 50001, list[0].GRPCPort)
+		assert.Equal(t, 3000, list[0].AppPort)
+		assert.Equal(t, "node app.js", list[0].Command)
+		assert.Equal(t, os.Getpid(), list[0].PID)
+
+		require.NoError(t, removeInstance(i))
+		list, err = List()
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+
+	t.Run("instances are sorted by creation time", func(t *testing.T) {
+		setupRegistry(t)
+
+		now := time.Now()
+		require.NoError(t, writeInstance(&instance{AppID: "b", CLIPID: os.Getpid(), Created: now}))
+		require.NoError(t, writeInstance(&instance{AppID: "a", CLIPID: os.Getpid(), Created: now.Add(time.Second)}))
+
+		instances, err := readInstances()
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+		assert.Equal(t, "b", instances[0].AppID)
+		assert.Equal(t, "a", instances[1].AppID)
+	})
+
+	t.Run("stale entries are garbage collected", func(t *testing.T) {
+		setupRegistry(t)
+
+		// The current process was created after this entry, so its PID is a reused one.
+		stale := &instance{AppID: "stale", CLIPID: os.Getpid(), Created: time.Unix(0, 0)}
+		require.NoError(t, writeInstance(stale))
+		corrupt := filepath.Join(defaultInstancesDirPath(), "corrupt_1.json")
+		require.NoError(t, ioutil.WriteFile(corrupt, []byte("{"), 0o600))
+
+		instances, err := readInstances()
+		require.NoError(t, err)
+		assert.Empty(t, instances)
+
+		_, err = os.Stat(stale.filePath())
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(corrupt)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
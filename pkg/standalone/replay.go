@@ -0,0 +1,320 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+
+	apisvr "github.com/bhojpur/api/pkg/core"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/application/pkg/recorder"
+)
+
+const cloudEventsContentType = "application/cloudevents+json"
+
+// replaySkippedHeaders are the recorded headers which are set by the sidecar
+// or the transport and are not sent again.
+var replaySkippedHeaders = map[string]bool{
+	"content-length": true,
+	"content-type":   true,
+	"host":           true,
+	"traceparent":    true,
+	"tracestate":     true,
+	"grpc-trace-bin": true,
+	"user-agent":     true,
+}
+
+// ReplayOptions holds the parameters of a replay.
+type ReplayOptions struct {
+	// AppID is the app whose sidecar the recording is sent through.
+	AppID string
+	// TargetAppID overrides the app ID recorded invocations are sent to.
+	TargetAppID string
+	// Kinds are the kinds of recorded entries which are replayed.
+	Kinds []string
+	// IgnoreFields are the JSON fields left out when comparing responses.
+	IgnoreFields []string
+	Socket       string
+}
+
+// ReplayResult is the outcome of replaying a recorded entry.
+type ReplayResult struct {
+	Entry  recorder.Entry
+	Status int
+	Match  bool
+	Diff   string
+	Err    error
+	// Skipped is set when the entry isn't replayed because a recorded body
+	// was streamed and not recorded.
+	Skipped bool
+}
+
+// Replay sends the recorded invocations and published events of the given
+// kinds again through the sidecar of an app and compares the responses with
+// the recorded ones. Invocations with a streamed body are skipped.
+func (s *Standalone) Replay(entries []recorder.Entry, opts ReplayOptions) ([]ReplayResult, error) {
+	if opts.AppID == "" {
+		return nil, fmt.Errorf("app ID is missing")
+	}
+
+	client, err := newSidecarClient(s.process, opts.AppID, opts.Socket)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := map[string]bool{}
+	for _, k := range opts.Kinds {
+		kinds[k] = true
+	}
+
+	results := []ReplayResult{}
+	for _, e := range entries {
+		if len(kinds) > 0 && !kinds[e.Kind] {
+			continue
+		}
+
+		var result ReplayResult
+		switch e.Kind {
+		case recorder.KindApp, recorder.KindInvoke:
+			if e.Streamed() {
+				result = ReplayResult{Skipped: true}
+				break
+			}
+			result = replayInvocation(client, e, opts)
+		case recorder.KindPublish:
+			result = replayPublish(client, e)
+		default:
+			result = ReplayResult{Err: fmt.Errorf("unknown recording kind %q", e.Kind)}
+		}
+		result.Entry = e
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func replayInvocation(client *sidecarClient, e recorder.Entry, opts ReplayOptions) ReplayResult {
+	if e.Request == nil {
+		return ReplayResult{Err: fmt.Errorf("recorded invocation has no request")}
+	}
+
+	target := e.TargetAppID
+	if opts.TargetAppID != "" {
+		target = opts.TargetAppID
+	}
+	body, err := e.Request.Body()
+	if err != nil {
+		return ReplayResult{Err: err}
+	}
+	query, err := url.ParseQuery(e.Request.QueryString)
+	if err != nil {
+		return ReplayResult{Err: fmt.Errorf("invalid recorded query string: %w", err)}
+	}
+
+	header := http.Header{}
+	for k, values := range e.Request.Headers {
+		if strings.HasPrefix(k, ":") || replaySkippedHeaders[strings.ToLower(k)] {
+			continue
+		}
+		for _, v := range values {
+			if v != recorder.RedactedValue {
+				header.Add(k, v)
+			}
+		}
+	}
+	if e.Request.ContentType != "" {
+		header.Set("Content-Type", e.Request.ContentType)
+	}
+
+	verb := e.Request.Verb
+	if verb == "" || verb == "NONE" {
+		verb = http.MethodPost
+	}
+
+	r, err := client.do(apisvr.RuntimeAPIVersion, verb, fmt.Sprintf("invoke/%s/method/%s", target, e.Request.Method), query, header, body)
+	if err != nil {
+		return ReplayResult{Err: err}
+	}
+	defer r.Body.Close()
+
+	replayed, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return ReplayResult{Err: err}
+	}
+
+	result := ReplayResult{Status: r.StatusCode}
+	result.Match, result.Diff, result.Err = compareResponse(e.Response, r.StatusCode, replayed, opts.IgnoreFields)
+	return result
+}
+
+func replayPublish(client *sidecarClient, e recorder.Entry) ReplayResult {
+	if e.Event == nil {
+		return ReplayResult{Err: fmt.Errorf("recorded publish has no event")}
+	}
+
+	body, err := e.Event.Body()
+	if err != nil {
+		return ReplayResult{Err: err}
+	}
+	query := url.Values{}
+	for k, v := range e.Event.Metadata {
+		if v != recorder.RedactedValue {
+			query.Set("metadata."+k, v)
+		}
+	}
+	header := http.Header{}
+	header.Set("Content-Type", cloudEventsContentType)
+
+	r, err := client.do(apisvr.RuntimeAPIVersion, http.MethodPost, fmt.Sprintf("publish/%s/%s", e.Event.PubsubName, e.Event.Topic), query, header, body)
+	if err != nil {
+		return ReplayResult{Err: err}
+	}
+	defer r.Body.Close()
+
+	result := ReplayResult{Status: r.StatusCode}
+	if _, err = readSidecarResponse(r); err != nil {
+		result.Diff = err.Error()
+	}
+	result.Match = (e.Error == "") == (err == nil)
+	return result
+}
+
+// compareResponse compares a replayed response with the recorded one, and
+// returns a unified diff of the bodies when they differ.
+func compareResponse(recorded *recorder.Response, status int, body []byte, ignoreFields []string) (bool, string, error) {
+	if recorded == nil {
+		// The recorded invocation failed before the app responded.
+		if status >= http.StatusBadRequest {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("status: recorded an error, replayed %d\n", status), nil
+	}
+
+	expectedStatus := int(recorded.Status)
+	if recorded.Protocol == recorder.ProtocolGRPC {
+		expectedStatus = invokev1.HTTPStatusFromCode(codes.Code(recorded.Status))
+	}
+	expectedBody, err := recorded.Body()
+	if err != nil {
+		return false, "", err
+	}
+
+	var diff strings.Builder
+	if status != expectedStatus {
+		fmt.Fprintf(&diff, "status: recorded %d, replayed %d\n", expectedStatus, status)
+	}
+
+	a, b := normalizeBodies(expectedBody, body, ignoreFields)
+	if a != b {
+		d, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(a),
+			B:        difflib.SplitLines(b),
+			FromFile: "recorded",
+			ToFile:   "replayed",
+			Context:  3,
+		})
+		if err != nil {
+			return false, "", err
+		}
+		diff.WriteString(d)
+	}
+	return diff.Len() == 0, diff.String(), nil
+}
+
+// normalizeBodies returns the recorded and replayed bodies as indented JSON
+// when both are JSON, with the ignored fields removed and the fields redacted
+// in the recording masked in the replayed body too.
+func normalizeBodies(recorded, replayed []byte, ignoreFields []string) (string, string) {
+	var a, b interface{}
+	if json.Unmarshal(recorded, &a) != nil || json.Unmarshal(replayed, &b) != nil {
+		return ensureNewline(string(recorded)), ensureNewline(string(replayed))
+	}
+
+	ignored := map[string]bool{}
+	for _, f := range ignoreFields {
+		ignored[strings.ToLower(f)] = true
+	}
+	a, b = maskJSON(a, b, ignored)
+
+	ab, _ := json.MarshalIndent(a, "", "  ")
+	bb, _ := json.MarshalIndent(b, "", "  ")
+	return ensureNewline(string(ab)), ensureNewline(string(bb))
+}
+
+func maskJSON(recorded, replayed interface{}, ignored map[string]bool) (interface{}, interface{}) {
+	switch a := recorded.(type) {
+	case map[string]interface{}:
+		b, _ := replayed.(map[string]interface{})
+		for _, k := range sortedKeys(a, b) {
+			if ignored[strings.ToLower(k)] {
+				delete(a, k)
+				delete(b, k)
+				continue
+			}
+			_, inA := a[k]
+			_, inB := b[k]
+			if inA && inB {
+				a[k], b[k] = maskJSON(a[k], b[k], ignored)
+			}
+		}
+	case []interface{}:
+		if b, ok := replayed.([]interface{}); ok {
+			for i := 0; i < len(a) && i < len(b); i++ {
+				a[i], b[i] = maskJSON(a[i], b[i], ignored)
+			}
+		}
+	case string:
+		if a == recorder.RedactedValue {
+			return a, recorder.RedactedValue
+		}
+	}
+	return recorded, replayed
+}
+
+func sortedKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	seen := map[string]bool{}
+	for _, m := range []map[string]interface{}{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func ensureNewline(s string) string {
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return s
+}
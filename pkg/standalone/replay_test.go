@@ -0,0 +1,173 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/application/pkg/recorder"
+)
+
+func TestReplay(t *testing.T) {
+	entries := []recorder.Entry{
+		{
+			Kind:        recorder.KindApp,
+			TargetAppID: "testapp",
+			Request: &recorder.Request{
+				Method:      "orders",
+				Verb:        "POST",
+				QueryString: "page=2",
+				ContentType: "application/json",
+				Headers: map[string][]string{
+					"X-Tenant":      {"t1"},
+					"Authorization": {recorder.RedactedValue},
+					"Traceparent":   {"00-abc-def-01"},
+				},
+				Data: `{"item":"book"}`,
+			},
+			Response: &recorder.Response{Status: 200, Protocol: recorder.ProtocolHTTP, Data: `{"id":"1","token":"[REDACTED]","total":10}`},
+		},
+		{
+			Kind:        recorder.KindInvoke,
+			TargetAppID: "stock",
+			Request:     &recorder.Request{Method: "items/book", Verb: "GET"},
+			Response:    &recorder.Response{Status: 0, Protocol: recorder.ProtocolGRPC, Data: `{"count":3}`},
+		},
+		{
+			Kind:  recorder.KindPublish,
+			Event: &recorder.Event{PubsubName: "pubsub", Topic: "orders", Data: `{"id":"1"}`},
+		},
+		{
+			Kind:        recorder.KindInvoke,
+			TargetAppID: "stock",
+			Request:     &recorder.Request{Method: "upload", Verb: "POST", Streamed: true},
+			Response:    &recorder.Response{Status: 200, Protocol: recorder.ProtocolHTTP},
+		},
+	}
+
+	client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0/invoke/testapp/method/orders":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "t1", r.Header.Get("X-Tenant"))
+			assert.Empty(t, r.Header.Get("Authorization"))
+			assert.Empty(t, r.Header.Get("Traceparent"))
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"item":"book"}`, string(b))
+			w.Write([]byte(`{"total":10,"token":"abc","id":"2"}`))
+		case "/v1.0/invoke/stock/method/items/book":
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.Write([]byte(`{"count":2}`))
+		case "/v1.0/publish/pubsub/orders":
+			assert.Equal(t, cloudEventsContentType, r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	t.Run("default kinds", func(t *testing.T) {
+		results, err := client.Replay(entries, ReplayOptions{
+			AppID:        "testapp",
+			Kinds:        []string{recorder.KindApp, recorder.KindInvoke},
+			IgnoreFields: []string{"id"},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		require.NoError(t, results[0].Err)
+		assert.True(t, results[0].Match, results[0].Diff)
+		assert.Equal(t, http.StatusOK, results[0].Status)
+
+		require.NoError(t, results[1].Err)
+		assert.False(t, results[1].Match)
+		assert.Contains(t, results[1].Diff, `-  "count": 3`)
+		assert.Contains(t, results[1].Diff, `+  "count": 2`)
+
+		assert.True(t, results[2].Skipped)
+		assert.Equal(t, 0, results[2].Status)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		results, err := client.Replay(entries, ReplayOptions{AppID: "testapp", Kinds: []string{recorder.KindPublish}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Match)
+		assert.Equal(t, http.StatusNoContent, results[0].Status)
+	})
+
+	t.Run("target override", func(t *testing.T) {
+		results, err := client.Replay(entries[1:2], ReplayOptions{AppID: "testapp", TargetAppID: "missing"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Match)
+		assert.Contains(t, results[0].Diff, "status: recorded 200, replayed 404")
+	})
+
+	t.Run("app not found", func(t *testing.T) {
+		_, err := client.Replay(entries, ReplayOptions{AppID: "otherapp"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCompareResponse(t *testing.T) {
+	t.Run("text bodies", func(t *testing.T) {
+		match, diff, err := compareResponse(&recorder.Response{Status: 200, Protocol: recorder.ProtocolHTTP, Data: "hello"}, 200, []byte("hello"), nil)
+		require.NoError(t, err)
+		assert.True(t, match)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("grpc status", func(t *testing.T) {
+		match, diff, err := compareResponse(&recorder.Response{Status: 5, Protocol: recorder.ProtocolGRPC}, http.StatusNotFound, nil, nil)
+		require.NoError(t, err)
+		assert.True(t, match, diff)
+	})
+
+	t.Run("recorded error", func(t *testing.T) {
+		match, _, err := compareResponse(nil, http.StatusInternalServerError, nil, nil)
+		require.NoError(t, err)
+		assert.True(t, match)
+
+		match, diff, err := compareResponse(nil, http.StatusOK, nil, nil)
+		require.NoError(t, err)
+		assert.False(t, match)
+		assert.Equal(t, "status: recorded an error, replayed 200\n", diff)
+	})
+
+	t.Run("nested redacted and ignored fields", func(t *testing.T) {
+		recorded := &recorder.Response{
+			Status:   200,
+			Protocol: recorder.ProtocolHTTP,
+			Data:     `{"items":[{"secret":"[REDACTED]","at":"1"}],"user":{"name":"a"}}`,
+		}
+		match, diff, err := compareResponse(recorded, 200, []byte(`{"items":[{"secret":"x","at":"2"}],"user":{"name":"a"}}`), []string{"AT"})
+		require.NoError(t, err)
+		assert.True(t, match, diff)
+	})
+}
@@ -22,6 +22,7 @@ package standalone
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -29,6 +30,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/Pallinder/sillyname-go"
 	"github.com/phayes/freeport"
@@ -36,6 +38,7 @@ import (
 
 	"github.com/bhojpur/application/pkg/components"
 	modes "github.com/bhojpur/application/pkg/config/modes"
+	"github.com/bhojpur/application/pkg/utils"
 )
 
 const sentryDefaultAddress = "localhost:50001"
@@ -59,6 +62,8 @@ type RunConfig struct {
 	MetricsPort        int    `env:"APP_METRICS_PORT" arg:"metrics-port"`
 	MaxRequestBodySize int    `arg:"app-http-max-request-size"`
 	UnixDomainSocket   string `arg:"unix-domain-socket"`
+	AppDir             string
+	Env                []string
 }
 
 func (meta *AppMeta) newAppID() string {
@@ -204,15 +209,17 @@ func newAppMeta() (*AppMeta, error) {
 	meta := AppMeta{}
 	meta.ExistingIDs = make(map[string]bool)
 	meta.ExistingPorts = make(map[int]bool)
-	app, err := List()
+	instances, err := readInstances()
 	if err != nil {
 		return nil, err
 	}
-	for _, instance := range app {
+	for _, instance := range instances {
 		meta.ExistingIDs[instance.AppID] = true
 		meta.ExistingPorts[instance.AppPort] = true
 		meta.ExistingPorts[instance.HTTPPort] = true
 		meta.ExistingPorts[instance.GRPCPort] = true
+		meta.ExistingPorts[instance.MetricsPort] = true
+		meta.ExistingPorts[instance.ProfilePort] = true
 	}
 	return &meta, nil
 }
@@ -280,12 +287,52 @@ type RunOutput struct {
 	AppGRPCPort int
 	AppID       string
 	AppCMD      *exec.Cmd
+	// SidecarLog and AppLog capture the output of the sidecar and of the
+	// application to rotated log files read by `appctl logs`.
+	SidecarLog io.WriteCloser
+	AppLog     io.WriteCloser
+
+	instance *instance
+}
+
+// CloseLogs flushes and closes the captured log files.
+func (output *RunOutput) CloseLogs() {
+	output.SidecarLog.Close()
+	if output.AppLog != nil {
+		output.AppLog.Close()
+	}
+}
+
+// UpdateInstance records the PIDs of the started sidecar and application in
+// the instances registry.
+func (output *RunOutput) UpdateInstance() error {
+	if output.SvrCMD != nil && output.SvrCMD.Process != nil {
+		output.instance.SidecarPID = output.SvrCMD.Process.Pid
+	}
+	if output.AppCMD != nil && output.AppCMD.Process != nil {
+		output.instance.AppPID = output.AppCMD.Process.Pid
+	}
+	return writeInstance(output.instance)
+}
+
+// RemoveInstance deletes the instance from the instances registry.
+func (output *RunOutput) RemoveInstance() error {
+	return removeInstance(output.instance)
 }
 
 func getSvrCommand(config *RunConfig) (*exec.Cmd, error) {
 	appCMD := binaryFilePath(defaultAppBinPath(), "appsvr")
 	args := config.getArgs()
 	cmd := exec.Command(appCMD, args...)
+	if mtlsEndpoint(config.ConfigFile) != "" {
+		env, err := localMTLSEnv(DefaultCertsDirPath(), config.AppID)
+		if err != nil {
+			return nil, err
+		}
+		if env != nil {
+			cmd.Env = append(os.Environ(), env...)
+		}
+	}
 	return cmd, nil
 }
 
@@ -325,7 +372,9 @@ func getAppCommand(config *RunConfig) *exec.Cmd {
 	}
 
 	cmd := exec.Command(command, args...)
+	cmd.Dir = config.AppDir
 	cmd.Env = os.Environ()
+	cmd.Env = append(cmd.Env, config.Env...)
 	cmd.Env = append(cmd.Env, config.getEnv()...)
 
 	return cmd
@@ -343,11 +392,49 @@ func Run(config *RunConfig) (*RunOutput, error) {
 	}
 
 	var appCMD *exec.Cmd = getAppCommand(config)
-	return &RunOutput{
+
+	instance := newInstance(config)
+	err = writeInstance(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RunOutput{
 		SvrCMD:      svrCMD,
 		AppCMD:      appCMD,
 		AppID:       config.AppID,
 		AppHTTPPort: config.HTTPPort,
 		AppGRPCPort: config.GRPCPort,
-	}, nil
+		SidecarLog:  newLogWriter(config.AppID, LogComponentSidecar),
+		instance:    instance,
+	}
+	if appCMD != nil {
+		output.AppLog = newLogWriter(config.AppID, LogComponentApp)
+	}
+	return output, nil
+}
+
+func newInstance(config *RunConfig) *instance {
+	i := &instance{
+		AppID:              config.AppID,
+		CLIPID:             os.Getpid(),
+		HTTPPort:           config.HTTPPort,
+		GRPCPort:           config.GRPCPort,
+		AppPort:            config.AppPort,
+		MetricsPort:        config.MetricsPort,
+		UnixDomainSocket:   config.UnixDomainSocket,
+		ConfigFile:         config.ConfigFile,
+		ComponentsPath:     config.ComponentsPath,
+		AppCommand:         strings.Join(config.Arguments, " "),
+		MaxRequestBodySize: config.MaxRequestBodySize,
+		Created:            time.Now(),
+	}
+	if config.EnableProfiling {
+		i.ProfilePort = config.ProfilePort
+	}
+	if config.UnixDomainSocket != "" {
+		i.HTTPSocket = utils.GetSocket(config.UnixDomainSocket, config.AppID, "http")
+		i.GRPCSocket = utils.GetSocket(config.UnixDomainSocket, config.AppID, "grpc")
+	}
+	return i
 }
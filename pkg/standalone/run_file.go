@@ -0,0 +1,209 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+const runFileVersion = 1
+
+// RunFile is a run template describing a set of applications that are started
+// together by `appctl run -f` and stopped together by `appctl stop -f`.
+type RunFile struct {
+	Version int          `yaml:"version"`
+	Apps    []RunFileApp `yaml:"apps"`
+
+	// dir is the directory holding the run file. Relative paths in the
+	// template are resolved against it.
+	dir string
+}
+
+// RunFileApp is a single application entry of a run file.
+type RunFileApp struct {
+	AppID          string            `yaml:"appID"`
+	AppDir         string            `yaml:"appDir"`
+	Command        []string          `yaml:"command"`
+	AppPort        int               `yaml:"appPort"`
+	AppProtocol    string            `yaml:"appProtocol"`
+	HTTPPort       int               `yaml:"appHTTPPort"`
+	GRPCPort       int               `yaml:"appGRPCPort"`
+	MetricsPort    int               `yaml:"metricsPort"`
+	ComponentsPath string            `yaml:"componentsPath"`
+	ConfigFile     string            `yaml:"configFilePath"`
+	LogLevel       string            `yaml:"logLevel"`
+	Env            map[string]string `yaml:"env"`
+}
+
+// ParseRunFile reads and validates the run file at the given path.
+func ParseRunFile(path string) (*RunFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var runFile RunFile
+	err = yaml.UnmarshalStrict(b, &runFile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing run file %s: %w", path, err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	runFile.dir = filepath.Dir(absPath)
+
+	err = runFile.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid run file %s: %w", path, err)
+	}
+	return &runFile, nil
+}
+
+func (f *RunFile) validate() error {
+	if f.Version != 0 && f.Version != runFileVersion {
+		return fmt.Errorf("unsupported version %d", f.Version)
+	}
+	if len(f.Apps) == 0 {
+		return fmt.Errorf("no apps defined")
+	}
+
+	appIDs := make(map[string]bool, len(f.Apps))
+	ports := make(map[int]string)
+	for i, app := range f.Apps {
+		if app.AppID == "" {
+			return fmt.Errorf("app at index %d has no appID", i)
+		}
+		if appIDs[app.AppID] {
+			return fmt.Errorf("duplicate appID %s", app.AppID)
+		}
+		appIDs[app.AppID] = true
+
+		for _, port := range []int{app.AppPort, app.HTTPPort, app.GRPCPort, app.MetricsPort} {
+			if port <= 0 {
+				continue
+			}
+			if owner, ok := ports[port]; ok {
+				return fmt.Errorf("port %d is used by both %s and %s", port, owner, app.AppID)
+			}
+			ports[port] = app.AppID
+		}
+	}
+	return nil
+}
+
+// AppIDs returns the IDs of all the applications in the run file.
+func (f *RunFile) AppIDs() []string {
+	appIDs := make([]string, 0, len(f.Apps))
+	for _, app := range f.Apps {
+		appIDs = append(appIDs, app.AppID)
+	}
+	return appIDs
+}
+
+// RunConfigs converts the run file entries to run configurations, applying the
+// same defaults as `appctl run`.
+func (f *RunFile) RunConfigs(placementHostAddr string) []*RunConfig {
+	configs := make([]*RunConfig, 0, len(f.Apps))
+	for _, app := range f.Apps {
+		config := &RunConfig{
+			AppID:              app.AppID,
+			AppPort:            app.AppPort,
+			HTTPPort:           app.HTTPPort,
+			GRPCPort:           app.GRPCPort,
+			MetricsPort:        app.MetricsPort,
+			ConfigFile:         f.resolvePath(app.ConfigFile, DefaultConfigFilePath()),
+			ComponentsPath:     f.resolvePath(app.ComponentsPath, DefaultComponentsDirPath()),
+			Protocol:           app.AppProtocol,
+			LogLevel:           app.LogLevel,
+			Arguments:          app.Command,
+			ProfilePort:        -1,
+			MaxConcurrency:     -1,
+			MaxRequestBodySize: -1,
+			PlacementHostAddr:  placementHostAddr,
+			AppDir:             f.resolvePath(app.AppDir, f.dir),
+		}
+		if config.Protocol == "" {
+			config.Protocol = "http"
+		}
+		if config.LogLevel == "" {
+			config.LogLevel = "info"
+		}
+
+		keys := make([]string, 0, len(app.Env))
+		for k := range app.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			config.Env = append(config.Env, fmt.Sprintf("%s=%s", k, app.Env[k]))
+		}
+
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+func (f *RunFile) resolvePath(path, defaultPath string) string {
+	if path == "" {
+		return defaultPath
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(f.dir, path)
+}
+
+// StopRunFile terminates all the applications started from the run file.
+// Other applications run by the same appctl process are left running.
+func StopRunFile(runFile *RunFile) error {
+	instances, err := readInstances()
+	if err != nil {
+		return err
+	}
+
+	appIDs := make(map[string]bool, len(runFile.Apps))
+	for _, appID := range runFile.AppIDs() {
+		appIDs[appID] = true
+	}
+
+	found := false
+	for _, i := range instances {
+		if !appIDs[i.AppID] {
+			continue
+		}
+		found = true
+		err = stopInstance(i)
+		if err != nil {
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("couldn't find any running app from the run file")
+	}
+	return nil
+}
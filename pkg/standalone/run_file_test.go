@@ -0,0 +1,149 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRunFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "runfile")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "run.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestParseRunFile(t *testing.T) {
+	t.Run("valid run file", func(t *testing.T) {
+		path := writeRunFile(t, `
+version: 1
+apps:
+  - appID: orders
+    appDir: ./orders
+    command: ["node", "app.js"]
+    appPort: 3000
+    appHTTPPort: 3500
+    componentsPath: ./components
+    configFilePath: /etc/app/config.yaml
+    env:
+      B: "2"
+      A: "1"
+  - appID: checkout
+    command: ["python", "app.py"]
+    appProtocol: grpc
+    logLevel: debug
+`)
+		runFile, err := ParseRunFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orders", "checkout"}, runFile.AppIDs())
+
+		dir := filepath.Dir(path)
+		configs := runFile.RunConfigs("localhost")
+		require.Len(t, configs, 2)
+
+		orders := configs[0]
+		assert.Equal(t, "orders", orders.AppID)
+		assert.Equal(t, 3000, orders.AppPort)
+		assert.Equal(t, 3500, orders.HTTPPort)
+		assert.Equal(t, []string{"node", "app.js"}, orders.Arguments)
+		assert.Equal(t, filepath.Join(dir, "orders"), orders.AppDir)
+		assert.Equal(t, filepath.Join(dir, "components"), orders.ComponentsPath)
+		assert.Equal(t, "/etc/app/config.yaml", orders.ConfigFile)
+		assert.Equal(t, []string{"A=1", "B=2"}, orders.Env)
+		assert.Equal(t, "http", orders.Protocol)
+		assert.Equal(t, "info", orders.LogLevel)
+		assert.Equal(t, "localhost", orders.PlacementHostAddr)
+
+		checkout := configs[1]
+		assert.Equal(t, dir, checkout.AppDir)
+		assert.Equal(t, DefaultComponentsDirPath(), checkout.ComponentsPath)
+		assert.Equal(t, DefaultConfigFilePath(), checkout.ConfigFile)
+		assert.Equal(t, "grpc", checkout.Protocol)
+		assert.Equal(t, "debug", checkout.LogLevel)
+		assert.Empty(t, checkout.Env)
+	})
+
+	t.Run("missing app id", func(t *testing.T) {
+		path := writeRunFile(t, `
+apps:
+  - command: ["node", "app.js"]
+`)
+		_, err := ParseRunFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate app id", func(t *testing.T) {
+		path := writeRunFile(t, `
+apps:
+  - appID: orders
+  - appID: orders
+`)
+		_, err := ParseRunFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("conflicting ports", func(t *testing.T) {
+		path := writeRunFile(t, `
+apps:
+  - appID: orders
+    appHTTPPort: 3500
+  - appID: checkout
+    appPort: 3500
+`)
+		_, err := ParseRunFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("no apps", func(t *testing.T) {
+		path := writeRunFile(t, `version: 1`)
+		_, err := ParseRunFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		path := writeRunFile(t, `
+version: 2
+apps:
+  - appID: orders
+`)
+		_, err := ParseRunFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := writeRunFile(t, `
+apps:
+  - appID: orders
+    appPrt: 3000
+`)
+		_, err := ParseRunFile(path)
+		assert.Error(t, err)
+	})
+}
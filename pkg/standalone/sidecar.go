@@ -0,0 +1,126 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/bhojpur/application/pkg/utils"
+)
+
+// alphaAPIVersion is the version of the sidecar APIs which are not stable yet.
+const alphaAPIVersion = "1.0-alpha1"
+
+// sidecarClient sends requests to the HTTP API of a sidecar, reached over a
+// TCP port or over its unix domain socket.
+type sidecarClient struct {
+	httpc http.Client
+	host  string
+}
+
+type sidecarErrorResponse struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+// newSidecarClient returns a client for the sidecar of a running app ID.
+func newSidecarClient(process AppProcess, appID, socket string) (*sidecarClient, error) {
+	list, err := process.List()
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := getAppInstance(list, appID)
+	if err != nil {
+		return nil, err
+	}
+	return newSidecarClientForInstance(instance, socket), nil
+}
+
+// newSidecarClientForInstance returns a client for the sidecar of a listed
+// instance, preferring its unix domain socket when it has one.
+func newSidecarClientForInstance(instance ListOutput, socket string) *sidecarClient {
+	if socket == "" {
+		socket = instance.UnixDomainSocket
+	}
+
+	if socket != "" {
+		client := &sidecarClient{host: "unix"}
+		client.httpc.Transport = &http.Transport{
+			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", utils.GetSocket(socket, instance.AppID, "http"))
+			},
+		}
+		return client
+	}
+	return newSidecarClientForAddress(fmt.Sprintf("127.0.0.1:%v", instance.HTTPPort))
+}
+
+// newSidecarClientForAddress returns a client for the sidecar listening on
+// the given host:port address.
+func newSidecarClientForAddress(address string) *sidecarClient {
+	return &sidecarClient{host: address}
+}
+
+func (c *sidecarClient) do(version, method, path string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	u := fmt.Sprintf("http://%s/v%s/%s", c.host, version, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.httpc.Do(req)
+}
+
+// readSidecarResponse returns the body of a successful response, or the error
+// reported by the sidecar.
+func readSidecarResponse(r *http.Response) ([]byte, error) {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		var errResp sidecarErrorResponse
+		if json.Unmarshal(b, &errResp) == nil && errResp.Message != "" {
+			return nil, fmt.Errorf("%s: %s", errResp.ErrorCode, errResp.Message)
+		}
+		return nil, fmt.Errorf("unexpected status code %d", r.StatusCode)
+	}
+	return b, nil
+}
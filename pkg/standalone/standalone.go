@@ -54,6 +54,7 @@ const (
 	appDefaultHost             = "localhost"
 	pubSubYamlFileName         = "pubsub.yaml"
 	stateStoreYamlFileName     = "statestore.yaml"
+	lockStoreYamlFileName      = "lockstore.yaml"
 	redisDockerImageName       = "redis"
 	zipkinDockerImageName      = "openzipkin/zipkin"
 
@@ -114,7 +115,8 @@ func isBinaryInstallationRequired(binaryFilePrefix, installDir string) (bool, er
 }
 
 // Init installs Bhojpur Application on a local machine using the supplied runtimeVersion.
-func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMode bool, imageRepositoryURL string) error {
+// With mtls, a local trust bundle is generated and sentry is started to issue workload certificates.
+func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMode bool, imageRepositoryURL string, mtls bool) error {
 	if !slimMode {
 		dockerInstalled := utils.IsDockerInstalled()
 		if !dockerInstalled {
@@ -169,6 +171,11 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 		initSteps = append(initSteps, createComponentsAndConfiguration, runPlacementService, runRedis, runZipkin)
 	}
 
+	if mtls {
+		// Install the sentry binary to issue workload certificates locally
+		wg.Add(1)
+	}
+
 	// Init other configurations, containers
 	wg.Add(len(initSteps))
 
@@ -195,6 +202,10 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 		go installBinary(&wg, errorChan, appBinDir, runtimeVersion, placementServiceFilePrefix, dockerNetwork, cli_ver.AppGitHubRepo)
 	}
 
+	if mtls {
+		go installBinary(&wg, errorChan, appBinDir, runtimeVersion, sentryServiceFilePrefix, dockerNetwork, cli_ver.AppGitHubRepo)
+	}
+
 	for _, step := range initSteps {
 		// Run init on the configurations and containers
 		go step(&wg, errorChan, appBinDir, runtimeVersion, dockerNetwork, imageRepositoryURL)
@@ -211,6 +222,13 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 		}
 	}
 
+	if mtls {
+		err = setupLocalMTLS(appBinDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	stopSpinning(utils.Success)
 
 	msg = "Downloaded Bhojpur Application binaries and completed components set up."
@@ -233,6 +251,9 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 		}
 		utils.InfoStatusEvent(os.Stdout, "Use `docker ps` to check running containers.")
 	}
+	if mtls {
+		utils.InfoStatusEvent(os.Stdout, "%s is running on %s with the trust bundle in %s.", sentryServiceFilePrefix, sentryDefaultAddress, DefaultCertsDirPath())
+	}
 	return nil
 }
 
@@ -588,6 +609,11 @@ func createComponentsAndConfiguration(wg *sync.WaitGroup, errorChan chan<- error
 		errorChan <- fmt.Errorf("error creating redis statestore component file: %s", err)
 		return
 	}
+	err = createInMemoryLockStore(componentsDir)
+	if err != nil {
+		errorChan <- fmt.Errorf("error creating in-memory lockstore component file: %s", err)
+		return
+	}
 	err = createDefaultConfiguration(zipkinHost, DefaultConfigFilePath())
 	if err != nil {
 		errorChan <- fmt.Errorf("error creating default configuration file: %s", err)
@@ -902,6 +928,27 @@ func createRedisPubSub(redisHost string, componentsPath string) error {
 	return err
 }
 
+func createInMemoryLockStore(componentsPath string) error {
+	lockStore := component{
+		APIVersion: "bhojpur.net/v1alpha1",
+		Kind:       "Component",
+	}
+
+	lockStore.Metadata.Name = "lockstore"
+	lockStore.Spec.Type = "lock.in-memory"
+	lockStore.Spec.Version = "v1"
+
+	b, err := yaml.Marshal(&lockStore)
+	if err != nil {
+		return err
+	}
+
+	filePath := path_filepath.Join(componentsPath, lockStoreYamlFileName)
+	err = checkAndOverWriteFile(filePath, b)
+
+	return err
+}
+
 func createDefaultConfiguration(zipkinHost, filePath string) error {
 	defaultConfig := configuration{
 		APIVersion: "bhojpur.net/v1alpha1",
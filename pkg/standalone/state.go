@@ -0,0 +1,291 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apisvr "github.com/bhojpur/api/pkg/core"
+)
+
+const (
+	stateMetadataPrefix = "metadata."
+	stateETagHeader     = "ETag"
+)
+
+// StateOptions holds the optional parameters of the state commands.
+type StateOptions struct {
+	ETag        string
+	Consistency string
+	Concurrency string
+	Metadata    map[string]string
+}
+
+// StateItem is a single entry read from a state store.
+type StateItem struct {
+	Key      string            `json:"key"                yaml:"key"`
+	Value    interface{}       `json:"value,omitempty"    yaml:"value,omitempty"`
+	ETag     string            `json:"etag,omitempty"     yaml:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Error    string            `json:"error,omitempty"    yaml:"error,omitempty"`
+}
+
+// StateQueryResult is the result of a state store query.
+type StateQueryResult struct {
+	Results []StateItem `json:"results"         yaml:"results"`
+	Token   string      `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+type stateSetOptions struct {
+	Concurrency string `json:"concurrency,omitempty"`
+	Consistency string `json:"consistency,omitempty"`
+}
+
+type stateSetRequest struct {
+	Key      string            `json:"key"`
+	Value    interface{}       `json:"value"`
+	ETag     *string           `json:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Options  *stateSetOptions  `json:"options,omitempty"`
+}
+
+type stateBulkGetRequest struct {
+	Keys     []string          `json:"keys"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type stateResponseItem struct {
+	Key      string            `json:"key"`
+	Data     json.RawMessage   `json:"data,omitempty"`
+	ETag     *string           `json:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type stateQueryResponse struct {
+	Results []stateResponseItem `json:"results"`
+	Token   string              `json:"token,omitempty"`
+}
+
+// GetState reads a key from a state store of the given app. A nil item is
+// returned when the key does not exist.
+func (s *Standalone) GetState(appID, storeName, key string, opts StateOptions, socket string) (*StateItem, error) {
+	query := stateQueryParams(opts)
+	if opts.Consistency != "" {
+		query.Set("consistency", opts.Consistency)
+	}
+
+	r, err := s.doStateRequest(appID, socket, http.MethodGet, fmt.Sprintf("state/%s/%s", storeName, url.PathEscape(key)), query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	b, err := readSidecarResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &StateItem{
+		Key:   key,
+		Value: decodeStateValue(b),
+		ETag:  r.Header.Get(stateETagHeader),
+	}
+	for k := range r.Header {
+		if strings.HasPrefix(strings.ToLower(k), stateMetadataPrefix) {
+			if item.Metadata == nil {
+				item.Metadata = map[string]string{}
+			}
+			item.Metadata[k[len(stateMetadataPrefix):]] = r.Header.Get(k)
+		}
+	}
+	return item, nil
+}
+
+// SaveState writes a key to a state store of the given app. Values which are
+// valid JSON are stored as such, anything else is stored as a string.
+func (s *Standalone) SaveState(appID, storeName, key string, value []byte, opts StateOptions, socket string) error {
+	req := stateSetRequest{
+		Key:      key,
+		Value:    decodeStateValue(value),
+		Metadata: opts.Metadata,
+	}
+	if opts.ETag != "" {
+		req.ETag = &opts.ETag
+	}
+	if opts.Concurrency != "" || opts.Consistency != "" {
+		req.Options = &stateSetOptions{
+			Concurrency: opts.Concurrency,
+			Consistency: opts.Consistency,
+		}
+	}
+
+	body, err := json.Marshal([]stateSetRequest{req})
+	if err != nil {
+		return err
+	}
+
+	r, err := s.doStateRequest(appID, socket, http.MethodPost, fmt.Sprintf("state/%s", storeName), nil, nil, body)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	_, err = readSidecarResponse(r)
+	return err
+}
+
+// DeleteState deletes a key from a state store of the given app.
+func (s *Standalone) DeleteState(appID, storeName, key string, opts StateOptions, socket string) error {
+	query := stateQueryParams(opts)
+	if opts.Consistency != "" {
+		query.Set("consistency", opts.Consistency)
+	}
+	if opts.Concurrency != "" {
+		query.Set("concurrency", opts.Concurrency)
+	}
+	header := http.Header{}
+	if opts.ETag != "" {
+		header.Set("If-Match", opts.ETag)
+	}
+
+	r, err := s.doStateRequest(appID, socket, http.MethodDelete, fmt.Sprintf("state/%s/%s", storeName, url.PathEscape(key)), query, header, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	_, err = readSidecarResponse(r)
+	return err
+}
+
+// BulkGetState reads several keys from a state store of the given app.
+func (s *Standalone) BulkGetState(appID, storeName string, keys []string, opts StateOptions, socket string) ([]StateItem, error) {
+	body, err := json.Marshal(stateBulkGetRequest{
+		Keys:     keys,
+		Metadata: opts.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.doStateRequest(appID, socket, http.MethodPost, fmt.Sprintf("state/%s/bulk", storeName), nil, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := readSidecarResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []stateResponseItem
+	err = json.Unmarshal(b, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bulk get response: %w", err)
+	}
+	return toStateItems(resp), nil
+}
+
+// QueryState runs a query against a state store of the given app.
+func (s *Standalone) QueryState(appID, storeName string, query []byte, opts StateOptions, socket string) (*StateQueryResult, error) {
+	r, err := s.doStateRequestWithVersion(appID, socket, alphaAPIVersion, http.MethodPost, fmt.Sprintf("state/%s/query", storeName), stateQueryParams(opts), nil, query)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := readSidecarResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StateQueryResult{Results: []StateItem{}}
+	if len(b) == 0 {
+		return result, nil
+	}
+
+	var resp stateQueryResponse
+	err = json.Unmarshal(b, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing query response: %w", err)
+	}
+	result.Results = toStateItems(resp.Results)
+	result.Token = resp.Token
+	return result, nil
+}
+
+func (s *Standalone) doStateRequest(appID, socket, method, path string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	return s.doStateRequestWithVersion(appID, socket, apisvr.RuntimeAPIVersion, method, path, query, header, body)
+}
+
+func (s *Standalone) doStateRequestWithVersion(appID, socket, version, method, path string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	client, err := newSidecarClient(s.process, appID, socket)
+	if err != nil {
+		return nil, err
+	}
+	return client.do(version, method, path, query, header, body)
+}
+
+func stateQueryParams(opts StateOptions) url.Values {
+	query := url.Values{}
+	for k, v := range opts.Metadata {
+		query.Set(stateMetadataPrefix+k, v)
+	}
+	return query
+}
+
+func toStateItems(resp []stateResponseItem) []StateItem {
+	items := make([]StateItem, 0, len(resp))
+	for _, r := range resp {
+		item := StateItem{
+			Key:      r.Key,
+			Metadata: r.Metadata,
+			Error:    r.Error,
+		}
+		if len(r.Data) > 0 {
+			item.Value = decodeStateValue(r.Data)
+		}
+		if r.ETag != nil {
+			item.ETag = *r.ETag
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// decodeStateValue returns the JSON document held by b, or b as a string when
+// it is not valid JSON.
+func decodeStateValue(b []byte) interface{} {
+	var v interface{}
+	if json.Unmarshal(b, &v) == nil {
+		return v
+	}
+	return string(b)
+}
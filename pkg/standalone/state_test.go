@@ -0,0 +1,179 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStateTestClient(t *testing.T, handler http.HandlerFunc) *Standalone {
+	ts, port := getTestServerFunc(handler)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	return &Standalone{
+		process: &mockAppProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+}
+
+func TestGetState(t *testing.T) {
+	t.Run("existing key", func(t *testing.T) {
+		client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/v1.0/state/store/order-1", r.URL.Path)
+			assert.Equal(t, "strong", r.URL.Query().Get("consistency"))
+			assert.Equal(t, "30", r.URL.Query().Get("metadata.ttlInSeconds"))
+			w.Header().Set("ETag", "3")
+			w.Header().Set("metadata.partition", "p1")
+			w.Write([]byte(`{"id":1}`))
+		})
+
+		item, err := client.GetState("testapp", "store", "order-1", StateOptions{
+			Consistency: "strong",
+			Metadata:    map[string]string{"ttlInSeconds": "30"},
+		}, "")
+		require.NoError(t, err)
+		require.NotNil(t, item)
+		assert.Equal(t, "order-1", item.Key)
+		assert.Equal(t, "3", item.ETag)
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, item.Value)
+		assert.Equal(t, map[string]string{"partition": "p1"}, item.Metadata)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		item, err := client.GetState("testapp", "store", "order-1", StateOptions{}, "")
+		require.NoError(t, err)
+		assert.Nil(t, item)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":"ERR_STATE_STORE_NOT_FOUND","message":"state store store is not found"}`))
+		})
+
+		_, err := client.GetState("testapp", "store", "order-1", StateOptions{}, "")
+		assert.EqualError(t, err, "ERR_STATE_STORE_NOT_FOUND: state store store is not found")
+	})
+
+	t.Run("app not found", func(t *testing.T) {
+		client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+		_, err := client.GetState("otherapp", "store", "order-1", StateOptions{}, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestSaveState(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		value    string
+		expected interface{}
+	}{
+		{name: "json value", value: `{"id":1}`, expected: map[string]interface{}{"id": float64(1)}},
+		{name: "string value", value: `hello world`, expected: "hello world"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v1.0/state/store", r.URL.Path)
+
+				var reqs []map[string]interface{}
+				b, _ := ioutil.ReadAll(r.Body)
+				require.NoError(t, json.Unmarshal(b, &reqs))
+				require.Len(t, reqs, 1)
+				assert.Equal(t, "order-1", reqs[0]["key"])
+				assert.Equal(t, tc.expected, reqs[0]["value"])
+				assert.Equal(t, "3", reqs[0]["etag"])
+				assert.Equal(t, map[string]interface{}{"concurrency": "first-write"}, reqs[0]["options"])
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			err := client.SaveState("testapp", "store", "order-1", []byte(tc.value), StateOptions{
+				ETag:        "3",
+				Concurrency: "first-write",
+			}, "")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteState(t *testing.T) {
+	client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/v1.0/state/store/order-1", r.URL.Path)
+		assert.Equal(t, "3", r.Header.Get("If-Match"))
+		assert.Equal(t, "first-write", r.URL.Query().Get("concurrency"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteState("testapp", "store", "order-1", StateOptions{
+		ETag:        "3",
+		Concurrency: "first-write",
+	}, "")
+	assert.NoError(t, err)
+}
+
+func TestBulkGetState(t *testing.T) {
+	client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0/state/store/bulk", r.URL.Path)
+
+		var req stateBulkGetRequest
+		b, _ := ioutil.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &req))
+		assert.Equal(t, []string{"a", "b"}, req.Keys)
+		w.Write([]byte(`[{"key":"a","data":"x","etag":"1"},{"key":"b","error":"boom"}]`))
+	})
+
+	items, err := client.BulkGetState("testapp", "store", []string{"a", "b"}, StateOptions{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []StateItem{
+		{Key: "a", Value: "x", ETag: "1"},
+		{Key: "b", Error: "boom"},
+	}, items)
+}
+
+func TestQueryState(t *testing.T) {
+	client := newStateTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0-alpha1/state/store/query", r.URL.Path)
+
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"filter":{"EQ":{"state":"CA"}}}`, string(b))
+		w.Write([]byte(`{"results":[{"key":"a","data":{"state":"CA"}}],"token":"2"}`))
+	})
+
+	result, err := client.QueryState("testapp", "store", []byte(`{"filter":{"EQ":{"state":"CA"}}}`), StateOptions{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2", result.Token)
+	assert.Equal(t, []StateItem{{Key: "a", Value: map[string]interface{}{"state": "CA"}}}, result.Results)
+}
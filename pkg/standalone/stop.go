@@ -31,20 +31,27 @@ import (
 
 // Stop terminates the Bhojpur Application process.
 func Stop(appID string) error {
-	apps, err := List()
+	instances, err := readInstances()
 	if err != nil {
 		return err
 	}
 
-	for _, a := range apps {
-		if a.AppID == appID {
-			pid := fmt.Sprintf("%v", a.PID)
-
-			_, err := utils.RunCmdAndWait("kill", pid)
-
-			return err
+	for _, i := range instances {
+		if i.AppID == appID {
+			return stopInstance(i)
 		}
 	}
 
 	return fmt.Errorf("couldn't find app id %s", appID)
 }
+
+// stopCLI signals the appctl process that owns one or more sidecars to shut down.
+func stopCLI(pid int) error {
+	return stopProcess(pid)
+}
+
+// stopProcess signals a sidecar or an application process to shut down.
+func stopProcess(pid int) error {
+	_, err := utils.RunCmdAndWait("kill", fmt.Sprintf("%v", pid))
+	return err
+}
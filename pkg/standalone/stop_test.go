@@ -0,0 +1,71 @@
+//go:build !windows
+// +build !windows
+
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startSleep(t *testing.T) *exec.Cmd {
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() { cmd.Process.Kill() })
+	return cmd
+}
+
+func TestStop(t *testing.T) {
+	setupRegistry(t)
+
+	// Both apps are owned by the test process, which acts as a run file cli.
+	orders := startSleep(t)
+	payments := startSleep(t)
+	for _, i := range []*instance{
+		{AppID: "orders", CLIPID: os.Getpid(), SidecarPID: orders.Process.Pid, Created: time.Now()},
+		{AppID: "payments", CLIPID: os.Getpid(), SidecarPID: payments.Process.Pid, Created: time.Now()},
+	} {
+		require.NoError(t, writeInstance(i))
+	}
+
+	require.NoError(t, Stop("orders"))
+	assert.Error(t, orders.Wait())
+
+	exited := make(chan struct{})
+	go func() {
+		payments.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		assert.Fail(t, "the other app of the cli was stopped")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Error(t, Stop("unknown"))
+}
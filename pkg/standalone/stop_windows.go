@@ -22,6 +22,7 @@ package standalone
 
 import (
 	"fmt"
+	"os"
 	"syscall"
 
 	"golang.org/x/sys/windows"
@@ -29,23 +30,36 @@ import (
 
 // Stop terminates the Bhojpur Application process.
 func Stop(appID string) error {
-	apps, err := List()
+	instances, err := readInstances()
 	if err != nil {
 		return err
 	}
 
-	for _, a := range apps {
-		if a.AppID == appID {
-			eventName, _ := syscall.UTF16FromString(fmt.Sprintf("app_cli_%v", a.PID))
-			eventHandle, err := windows.OpenEvent(windows.EVENT_MODIFY_STATE, false, &eventName[0])
-			if err != nil {
-				return err
-			}
-
-			err = windows.SetEvent(eventHandle)
-			return err
+	for _, i := range instances {
+		if i.AppID == appID {
+			return stopInstance(i)
 		}
 	}
 
 	return fmt.Errorf("couldn't find app id %s", appID)
 }
+
+// stopCLI signals the appctl process that owns one or more sidecars to shut down.
+func stopCLI(pid int) error {
+	eventName, _ := syscall.UTF16FromString(fmt.Sprintf("app_cli_%v", pid))
+	eventHandle, err := windows.OpenEvent(windows.EVENT_MODIFY_STATE, false, &eventName[0])
+	if err != nil {
+		return err
+	}
+
+	return windows.SetEvent(eventHandle)
+}
+
+// stopProcess terminates a sidecar or an application process.
+func stopProcess(pid int) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
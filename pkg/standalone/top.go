@@ -0,0 +1,422 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	apisvr "github.com/bhojpur/api/pkg/core"
+)
+
+const (
+	httpResponseCountMetric = "app_http_server_response_count"
+	httpLatencyMetric       = "app_http_server_latency"
+	grpcCompletedRPCsMetric = "app_grpc_io_server_completed_rpcs"
+	grpcLatencyMetric       = "app_grpc_io_server_server_latency"
+
+	topRequestTimeout = 2 * time.Second
+)
+
+// RegisteredComponent is a component loaded by a sidecar.
+type RegisteredComponent struct {
+	Name    string `csv:"NAME"    json:"name"    yaml:"name"`
+	Type    string `csv:"TYPE"    json:"type"    yaml:"type"`
+	Version string `csv:"VERSION" json:"version" yaml:"version"`
+}
+
+// EndpointTop holds the live traffic of an HTTP route or a gRPC method served by
+// a sidecar. Latencies are in milliseconds.
+type EndpointTop struct {
+	Name        string  `csv:"ENDPOINT" json:"name"        yaml:"name"`
+	RequestRate float64 `csv:"REQ/S"    json:"requestRate" yaml:"requestRate"`
+	ErrorRate   float64 `csv:"ERR/S"    json:"errorRate"   yaml:"errorRate"`
+	P50         float64 `csv:"P50 (MS)" json:"p50"         yaml:"p50"`
+	P90         float64 `csv:"P90 (MS)" json:"p90"         yaml:"p90"`
+	P99         float64 `csv:"P99 (MS)" json:"p99"         yaml:"p99"`
+}
+
+// AppTop is the live view of a standalone app shown by `appctl top`. Rates are
+// computed over the interval since the previous refresh, and percentiles over
+// the requests completed in that interval.
+type AppTop struct {
+	App          ListOutput
+	RequestRate  float64
+	ErrorRate    float64
+	P50          float64
+	P90          float64
+	P99          float64
+	Endpoints    []EndpointTop
+	ActiveActors []ActiveActors
+	Components   []RegisteredComponent
+	// Err is set when the metrics or the metadata of the sidecar could not be read.
+	Err error
+}
+
+// Actors returns the total number of active actors hosted by the app.
+func (a *AppTop) Actors() int {
+	count := 0
+	for _, actors := range a.ActiveActors {
+		count += actors.Count
+	}
+	return count
+}
+
+// histogram is a cumulative latency histogram read from the metrics endpoint.
+type histogram struct {
+	bounds []float64
+	counts []float64
+}
+
+// endpointMetrics accumulates the counters of an HTTP route or a gRPC method.
+type endpointMetrics struct {
+	requests float64
+	errors   float64
+	latency  histogram
+}
+
+// appSnapshot holds the metrics and metadata read from a sidecar at one point in time.
+type appSnapshot struct {
+	time       time.Time
+	endpoints  map[string]*endpointMetrics
+	actors     []ActiveActors
+	components []RegisteredComponent
+	err        error
+}
+
+type sidecarMetadata struct {
+	Actors     []ActiveActors        `json:"actors"`
+	Components []RegisteredComponent `json:"components"`
+}
+
+// Top samples the sidecars of the running apps and computes their live stats.
+type Top struct {
+	process AppProcess
+	httpc   http.Client
+	prev    map[string]*appSnapshot
+}
+
+// NewTop returns a sampler for the apps started by `appctl run`.
+func NewTop() *Top {
+	return newTop(&appProcess{})
+}
+
+func newTop(process AppProcess) *Top {
+	return &Top{
+		process: process,
+		httpc:   http.Client{Timeout: topRequestTimeout},
+		prev:    map[string]*appSnapshot{},
+	}
+}
+
+// Refresh samples every running app and returns their stats sorted by app ID.
+func (t *Top) Refresh() ([]AppTop, error) {
+	apps, err := t.process.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].AppID < apps[j].AppID })
+
+	snapshots := make([]*appSnapshot, len(apps))
+	var wg sync.WaitGroup
+	for i := range apps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			snapshots[i] = t.sample(apps[i])
+		}(i)
+	}
+	wg.Wait()
+
+	prev := t.prev
+	t.prev = make(map[string]*appSnapshot, len(apps))
+	tops := make([]AppTop, 0, len(apps))
+	for i, app := range apps {
+		key := fmt.Sprintf("%s_%d", app.AppID, app.PID)
+		tops = append(tops, newAppTop(app, prev[key], snapshots[i]))
+		t.prev[key] = snapshots[i]
+	}
+	return tops, nil
+}
+
+func (t *Top) sample(app ListOutput) *appSnapshot {
+	s := &appSnapshot{
+		time:      time.Now(),
+		endpoints: map[string]*endpointMetrics{},
+	}
+
+	r, err := t.httpc.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", app.MetricsPort))
+	if err != nil {
+		s.err = fmt.Errorf("error reading metrics: %w", err)
+		return s
+	}
+	s.endpoints, err = parseEndpointMetrics(r.Body)
+	r.Body.Close()
+	if err != nil {
+		s.err = fmt.Errorf("error parsing metrics: %w", err)
+		return s
+	}
+
+	sidecar := newSidecarClientForInstance(app, "")
+	sidecar.httpc.Timeout = topRequestTimeout
+	r, err = sidecar.do(apisvr.RuntimeAPIVersion, http.MethodGet, "metadata", nil, nil, nil)
+	if err != nil {
+		s.err = fmt.Errorf("error reading metadata: %w", err)
+		return s
+	}
+	defer r.Body.Close()
+	b, err := readSidecarResponse(r)
+	if err != nil {
+		s.err = fmt.Errorf("error reading metadata: %w", err)
+		return s
+	}
+
+	var metadata sidecarMetadata
+	err = json.Unmarshal(b, &metadata)
+	if err != nil {
+		s.err = fmt.Errorf("error parsing metadata response: %w", err)
+		return s
+	}
+	s.actors = metadata.Actors
+	s.components = metadata.Components
+	return s
+}
+
+// parseEndpointMetrics reads the server side request counts and latencies
+// recorded by pkg/diagnostics, keyed by "METHOD path" for HTTP and by the full
+// method name for gRPC. HTTP statuses from 400 and gRPC statuses other than OK
+// are counted as errors.
+func parseEndpointMetrics(r io.Reader) (map[string]*endpointMetrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := map[string]*endpointMetrics{}
+	endpoint := func(name string) *endpointMetrics {
+		e, ok := endpoints[name]
+		if !ok {
+			e = &endpointMetrics{}
+			endpoints[name] = e
+		}
+		return e
+	}
+
+	for _, m := range families[httpResponseCountMetric].GetMetric() {
+		labels := metricLabels(m)
+		e := endpoint(labels["method"] + " " + labels["path"])
+		value := metricValue(m)
+		e.requests += value
+		if status, _ := strconv.Atoi(labels["status"]); status >= 400 {
+			e.errors += value
+		}
+	}
+	for _, m := range families[httpLatencyMetric].GetMetric() {
+		labels := metricLabels(m)
+		endpoint(labels["method"] + " " + labels["path"]).latency.add(m.GetHistogram())
+	}
+
+	for _, m := range families[grpcCompletedRPCsMetric].GetMetric() {
+		labels := metricLabels(m)
+		e := endpoint(labels["grpc_server_method"])
+		value := metricValue(m)
+		e.requests += value
+		if labels["grpc_server_status"] != "OK" {
+			e.errors += value
+		}
+	}
+	for _, m := range families[grpcLatencyMetric].GetMetric() {
+		endpoint(metricLabels(m)["grpc_server_method"]).latency.add(m.GetHistogram())
+	}
+
+	return endpoints, nil
+}
+
+func metricLabels(m *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(m.GetLabel()))
+	for _, l := range m.GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	return labels
+}
+
+func metricValue(m *dto.Metric) float64 {
+	switch {
+	case m.Counter != nil:
+		return m.GetCounter().GetValue()
+	case m.Gauge != nil:
+		return m.GetGauge().GetValue()
+	default:
+		return m.GetUntyped().GetValue()
+	}
+}
+
+// add merges the buckets of a histogram with the same bounds, as recorded for
+// every status code of an endpoint.
+func (h *histogram) add(hist *dto.Histogram) {
+	buckets := hist.GetBucket()
+	if h.bounds == nil {
+		h.bounds = make([]float64, len(buckets))
+		h.counts = make([]float64, len(buckets))
+		for i, b := range buckets {
+			h.bounds[i] = b.GetUpperBound()
+		}
+	}
+	if len(buckets) != len(h.bounds) {
+		return
+	}
+	for i, b := range buckets {
+		h.counts[i] += float64(b.GetCumulativeCount())
+	}
+}
+
+// merge adds the counts of another histogram with the same bounds.
+func (h *histogram) merge(o histogram) {
+	if o.bounds == nil {
+		return
+	}
+	if h.bounds == nil {
+		h.bounds = o.bounds
+		h.counts = make([]float64, len(o.counts))
+	}
+	if len(o.counts) != len(h.counts) {
+		return
+	}
+	for i := range o.counts {
+		h.counts[i] += o.counts[i]
+	}
+}
+
+// since returns the histogram of the observations recorded after prev. A
+// sidecar restart resets the counters, in which case h is returned unchanged.
+func (h histogram) since(prev histogram) histogram {
+	if len(prev.counts) != len(h.counts) {
+		return h
+	}
+	delta := histogram{bounds: h.bounds, counts: make([]float64, len(h.counts))}
+	for i := range h.counts {
+		delta.counts[i] = h.counts[i] - prev.counts[i]
+		if delta.counts[i] < 0 {
+			return h
+		}
+	}
+	return delta
+}
+
+// quantile estimates the q-quantile by linear interpolation within the bucket
+// holding it, as the Prometheus histogram_quantile function does. Observations
+// above the largest bound are reported at that bound.
+func (h histogram) quantile(q float64) float64 {
+	if len(h.counts) == 0 {
+		return 0
+	}
+	total := h.counts[len(h.counts)-1]
+	if total == 0 {
+		return 0
+	}
+
+	rank := q * total
+	lowerBound, lowerCount := 0.0, 0.0
+	for i, bound := range h.bounds {
+		if h.counts[i] >= rank {
+			if math.IsInf(bound, 1) {
+				return lowerBound
+			}
+			inBucket := h.counts[i] - lowerCount
+			if inBucket == 0 {
+				return bound
+			}
+			return lowerBound + (bound-lowerBound)*(rank-lowerCount)/inBucket
+		}
+		lowerBound, lowerCount = bound, h.counts[i]
+	}
+	return lowerBound
+}
+
+func newAppTop(app ListOutput, prev, cur *appSnapshot) AppTop {
+	top := AppTop{
+		App:          app,
+		ActiveActors: cur.actors,
+		Components:   cur.components,
+		Err:          cur.err,
+	}
+
+	elapsed := 0.0
+	if prev != nil && prev.err == nil {
+		elapsed = cur.time.Sub(prev.time).Seconds()
+	}
+
+	var total histogram
+	for name, e := range cur.endpoints {
+		requests, errors := e.requests, e.errors
+		latency := e.latency
+		if p, ok := prev.endpoint(name); ok && elapsed > 0 && requests >= p.requests {
+			requests -= p.requests
+			errors -= p.errors
+			latency = latency.since(p.latency)
+		}
+
+		et := EndpointTop{
+			Name: name,
+			P50:  latency.quantile(0.5),
+			P90:  latency.quantile(0.9),
+			P99:  latency.quantile(0.99),
+		}
+		if elapsed > 0 {
+			et.RequestRate = requests / elapsed
+			et.ErrorRate = errors / elapsed
+		}
+		top.RequestRate += et.RequestRate
+		top.ErrorRate += et.ErrorRate
+		total.merge(latency)
+		top.Endpoints = append(top.Endpoints, et)
+	}
+
+	top.P50 = total.quantile(0.5)
+	top.P90 = total.quantile(0.9)
+	top.P99 = total.quantile(0.99)
+	sort.Slice(top.Endpoints, func(i, j int) bool {
+		if top.Endpoints[i].RequestRate != top.Endpoints[j].RequestRate {
+			return top.Endpoints[i].RequestRate > top.Endpoints[j].RequestRate
+		}
+		return top.Endpoints[i].Name < top.Endpoints[j].Name
+	})
+	return top
+}
+
+func (s *appSnapshot) endpoint(name string) (*endpointMetrics, bool) {
+	if s == nil || s.err != nil {
+		return nil, false
+	}
+	e, ok := s.endpoints[name]
+	return e, ok
+}
@@ -0,0 +1,168 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP app_http_server_response_count The number of HTTP responses
+# TYPE app_http_server_response_count counter
+app_http_server_response_count{app_id="app1",method="GET",path="/v1.0/state/store",status="200"} 8
+app_http_server_response_count{app_id="app1",method="GET",path="/v1.0/state/store",status="500"} 2
+# HELP app_http_server_latency HTTP request end to end latency in server.
+# TYPE app_http_server_latency histogram
+app_http_server_latency_bucket{app_id="app1",method="GET",path="/v1.0/state/store",status="200",le="1"} 4
+app_http_server_latency_bucket{app_id="app1",method="GET",path="/v1.0/state/store",status="200",le="2"} 8
+app_http_server_latency_bucket{app_id="app1",method="GET",path="/v1.0/state/store",status="200",le="+Inf"} 8
+app_http_server_latency_sum{app_id="app1",method="GET",path="/v1.0/state/store",status="200"} 10
+app_http_server_latency_count{app_id="app1",method="GET",path="/v1.0/state/store",status="200"} 8
+app_http_server_latency_bucket{app_id="app1",method="GET",path="/v1.0/state/store",status="500",le="1"} 0
+app_http_server_latency_bucket{app_id="app1",method="GET",path="/v1.0/state/store",status="500",le="2"} 0
+app_http_server_latency_bucket{app_id="app1",method="GET",path="/v1.0/state/store",status="500",le="+Inf"} 2
+app_http_server_latency_sum{app_id="app1",method="GET",path="/v1.0/state/store",status="500"} 9
+app_http_server_latency_count{app_id="app1",method="GET",path="/v1.0/state/store",status="500"} 2
+# HELP app_grpc_io_server_completed_rpcs Distribution of bytes sent per RPC, by method.
+# TYPE app_grpc_io_server_completed_rpcs counter
+app_grpc_io_server_completed_rpcs{app_id="app1",grpc_server_method="/app.proto.runtime.v1.App/GetState",grpc_server_status="OK"} 5
+app_grpc_io_server_completed_rpcs{app_id="app1",grpc_server_method="/app.proto.runtime.v1.App/GetState",grpc_server_status="NOT_FOUND"} 1
+`
+
+func TestParseEndpointMetrics(t *testing.T) {
+	endpoints, err := parseEndpointMetrics(strings.NewReader(testMetrics))
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	state := endpoints["GET /v1.0/state/store"]
+	assert.Equal(t, 10.0, state.requests)
+	assert.Equal(t, 2.0, state.errors)
+	assert.Equal(t, []float64{4, 8, 10}, state.latency.counts)
+
+	grpc := endpoints["/app.proto.runtime.v1.App/GetState"]
+	assert.Equal(t, 6.0, grpc.requests)
+	assert.Equal(t, 1.0, grpc.errors)
+	assert.Nil(t, grpc.latency.counts)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := histogram{bounds: []float64{1, 2, 4}, counts: []float64{4, 8, 10}}
+
+	assert.Equal(t, 1.25, h.quantile(0.5))
+	assert.Equal(t, 3.0, h.quantile(0.9))
+	assert.Equal(t, 0.0, histogram{}.quantile(0.5))
+
+	t.Run("observations above the largest bound", func(t *testing.T) {
+		h := histogram{bounds: []float64{1, 2, math.Inf(1)}, counts: []float64{4, 8, 10}}
+		assert.Equal(t, 2.0, h.quantile(0.99))
+	})
+
+	t.Run("since previous sample", func(t *testing.T) {
+		delta := h.since(histogram{bounds: h.bounds, counts: []float64{4, 4, 4}})
+		assert.Equal(t, []float64{0, 4, 6}, delta.counts)
+	})
+
+	t.Run("counters reset", func(t *testing.T) {
+		delta := h.since(histogram{bounds: h.bounds, counts: []float64{5, 9, 12}})
+		assert.Equal(t, h.counts, delta.counts)
+	})
+}
+
+func TestNewAppTop(t *testing.T) {
+	now := time.Now()
+	prev := &appSnapshot{
+		time: now.Add(-2 * time.Second),
+		endpoints: map[string]*endpointMetrics{
+			"GET /v1.0/state/store": {requests: 4, errors: 0, latency: histogram{bounds: []float64{1, 2}, counts: []float64{4, 4}}},
+		},
+	}
+	cur := &appSnapshot{
+		time: now,
+		endpoints: map[string]*endpointMetrics{
+			"GET /v1.0/state/store":            {requests: 10, errors: 2, latency: histogram{bounds: []float64{1, 2}, counts: []float64{4, 10}}},
+			"POST /v1.0/publish/pubsub/orders": {requests: 2},
+		},
+		actors: []ActiveActors{{Type: "Order", Count: 2}, {Type: "Cart", Count: 1}},
+	}
+
+	top := newAppTop(ListOutput{AppID: "app1"}, prev, cur)
+	assert.Equal(t, 4.0, top.RequestRate)
+	assert.Equal(t, 1.0, top.ErrorRate)
+	assert.Equal(t, 1.5, top.P50)
+	assert.Equal(t, 3, top.Actors())
+	require.Len(t, top.Endpoints, 2)
+	assert.Equal(t, "GET /v1.0/state/store", top.Endpoints[0].Name)
+	assert.Equal(t, 3.0, top.Endpoints[0].RequestRate)
+	assert.Equal(t, 1.0, top.Endpoints[0].ErrorRate)
+	assert.InDelta(t, 1.99, top.Endpoints[0].P99, 1e-9)
+	assert.Equal(t, 1.0, top.Endpoints[1].RequestRate)
+
+	t.Run("first sample has no rates", func(t *testing.T) {
+		top := newAppTop(ListOutput{AppID: "app1"}, nil, cur)
+		assert.Equal(t, 0.0, top.RequestRate)
+		assert.InDelta(t, 1.1667, top.P50, 1e-3)
+	})
+}
+
+func TestTopRefresh(t *testing.T) {
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics":
+			w.Write([]byte(testMetrics))
+		case "/v1.0/metadata":
+			w.Write([]byte(`{"id":"app1","actors":[{"type":"Order","count":2}],"components":[{"name":"store","type":"state.redis","version":"v1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	top := newTop(&mockAppProcess{
+		Lo: []ListOutput{
+			{AppID: "app2", HTTPPort: 1, MetricsPort: 1},
+			{AppID: "app1", HTTPPort: port, MetricsPort: port},
+		},
+	})
+
+	apps, err := top.Refresh()
+	require.NoError(t, err)
+	require.Len(t, apps, 2)
+
+	assert.Equal(t, "app1", apps[0].App.AppID)
+	assert.NoError(t, apps[0].Err)
+	assert.Equal(t, []ActiveActors{{Type: "Order", Count: 2}}, apps[0].ActiveActors)
+	assert.Equal(t, []RegisteredComponent{{Name: "store", Type: "state.redis", Version: "v1"}}, apps[0].Components)
+	assert.Len(t, apps[0].Endpoints, 2)
+
+	assert.Equal(t, "app2", apps[1].App.AppID)
+	assert.Error(t, apps[1].Err)
+
+	apps, err = top.Refresh()
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, apps[0].RequestRate)
+}
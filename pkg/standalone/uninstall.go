@@ -97,8 +97,13 @@ func Uninstall(uninstallAll bool, dockerNetwork string) error {
 	_, placementErr := os.Stat(placementFilePath) // check if the placement binary exists
 	uninstallPlacementContainer := os.IsNotExist(placementErr)
 
+	err := stopSentry()
+	if err != nil {
+		utils.WarningStatusEvent(os.Stdout, "WARNING: could not stop %s: %s", sentryServiceFilePrefix, err)
+	}
+
 	// Remove .bhojpur/bin
-	err := removeDir(appBinDir)
+	err = removeDir(appBinDir)
 	if err != nil {
 		utils.WarningStatusEvent(os.Stdout, "WARNING: could not delete Bhojpur Application bin dir: %s", appBinDir)
 	}
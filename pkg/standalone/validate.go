@@ -0,0 +1,45 @@
+package standalone
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/bhojpur/application/pkg/lint"
+)
+
+// GetComponentTypes returns the components included in the local Bhojpur
+// Application runtime.
+func GetComponentTypes() (*lint.ComponentTypes, error) {
+	appCMD := binaryFilePath(defaultAppBinPath(), appRuntimeFilePrefix)
+	out, err := exec.Command(appCMD, "--list-components").Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not list the components included in %s: %w", appCMD, err)
+	}
+
+	var types lint.ComponentTypes
+	if err := json.Unmarshal(out, &types); err != nil {
+		return nil, fmt.Errorf("could not parse the components included in %s: %w", appCMD, err)
+	}
+	return &types, nil
+}
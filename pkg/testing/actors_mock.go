@@ -166,6 +166,29 @@ func (_m *MockActors) GetState(ctx context.Context, req *actors.GetStateRequest)
 	return r0, r1
 }
 
+// QueryState provides a mock function with given fields: req
+func (_m *MockActors) QueryState(ctx context.Context, req *actors.QueryStateRequest) (*actors.QueryStateResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *actors.QueryStateResponse
+	if rf, ok := ret.Get(0).(func(*actors.QueryStateRequest) *actors.QueryStateResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*actors.QueryStateResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*actors.QueryStateRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Init provides a mock function with given fields:
 func (_m *MockActors) Init() error {
 	ret := _m.Called()
@@ -222,6 +245,29 @@ func (_m *MockActors) GetReminder(ctx context.Context, req *actors.GetReminderRe
 	return r0, r1
 }
 
+// ListReminders provides a mock function with given fields: req
+func (_m *MockActors) ListReminders(ctx context.Context, req *actors.ListRemindersRequest) ([]actors.Reminder, error) {
+	ret := _m.Called(req)
+
+	var r0 []actors.Reminder
+	if rf, ok := ret.Get(0).(func(*actors.ListRemindersRequest) []actors.Reminder); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]actors.Reminder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*actors.ListRemindersRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetActiveActorsCount provides a mock function
 func (_m *MockActors) GetActiveActorsCount(ctx context.Context) []actors.ActiveActorsCount {
 	_m.Called()
@@ -236,3 +282,17 @@ func (_m *MockActors) GetActiveActorsCount(ctx context.Context) []actors.ActiveA
 		},
 	}
 }
+
+// RegisterInternalActor provides a mock function with given fields: actorType, actor
+func (_m *MockActors) RegisterInternalActor(ctx context.Context, actorType string, actor actors.InternalActor) error {
+	ret := _m.Called(actorType, actor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, actors.InternalActor) error); ok {
+		r0 = rf(actorType, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -24,12 +24,15 @@ package testing
 
 import (
 	"github.com/bhojpur/service/pkg/pubsub"
+
+	runtime_pubsub "github.com/bhojpur/application/pkg/runtime/pubsub"
 )
 
 // MockPubSubAdapter is mock for PubSubAdapter
 type MockPubSubAdapter struct {
-	PublishFn   func(req *pubsub.PublishRequest) error
-	GetPubSubFn func(pubsubName string) pubsub.PubSub
+	PublishFn     func(req *pubsub.PublishRequest) error
+	BulkPublishFn func(req *runtime_pubsub.BulkPublishRequest) (runtime_pubsub.BulkPublishResponse, error)
+	GetPubSubFn   func(pubsubName string) pubsub.PubSub
 }
 
 // Publish is an adapter method for the runtime to pre-validate publish requests
@@ -39,6 +42,12 @@ func (a *MockPubSubAdapter) Publish(req *pubsub.PublishRequest) error {
 	return a.PublishFn(req)
 }
 
+// BulkPublish is an adapter method for the runtime to pre-validate bulk publish requests
+// And then forward their entries to the Pub/Sub component.
+func (a *MockPubSubAdapter) BulkPublish(req *runtime_pubsub.BulkPublishRequest) (runtime_pubsub.BulkPublishResponse, error) {
+	return a.BulkPublishFn(req)
+}
+
 // GetPubSub is an adapter method to fetch a pubsub
 func (a *MockPubSubAdapter) GetPubSub(pubsubName string) pubsub.PubSub {
 	return a.GetPubSubFn(pubsubName)
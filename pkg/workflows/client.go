@@ -0,0 +1,134 @@
+package workflows
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/application/pkg/actors"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+)
+
+var (
+	// ErrInstanceNotFound is returned when a workflow instance does not exist.
+	ErrInstanceNotFound = errors.New("workflow instance not found")
+	// ErrInstanceExists is returned when starting a workflow instance with an ID that is already used.
+	ErrInstanceExists = errors.New("workflow instance already exists")
+	// ErrInvalidRequest is returned when a request is rejected before reaching the workflow instance.
+	ErrInvalidRequest = errors.New("invalid workflow request")
+)
+
+// Client manages the workflow instances of an app through the actors runtime,
+// wherever the instances are placed.
+type Client struct {
+	actors    actors.Actors
+	actorType string
+}
+
+// NewClient returns a client for the workflow instances of the given app.
+func NewClient(actorsRuntime actors.Actors, appID string) *Client {
+	return &Client{
+		actors:    actorsRuntime,
+		actorType: ActorType(appID),
+	}
+}
+
+// Start starts a new workflow instance.
+func (c *Client) Start(ctx context.Context, instanceID string, definition *Definition, input []byte) (*State, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	if definition == nil {
+		return nil, errors.Wrap(ErrInvalidRequest, "workflow definition is missing")
+	}
+	if err := definition.Validate(); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+
+	return c.call(ctx, instanceID, methodStart, startRequest{
+		Definition: definition,
+		Input:      toJSON(input),
+	})
+}
+
+// Get returns the state of a workflow instance.
+func (c *Client) Get(ctx context.Context, instanceID string) (*State, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	return c.call(ctx, instanceID, methodGetStatus, nil)
+}
+
+// Terminate stops a running workflow instance. Terminating a finished instance has no effect.
+func (c *Client) Terminate(ctx context.Context, instanceID string, reason string) (*State, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	return c.call(ctx, instanceID, methodTerminate, terminateRequest{Reason: reason})
+}
+
+// RaiseEvent delivers an event to a workflow instance. Events raised to a finished instance are dropped.
+func (c *Client) RaiseEvent(ctx context.Context, instanceID string, eventName string, data []byte) (*State, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	if eventName == "" {
+		return nil, errors.Wrap(ErrInvalidRequest, "event name is empty")
+	}
+	return c.call(ctx, instanceID, methodRaiseEvent, raiseEventRequest{
+		Name: eventName,
+		Data: toJSON(data),
+	})
+}
+
+func (c *Client) call(ctx context.Context, instanceID string, method string, data interface{}) (*State, error) {
+	req := invokev1.NewInvokeMethodRequest(method)
+	req.WithActor(c.actorType, instanceID)
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		req.WithRawData(b, invokev1.JSONContentType)
+	}
+
+	resp, err := c.actors.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result methodResult
+	_, body := resp.RawData()
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, errors.Wrap(err, "error decoding workflow response")
+	}
+
+	switch result.Code {
+	case resultNotFound:
+		return nil, ErrInstanceNotFound
+	case resultExists:
+		return nil, ErrInstanceExists
+	}
+	return result.State, nil
+}
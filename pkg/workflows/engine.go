@@ -0,0 +1,444 @@
+package workflows
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/application/pkg/actors"
+	"github.com/bhojpur/application/pkg/messaging"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/service/pkg/bindings"
+	"github.com/bhojpur/service/pkg/utils/logger"
+)
+
+var log = logger.NewLogger("app.runtime.workflows")
+
+const (
+	historyKey = "history"
+
+	methodStart      = "Start"
+	methodGetStatus  = "GetStatus"
+	methodTerminate  = "Terminate"
+	methodRaiseEvent = "RaiseEvent"
+
+	runReminderPrefix   = "run-"
+	timerReminderPrefix = "timer-"
+)
+
+// resultCode is the outcome of a workflow actor method. Errors returned by remote
+// actors lose their type, so expected outcomes travel in the response instead.
+type resultCode string
+
+const (
+	resultOK       resultCode = ""
+	resultNotFound resultCode = "NotFound"
+	resultExists   resultCode = "AlreadyExists"
+)
+
+type startRequest struct {
+	Definition *Definition     `json:"definition"`
+	Input      json.RawMessage `json:"input,omitempty"`
+}
+
+type terminateRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type raiseEventRequest struct {
+	Name string          `json:"name"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+type methodResult struct {
+	Code  resultCode `json:"code,omitempty"`
+	State *State     `json:"state,omitempty"`
+}
+
+// Engine executes workflow instances as internal actors. Every change to an
+// instance is appended to its history in the actor state before anything else
+// happens, and all progress is driven by one-shot reminders, so an instance
+// resumes from its history on whichever host owns it after a restart. Activities
+// are executed at least once.
+type Engine struct {
+	actorType             string
+	actors                actors.Actors
+	directMessaging       messaging.DirectMessaging
+	sendToOutputBindingFn func(name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error)
+	clock                 func() time.Time
+}
+
+// NewEngine returns the workflow engine of the given app. It must be registered
+// with the actors runtime under ActorType(appID) before the runtime is initialized.
+func NewEngine(appID string, directMessaging messaging.DirectMessaging, sendToOutputBindingFn func(name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error)) *Engine {
+	return &Engine{
+		actorType:             ActorType(appID),
+		directMessaging:       directMessaging,
+		sendToOutputBindingFn: sendToOutputBindingFn,
+		clock:                 time.Now,
+	}
+}
+
+// SetActorRuntime implements actors.InternalActor.
+func (e *Engine) SetActorRuntime(actorsRuntime actors.Actors) {
+	e.actors = actorsRuntime
+}
+
+// InvokeMethod implements actors.InternalActor.
+func (e *Engine) InvokeMethod(ctx context.Context, actorID string, methodName string, data []byte) ([]byte, error) {
+	inst, err := e.load(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result methodResult
+	switch methodName {
+	case methodStart:
+		result, err = e.start(ctx, inst, data)
+	case methodGetStatus:
+		if !inst.exists() {
+			result.Code = resultNotFound
+		}
+	case methodTerminate:
+		result, err = e.terminate(ctx, inst, data)
+	case methodRaiseEvent:
+		result, err = e.raiseEvent(ctx, inst, data)
+	default:
+		return nil, errors.Errorf("unknown workflow method %s", methodName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Code == resultOK {
+		result.State = inst.state()
+	}
+	return json.Marshal(result)
+}
+
+// InvokeReminder implements actors.InternalActor.
+func (e *Engine) InvokeReminder(ctx context.Context, actorID string, reminderName string, data interface{}) error {
+	inst, err := e.load(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if !inst.exists() || inst.status != StatusRunning {
+		// Reminders of finished instances are stale.
+		return nil
+	}
+
+	if strings.HasPrefix(reminderName, timerReminderPrefix) {
+		step, err := strconv.Atoi(strings.TrimPrefix(reminderName, timerReminderPrefix))
+		if err != nil || step != inst.step || inst.timerFireAt == nil || inst.timerFired {
+			log.Debugf("ignoring stale timer %s of workflow instance %s", reminderName, actorID)
+			return nil
+		}
+		if err := e.record(ctx, inst, HistoryEvent{Type: EventTimerFired, Step: step}); err != nil {
+			return err
+		}
+	}
+
+	return e.run(ctx, inst)
+}
+
+// DeactivateActor implements actors.InternalActor. Instances are not cached, so there is nothing to release.
+func (e *Engine) DeactivateActor(ctx context.Context, actorID string) error {
+	return nil
+}
+
+func (e *Engine) start(ctx context.Context, inst *instance, data []byte) (methodResult, error) {
+	var req startRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return methodResult{}, errors.Wrap(err, "error decoding start request")
+	}
+	if req.Definition == nil {
+		return methodResult{}, errors.New("workflow definition is missing")
+	}
+	if err := req.Definition.Validate(); err != nil {
+		return methodResult{}, err
+	}
+	if inst.exists() {
+		return methodResult{Code: resultExists}, nil
+	}
+
+	err := e.record(ctx, inst, HistoryEvent{
+		Type:       EventWorkflowStarted,
+		Definition: req.Definition,
+		Data:       req.Input,
+	})
+	if err != nil {
+		return methodResult{}, err
+	}
+	return methodResult{}, e.scheduleRun(ctx, inst, 0)
+}
+
+func (e *Engine) terminate(ctx context.Context, inst *instance, data []byte) (methodResult, error) {
+	if !inst.exists() {
+		return methodResult{Code: resultNotFound}, nil
+	}
+	if inst.status != StatusRunning {
+		return methodResult{}, nil
+	}
+
+	var req terminateRequest
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &req); err != nil {
+			return methodResult{}, errors.Wrap(err, "error decoding terminate request")
+		}
+	}
+	return methodResult{}, e.record(ctx, inst, HistoryEvent{Type: EventWorkflowTerminated, Error: req.Reason})
+}
+
+func (e *Engine) raiseEvent(ctx context.Context, inst *instance, data []byte) (methodResult, error) {
+	if !inst.exists() {
+		return methodResult{Code: resultNotFound}, nil
+	}
+	if inst.status != StatusRunning {
+		return methodResult{}, nil
+	}
+
+	var req raiseEventRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return methodResult{}, errors.Wrap(err, "error decoding raise event request")
+	}
+	if err := e.record(ctx, inst, HistoryEvent{Type: EventRaised, Name: req.Name, Data: req.Data}); err != nil {
+		return methodResult{}, err
+	}
+	return methodResult{}, e.scheduleRun(ctx, inst, 0)
+}
+
+// run executes the steps of a running instance until it finishes or has to wait
+// for a timer, an event or a retry.
+func (e *Engine) run(ctx context.Context, inst *instance) error {
+	for inst.status == StatusRunning {
+		step := inst.currentStep()
+		if step == nil {
+			return e.record(ctx, inst, HistoryEvent{Type: EventWorkflowCompleted, Data: inst.lastOutput})
+		}
+
+		var (
+			advanced bool
+			err      error
+		)
+		switch {
+		case step.Timer != nil:
+			advanced, err = e.runTimer(ctx, inst, step)
+		case step.WaitForEvent != nil:
+			advanced, err = e.runWaitForEvent(ctx, inst, step)
+		default:
+			advanced, err = e.runActivity(ctx, inst, step)
+		}
+		if err != nil || !advanced {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Engine) runActivity(ctx context.Context, inst *instance, step *Step) (bool, error) {
+	if inst.retryAt != nil && e.clock().Before(*inst.retryAt) {
+		return false, nil
+	}
+
+	output, err := e.execute(ctx, step, inst.stepInput(step))
+	if err == nil {
+		return true, e.record(ctx, inst, HistoryEvent{Type: EventStepCompleted, Step: inst.step, Data: output})
+	}
+
+	failed := HistoryEvent{
+		Type:    EventStepFailed,
+		Step:    inst.step,
+		Attempt: inst.attempts + 1,
+		Error:   err.Error(),
+	}
+	if step.Retry == nil || failed.Attempt >= step.Retry.MaxAttempts {
+		return false, e.record(ctx, inst, failed, HistoryEvent{
+			Type:  EventWorkflowFailed,
+			Step:  inst.step,
+			Error: fmt.Sprintf("step %s failed: %s", step.Name, err),
+		})
+	}
+
+	var interval time.Duration
+	if step.Retry.Interval != "" {
+		interval, _ = time.ParseDuration(step.Retry.Interval)
+	}
+	retryAt := e.clock().Add(interval)
+	failed.FireAt = &retryAt
+	if err := e.record(ctx, inst, failed); err != nil {
+		return false, err
+	}
+	return false, e.scheduleRun(ctx, inst, interval)
+}
+
+func (e *Engine) runTimer(ctx context.Context, inst *instance, step *Step) (bool, error) {
+	if inst.timerFired {
+		return true, e.record(ctx, inst, HistoryEvent{Type: EventStepCompleted, Step: inst.step, Data: inst.stepInput(step)})
+	}
+	if inst.timerFireAt == nil {
+		return false, e.startTimer(ctx, inst, step)
+	}
+	return false, nil
+}
+
+func (e *Engine) runWaitForEvent(ctx context.Context, inst *instance, step *Step) (bool, error) {
+	name := step.WaitForEvent.EventName
+	if data, ok := inst.nextEvent(name); ok {
+		return true, e.record(ctx, inst, HistoryEvent{Type: EventStepCompleted, Step: inst.step, Name: name, Data: data})
+	}
+	if inst.timerFired {
+		return false, e.record(ctx, inst, HistoryEvent{
+			Type:  EventWorkflowFailed,
+			Step:  inst.step,
+			Error: fmt.Sprintf("step %s timed out waiting for event %s", step.Name, name),
+		})
+	}
+	if _, ok := stepTimerDuration(step); ok && inst.timerFireAt == nil {
+		return false, e.startTimer(ctx, inst, step)
+	}
+	return false, nil
+}
+
+func (e *Engine) startTimer(ctx context.Context, inst *instance, step *Step) error {
+	d, _ := stepTimerDuration(step)
+	fireAt := e.clock().Add(d)
+	if err := e.record(ctx, inst, HistoryEvent{Type: EventTimerCreated, Step: inst.step, FireAt: &fireAt}); err != nil {
+		return err
+	}
+	return e.createReminder(ctx, inst, fmt.Sprintf("%s%d", timerReminderPrefix, inst.step), d)
+}
+
+// scheduleRun resumes the instance in a new turn after the given delay. The name
+// of the reminder is derived from the length of the history so it is never reused.
+func (e *Engine) scheduleRun(ctx context.Context, inst *instance, delay time.Duration) error {
+	return e.createReminder(ctx, inst, fmt.Sprintf("%s%d", runReminderPrefix, len(inst.history)), delay)
+}
+
+func (e *Engine) createReminder(ctx context.Context, inst *instance, name string, dueTime time.Duration) error {
+	return e.actors.CreateReminder(ctx, &actors.CreateReminderRequest{
+		Name:      name,
+		ActorType: e.actorType,
+		ActorID:   inst.id,
+		DueTime:   dueTime.String(),
+	})
+}
+
+func (e *Engine) execute(ctx context.Context, step *Step, input json.RawMessage) (json.RawMessage, error) {
+	if step.Invoke != nil {
+		return e.invokeService(ctx, step.Invoke, input)
+	}
+	return e.invokeBinding(step.Binding, input)
+}
+
+func (e *Engine) invokeService(ctx context.Context, activity *InvokeActivity, input json.RawMessage) (json.RawMessage, error) {
+	if e.directMessaging == nil {
+		return nil, errors.New("service invocation is not available")
+	}
+
+	verb := activity.HTTPVerb
+	if verb == "" {
+		verb = "POST"
+	}
+	req := invokev1.NewInvokeMethodRequest(activity.Method)
+	req.WithHTTPExtension(strings.ToUpper(verb), "")
+	req.WithRawData(input, invokev1.JSONContentType)
+
+	resp, err := e.directMessaging.Invoke(ctx, activity.AppID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int(resp.Status().Code)
+	if resp.IsHTTPResponse() && (code < 200 || code > 299) || !resp.IsHTTPResponse() && code != 0 {
+		return nil, errors.Errorf("method %s of app %s returned status code %d", activity.Method, activity.AppID, code)
+	}
+	_, body := resp.RawData()
+	return toJSON(body), nil
+}
+
+func (e *Engine) invokeBinding(activity *BindingActivity, input json.RawMessage) (json.RawMessage, error) {
+	if e.sendToOutputBindingFn == nil {
+		return nil, errors.New("output bindings are not available")
+	}
+
+	resp, err := e.sendToOutputBindingFn(activity.Name, &bindings.InvokeRequest{
+		Data:      input,
+		Metadata:  activity.Metadata,
+		Operation: bindings.OperationKind(activity.Operation),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return toJSON(resp.Data), nil
+}
+
+func (e *Engine) load(ctx context.Context, instanceID string) (*instance, error) {
+	resp, err := e.actors.GetState(ctx, &actors.GetStateRequest{
+		ActorType: e.actorType,
+		ActorID:   instanceID,
+		Key:       historyKey,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading history of workflow instance %s", instanceID)
+	}
+
+	var history []HistoryEvent
+	if resp != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, &history); err != nil {
+			return nil, errors.Wrapf(err, "error decoding history of workflow instance %s", instanceID)
+		}
+	}
+	return replay(instanceID, history), nil
+}
+
+// record applies the events to the instance and persists its history.
+func (e *Engine) record(ctx context.Context, inst *instance, events ...HistoryEvent) error {
+	now := e.clock().UTC()
+	for _, ev := range events {
+		ev.Timestamp = now
+		inst.apply(ev)
+	}
+
+	err := e.actors.TransactionalStateOperation(ctx, &actors.TransactionalRequest{
+		ActorType: e.actorType,
+		ActorID:   inst.id,
+		Operations: []actors.TransactionalOperation{
+			{
+				Operation: actors.Upsert,
+				Request: actors.TransactionalUpsert{
+					Key:   historyKey,
+					Value: inst.history,
+				},
+			},
+		},
+	})
+	return errors.Wrapf(err, "error saving history of workflow instance %s", inst.id)
+}
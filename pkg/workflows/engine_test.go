@@ -0,0 +1,450 @@
+package workflows
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhojpur/application/pkg/actors"
+	invokev1 "github.com/bhojpur/application/pkg/messaging/v1"
+	"github.com/bhojpur/service/pkg/bindings"
+)
+
+const testAppID = "app"
+
+// fakeActors is an in-memory actors runtime hosting a single internal actor type.
+// Reminders fire when the tests call fire, once their due time has elapsed on the fake clock.
+type fakeActors struct {
+	actors.Actors
+
+	engine    *Engine
+	now       time.Time
+	state     map[string][]byte
+	reminders map[string]fakeReminder
+}
+
+type fakeReminder struct {
+	req    *actors.CreateReminderRequest
+	fireAt time.Time
+}
+
+func newFakeActors() *fakeActors {
+	return &fakeActors{
+		now:       time.Now(),
+		state:     map[string][]byte{},
+		reminders: map[string]fakeReminder{},
+	}
+}
+
+// host registers a new engine, as a restarted sidecar would, and returns it.
+func (f *fakeActors) host(engine *Engine) *Engine {
+	engine.SetActorRuntime(f)
+	engine.clock = func() time.Time { return f.now }
+	f.engine = engine
+	return engine
+}
+
+func (f *fakeActors) advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeActors) Call(ctx context.Context, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	_, data := req.RawData()
+	result, err := f.engine.InvokeMethod(ctx, req.Actor().GetActorId(), req.Message().Method, data)
+	if err != nil {
+		return nil, err
+	}
+	resp := invokev1.NewInvokeMethodResponse(http.StatusOK, "OK", nil)
+	resp.WithRawData(result, invokev1.JSONContentType)
+	return resp, nil
+}
+
+func (f *fakeActors) GetState(ctx context.Context, req *actors.GetStateRequest) (*actors.StateResponse, error) {
+	return &actors.StateResponse{Data: f.state[req.ActorID+"||"+req.Key]}, nil
+}
+
+func (f *fakeActors) TransactionalStateOperation(ctx context.Context, req *actors.TransactionalRequest) error {
+	for _, o := range req.Operations {
+		upsert := o.Request.(actors.TransactionalUpsert)
+		b, err := json.Marshal(upsert.Value)
+		if err != nil {
+			return err
+		}
+		f.state[req.ActorID+"||"+upsert.Key] = b
+	}
+	return nil
+}
+
+func (f *fakeActors) CreateReminder(ctx context.Context, req *actors.CreateReminderRequest) error {
+	dueTime, err := time.ParseDuration(req.DueTime)
+	if err != nil {
+		return err
+	}
+	f.reminders[req.ActorID+"||"+req.Name] = fakeReminder{req: req, fireAt: f.now.Add(dueTime)}
+	return nil
+}
+
+// fire fires the due reminders of the instance, including the ones they create, in name order.
+func (f *fakeActors) fire(t *testing.T, instanceID string) {
+	for {
+		keys := []string{}
+		for k, r := range f.reminders {
+			if r.req.ActorID == instanceID && !r.fireAt.After(f.now) {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) == 0 {
+			return
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			r := f.reminders[k]
+			delete(f.reminders, k)
+			require.NoError(t, f.engine.InvokeReminder(context.Background(), instanceID, r.req.Name, nil))
+		}
+	}
+}
+
+func (f *fakeActors) pendingReminders(instanceID string) []string {
+	names := []string{}
+	for _, r := range f.reminders {
+		if r.req.ActorID == instanceID {
+			names = append(names, r.req.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type fakeDirectMessaging struct {
+	calls   []string
+	invoker func(appID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error)
+}
+
+func (f *fakeDirectMessaging) Invoke(ctx context.Context, targetAppID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	f.calls = append(f.calls, targetAppID+"/"+req.Message().Method)
+	return f.invoker(targetAppID, req)
+}
+
+// echo replies with the request body wrapped in an object keyed by the method.
+func echo(appID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+	_, data := req.RawData()
+	body, _ := json.Marshal(map[string]json.RawMessage{req.Message().Method: data})
+	resp := invokev1.NewInvokeMethodResponse(http.StatusOK, "OK", nil)
+	resp.WithRawData(body, invokev1.JSONContentType)
+	return resp, nil
+}
+
+func newTestEngine(messaging *fakeDirectMessaging) (*fakeActors, *Client) {
+	fake := newFakeActors()
+	fake.host(NewEngine(testAppID, messaging, func(name string, req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+		return &bindings.InvokeResponse{Data: req.Data}, nil
+	}))
+	return fake, NewClient(fake, testAppID)
+}
+
+func TestActorType(t *testing.T) {
+	assert.Equal(t, "app.internal.workflow.app", ActorType(testAppID))
+	assert.True(t, actors.IsInternalActor(ActorType(testAppID)))
+}
+
+func TestWorkflowActivities(t *testing.T) {
+	messaging := &fakeDirectMessaging{invoker: echo}
+	fake, client := newTestEngine(messaging)
+	ctx := context.Background()
+
+	definition := &Definition{Name: "order", Steps: []Step{
+		{Name: "reserve", Invoke: &InvokeActivity{AppID: "inventory", Method: "reserve"}},
+		{Name: "store", Binding: &BindingActivity{Name: "blob", Operation: "create"}},
+		{Name: "ship", Invoke: &InvokeActivity{AppID: "shipping", Method: "ship"}, Input: json.RawMessage(`"express"`)},
+	}}
+
+	s, err := client.Start(ctx, "order-1", definition, []byte(`{"item":"book"}`))
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, s.RuntimeStatus)
+	assert.Equal(t, "reserve", s.CurrentStep)
+	assert.Empty(t, messaging.calls, "activities run in their own turn")
+
+	fake.fire(t, "order-1")
+
+	s, err = client.Get(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, s.RuntimeStatus)
+	assert.Equal(t, "order", s.WorkflowName)
+	assert.Equal(t, []string{"inventory/reserve", "shipping/ship"}, messaging.calls)
+	assert.JSONEq(t, `{"item":"book"}`, string(s.Input))
+	assert.JSONEq(t, `{"ship":"express"}`, string(s.Output))
+	assert.Empty(t, fake.pendingReminders("order-1"))
+}
+
+func TestWorkflowStartErrors(t *testing.T) {
+	_, client := newTestEngine(&fakeDirectMessaging{invoker: echo})
+	ctx := context.Background()
+	definition := &Definition{Name: "wf", Steps: []Step{{Name: "wait", Timer: &TimerStep{Duration: "1m"}}}}
+
+	_, err := client.Start(ctx, "", definition, nil)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+	_, err = client.Start(ctx, "wf-1", &Definition{Name: "wf"}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+	_, err = client.Start(ctx, "wf-1", definition, nil)
+	assert.NoError(t, err)
+
+	_, err = client.Start(ctx, "wf-1", definition, nil)
+	assert.Equal(t, ErrInstanceExists, err)
+
+	_, err = client.Get(ctx, "wf-2")
+	assert.Equal(t, ErrInstanceNotFound, err)
+
+	_, err = client.Terminate(ctx, "wf-2", "")
+	assert.Equal(t, ErrInstanceNotFound, err)
+
+	_, err = client.RaiseEvent(ctx, "wf-2", "approved", nil)
+	assert.Equal(t, ErrInstanceNotFound, err)
+}
+
+func TestWorkflowRetries(t *testing.T) {
+	failures := 0
+	messaging := &fakeDirectMessaging{invoker: func(appID string, req *invokev1.InvokeMethodRequest) (*invokev1.InvokeMethodResponse, error) {
+		if failures > 0 {
+			failures--
+			return invokev1.NewInvokeMethodResponse(http.StatusInternalServerError, "error", nil), nil
+		}
+		return echo(appID, req)
+	}}
+	definition := &Definition{Name: "wf", Steps: []Step{
+		{Name: "pay", Invoke: &InvokeActivity{AppID: "payments", Method: "pay"}, Retry: &RetryPolicy{MaxAttempts: 3, Interval: "5s"}},
+	}}
+	ctx := context.Background()
+
+	t.Run("succeed after retries", func(t *testing.T) {
+		failures = 2
+		fake, client := newTestEngine(messaging)
+
+		_, err := client.Start(ctx, "wf-1", definition, nil)
+		require.NoError(t, err)
+
+		fake.fire(t, "wf-1")
+		reminders := fake.pendingReminders("wf-1")
+		require.Len(t, reminders, 1)
+		assert.Equal(t, "5s", fake.reminders["wf-1||"+reminders[0]].req.DueTime)
+
+		// a run triggered by an event does not retry before the interval elapsed.
+		_, err = client.RaiseEvent(ctx, "wf-1", "ignored", nil)
+		require.NoError(t, err)
+		fake.fire(t, "wf-1")
+		assert.Equal(t, 1, failures)
+
+		fake.advance(5 * time.Second)
+		fake.fire(t, "wf-1")
+		assert.Equal(t, 0, failures)
+		fake.advance(5 * time.Second)
+		fake.fire(t, "wf-1")
+
+		s, err := client.Get(ctx, "wf-1")
+		require.NoError(t, err)
+		assert.Equal(t, StatusCompleted, s.RuntimeStatus)
+	})
+
+	t.Run("fail after max attempts", func(t *testing.T) {
+		failures = 5
+		fake, client := newTestEngine(messaging)
+
+		_, err := client.Start(ctx, "wf-1", definition, nil)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			fake.fire(t, "wf-1")
+			fake.advance(5 * time.Second)
+		}
+
+		s, err := client.Get(ctx, "wf-1")
+		require.NoError(t, err)
+		assert.Equal(t, StatusFailed, s.RuntimeStatus)
+		assert.Contains(t, s.Error, "step pay failed")
+		assert.Equal(t, 2, failures)
+		assert.Empty(t, fake.pendingReminders("wf-1"))
+	})
+}
+
+func TestWorkflowTimer(t *testing.T) {
+	fake, client := newTestEngine(&fakeDirectMessaging{invoker: echo})
+	ctx := context.Background()
+	definition := &Definition{Name: "wf", Steps: []Step{{Name: "sleep", Timer: &TimerStep{Duration: "1h"}}}}
+
+	_, err := client.Start(ctx, "wf-1", definition, []byte(`42`))
+	require.NoError(t, err)
+
+	fake.fire(t, "wf-1")
+	assert.Equal(t, []string{"timer-0"}, fake.pendingReminders("wf-1"))
+	assert.Equal(t, "1h0m0s", fake.reminders["wf-1||timer-0"].req.DueTime)
+
+	s, _ := client.Get(ctx, "wf-1")
+	assert.Equal(t, StatusRunning, s.RuntimeStatus)
+	assert.Equal(t, "sleep", s.CurrentStep)
+
+	fake.advance(time.Hour)
+	fake.fire(t, "wf-1")
+	s, _ = client.Get(ctx, "wf-1")
+	assert.Equal(t, StatusCompleted, s.RuntimeStatus)
+	assert.JSONEq(t, `42`, string(s.Output))
+}
+
+func TestWorkflowWaitForEvent(t *testing.T) {
+	ctx := context.Background()
+	definition := &Definition{Name: "wf", Steps: []Step{
+		{Name: "approval", WaitForEvent: &WaitForEventStep{EventName: "approved", Timeout: "24h"}},
+	}}
+
+	t.Run("event raised", func(t *testing.T) {
+		fake, client := newTestEngine(&fakeDirectMessaging{invoker: echo})
+		_, err := client.Start(ctx, "wf-1", definition, nil)
+		require.NoError(t, err)
+		fake.fire(t, "wf-1")
+
+		_, err = client.RaiseEvent(ctx, "wf-1", "other", []byte(`"no"`))
+		require.NoError(t, err)
+		fake.fire(t, "wf-1")
+		s, _ := client.Get(ctx, "wf-1")
+		assert.Equal(t, StatusRunning, s.RuntimeStatus)
+
+		_, err = client.RaiseEvent(ctx, "wf-1", "approved", []byte(`{"by":"alice"}`))
+		require.NoError(t, err)
+		fake.fire(t, "wf-1")
+
+		s, _ = client.Get(ctx, "wf-1")
+		assert.Equal(t, StatusCompleted, s.RuntimeStatus)
+		assert.JSONEq(t, `{"by":"alice"}`, string(s.Output))
+	})
+
+	t.Run("event raised before the step", func(t *testing.T) {
+		fake, client := newTestEngine(&fakeDirectMessaging{invoker: echo})
+		_, err := client.Start(ctx, "wf-1", definition, nil)
+		require.NoError(t, err)
+		_, err = client.RaiseEvent(ctx, "wf-1", "approved", []byte("yes"))
+		require.NoError(t, err)
+		fake.fire(t, "wf-1")
+
+		s, _ := client.Get(ctx, "wf-1")
+		assert.Equal(t, StatusCompleted, s.RuntimeStatus)
+		assert.JSONEq(t, `"yes"`, string(s.Output))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		fake, client := newTestEngine(&fakeDirectMessaging{invoker: echo})
+		_, err := client.Start(ctx, "wf-1", definition, nil)
+		require.NoError(t, err)
+		fake.fire(t, "wf-1")
+		assert.Equal(t, []string{"timer-0"}, fake.pendingReminders("wf-1"))
+		fake.advance(24 * time.Hour)
+		fake.fire(t, "wf-1")
+
+		s, _ := client.Get(ctx, "wf-1")
+		assert.Equal(t, StatusFailed, s.RuntimeStatus)
+		assert.Contains(t, s.Error, "timed out waiting for event approved")
+	})
+}
+
+func TestWorkflowTerminate(t *testing.T) {
+	messaging := &fakeDirectMessaging{invoker: echo}
+	fake, client := newTestEngine(messaging)
+	ctx := context.Background()
+	definition := &Definition{Name: "wf", Steps: []Step{
+		{Name: "sleep", Timer: &TimerStep{Duration: "1h"}},
+		{Name: "call", Invoke: &InvokeActivity{AppID: "target", Method: "run"}},
+	}}
+
+	_, err := client.Start(ctx, "wf-1", definition, nil)
+	require.NoError(t, err)
+	fake.fire(t, "wf-1")
+
+	s, err := client.Terminate(ctx, "wf-1", "cancelled by user")
+	require.NoError(t, err)
+	assert.Equal(t, StatusTerminated, s.RuntimeStatus)
+	assert.Equal(t, "cancelled by user", s.Error)
+
+	// the pending timer is stale and the next step never runs.
+	fake.advance(time.Hour)
+	fake.fire(t, "wf-1")
+	assert.Empty(t, messaging.calls)
+
+	s, err = client.Terminate(ctx, "wf-1", "again")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled by user", s.Error)
+}
+
+func TestWorkflowRecovery(t *testing.T) {
+	messaging := &fakeDirectMessaging{invoker: echo}
+	fake, client := newTestEngine(messaging)
+	ctx := context.Background()
+	definition := &Definition{Name: "wf", Steps: []Step{
+		{Name: "first", Invoke: &InvokeActivity{AppID: "target", Method: "first"}},
+		{Name: "sleep", Timer: &TimerStep{Duration: "1m"}},
+		{Name: "second", Invoke: &InvokeActivity{AppID: "target", Method: "second"}},
+	}}
+
+	_, err := client.Start(ctx, "wf-1", definition, []byte(`1`))
+	require.NoError(t, err)
+	fake.fire(t, "wf-1")
+	assert.Equal(t, []string{"target/first"}, messaging.calls)
+
+	// a new engine picks up the instance from its history and the persisted reminders.
+	fake.host(NewEngine(testAppID, messaging, nil))
+	fake.advance(time.Minute)
+	fake.fire(t, "wf-1")
+
+	s, err := client.Get(ctx, "wf-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, s.RuntimeStatus)
+	assert.Equal(t, []string{"target/first", "target/second"}, messaging.calls)
+	assert.JSONEq(t, `{"second":{"first":1}}`, string(s.Output))
+}
+
+func TestReplay(t *testing.T) {
+	definition := &Definition{Name: "wf", Steps: []Step{
+		{Name: "approval", WaitForEvent: &WaitForEventStep{EventName: "approved"}},
+		{Name: "second", WaitForEvent: &WaitForEventStep{EventName: "approved"}},
+	}}
+	history := []HistoryEvent{
+		{Type: EventWorkflowStarted, Definition: definition},
+		{Type: EventRaised, Name: "approved", Data: json.RawMessage(`1`)},
+		{Type: EventRaised, Name: "approved", Data: json.RawMessage(`2`)},
+		{Type: EventStepCompleted, Step: 0, Name: "approved", Data: json.RawMessage(`1`)},
+	}
+
+	inst := replay("wf-1", history)
+	assert.Equal(t, StatusRunning, inst.status)
+	assert.Equal(t, 1, inst.step)
+	data, ok := inst.nextEvent("approved")
+	assert.True(t, ok)
+	assert.Equal(t, json.RawMessage(`2`), data)
+	assert.Len(t, inst.history, len(history))
+}
@@ -0,0 +1,201 @@
+package workflows
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType is the type of a workflow history event.
+type EventType string
+
+const (
+	EventWorkflowStarted    EventType = "WorkflowStarted"
+	EventStepCompleted      EventType = "StepCompleted"
+	EventStepFailed         EventType = "StepFailed"
+	EventTimerCreated       EventType = "TimerCreated"
+	EventTimerFired         EventType = "TimerFired"
+	EventRaised             EventType = "EventRaised"
+	EventWorkflowCompleted  EventType = "WorkflowCompleted"
+	EventWorkflowFailed     EventType = "WorkflowFailed"
+	EventWorkflowTerminated EventType = "WorkflowTerminated"
+)
+
+// HistoryEvent is an entry of the append-only history of a workflow instance.
+// The state of an instance is never stored directly: it is rebuilt by replaying
+// its history, which is what allows an instance to resume on any host after a restart.
+type HistoryEvent struct {
+	Type       EventType       `json:"type"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Step       int             `json:"step"`
+	Attempt    int             `json:"attempt,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	FireAt     *time.Time      `json:"fireAt,omitempty"`
+	Definition *Definition     `json:"definition,omitempty"`
+}
+
+// instance is the in-memory state of a workflow instance rebuilt from its history.
+type instance struct {
+	id         string
+	definition *Definition
+	input      json.RawMessage
+	status     RuntimeStatus
+	createdAt  time.Time
+	updatedAt  time.Time
+
+	// step is the index of the step being executed and lastOutput the input it receives
+	// when it doesn't define its own.
+	step       int
+	lastOutput json.RawMessage
+
+	// attempts and retryAt track the failed attempts of the current step.
+	attempts int
+	retryAt  *time.Time
+
+	// timerFireAt and timerFired track the timer of the current step.
+	timerFireAt *time.Time
+	timerFired  bool
+
+	// events holds the raised events not consumed yet, in order, by name.
+	events map[string][]json.RawMessage
+
+	output  json.RawMessage
+	failure string
+
+	history []HistoryEvent
+}
+
+// replay rebuilds an instance from its history.
+func replay(id string, history []HistoryEvent) *instance {
+	inst := &instance{
+		id:     id,
+		events: map[string][]json.RawMessage{},
+	}
+	for _, e := range history {
+		inst.apply(e)
+	}
+	return inst
+}
+
+// apply appends an event to the history of the instance and updates its state.
+// It is used both when replaying a stored history and when recording new events,
+// so a replayed instance is always identical to the one that produced the history.
+func (i *instance) apply(e HistoryEvent) {
+	i.history = append(i.history, e)
+	i.updatedAt = e.Timestamp
+
+	switch e.Type {
+	case EventWorkflowStarted:
+		i.definition = e.Definition
+		i.input = e.Data
+		i.lastOutput = e.Data
+		i.status = StatusRunning
+		i.createdAt = e.Timestamp
+	case EventStepCompleted:
+		if e.Step != i.step {
+			return
+		}
+		if s := i.currentStep(); s != nil && s.WaitForEvent != nil {
+			i.consumeEvent(s.WaitForEvent.EventName)
+		}
+		i.step++
+		i.lastOutput = e.Data
+		i.attempts = 0
+		i.retryAt = nil
+		i.timerFireAt = nil
+		i.timerFired = false
+	case EventStepFailed:
+		i.attempts = e.Attempt
+		i.retryAt = e.FireAt
+	case EventTimerCreated:
+		i.timerFireAt = e.FireAt
+	case EventTimerFired:
+		if e.Step == i.step {
+			i.timerFired = true
+		}
+	case EventRaised:
+		i.events[e.Name] = append(i.events[e.Name], e.Data)
+	case EventWorkflowCompleted:
+		i.status = StatusCompleted
+		i.output = e.Data
+	case EventWorkflowFailed:
+		i.status = StatusFailed
+		i.failure = e.Error
+	case EventWorkflowTerminated:
+		i.status = StatusTerminated
+		i.failure = e.Error
+	}
+}
+
+func (i *instance) exists() bool {
+	return i.definition != nil
+}
+
+func (i *instance) currentStep() *Step {
+	if i.definition == nil || i.step >= len(i.definition.Steps) {
+		return nil
+	}
+	return &i.definition.Steps[i.step]
+}
+
+// nextEvent returns the oldest raised event with the given name that wasn't consumed yet.
+func (i *instance) nextEvent(name string) (json.RawMessage, bool) {
+	queue := i.events[name]
+	if len(queue) == 0 {
+		return nil, false
+	}
+	return queue[0], true
+}
+
+func (i *instance) consumeEvent(name string) {
+	if queue := i.events[name]; len(queue) > 0 {
+		i.events[name] = queue[1:]
+	}
+}
+
+// state returns the public state of the instance.
+func (i *instance) state() *State {
+	s := &State{
+		InstanceID:    i.id,
+		WorkflowName:  i.definition.Name,
+		RuntimeStatus: i.status,
+		CreatedAt:     i.createdAt,
+		LastUpdatedAt: i.updatedAt,
+		Input:         i.input,
+		Output:        i.output,
+		Error:         i.failure,
+	}
+	if step := i.currentStep(); step != nil && i.status == StatusRunning {
+		s.CurrentStep = step.Name
+	}
+	return s
+}
+
+// stepInput returns the input of the given step.
+func (i *instance) stepInput(s *Step) json.RawMessage {
+	if len(s.Input) > 0 {
+		return s.Input
+	}
+	return i.lastOutput
+}
@@ -0,0 +1,249 @@
+package workflows
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bhojpur/application/pkg/actors"
+)
+
+// actorTypePrefix is the prefix of the internal actor type hosting the workflow instances of an app.
+const actorTypePrefix = actors.InternalActorTypePrefix + "workflow."
+
+// RuntimeStatus is the status of a workflow instance.
+type RuntimeStatus string
+
+const (
+	// StatusRunning means the workflow instance is executing or waiting for a timer or an event.
+	StatusRunning RuntimeStatus = "RUNNING"
+	// StatusCompleted means all the steps of the workflow instance completed successfully.
+	StatusCompleted RuntimeStatus = "COMPLETED"
+	// StatusFailed means a step of the workflow instance failed and exhausted its retries.
+	StatusFailed RuntimeStatus = "FAILED"
+	// StatusTerminated means the workflow instance was terminated by a client.
+	StatusTerminated RuntimeStatus = "TERMINATED"
+)
+
+// ActorType returns the internal actor type hosting the workflow instances of the given app.
+func ActorType(appID string) string {
+	return actorTypePrefix + appID
+}
+
+// Definition is a declarative workflow made of steps executed one after the other.
+// Unless a step sets its own input, it receives the output of the previous step,
+// and the first step receives the input of the workflow. The output of the last
+// step is the output of the workflow.
+type Definition struct {
+	Name  string `json:"name"`
+	Steps []Step `json:"steps"`
+}
+
+// Step is a single unit of work of a workflow. Exactly one of Invoke, Binding,
+// Timer or WaitForEvent must be set.
+type Step struct {
+	Name         string            `json:"name"`
+	Input        json.RawMessage   `json:"input,omitempty"`
+	Invoke       *InvokeActivity   `json:"invoke,omitempty"`
+	Binding      *BindingActivity  `json:"binding,omitempty"`
+	Timer        *TimerStep        `json:"timer,omitempty"`
+	WaitForEvent *WaitForEventStep `json:"waitForEvent,omitempty"`
+	Retry        *RetryPolicy      `json:"retry,omitempty"`
+}
+
+// InvokeActivity calls a method of an app through service invocation.
+type InvokeActivity struct {
+	AppID    string `json:"appId"`
+	Method   string `json:"method"`
+	HTTPVerb string `json:"httpVerb,omitempty"`
+}
+
+// BindingActivity invokes an output binding.
+type BindingActivity struct {
+	Name      string            `json:"name"`
+	Operation string            `json:"operation"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// TimerStep pauses the workflow for the given duration, e.g. "30s".
+// The input of the step is passed through as its output.
+type TimerStep struct {
+	Duration string `json:"duration"`
+}
+
+// WaitForEventStep pauses the workflow until an event with the given name is raised.
+// The data of the event is the output of the step. If Timeout is set and no event
+// is raised in time, the workflow fails.
+type WaitForEventStep struct {
+	EventName string `json:"eventName"`
+	Timeout   string `json:"timeout,omitempty"`
+}
+
+// RetryPolicy retries a failed activity up to MaxAttempts times in total,
+// waiting Interval between attempts.
+type RetryPolicy struct {
+	MaxAttempts int    `json:"maxAttempts"`
+	Interval    string `json:"interval,omitempty"`
+}
+
+// State is the status of a workflow instance, as computed from its history.
+type State struct {
+	InstanceID    string          `json:"instanceId"`
+	WorkflowName  string          `json:"workflowName"`
+	RuntimeStatus RuntimeStatus   `json:"runtimeStatus"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	LastUpdatedAt time.Time       `json:"lastUpdatedAt"`
+	CurrentStep   string          `json:"currentStep,omitempty"`
+	Input         json.RawMessage `json:"input,omitempty"`
+	Output        json.RawMessage `json:"output,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// Validate checks that the definition can be executed.
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return errors.New("workflow name is empty")
+	}
+	if len(d.Steps) == 0 {
+		return errors.Errorf("workflow %s has no steps", d.Name)
+	}
+
+	names := make(map[string]struct{}, len(d.Steps))
+	for i := range d.Steps {
+		step := &d.Steps[i]
+		if step.Name == "" {
+			return errors.Errorf("step %d has no name", i)
+		}
+		if _, ok := names[step.Name]; ok {
+			return errors.Errorf("duplicate step name %s", step.Name)
+		}
+		names[step.Name] = struct{}{}
+
+		if err := step.validate(); err != nil {
+			return errors.Wrapf(err, "invalid step %s", step.Name)
+		}
+	}
+	return nil
+}
+
+func (s *Step) validate() error {
+	kinds := 0
+	for _, set := range []bool{s.Invoke != nil, s.Binding != nil, s.Timer != nil, s.WaitForEvent != nil} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.New("exactly one of invoke, binding, timer or waitForEvent must be set")
+	}
+	if len(s.Input) > 0 && !json.Valid(s.Input) {
+		return errors.New("input is not valid JSON")
+	}
+
+	switch {
+	case s.Invoke != nil:
+		if s.Invoke.AppID == "" || s.Invoke.Method == "" {
+			return errors.New("invoke requires appId and method")
+		}
+	case s.Binding != nil:
+		if s.Binding.Name == "" || s.Binding.Operation == "" {
+			return errors.New("binding requires name and operation")
+		}
+	case s.Timer != nil:
+		d, err := time.ParseDuration(s.Timer.Duration)
+		if err != nil {
+			return errors.Wrap(err, "invalid timer duration")
+		}
+		if d <= 0 {
+			return errors.New("timer duration must be positive")
+		}
+	case s.WaitForEvent != nil:
+		if s.WaitForEvent.EventName == "" {
+			return errors.New("waitForEvent requires eventName")
+		}
+		if s.WaitForEvent.Timeout != "" {
+			if d, err := time.ParseDuration(s.WaitForEvent.Timeout); err != nil || d <= 0 {
+				return errors.Errorf("invalid waitForEvent timeout %s", s.WaitForEvent.Timeout)
+			}
+		}
+	}
+
+	if s.Retry != nil {
+		if s.Invoke == nil && s.Binding == nil {
+			return errors.New("retry is only supported for invoke and binding steps")
+		}
+		if s.Retry.MaxAttempts < 1 {
+			return errors.New("retry maxAttempts must be at least 1")
+		}
+		if s.Retry.Interval != "" {
+			if d, err := time.ParseDuration(s.Retry.Interval); err != nil || d < 0 {
+				return errors.Errorf("invalid retry interval %s", s.Retry.Interval)
+			}
+		}
+	}
+	return nil
+}
+
+// validateInstanceID makes sure the instance ID can be used as an actor ID.
+func validateInstanceID(instanceID string) error {
+	if instanceID == "" {
+		return errors.New("instance id is empty")
+	}
+	if strings.Contains(instanceID, "||") {
+		return errors.Errorf("instance id %s must not contain ||", instanceID)
+	}
+	return nil
+}
+
+// toJSON returns data unchanged if it is valid JSON, or encodes it as a JSON string otherwise.
+func toJSON(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	if json.Valid(data) {
+		return json.RawMessage(data)
+	}
+	b, _ := json.Marshal(string(data))
+	return b
+}
+
+// stepTimerDuration returns the duration of the timer used by the step, if any.
+func stepTimerDuration(s *Step) (time.Duration, bool) {
+	var raw string
+	switch {
+	case s.Timer != nil:
+		raw = s.Timer.Duration
+	case s.WaitForEvent != nil:
+		raw = s.WaitForEvent.Timeout
+	}
+	if raw == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
@@ -0,0 +1,106 @@
+package workflows
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinitionValidate(t *testing.T) {
+	invoke := &InvokeActivity{AppID: "orders", Method: "create"}
+
+	testCases := []struct {
+		name       string
+		definition Definition
+		valid      bool
+	}{
+		{
+			name: "valid",
+			definition: Definition{Name: "wf", Steps: []Step{
+				{Name: "create", Invoke: invoke, Retry: &RetryPolicy{MaxAttempts: 3, Interval: "1s"}},
+				{Name: "notify", Binding: &BindingActivity{Name: "mail", Operation: "create"}},
+				{Name: "pause", Timer: &TimerStep{Duration: "10s"}},
+				{Name: "approve", WaitForEvent: &WaitForEventStep{EventName: "approved", Timeout: "1h"}},
+			}},
+			valid: true,
+		},
+		{
+			name:       "missing name",
+			definition: Definition{Steps: []Step{{Name: "create", Invoke: invoke}}},
+		},
+		{
+			name:       "no steps",
+			definition: Definition{Name: "wf"},
+		},
+		{
+			name:       "duplicate step names",
+			definition: Definition{Name: "wf", Steps: []Step{{Name: "a", Invoke: invoke}, {Name: "a", Invoke: invoke}}},
+		},
+		{
+			name:       "no activity",
+			definition: Definition{Name: "wf", Steps: []Step{{Name: "a"}}},
+		},
+		{
+			name: "two activities",
+			definition: Definition{Name: "wf", Steps: []Step{
+				{Name: "a", Invoke: invoke, Timer: &TimerStep{Duration: "1s"}},
+			}},
+		},
+		{
+			name:       "invoke without method",
+			definition: Definition{Name: "wf", Steps: []Step{{Name: "a", Invoke: &InvokeActivity{AppID: "orders"}}}},
+		},
+		{
+			name:       "invalid timer",
+			definition: Definition{Name: "wf", Steps: []Step{{Name: "a", Timer: &TimerStep{Duration: "P1D"}}}},
+		},
+		{
+			name: "retry on timer",
+			definition: Definition{Name: "wf", Steps: []Step{
+				{Name: "a", Timer: &TimerStep{Duration: "1s"}, Retry: &RetryPolicy{MaxAttempts: 2}},
+			}},
+		},
+		{
+			name:       "invalid input",
+			definition: Definition{Name: "wf", Steps: []Step{{Name: "a", Invoke: invoke, Input: json.RawMessage("{")}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.definition.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	assert.Nil(t, toJSON(nil))
+	assert.Equal(t, json.RawMessage(`{"a":1}`), toJSON([]byte(`{"a":1}`)))
+	assert.Equal(t, json.RawMessage(`"plain text"`), toJSON([]byte("plain text")))
+}